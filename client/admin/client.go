@@ -297,6 +297,174 @@ func (c *clientImpl) ResendReplicationTasks(
 	return client.ResendReplicationTasks(ctx, request, opts...)
 }
 
+func (c *clientImpl) DescribeTaskQueue(
+	ctx context.Context,
+	request *adminservice.DescribeTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskQueueResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeTaskQueue(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *adminservice.UpdateWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateWorkerBuildIdCompatibilityResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateWorkerBuildIdCompatibility(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *adminservice.GetWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetWorkerBuildIdCompatibilityResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetWorkerBuildIdCompatibility(ctx, request, opts...)
+}
+
+func (c *clientImpl) PauseTaskQueue(
+	ctx context.Context,
+	request *adminservice.PauseTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseTaskQueueResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PauseTaskQueue(ctx, request, opts...)
+}
+
+func (c *clientImpl) ResumeTaskQueue(
+	ctx context.Context,
+	request *adminservice.ResumeTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResumeTaskQueueResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ResumeTaskQueue(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateTaskQueueRateLimit(
+	ctx context.Context,
+	request *adminservice.UpdateTaskQueueRateLimitRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateTaskQueueRateLimitResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateTaskQueueRateLimit(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListWorkers(
+	ctx context.Context,
+	request *adminservice.ListWorkersRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListWorkersResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListWorkers(ctx, request, opts...)
+}
+
+func (c *clientImpl) ResetWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ResetWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResetWorkflowExecutionResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ResetWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) PollForDecisionTask(
+	ctx context.Context,
+	request *adminservice.PollForDecisionTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PollForDecisionTaskResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PollForDecisionTask(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateWorkflowExecutionMetadata(
+	ctx context.Context,
+	request *adminservice.UpdateWorkflowExecutionMetadataRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateWorkflowExecutionMetadataResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateWorkflowExecutionMetadata(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateNamespaceDefaultRetryPoliciesResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateNamespaceDefaultRetryPolicies(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, error) {
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeNamespaceDefaultRetryPolicies(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
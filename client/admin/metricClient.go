@@ -344,3 +344,207 @@ func (c *metricClient) ResendReplicationTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) DescribeTaskQueue(
+	ctx context.Context,
+	request *adminservice.DescribeTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskQueueResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskQueueScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeTaskQueueScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeTaskQueue(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeTaskQueueScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *adminservice.UpdateWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateWorkerBuildIdCompatibilityResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateBuildIdCompatibilityScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateBuildIdCompatibilityScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateWorkerBuildIdCompatibility(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateBuildIdCompatibilityScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *adminservice.GetWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetWorkerBuildIdCompatibilityResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetBuildIdCompatibilityScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetBuildIdCompatibilityScope, metrics.ClientLatency)
+	resp, err := c.client.GetWorkerBuildIdCompatibility(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetBuildIdCompatibilityScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) PauseTaskQueue(
+	ctx context.Context,
+	request *adminservice.PauseTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseTaskQueueResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientPauseTaskQueueScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientPauseTaskQueueScope, metrics.ClientLatency)
+	resp, err := c.client.PauseTaskQueue(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientPauseTaskQueueScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ResumeTaskQueue(
+	ctx context.Context,
+	request *adminservice.ResumeTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResumeTaskQueueResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientResumeTaskQueueScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientResumeTaskQueueScope, metrics.ClientLatency)
+	resp, err := c.client.ResumeTaskQueue(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientResumeTaskQueueScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateTaskQueueRateLimit(
+	ctx context.Context,
+	request *adminservice.UpdateTaskQueueRateLimitRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateTaskQueueRateLimitResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateTaskQueueRateLimitScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateTaskQueueRateLimitScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateTaskQueueRateLimit(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateTaskQueueRateLimitScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ListWorkers(
+	ctx context.Context,
+	request *adminservice.ListWorkersRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListWorkersResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListWorkersScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListWorkersScope, metrics.ClientLatency)
+	resp, err := c.client.ListWorkers(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListWorkersScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ResetWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ResetWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResetWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientResetWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientResetWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ResetWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientResetWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) PollForDecisionTask(
+	ctx context.Context,
+	request *adminservice.PollForDecisionTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PollForDecisionTaskResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientPollForDecisionTaskScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientPollForDecisionTaskScope, metrics.ClientLatency)
+	resp, err := c.client.PollForDecisionTask(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientPollForDecisionTaskScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateWorkflowExecutionMetadata(
+	ctx context.Context,
+	request *adminservice.UpdateWorkflowExecutionMetadataRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateWorkflowExecutionMetadataResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateWorkflowExecutionMetadataScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateWorkflowExecutionMetadataScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateWorkflowExecutionMetadata(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateWorkflowExecutionMetadataScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateNamespaceDefaultRetryPoliciesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateNamespaceDefaultRetryPoliciesScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateNamespaceDefaultRetryPoliciesScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateNamespaceDefaultRetryPolicies(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateNamespaceDefaultRetryPoliciesScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DescribeNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeNamespaceDefaultRetryPoliciesScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeNamespaceDefaultRetryPoliciesScope, metrics.ClientLatency)
+	resp, err := c.client.DescribeNamespaceDefaultRetryPolicies(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeNamespaceDefaultRetryPoliciesScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
@@ -317,3 +317,195 @@ func (c *retryableClient) ResendReplicationTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) DescribeTaskQueue(
+	ctx context.Context,
+	request *adminservice.DescribeTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeTaskQueueResponse, error) {
+
+	var resp *adminservice.DescribeTaskQueueResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeTaskQueue(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *adminservice.UpdateWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateWorkerBuildIdCompatibilityResponse, error) {
+
+	var resp *adminservice.UpdateWorkerBuildIdCompatibilityResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateWorkerBuildIdCompatibility(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *adminservice.GetWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetWorkerBuildIdCompatibilityResponse, error) {
+
+	var resp *adminservice.GetWorkerBuildIdCompatibilityResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetWorkerBuildIdCompatibility(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) PauseTaskQueue(
+	ctx context.Context,
+	request *adminservice.PauseTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PauseTaskQueueResponse, error) {
+
+	var resp *adminservice.PauseTaskQueueResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PauseTaskQueue(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ResumeTaskQueue(
+	ctx context.Context,
+	request *adminservice.ResumeTaskQueueRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResumeTaskQueueResponse, error) {
+
+	var resp *adminservice.ResumeTaskQueueResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ResumeTaskQueue(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateTaskQueueRateLimit(
+	ctx context.Context,
+	request *adminservice.UpdateTaskQueueRateLimitRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateTaskQueueRateLimitResponse, error) {
+
+	var resp *adminservice.UpdateTaskQueueRateLimitResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateTaskQueueRateLimit(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListWorkers(
+	ctx context.Context,
+	request *adminservice.ListWorkersRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ListWorkersResponse, error) {
+
+	var resp *adminservice.ListWorkersResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListWorkers(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ResetWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ResetWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResetWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.ResetWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ResetWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) PollForDecisionTask(
+	ctx context.Context,
+	request *adminservice.PollForDecisionTaskRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.PollForDecisionTaskResponse, error) {
+
+	var resp *adminservice.PollForDecisionTaskResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PollForDecisionTask(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateWorkflowExecutionMetadata(
+	ctx context.Context,
+	request *adminservice.UpdateWorkflowExecutionMetadataRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateWorkflowExecutionMetadataResponse, error) {
+
+	var resp *adminservice.UpdateWorkflowExecutionMetadataResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateWorkflowExecutionMetadata(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateNamespaceDefaultRetryPoliciesResponse, error) {
+
+	var resp *adminservice.UpdateNamespaceDefaultRetryPoliciesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateNamespaceDefaultRetryPolicies(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DescribeNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, error) {
+
+	var resp *adminservice.DescribeNamespaceDefaultRetryPoliciesResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeNamespaceDefaultRetryPolicies(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -153,11 +153,14 @@ func (cf *rpcClientFactory) NewMatchingClientWithTimeout(
 		return matchingservice.NewMatchingServiceClient(connection), nil
 	}
 
+	clients := common.NewClientCache(keyResolver, clientProvider)
+	// partition counts of automatically scaled task queues are looked up from their root partition
+	partitionCounts := matching.NewPartitionCountCache(matching.NewPartitionCountFetchFn(clients))
 	client := matching.NewClient(
 		timeout,
 		longPollTimeout,
-		common.NewClientCache(keyResolver, clientProvider),
-		matching.NewLoadBalancer(namespaceIDToName, cf.dynConfig),
+		clients,
+		matching.NewLoadBalancer(namespaceIDToName, cf.dynConfig, partitionCounts),
 	)
 
 	if cf.metricsClient != nil {
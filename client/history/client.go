@@ -237,6 +237,31 @@ func (c *clientImpl) CloseShard(
 	return response, nil
 }
 
+func (c *clientImpl) AcquireShard(
+	ctx context.Context,
+	request *historyservice.AcquireShardRequest,
+	opts ...grpc.CallOption) (*historyservice.AcquireShardResponse, error) {
+
+	client, err := c.getClientForShardID(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.AcquireShardResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.AcquireShard(ctx, request, opts...)
+		return err
+	}
+
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) DescribeMutableState(
 	ctx context.Context,
 	request *historyservice.DescribeMutableStateRequest,
@@ -1031,6 +1056,30 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	return response, nil
 }
 
+func (c *clientImpl) UpdateWorkflowExecutionMetadata(
+	ctx context.Context,
+	request *historyservice.UpdateWorkflowExecutionMetadataRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UpdateWorkflowExecutionMetadataResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.UpdateWorkflowExecutionMetadataResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.UpdateWorkflowExecutionMetadata(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
@@ -92,6 +92,15 @@ func (c *metricClient) CloseShard(
 	return resp, err
 }
 
+func (c *metricClient) AcquireShard(
+	context context.Context,
+	request *historyservice.AcquireShardRequest,
+	opts ...grpc.CallOption) (*historyservice.AcquireShardResponse, error) {
+	resp, err := c.client.AcquireShard(context, request, opts...)
+
+	return resp, err
+}
+
 func (c *metricClient) DescribeMutableState(
 	context context.Context,
 	request *historyservice.DescribeMutableStateRequest,
@@ -660,3 +669,20 @@ func (c *metricClient) RefreshWorkflowTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) UpdateWorkflowExecutionMetadata(
+	ctx context.Context,
+	request *historyservice.UpdateWorkflowExecutionMetadataRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UpdateWorkflowExecutionMetadataResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionMetadataScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpdateWorkflowExecutionMetadataScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateWorkflowExecutionMetadata(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionMetadataScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
@@ -98,6 +98,22 @@ func (c *retryableClient) CloseShard(
 	return resp, err
 }
 
+func (c *retryableClient) AcquireShard(
+	ctx context.Context,
+	request *historyservice.AcquireShardRequest,
+	opts ...grpc.CallOption) (*historyservice.AcquireShardResponse, error) {
+
+	var resp *historyservice.AcquireShardResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.AcquireShard(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) RemoveTask(
 	ctx context.Context,
 	request *historyservice.RemoveTaskRequest,
@@ -658,3 +674,20 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) UpdateWorkflowExecutionMetadata(
+	ctx context.Context,
+	request *historyservice.UpdateWorkflowExecutionMetadataRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.UpdateWorkflowExecutionMetadataResponse, error) {
+
+	var resp *historyservice.UpdateWorkflowExecutionMetadataResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateWorkflowExecutionMetadata(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
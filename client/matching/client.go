@@ -29,6 +29,7 @@ import (
 	"time"
 
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
 	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
@@ -66,6 +67,31 @@ func NewClient(
 	}
 }
 
+// NewPartitionCountFetchFn returns a PartitionCountFetchFn asking the matching host owning
+// the root partition of a task queue for its partition counts
+func NewPartitionCountFetchFn(clients common.ClientCache) PartitionCountFetchFn {
+	return func(
+		ctx context.Context,
+		namespaceID string,
+		taskQueue string,
+		taskQueueType enumspb.TaskQueueType,
+	) (int, int, error) {
+		client, err := clients.GetClientForKey(routingKey(taskQueue))
+		if err != nil {
+			return 0, 0, err
+		}
+		resp, err := client.(matchingservice.MatchingServiceClient).GetTaskQueuePartitionCount(ctx, &matchingservice.GetTaskQueuePartitionCountRequest{
+			NamespaceId:   namespaceID,
+			TaskQueue:     &taskqueuepb.TaskQueue{Name: taskQueue, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+			TaskQueueType: taskQueueType,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		return int(resp.GetNumReadPartitions()), int(resp.GetNumWritePartitions()), nil
+	}
+}
+
 func (c *clientImpl) AddActivityTask(
 	ctx context.Context,
 	request *matchingservice.AddActivityTaskRequest,
@@ -203,6 +229,67 @@ func (c *clientImpl) ListTaskQueuePartitions(ctx context.Context, request *match
 	return client.ListTaskQueuePartitions(ctx, request, opts...)
 }
 
+func (c *clientImpl) GetTaskQueuePartitionCount(ctx context.Context, request *matchingservice.GetTaskQueuePartitionCountRequest, opts ...grpc.CallOption) (*matchingservice.GetTaskQueuePartitionCountResponse, error) {
+	client, err := c.getClientForTaskqueue(request.TaskQueue.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetTaskQueuePartitionCount(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateWorkerBuildIdCompatibility(ctx context.Context, request *matchingservice.UpdateWorkerBuildIdCompatibilityRequest, opts ...grpc.CallOption) (*matchingservice.UpdateWorkerBuildIdCompatibilityResponse, error) {
+	client, err := c.getClientForTaskqueue(request.GetTaskQueue())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateWorkerBuildIdCompatibility(ctx, request, opts...)
+}
+
+func (c *clientImpl) GetWorkerBuildIdCompatibility(ctx context.Context, request *matchingservice.GetWorkerBuildIdCompatibilityRequest, opts ...grpc.CallOption) (*matchingservice.GetWorkerBuildIdCompatibilityResponse, error) {
+	client, err := c.getClientForTaskqueue(request.GetTaskQueue())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.GetWorkerBuildIdCompatibility(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateTaskQueueState(ctx context.Context, request *matchingservice.UpdateTaskQueueStateRequest, opts ...grpc.CallOption) (*matchingservice.UpdateTaskQueueStateResponse, error) {
+	client, err := c.getClientForTaskqueue(request.TaskQueue.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateTaskQueueState(ctx, request, opts...)
+}
+
+func (c *clientImpl) UpdateTaskQueueRateLimit(ctx context.Context, request *matchingservice.UpdateTaskQueueRateLimitRequest, opts ...grpc.CallOption) (*matchingservice.UpdateTaskQueueRateLimitResponse, error) {
+	client, err := c.getClientForTaskqueue(request.TaskQueue.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UpdateTaskQueueRateLimit(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListWorkers(ctx context.Context, request *matchingservice.ListWorkersRequest, opts ...grpc.CallOption) (*matchingservice.ListWorkersResponse, error) {
+	// workers are registered by the matching host their polls are sent to
+	client, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.(matchingservice.MatchingServiceClient).ListWorkers(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
@@ -211,8 +298,8 @@ func (c *clientImpl) createLongPollContext(parent context.Context) (context.Cont
 	return context.WithTimeout(parent, c.longPollTimeout)
 }
 
-func (c *clientImpl) getClientForTaskqueue(key string) (matchingservice.MatchingServiceClient, error) {
-	client, err := c.clients.GetClientForKey(key)
+func (c *clientImpl) getClientForTaskqueue(taskQueueName string) (matchingservice.MatchingServiceClient, error) {
+	client, err := c.clients.GetClientForKey(routingKey(taskQueueName))
 	if err != nil {
 		return nil, err
 	}
@@ -67,24 +67,33 @@ type (
 	defaultLoadBalancer struct {
 		nReadPartitions   dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 		nWritePartitions  dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+		enableAutoScaling dynamicconfig.BoolPropertyFnWithTaskQueueInfoFilters
 		namespaceIDToName func(string) (string, error)
+		// partition counts of automatically scaled task queues, nil to always use the configured counts
+		partitionCounts *PartitionCountCache
 	}
 )
 
 const (
 	taskQueuePartitionPrefix = "/__temporal_sys/"
+	// versionSetSeparator separates the version set id from the partitionID in the name of a versioned task queue
+	versionSetSeparator = ":"
 )
 
 // NewLoadBalancer returns an instance of matching load balancer that
-// can help distribute api calls across task queue partitions
+// can help distribute api calls across task queue partitions. The partition counts
+// of automatically scaled task queues are looked up in partitionCounts, if not nil.
 func NewLoadBalancer(
 	namespaceIDToName func(string) (string, error),
 	dc *dynamicconfig.Collection,
+	partitionCounts *PartitionCountCache,
 ) LoadBalancer {
 	return &defaultLoadBalancer{
 		namespaceIDToName: namespaceIDToName,
+		partitionCounts:   partitionCounts,
 		nReadPartitions:   dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingNumTaskqueueReadPartitions, 1),
 		nWritePartitions:  dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingNumTaskqueueWritePartitions, 1),
+		enableAutoScaling: dc.GetBoolPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingEnablePartitionAutoScaling, false),
 	}
 }
 
@@ -94,7 +103,7 @@ func (lb *defaultLoadBalancer) PickWritePartition(
 	taskQueueType enumspb.TaskQueueType,
 	forwardedFrom string,
 ) string {
-	return lb.pickPartition(namespaceID, taskQueue, taskQueueType, forwardedFrom, lb.nWritePartitions)
+	return lb.pickPartition(namespaceID, taskQueue, taskQueueType, forwardedFrom, true)
 }
 
 func (lb *defaultLoadBalancer) PickReadPartition(
@@ -103,7 +112,7 @@ func (lb *defaultLoadBalancer) PickReadPartition(
 	taskQueueType enumspb.TaskQueueType,
 	forwardedFrom string,
 ) string {
-	return lb.pickPartition(namespaceID, taskQueue, taskQueueType, forwardedFrom, lb.nReadPartitions)
+	return lb.pickPartition(namespaceID, taskQueue, taskQueueType, forwardedFrom, false)
 }
 
 func (lb *defaultLoadBalancer) pickPartition(
@@ -111,7 +120,7 @@ func (lb *defaultLoadBalancer) pickPartition(
 	taskQueue taskqueuepb.TaskQueue,
 	taskQueueType enumspb.TaskQueueType,
 	forwardedFrom string,
-	nPartitions dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters,
+	write bool,
 ) string {
 
 	if forwardedFrom != "" || taskQueue.GetKind() == enumspb.TASK_QUEUE_KIND_STICKY {
@@ -128,7 +137,7 @@ func (lb *defaultLoadBalancer) pickPartition(
 		return taskQueue.GetName()
 	}
 
-	n := nPartitions(namespace, taskQueue.GetName(), taskQueueType)
+	n := lb.numPartitions(namespaceID, namespace, taskQueue.GetName(), taskQueueType, write)
 	if n <= 0 {
 		return taskQueue.GetName()
 	}
@@ -140,3 +149,44 @@ func (lb *defaultLoadBalancer) pickPartition(
 
 	return fmt.Sprintf("%v%v/%v", taskQueuePartitionPrefix, taskQueue.GetName(), p)
 }
+
+func (lb *defaultLoadBalancer) numPartitions(
+	namespaceID string,
+	namespace string,
+	taskQueueName string,
+	taskQueueType enumspb.TaskQueueType,
+	write bool,
+) int {
+	if lb.partitionCounts != nil && lb.enableAutoScaling(namespace, taskQueueName, taskQueueType) {
+		if numRead, numWrite, ok := lb.partitionCounts.Get(namespaceID, taskQueueName, taskQueueType); ok {
+			if write {
+				return numWrite
+			}
+			return numRead
+		}
+	}
+	if write {
+		return lb.nWritePartitions(namespace, taskQueueName, taskQueueType)
+	}
+	return lb.nReadPartitions(namespace, taskQueueName, taskQueueType)
+}
+
+// routingKey returns the key used to look up the matching host owning the given task queue partition.
+// The partitions of a worker version set are owned by the host owning the same unversioned partition,
+// so that matching can redirect tasks and polls to them without another network hop.
+func routingKey(taskQueueName string) string {
+	if !strings.HasPrefix(taskQueueName, taskQueuePartitionPrefix) {
+		return taskQueueName
+	}
+	suffixOff := strings.LastIndex(taskQueueName, "/")
+	versionOff := strings.LastIndex(taskQueueName, versionSetSeparator)
+	if versionOff < suffixOff {
+		return taskQueueName
+	}
+	baseName := taskQueueName[len(taskQueuePartitionPrefix):suffixOff]
+	partition := taskQueueName[versionOff+1:]
+	if partition == "0" {
+		return baseName
+	}
+	return fmt.Sprintf("%v%v/%v", taskQueuePartitionPrefix, baseName, partition)
+}
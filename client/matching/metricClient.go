@@ -246,6 +246,114 @@ func (c *metricClient) ListTaskQueuePartitions(
 	return resp, err
 }
 
+func (c *metricClient) GetTaskQueuePartitionCount(
+	ctx context.Context,
+	request *matchingservice.GetTaskQueuePartitionCountRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetTaskQueuePartitionCountResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientGetPartitionCountScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientGetPartitionCountScope, metrics.ClientLatency)
+	resp, err := c.client.GetTaskQueuePartitionCount(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientGetPartitionCountScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) UpdateWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *matchingservice.UpdateWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateWorkerBuildIdCompatibilityResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientUpdateVersionSetsScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientUpdateVersionSetsScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateWorkerBuildIdCompatibility(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientUpdateVersionSetsScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) GetWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *matchingservice.GetWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetWorkerBuildIdCompatibilityResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientGetVersionSetsScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientGetVersionSetsScope, metrics.ClientLatency)
+	resp, err := c.client.GetWorkerBuildIdCompatibility(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientGetVersionSetsScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) UpdateTaskQueueState(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskQueueStateRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateTaskQueueStateResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskQueueStateScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientUpdateTaskQueueStateScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateTaskQueueState(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskQueueStateScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) UpdateTaskQueueRateLimit(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskQueueRateLimitRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateTaskQueueRateLimitResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskQueueRateLimitScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientUpdateTaskQueueRateLimitScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateTaskQueueRateLimit(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientUpdateTaskQueueRateLimitScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) ListWorkers(
+	ctx context.Context,
+	request *matchingservice.ListWorkersRequest,
+	opts ...grpc.CallOption) (*matchingservice.ListWorkersResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientListWorkersScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientListWorkersScope, metrics.ClientLatency)
+	resp, err := c.client.ListWorkers(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientListWorkersScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) emitForwardedFromStats(scope int, forwardedFrom string, taskQueue *taskqueuepb.TaskQueue) {
 	if taskQueue == nil {
 		return
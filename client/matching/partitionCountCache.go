@@ -0,0 +1,139 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+)
+
+const (
+	// partitionCountRefreshInterval is how often cached partition counts are refreshed from the root partition
+	partitionCountRefreshInterval = 10 * time.Second
+	// partitionCountFetchTimeout is the timeout of a single fetch of the partition counts
+	partitionCountFetchTimeout = 5 * time.Second
+	// partitionCountTTL is how long an entry is kept in the cache after its last lookup
+	partitionCountTTL = 10 * time.Minute
+)
+
+type (
+	// PartitionCountFetchFn returns the number of read and write partitions of the given root task queue
+	PartitionCountFetchFn func(ctx context.Context, namespaceID string, taskQueue string, taskQueueType enumspb.TaskQueueType) (numRead int, numWrite int, err error)
+
+	// PartitionCountCache caches the partition counts of automatically scaled task queues as decided
+	// by their root partition. Lookups never block on a remote call: a missing or stale entry is
+	// refreshed in the background while the last known value, if any, is returned. Entries that
+	// are not looked up for partitionCountTTL are evicted.
+	PartitionCountCache struct {
+		sync.Mutex
+		fetch     PartitionCountFetchFn
+		entries   map[partitionCountKey]*partitionCountEntry
+		evictTime time.Time // last time expired entries were evicted
+	}
+
+	partitionCountKey struct {
+		namespaceID   string
+		taskQueue     string
+		taskQueueType enumspb.TaskQueueType
+	}
+
+	partitionCountEntry struct {
+		numRead     int
+		numWrite    int
+		known       bool
+		refreshTime time.Time
+		accessTime  time.Time
+		refreshing  bool
+	}
+)
+
+// NewPartitionCountCache returns a partition count cache that loads missing entries using fetch
+func NewPartitionCountCache(fetch PartitionCountFetchFn) *PartitionCountCache {
+	return &PartitionCountCache{
+		fetch:     fetch,
+		entries:   make(map[partitionCountKey]*partitionCountEntry),
+		evictTime: time.Now(),
+	}
+}
+
+// Get returns the cached partition counts of the given root task queue; ok is false
+// when they are not known yet, in which case the caller should use its configured counts
+func (c *PartitionCountCache) Get(
+	namespaceID string,
+	taskQueue string,
+	taskQueueType enumspb.TaskQueueType,
+) (numRead int, numWrite int, ok bool) {
+	key := partitionCountKey{namespaceID: namespaceID, taskQueue: taskQueue, taskQueueType: taskQueueType}
+
+	c.Lock()
+	defer c.Unlock()
+	now := time.Now()
+	c.evictExpiredLocked(now)
+	entry, found := c.entries[key]
+	if !found {
+		entry = &partitionCountEntry{}
+		c.entries[key] = entry
+	}
+	entry.accessTime = now
+	if !entry.refreshing && time.Since(entry.refreshTime) >= partitionCountRefreshInterval {
+		entry.refreshing = true
+		go c.refresh(key, entry)
+	}
+	return entry.numRead, entry.numWrite, entry.known
+}
+
+// evictExpiredLocked removes the entries not looked up for partitionCountTTL, at most once per refresh interval
+func (c *PartitionCountCache) evictExpiredLocked(now time.Time) {
+	if now.Sub(c.evictTime) < partitionCountRefreshInterval {
+		return
+	}
+	c.evictTime = now
+	for key, entry := range c.entries {
+		if !entry.refreshing && now.Sub(entry.accessTime) >= partitionCountTTL {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *PartitionCountCache) refresh(key partitionCountKey, entry *partitionCountEntry) {
+	ctx, cancel := context.WithTimeout(context.Background(), partitionCountFetchTimeout)
+	defer cancel()
+	numRead, numWrite, err := c.fetch(ctx, key.namespaceID, key.taskQueue, key.taskQueueType)
+
+	c.Lock()
+	defer c.Unlock()
+	entry.refreshing = false
+	entry.refreshTime = time.Now()
+	if err != nil || numRead <= 0 || numWrite <= 0 {
+		// keep the last known counts, they are retried after the refresh interval
+		return
+	}
+	entry.numRead = numRead
+	entry.numWrite = numWrite
+	entry.known = true
+}
@@ -193,3 +193,99 @@ func (c *retryableClient) ListTaskQueuePartitions(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) GetTaskQueuePartitionCount(
+	ctx context.Context,
+	request *matchingservice.GetTaskQueuePartitionCountRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetTaskQueuePartitionCountResponse, error) {
+
+	var resp *matchingservice.GetTaskQueuePartitionCountResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetTaskQueuePartitionCount(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *matchingservice.UpdateWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateWorkerBuildIdCompatibilityResponse, error) {
+
+	var resp *matchingservice.UpdateWorkerBuildIdCompatibilityResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateWorkerBuildIdCompatibility(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *matchingservice.GetWorkerBuildIdCompatibilityRequest,
+	opts ...grpc.CallOption) (*matchingservice.GetWorkerBuildIdCompatibilityResponse, error) {
+
+	var resp *matchingservice.GetWorkerBuildIdCompatibilityResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetWorkerBuildIdCompatibility(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateTaskQueueState(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskQueueStateRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateTaskQueueStateResponse, error) {
+
+	var resp *matchingservice.UpdateTaskQueueStateResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateTaskQueueState(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateTaskQueueRateLimit(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskQueueRateLimitRequest,
+	opts ...grpc.CallOption) (*matchingservice.UpdateTaskQueueRateLimitResponse, error) {
+
+	var resp *matchingservice.UpdateTaskQueueRateLimitResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateTaskQueueRateLimit(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ListWorkers(
+	ctx context.Context,
+	request *matchingservice.ListWorkersRequest,
+	opts ...grpc.CallOption) (*matchingservice.ListWorkersResponse, error) {
+
+	var resp *matchingservice.ListWorkersResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListWorkers(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
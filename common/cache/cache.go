@@ -25,6 +25,7 @@
 package cache
 
 import (
+	"sync/atomic"
 	"time"
 )
 
@@ -53,6 +54,14 @@ type Cache interface {
 
 	// Size returns the number of entries currently stored in the Cache
 	Size() int
+
+	// TotalSize returns the total size of the entries currently stored in the Cache,
+	// as measured by its ItemSizeFunc, or their number if it doesn't have one
+	TotalSize() int
+
+	// DetachSizeBudget gives the size of the entries back to the SizeBudget of the Cache, if any.
+	// It is called when the Cache is discarded, its entries are not charged to the budget anymore.
+	DetachSizeBudget()
 }
 
 // Options control the behavior of the cache
@@ -70,6 +79,27 @@ type Options struct {
 	// RemovedFunc is an optional function called when an element
 	// is scheduled for deletion
 	RemovedFunc RemovedFunc
+
+	// ItemSizeFunc is an optional function returning the size of an element.
+	// If set, the max size of the cache bounds the total size of its elements
+	// instead of their number. The size of a pinned element is measured again
+	// when it is released, as its value may have changed while in use.
+	ItemSizeFunc ItemSizeFunc
+
+	// EvictedFunc is an optional function called when an element is evicted
+	// to keep the cache within its max size
+	EvictedFunc EvictedFunc
+
+	// SizeBudget is an optional budget shared with other caches, bounding the total size of their
+	// elements as measured by their ItemSizeFunc. While it is exceeded, the cache evicts its least
+	// recently used elements whenever an element is added or released.
+	SizeBudget *SizeBudget
+}
+
+// SizeBudget bounds the total size of the elements of several caches
+type SizeBudget struct {
+	maxSize  int64
+	currSize int64
 }
 
 // SimpleOptions provides options that can be used to configure SimpleCache
@@ -88,6 +118,32 @@ type SimpleOptions struct {
 // deletion, Cache calls go f(i)
 type RemovedFunc func(interface{})
 
+// ItemSizeFunc is a type for measuring the size of a value stored in the Cache
+type ItemSizeFunc func(interface{}) int
+
+// EvictedFunc is a type for notifying applications when an item is evicted
+// from the Cache, along with its size. It is called synchronously with the
+// Cache locked, so it must not access the Cache.
+type EvictedFunc func(value interface{}, size int)
+
+// NewSizeBudget creates a budget bounding the total size of the elements of the caches sharing it to maxSize
+func NewSizeBudget(maxSize int) *SizeBudget {
+	return &SizeBudget{maxSize: int64(maxSize)}
+}
+
+// TotalSize returns the total size of the elements of the caches sharing the budget
+func (b *SizeBudget) TotalSize() int {
+	return int(atomic.LoadInt64(&b.currSize))
+}
+
+func (b *SizeBudget) add(size int) {
+	atomic.AddInt64(&b.currSize, int64(size))
+}
+
+func (b *SizeBudget) isExceeded() bool {
+	return atomic.LoadInt64(&b.currSize) > b.maxSize
+}
+
 // Iterator represents the interface for cache iterators
 type Iterator interface {
 	// Close closes the iterator
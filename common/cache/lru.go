@@ -39,13 +39,17 @@ var (
 // lru is a concurrent fixed size cache that evicts elements in lru order
 type (
 	lru struct {
-		mut      sync.Mutex
-		byAccess *list.List
-		byKey    map[interface{}]*list.Element
-		maxSize  int
-		ttl      time.Duration
-		pin      bool
-		rmFunc   RemovedFunc
+		mut       sync.Mutex
+		byAccess  *list.List
+		byKey     map[interface{}]*list.Element
+		maxSize   int
+		currSize  int
+		ttl       time.Duration
+		pin       bool
+		rmFunc    RemovedFunc
+		sizeFunc  ItemSizeFunc
+		evictFunc EvictedFunc
+		budget    *SizeBudget
 	}
 
 	iteratorImpl struct {
@@ -59,6 +63,7 @@ type (
 		createTime time.Time
 		value      interface{}
 		refCount   int
+		size       int
 	}
 )
 
@@ -129,19 +134,28 @@ func (entry *entryImpl) CreateTime() time.Time {
 	return entry.createTime
 }
 
-// New creates a new cache with the given options
+// New creates a new cache with the given options. maxSize bounds the number of
+// entries in the cache, or their total size if opts has an ItemSizeFunc.
 func New(maxSize int, opts *Options) Cache {
 	if opts == nil {
 		opts = &Options{}
 	}
 
+	var budget *SizeBudget
+	if opts.ItemSizeFunc != nil {
+		budget = opts.SizeBudget
+	}
+
 	return &lru{
-		byAccess: list.New(),
-		byKey:    make(map[interface{}]*list.Element, opts.InitialCapacity),
-		ttl:      opts.TTL,
-		maxSize:  maxSize,
-		pin:      opts.Pin,
-		rmFunc:   opts.RemovedFunc,
+		byAccess:  list.New(),
+		byKey:     make(map[interface{}]*list.Element, opts.InitialCapacity),
+		ttl:       opts.TTL,
+		maxSize:   maxSize,
+		pin:       opts.Pin,
+		rmFunc:    opts.RemovedFunc,
+		sizeFunc:  opts.ItemSizeFunc,
+		evictFunc: opts.EvictedFunc,
+		budget:    budget,
 	}
 }
 
@@ -230,6 +244,10 @@ func (c *lru) Release(key interface{}) {
 	}
 	entry := elt.Value.(*entryImpl)
 	entry.refCount--
+	if c.sizeFunc != nil {
+		c.resizeInternal(entry)
+		c.evictInternal()
+	}
 }
 
 // Size returns the number of entries currently in the lru, useful if cache is not full
@@ -240,6 +258,25 @@ func (c *lru) Size() int {
 	return len(c.byKey)
 }
 
+// TotalSize returns the total size of the entries currently in the lru
+func (c *lru) TotalSize() int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.currSize
+}
+
+// DetachSizeBudget gives the size of the entries back to the budget shared with other caches
+func (c *lru) DetachSizeBudget() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.budget != nil {
+		c.budget.add(-c.currSize)
+		c.budget = nil
+	}
+}
+
 // Put puts a new value associated with a given key, returning the existing value (if present)
 // allowUpdate flag is used to control overwrite behavior if the value exists
 func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool) (interface{}, error) {
@@ -254,16 +291,17 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 			c.deleteInternal(elt)
 		} else {
 			existing := entry.value
+			c.byAccess.MoveToFront(elt)
+			if c.pin {
+				entry.refCount++
+			}
 			if allowUpdate {
 				entry.value = value
 				if c.ttl != 0 {
 					entry.createTime = time.Now()
 				}
-			}
-
-			c.byAccess.MoveToFront(elt)
-			if c.pin {
-				entry.refCount++
+				c.resizeInternal(entry)
+				c.evictInternal()
 			}
 			return existing, nil
 		}
@@ -272,6 +310,7 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 	entry := &entryImpl{
 		key:   key,
 		value: value,
+		size:  c.itemSize(value),
 	}
 
 	if c.pin {
@@ -283,20 +322,69 @@ func (c *lru) putInternal(key interface{}, value interface{}, allowUpdate bool)
 	}
 
 	c.byKey[key] = c.byAccess.PushFront(entry)
-	if len(c.byKey) == c.maxSize {
-		oldest := c.byAccess.Back().Value.(*entryImpl)
-
-		if oldest.refCount > 0 {
-			// Cache is full with pinned elements
-			// revert the insert and return
-			c.deleteInternal(c.byAccess.Front())
-			return nil, ErrCacheFull
+	c.addSize(entry.size)
+	if !c.evictInternal() {
+		// Cache is full with pinned elements
+		// revert the insert and return
+		c.deleteInternal(c.byAccess.Front())
+		return nil, ErrCacheFull
+	}
+
+	return nil, nil
+}
+
+// evictInternal evicts the least recently used entries until the cache fits in its max size and
+// its size budget, stopping at the first pinned one. It returns false if the cache still exceeds
+// its own max size: an exceeded budget is tolerated, as the other caches sharing it evict their
+// own entries when they are used, so the most recently used entry is kept for it.
+func (c *lru) evictInternal() bool {
+	for c.isFull() {
+		oldest := c.byAccess.Back()
+		if oldest == nil || oldest.Value.(*entryImpl).refCount > 0 {
+			return !c.exceedsMaxSize()
+		}
+		if oldest == c.byAccess.Front() && !c.exceedsMaxSize() {
+			return true
 		}
 
-		c.deleteInternal(c.byAccess.Back())
+		entry := oldest.Value.(*entryImpl)
+		c.deleteInternal(oldest)
+		if c.evictFunc != nil {
+			c.evictFunc(entry.value, entry.size)
+		}
 	}
+	return true
+}
 
-	return nil, nil
+func (c *lru) isFull() bool {
+	return c.exceedsMaxSize() || (c.budget != nil && c.budget.isExceeded())
+}
+
+func (c *lru) exceedsMaxSize() bool {
+	if c.sizeFunc == nil {
+		return len(c.byKey) == c.maxSize
+	}
+	return c.currSize > c.maxSize
+}
+
+func (c *lru) itemSize(value interface{}) int {
+	if c.sizeFunc == nil {
+		return 1
+	}
+	return c.sizeFunc(value)
+}
+
+func (c *lru) resizeInternal(entry *entryImpl) {
+	size := c.itemSize(entry.value)
+	c.addSize(size - entry.size)
+	entry.size = size
+}
+
+func (c *lru) addSize(size int) {
+	c.currSize += size
+	if c.budget != nil {
+		c.budget.add(size)
+	}
 }
 
 func (c *lru) deleteInternal(element *list.Element) {
@@ -305,6 +393,7 @@ func (c *lru) deleteInternal(element *list.Element) {
 		go c.rmFunc(entry.value)
 	}
 	delete(c.byKey, entry.key)
+	c.addSize(-entry.size)
 }
 
 func (c *lru) isEntryExpired(entry *entryImpl, currentTime time.Time) bool {
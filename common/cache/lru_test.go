@@ -236,6 +236,118 @@ func TestRemovedFuncWithTTL_Pin(t *testing.T) {
 	}
 }
 
+func TestLRUWithItemSize(t *testing.T) {
+	var evicted []string
+	cache := New(10, &Options{
+		ItemSizeFunc: func(i interface{}) int {
+			return len(i.(string))
+		},
+		EvictedFunc: func(i interface{}, size int) {
+			assert.Equal(t, len(i.(string)), size)
+			evicted = append(evicted, i.(string))
+		},
+	})
+
+	cache.Put("A", "Foo")
+	cache.Put("B", "Bar")
+	cache.Put("C", "Cid")
+	assert.Equal(t, 3, cache.Size())
+	assert.Equal(t, 9, cache.TotalSize())
+
+	// A is the oldest, only it needs to go to make room
+	cache.Put("D", "Delt")
+	assert.Nil(t, cache.Get("A"))
+	assert.Equal(t, 3, cache.Size())
+	assert.Equal(t, 10, cache.TotalSize())
+	assert.Equal(t, []string{"Foo"}, evicted)
+
+	// growing an entry evicts the least recently used ones
+	cache.Get("B")
+	cache.Put("C", "CidCid")
+	assert.Nil(t, cache.Get("D"))
+	assert.Equal(t, "Bar", cache.Get("B"))
+	assert.Equal(t, "CidCid", cache.Get("C"))
+	assert.Equal(t, 9, cache.TotalSize())
+	assert.Equal(t, []string{"Foo", "Delt"}, evicted)
+
+	cache.Delete("C")
+	assert.Equal(t, 3, cache.TotalSize())
+}
+
+func TestLRUWithItemSize_Pin(t *testing.T) {
+	cache := New(10, &Options{
+		Pin: true,
+		ItemSizeFunc: func(i interface{}) int {
+			return len(*i.(*string))
+		},
+	})
+
+	a := "Foo"
+	_, err := cache.PutIfNotExist("A", &a)
+	assert.NoError(t, err)
+	b := "Bar"
+	_, err = cache.PutIfNotExist("B", &b)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, cache.TotalSize())
+
+	// values are measured again on release
+	a = "FooFoo"
+	cache.Release("A")
+	assert.Equal(t, 9, cache.TotalSize())
+
+	// B is pinned, so A is evicted and C still doesn't fit
+	c := "CidCidCid"
+	_, err = cache.PutIfNotExist("C", &c)
+	assert.Equal(t, ErrCacheFull, err)
+	assert.Nil(t, cache.Get("A"))
+	assert.Equal(t, 3, cache.TotalSize())
+
+	cache.Release("B")
+	_, err = cache.PutIfNotExist("C", &c)
+	assert.NoError(t, err)
+	assert.Nil(t, cache.Get("B"))
+	assert.Equal(t, 9, cache.TotalSize())
+}
+
+func TestLRUWithSizeBudget(t *testing.T) {
+	budget := NewSizeBudget(10)
+	opts := &Options{
+		ItemSizeFunc: func(i interface{}) int {
+			return len(i.(string))
+		},
+		SizeBudget: budget,
+	}
+	cache1 := New(10, opts)
+	cache2 := New(10, opts)
+
+	cache1.Put("A", "Foo")
+	cache1.Put("B", "Bar")
+	cache2.Put("C", "Cid")
+	assert.Equal(t, 9, budget.TotalSize())
+
+	// the budget is exceeded, so cache2 evicts its own entries to make room
+	cache2.Put("D", "Delt")
+	assert.Nil(t, cache2.Get("C"))
+	assert.Equal(t, 4, cache2.TotalSize())
+	assert.Equal(t, 10, budget.TotalSize())
+
+	// cache1 evicts its entries as it is used
+	cache1.Put("E", "E")
+	assert.Nil(t, cache1.Get("A"))
+	assert.Equal(t, "Bar", cache1.Get("B"))
+	assert.Equal(t, 8, budget.TotalSize())
+
+	// the most recently used entry is kept as long as the cache fits in its own max size
+	cache2.Put("F", "FooBarCid")
+	assert.Equal(t, "FooBarCid", cache2.Get("F"))
+	assert.Equal(t, 13, budget.TotalSize())
+
+	cache2.DetachSizeBudget()
+	assert.Equal(t, 4, budget.TotalSize())
+	cache2.Delete("F")
+	assert.Equal(t, 4, budget.TotalSize())
+}
+
 func TestIterator(t *testing.T) {
 	expected := map[string]string{
 		"A": "Alpha",
@@ -166,6 +166,15 @@ func (c *simple) Size() int {
 	return len(c.accessMap)
 }
 
+// TotalSize returns the number of entries currently in the cache, as simple cache doesn't measure them
+func (c *simple) TotalSize() int {
+	return c.Size()
+}
+
+// DetachSizeBudget is a no-op, as simple cache doesn't have a size budget
+func (c *simple) DetachSizeBudget() {
+}
+
 func (c *simple) Iterator() Iterator {
 	c.RLock()
 	iterator := &simpleItr{
@@ -65,11 +65,13 @@ const (
 // Data encoding types
 const (
 	// todo: Deprecate and use protoEncodingEnum.ToString()
-	EncodingTypeJSON    EncodingType = "json"
-	EncodingTypeGob     EncodingType = "gob"
-	EncodingTypeUnknown EncodingType = "unknow"
-	EncodingTypeEmpty   EncodingType = ""
-	EncodingTypeProto3  EncodingType = "proto3"
+	EncodingTypeJSON         EncodingType = "json"
+	EncodingTypeGob          EncodingType = "gob"
+	EncodingTypeUnknown      EncodingType = "unknow"
+	EncodingTypeEmpty        EncodingType = ""
+	EncodingTypeProto3       EncodingType = "proto3"
+	EncodingTypeProto3Snappy EncodingType = "proto3+snappy"
+	EncodingTypeProto3Zstd   EncodingType = "proto3+zstd"
 )
 
 func (e EncodingType) String() string {
@@ -139,6 +141,34 @@ const (
 	ArchivalPaused = "paused"
 )
 
+const (
+	// ReservedHeaderKeyPrefix is the prefix of the header keys reserved for options of the server. The
+	// fields under these keys are kept in history but removed from the history and tasks sent to workers.
+	// Requests carrying them are rejected unless reserved header options are enabled for the namespace.
+	ReservedHeaderKeyPrefix = "_temporal_"
+	// TaskPriorityHeaderKey is the reserved header key used to carry the dispatch priority
+	// of workflow and activity tasks
+	TaskPriorityHeaderKey = "_temporal_task_priority"
+	// HighestTaskPriority is the most urgent task priority
+	HighestTaskPriority int32 = 1
+	// LowestTaskPriority is the least urgent task priority
+	LowestTaskPriority int32 = 5
+	// DefaultTaskPriority is used when no priority is specified
+	DefaultTaskPriority int32 = 3
+	// TaskFairnessKeyHeaderKey is the reserved header key used to carry the fairness key, e.g. a
+	// tenant id, by which matching shares dispatch of workflow and activity tasks
+	TaskFairnessKeyHeaderKey = "_temporal_task_fairness_key"
+	// WorkflowRetentionDaysHeaderKey is the reserved header key used to carry a retention override,
+	// in days, for the history of the started workflow execution
+	WorkflowRetentionDaysHeaderKey = "_temporal_workflow_retention_days"
+	// WorkflowIDReusePolicyHeaderKey is the reserved header key used to carry a workflow id reuse policy
+	// beyond those of the request, it takes precedence over the policy of the request
+	WorkflowIDReusePolicyHeaderKey = "_temporal_workflow_id_reuse_policy"
+	// WorkflowIDReusePolicyTerminateIfRunning terminates the running current run, if any, and starts the
+	// new run in the same transaction, closed runs are handled as with WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE
+	WorkflowIDReusePolicyTerminateIfRunning = "TerminateIfRunning"
+)
+
 // enum for dynamic config AdvancedVisibilityWritingMode
 const (
 	// AdvancedVisibilityWritingModeOff means do not write to advanced visibility store
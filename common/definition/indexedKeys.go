@@ -30,19 +30,20 @@ import (
 
 // valid indexed fields on ES
 const (
-	NamespaceID     = "NamespaceId"
-	WorkflowID      = "WorkflowId"
-	RunID           = "RunId"
-	WorkflowType    = "WorkflowType"
-	StartTime       = "StartTime"
-	ExecutionTime   = "ExecutionTime"
-	CloseTime       = "CloseTime"
-	ExecutionStatus = "ExecutionStatus"
-	HistoryLength   = "HistoryLength"
-	Encoding        = "Encoding"
-	KafkaKey        = "KafkaKey"
-	BinaryChecksums = "BinaryChecksums"
-	TaskQueue       = "TaskQueue"
+	NamespaceID      = "NamespaceId"
+	WorkflowID       = "WorkflowId"
+	RunID            = "RunId"
+	WorkflowType     = "WorkflowType"
+	StartTime        = "StartTime"
+	ExecutionTime    = "ExecutionTime"
+	CloseTime        = "CloseTime"
+	ExecutionStatus  = "ExecutionStatus"
+	HistoryLength    = "HistoryLength"
+	HistorySizeBytes = "HistorySizeBytes"
+	Encoding         = "Encoding"
+	KafkaKey         = "KafkaKey"
+	BinaryChecksums  = "BinaryChecksums"
+	TaskQueue        = "TaskQueue"
 
 	CustomStringField     = "CustomStringField"
 	CustomKeywordField    = "CustomKeywordField"
@@ -88,16 +89,17 @@ func GetDefaultIndexedKeys() map[string]interface{} {
 
 // systemIndexedKeys is Temporal created visibility keys
 var systemIndexedKeys = map[string]interface{}{
-	NamespaceID:     enumspb.INDEXED_VALUE_TYPE_KEYWORD,
-	WorkflowID:      enumspb.INDEXED_VALUE_TYPE_KEYWORD,
-	RunID:           enumspb.INDEXED_VALUE_TYPE_KEYWORD,
-	WorkflowType:    enumspb.INDEXED_VALUE_TYPE_KEYWORD,
-	StartTime:       enumspb.INDEXED_VALUE_TYPE_INT,
-	ExecutionTime:   enumspb.INDEXED_VALUE_TYPE_INT,
-	CloseTime:       enumspb.INDEXED_VALUE_TYPE_INT,
-	ExecutionStatus: enumspb.INDEXED_VALUE_TYPE_INT,
-	HistoryLength:   enumspb.INDEXED_VALUE_TYPE_INT,
-	TaskQueue:       enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	NamespaceID:      enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	WorkflowID:       enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	RunID:            enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	WorkflowType:     enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+	StartTime:        enumspb.INDEXED_VALUE_TYPE_INT,
+	ExecutionTime:    enumspb.INDEXED_VALUE_TYPE_INT,
+	CloseTime:        enumspb.INDEXED_VALUE_TYPE_INT,
+	ExecutionStatus:  enumspb.INDEXED_VALUE_TYPE_INT,
+	HistoryLength:    enumspb.INDEXED_VALUE_TYPE_INT,
+	HistorySizeBytes: enumspb.INDEXED_VALUE_TYPE_INT,
+	TaskQueue:        enumspb.INDEXED_VALUE_TYPE_KEYWORD,
 }
 
 // IsSystemIndexedKey return true is key is system added
@@ -30,18 +30,19 @@ import (
 
 // All legal fields allowed in elastic search index
 const (
-	NamespaceID     = "NamespaceId"
-	WorkflowID      = "WorkflowId"
-	RunID           = "RunId"
-	WorkflowType    = "WorkflowType"
-	StartTime       = "StartTime"
-	ExecutionTime   = "ExecutionTime"
-	CloseTime       = "CloseTime"
-	ExecutionStatus = "ExecutionStatus"
-	HistoryLength   = "HistoryLength"
-	Memo            = "Memo"
-	Encoding        = "Encoding"
-	TaskQueue       = "TaskQueue"
+	NamespaceID      = "NamespaceId"
+	WorkflowID       = "WorkflowId"
+	RunID            = "RunId"
+	WorkflowType     = "WorkflowType"
+	StartTime        = "StartTime"
+	ExecutionTime    = "ExecutionTime"
+	CloseTime        = "CloseTime"
+	ExecutionStatus  = "ExecutionStatus"
+	HistoryLength    = "HistoryLength"
+	HistorySizeBytes = "HistorySizeBytes"
+	Memo             = "Memo"
+	Encoding         = "Encoding"
+	TaskQueue        = "TaskQueue"
 
 	KafkaKey = "KafkaKey"
 )
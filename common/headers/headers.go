@@ -26,7 +26,9 @@ package headers
 
 import (
 	"context"
+	"strconv"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -41,6 +43,14 @@ const (
 
 	// ClientImplHeaderName refers to the name of the gRPC metadata header that contains the client implementation.
 	ClientImplHeaderName = "temporal-client-name"
+
+	// SuggestContinueAsNewHeaderName refers to the name of the gRPC response header set on polled decision tasks when
+	// the workflow history has grown past the namespace warn limits and the workflow should continue as new.
+	SuggestContinueAsNewHeaderName = "temporal-suggest-continue-as-new"
+
+	// HistorySizeBytesHeaderName refers to the name of the gRPC response header that contains the size of the workflow
+	// history of polled decision tasks.
+	HistorySizeBytesHeaderName = "temporal-history-size-bytes"
 )
 
 var (
@@ -109,6 +119,15 @@ func SetCLIVersions(ctx context.Context) context.Context {
 	return metadata.NewOutgoingContext(ctx, cliVersionHeaders)
 }
 
+// SetContinueAsNewSuggestion sends the continue as new suggestion and the history size of a polled decision task as
+// gRPC response headers, as the WorkflowService API response has no field for them.
+func SetContinueAsNewSuggestion(ctx context.Context, suggestContinueAsNew bool, historySizeBytes int64) error {
+	return grpc.SetHeader(ctx, metadata.Pairs(
+		SuggestContinueAsNewHeaderName, strconv.FormatBool(suggestContinueAsNew),
+		HistorySizeBytesHeaderName, strconv.FormatInt(historySizeBytes, 10),
+	))
+}
+
 // SetVersionsForTests sets headers as they would be received from the client.
 // Must be used in tests only.
 func SetVersionsForTests(ctx context.Context, clientVersion, clientImpl, clientFeatureVersion string) context.Context {
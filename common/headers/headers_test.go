@@ -30,6 +30,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -38,6 +39,10 @@ type (
 		*require.Assertions
 		suite.Suite
 	}
+
+	testServerTransportStream struct {
+		header metadata.MD
+	}
 )
 
 func TestHeadersSuite(t *testing.T) {
@@ -127,3 +132,34 @@ func (s *HeadersSuite) TestPropagateHeaders_EmptyIncomingContext() {
 	s.Equal("21.04.16", md.Get(ClientFeatureVersionHeaderName)[0])
 	s.Equal("28.08.14", md.Get(ClientImplHeaderName)[0])
 }
+
+func (s *HeadersSuite) TestSetContinueAsNewSuggestion() {
+	stream := &testServerTransportStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+	s.NoError(SetContinueAsNewSuggestion(ctx, true, 12345))
+
+	s.Equal([]string{"true"}, stream.header.Get(SuggestContinueAsNewHeaderName))
+	s.Equal([]string{"12345"}, stream.header.Get(HistorySizeBytesHeaderName))
+}
+
+func (s *HeadersSuite) TestSetContinueAsNewSuggestion_NoStream() {
+	s.Error(SetContinueAsNewSuggestion(context.Background(), true, 12345))
+}
+
+func (t *testServerTransportStream) Method() string {
+	return "PollForDecisionTask"
+}
+
+func (t *testServerTransportStream) SetHeader(md metadata.MD) error {
+	t.header = metadata.Join(t.header, md)
+	return nil
+}
+
+func (t *testServerTransportStream) SendHeader(md metadata.MD) error {
+	return t.SetHeader(md)
+}
+
+func (t *testServerTransportStream) SetTrailer(metadata.MD) error {
+	return nil
+}
@@ -852,3 +852,13 @@ func QueryLevel(s time.Time) Tag {
 func TaskQueueInfo(s interface{}) Tag {
 	return newObjectTag("task-queue-info", s)
 }
+
+// ReadPartitions returns tag for the number of read partitions of a task queue
+func ReadPartitions(n int) Tag {
+	return newInt("read-partitions", n)
+}
+
+// WritePartitions returns tag for the number of write partitions of a task queue
+func WritePartitions(n int) Tag {
+	return newInt("write-partitions", n)
+}
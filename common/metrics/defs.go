@@ -108,6 +108,9 @@ const (
 	GcPauseMsTimer       = "memory_gc_pause_ms"
 )
 
+// HistoryCompressionRatioBuckets are the histogram buckets for the ratio between uncompressed and compressed history size
+var HistoryCompressionRatioBuckets = tally.ValueBuckets{1, 1.25, 1.5, 2, 2.5, 3, 4, 5, 6, 8, 10, 15, 20}
+
 // ServiceMetrics are types for common service base metrics
 var ServiceMetrics = map[MetricName]MetricType{
 	RestartCount: Counter,
@@ -178,6 +181,18 @@ const (
 	PersistenceDeleteReplicationTaskFromDLQScope
 	// PersistenceRangeDeleteReplicationTaskFromDLQScope tracks PersistenceRangeDeleteReplicationTaskFromDLQScope calls made by service to persistence layer
 	PersistenceRangeDeleteReplicationTaskFromDLQScope
+	// PersistencePutTransferTaskToDLQScope tracks PutTransferTaskToDLQ calls made by service to persistence layer
+	PersistencePutTransferTaskToDLQScope
+	// PersistenceGetTransferTasksFromDLQScope tracks GetTransferTasksFromDLQ calls made by service to persistence layer
+	PersistenceGetTransferTasksFromDLQScope
+	// PersistenceRangeDeleteTransferTaskFromDLQScope tracks RangeDeleteTransferTaskFromDLQ calls made by service to persistence layer
+	PersistenceRangeDeleteTransferTaskFromDLQScope
+	// PersistencePutTimerTaskToDLQScope tracks PutTimerTaskToDLQ calls made by service to persistence layer
+	PersistencePutTimerTaskToDLQScope
+	// PersistenceGetTimerTasksFromDLQScope tracks GetTimerTasksFromDLQ calls made by service to persistence layer
+	PersistenceGetTimerTasksFromDLQScope
+	// PersistenceRangeDeleteTimerTaskFromDLQScope tracks RangeDeleteTimerTaskFromDLQ calls made by service to persistence layer
+	PersistenceRangeDeleteTimerTaskFromDLQScope
 	// PersistenceGetTimerTaskScope tracks GetTimerTask calls made by service to persistence layer
 	PersistenceGetTimerTaskScope
 	// PersistenceGetTimerIndexTasksScope tracks GetTimerIndexTasks calls made by service to persistence layer
@@ -354,6 +369,8 @@ const (
 	HistoryClientMergeDLQMessagesScope
 	// HistoryClientRefreshWorkflowTasksScope tracks RPC calls to history service
 	HistoryClientRefreshWorkflowTasksScope
+	// HistoryClientUpdateWorkflowExecutionMetadataScope tracks RPC calls to history service
+	HistoryClientUpdateWorkflowExecutionMetadataScope
 	// MatchingClientPollForDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientPollForDecisionTaskScope
 	// MatchingClientPollForActivityTaskScope tracks RPC calls to matching service
@@ -372,6 +389,18 @@ const (
 	MatchingClientDescribeTaskQueueScope
 	// MatchingClientListTaskQueuePartitionsScope tracks RPC calls to matching service
 	MatchingClientListTaskQueuePartitionsScope
+	// MatchingClientGetPartitionCountScope tracks RPC calls to matching service
+	MatchingClientGetPartitionCountScope
+	// MatchingClientUpdateVersionSetsScope tracks RPC calls to matching service
+	MatchingClientUpdateVersionSetsScope
+	// MatchingClientGetVersionSetsScope tracks RPC calls to matching service
+	MatchingClientGetVersionSetsScope
+	// MatchingClientUpdateTaskQueueStateScope tracks RPC calls to matching service
+	MatchingClientUpdateTaskQueueStateScope
+	// MatchingClientUpdateTaskQueueRateLimitScope tracks RPC calls to matching service
+	MatchingClientUpdateTaskQueueRateLimitScope
+	// MatchingClientListWorkersScope tracks RPC calls to matching service
+	MatchingClientListWorkersScope
 	// FrontendClientDeprecateNamespaceScope tracks RPC calls to frontend service
 	FrontendClientDeprecateNamespaceScope
 	// FrontendClientDescribeNamespaceScope tracks RPC calls to frontend service
@@ -484,6 +513,30 @@ const (
 	AdminClientRefreshWorkflowTasksScope
 	// AdminClientResendReplicationTasksScope tracks RPC calls to admin service
 	AdminClientResendReplicationTasksScope
+	// AdminClientDescribeTaskQueueScope tracks RPC calls to admin service
+	AdminClientDescribeTaskQueueScope
+	// AdminClientUpdateBuildIdCompatibilityScope tracks RPC calls to admin service
+	AdminClientUpdateBuildIdCompatibilityScope
+	// AdminClientGetBuildIdCompatibilityScope tracks RPC calls to admin service
+	AdminClientGetBuildIdCompatibilityScope
+	// AdminClientPauseTaskQueueScope tracks RPC calls to admin service
+	AdminClientPauseTaskQueueScope
+	// AdminClientResumeTaskQueueScope tracks RPC calls to admin service
+	AdminClientResumeTaskQueueScope
+	// AdminClientUpdateTaskQueueRateLimitScope tracks RPC calls to admin service
+	AdminClientUpdateTaskQueueRateLimitScope
+	// AdminClientListWorkersScope tracks RPC calls to admin service
+	AdminClientListWorkersScope
+	// AdminClientResetWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientResetWorkflowExecutionScope
+	// AdminClientPollForDecisionTaskScope tracks RPC calls to admin service
+	AdminClientPollForDecisionTaskScope
+	// AdminClientUpdateWorkflowExecutionMetadataScope tracks RPC calls to admin service
+	AdminClientUpdateWorkflowExecutionMetadataScope
+	// AdminClientUpdateNamespaceDefaultRetryPoliciesScope tracks RPC calls to admin service
+	AdminClientUpdateNamespaceDefaultRetryPoliciesScope
+	// AdminClientDescribeNamespaceDefaultRetryPoliciesScope tracks RPC calls to admin service
+	AdminClientDescribeNamespaceDefaultRetryPoliciesScope
 	// DCRedirectionDeprecateNamespaceScope tracks RPC calls for dc redirection
 	DCRedirectionDeprecateNamespaceScope
 	// DCRedirectionDescribeNamespaceScope tracks RPC calls for dc redirection
@@ -687,6 +740,30 @@ const (
 	AdminRefreshWorkflowTasksScope
 	// AdminResendReplicationTasksScope is the metric scope for admin.ResendReplicationTasks
 	AdminResendReplicationTasksScope
+	// AdminDescribeTaskQueueScope is the metric scope for admin.DescribeTaskQueue
+	AdminDescribeTaskQueueScope
+	// AdminUpdateBuildIdCompatibilityScope is the metric scope for admin.UpdateWorkerBuildIdCompatibility
+	AdminUpdateBuildIdCompatibilityScope
+	// AdminGetBuildIdCompatibilityScope is the metric scope for admin.GetWorkerBuildIdCompatibility
+	AdminGetBuildIdCompatibilityScope
+	// AdminPauseTaskQueueScope is the metric scope for admin.PauseTaskQueue
+	AdminPauseTaskQueueScope
+	// AdminResumeTaskQueueScope is the metric scope for admin.ResumeTaskQueue
+	AdminResumeTaskQueueScope
+	// AdminUpdateTaskQueueRateLimitScope is the metric scope for admin.UpdateTaskQueueRateLimit
+	AdminUpdateTaskQueueRateLimitScope
+	// AdminListWorkersScope is the metric scope for admin.ListWorkers
+	AdminListWorkersScope
+	// AdminResetWorkflowExecutionScope is the metric scope for admin.ResetWorkflowExecution
+	AdminResetWorkflowExecutionScope
+	// AdminPollForDecisionTaskScope is the metric scope for admin.PollForDecisionTask
+	AdminPollForDecisionTaskScope
+	// AdminUpdateWorkflowExecutionMetadataScope is the metric scope for admin.UpdateWorkflowExecutionMetadata
+	AdminUpdateWorkflowExecutionMetadataScope
+	// AdminUpdateNamespaceRetryPoliciesScope is the metric scope for admin.UpdateNamespaceDefaultRetryPolicies
+	AdminUpdateNamespaceRetryPoliciesScope
+	// AdminDescribeNamespaceRetryPoliciesScope is the metric scope for admin.DescribeNamespaceDefaultRetryPolicies
+	AdminDescribeNamespaceRetryPoliciesScope
 	// AdminRemoveTaskScope is the metric scope for admin.AdminRemoveTaskScope
 	AdminRemoveTaskScope
 	//AdminCloseShardTaskScope is the metric scope for admin.AdminRemoveTaskScope
@@ -855,6 +932,8 @@ const (
 	HistoryReapplyEventsScope
 	// HistoryRefreshWorkflowTasksScope is the scope used by refresh workflow tasks API
 	HistoryRefreshWorkflowTasksScope
+	// HistoryUpdateWorkflowExecutionMetadataScope is the scope used by update workflow execution metadata API
+	HistoryUpdateWorkflowExecutionMetadataScope
 	// TaskPriorityAssignerScope is the scope used by all metric emitted by task priority assigner
 	TaskPriorityAssignerScope
 	// TransferQueueProcessorScope is the scope used by all metric emitted by transfer queue processor
@@ -1013,6 +1092,18 @@ const (
 	MatchingDescribeTaskQueueScope
 	// MatchingListTaskQueuePartitionsScope tracks ListTaskQueuePartitions API calls received by service
 	MatchingListTaskQueuePartitionsScope
+	// MatchingGetPartitionCountScope tracks GetTaskQueuePartitionCount API calls received by service
+	MatchingGetPartitionCountScope
+	// MatchingUpdateVersionSetsScope tracks UpdateWorkerBuildIdCompatibility API calls received by service
+	MatchingUpdateVersionSetsScope
+	// MatchingGetVersionSetsScope tracks GetWorkerBuildIdCompatibility API calls received by service
+	MatchingGetVersionSetsScope
+	// MatchingUpdateTaskQueueStateScope tracks UpdateTaskQueueState API calls received by service
+	MatchingUpdateTaskQueueStateScope
+	// MatchingUpdateTaskQueueRateLimitScope tracks UpdateTaskQueueRateLimit API calls received by service
+	MatchingUpdateTaskQueueRateLimitScope
+	// MatchingListWorkersScope tracks ListWorkers API calls received by service
+	MatchingListWorkersScope
 
 	NumMatchingScopes
 )
@@ -1091,6 +1182,12 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceGetReplicationTasksFromDLQScope:               {operation: "GetReplicationTasksFromDLQ"},
 		PersistenceDeleteReplicationTaskFromDLQScope:             {operation: "DeleteReplicationTaskFromDLQ"},
 		PersistenceRangeDeleteReplicationTaskFromDLQScope:        {operation: "RangeDeleteReplicationTaskFromDLQ"},
+		PersistencePutTransferTaskToDLQScope:                     {operation: "PutTransferTaskToDLQ"},
+		PersistenceGetTransferTasksFromDLQScope:                  {operation: "GetTransferTasksFromDLQ"},
+		PersistenceRangeDeleteTransferTaskFromDLQScope:           {operation: "RangeDeleteTransferTaskFromDLQ"},
+		PersistencePutTimerTaskToDLQScope:                        {operation: "PutTimerTaskToDLQ"},
+		PersistenceGetTimerTasksFromDLQScope:                     {operation: "GetTimerTasksFromDLQ"},
+		PersistenceRangeDeleteTimerTaskFromDLQScope:              {operation: "RangeDeleteTimerTaskFromDLQ"},
 		PersistenceGetTimerTaskScope:                             {operation: "GetTimerTask"},
 		PersistenceGetTimerIndexTasksScope:                       {operation: "GetTimerIndexTasks"},
 		PersistenceCompleteTimerTaskScope:                        {operation: "CompleteTimerTask"},
@@ -1190,6 +1287,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientPurgeDLQMessagesScope:                    {operation: "HistoryClientPurgeDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientMergeDLQMessagesScope:                    {operation: "HistoryClientMergeDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRefreshWorkflowTasksScope:                {operation: "HistoryClientRefreshWorkflowTasksScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUpdateWorkflowExecutionMetadataScope:     {operation: "HistoryClientUpdateWorkflowExecutionMetadata", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		MatchingClientPollForDecisionTaskScope:                {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientPollForActivityTaskScope:                {operation: "MatchingClientPollForActivityTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientAddActivityTaskScope:                    {operation: "MatchingClientAddActivityTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
@@ -1199,6 +1297,12 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingClientCancelOutstandingPollScope:              {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientDescribeTaskQueueScope:                  {operation: "MatchingClientDescribeTaskQueue", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientListTaskQueuePartitionsScope:            {operation: "MatchingClientListTaskQueuePartitions", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientGetPartitionCountScope:                  {operation: "MatchingClientGetTaskQueuePartitionCount", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientUpdateVersionSetsScope:                  {operation: "MatchingClientUpdateWorkerBuildIdCompatibility", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientGetVersionSetsScope:                     {operation: "MatchingClientGetWorkerBuildIdCompatibility", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientUpdateTaskQueueStateScope:               {operation: "MatchingClientUpdateTaskQueueState", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientUpdateTaskQueueRateLimitScope:           {operation: "MatchingClientUpdateTaskQueueRateLimit", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientListWorkersScope:                        {operation: "MatchingClientListWorkers", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		FrontendClientDeprecateNamespaceScope:                 {operation: "FrontendClientDeprecateNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeNamespaceScope:                  {operation: "FrontendClientDescribeNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeTaskQueueScope:                  {operation: "FrontendClientDescribeTaskQueue", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
@@ -1251,6 +1355,18 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminClientDescribeClusterScope:                       {operation: "AdminClientDescribeCluster", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientRefreshWorkflowTasksScope:                  {operation: "AdminClientRefreshWorkflowTasks", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientResendReplicationTasksScope:                {operation: "AdminClientResendReplicationTasks", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeTaskQueueScope:                     {operation: "AdminClientDescribeTaskQueue", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateBuildIdCompatibilityScope:            {operation: "AdminClientUpdateWorkerBuildIdCompatibility", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetBuildIdCompatibilityScope:               {operation: "AdminClientGetWorkerBuildIdCompatibility", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientPauseTaskQueueScope:                        {operation: "AdminClientPauseTaskQueue", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientResumeTaskQueueScope:                       {operation: "AdminClientResumeTaskQueue", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateTaskQueueRateLimitScope:              {operation: "AdminClientUpdateTaskQueueRateLimit", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientListWorkersScope:                           {operation: "AdminClientListWorkers", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientResetWorkflowExecutionScope:                {operation: "AdminClientResetWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientPollForDecisionTaskScope:                   {operation: "AdminClientPollForDecisionTask", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateWorkflowExecutionMetadataScope:       {operation: "AdminClientUpdateWorkflowExecutionMetadata", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateNamespaceDefaultRetryPoliciesScope:   {operation: "AdminClientUpdateNamespaceDefaultRetryPolicies", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeNamespaceDefaultRetryPoliciesScope: {operation: "AdminClientDescribeNamespaceDefaultRetryPolicies", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientCloseShardScope:                            {operation: "AdminClientCloseShard", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientReadDLQMessagesScope:                       {operation: "AdminClientReadDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientPurgeDLQMessagesScope:                      {operation: "AdminClientPurgeDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
@@ -1353,6 +1469,18 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminReapplyEventsScope:                    {operation: "ReapplyEvents"},
 		AdminRefreshWorkflowTasksScope:             {operation: "RefreshWorkflowTasks"},
 		AdminResendReplicationTasksScope:           {operation: "ResendReplicationTasks"},
+		AdminDescribeTaskQueueScope:                {operation: "DescribeTaskQueue"},
+		AdminUpdateBuildIdCompatibilityScope:       {operation: "UpdateWorkerBuildIdCompatibility"},
+		AdminGetBuildIdCompatibilityScope:          {operation: "GetWorkerBuildIdCompatibility"},
+		AdminPauseTaskQueueScope:                   {operation: "PauseTaskQueue"},
+		AdminResumeTaskQueueScope:                  {operation: "ResumeTaskQueue"},
+		AdminUpdateTaskQueueRateLimitScope:         {operation: "UpdateTaskQueueRateLimit"},
+		AdminListWorkersScope:                      {operation: "ListWorkers"},
+		AdminResetWorkflowExecutionScope:           {operation: "ResetWorkflowExecution"},
+		AdminPollForDecisionTaskScope:              {operation: "PollForDecisionTask"},
+		AdminUpdateWorkflowExecutionMetadataScope:  {operation: "UpdateWorkflowExecutionMetadata"},
+		AdminUpdateNamespaceRetryPoliciesScope:     {operation: "UpdateNamespaceDefaultRetryPolicies"},
+		AdminDescribeNamespaceRetryPoliciesScope:   {operation: "DescribeNamespaceDefaultRetryPolicies"},
 
 		FrontendStartWorkflowExecutionScope:             {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:                {operation: "PollForDecisionTask"},
@@ -1433,6 +1561,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryShardControllerScope:                            {operation: "ShardController"},
 		HistoryReapplyEventsScope:                              {operation: "EventReapplication"},
 		HistoryRefreshWorkflowTasksScope:                       {operation: "RefreshWorkflowTasks"},
+		HistoryUpdateWorkflowExecutionMetadataScope:            {operation: "UpdateWorkflowExecutionMetadata"},
 		TaskPriorityAssignerScope:                              {operation: "TaskPriorityAssigner"},
 		TransferQueueProcessorScope:                            {operation: "TransferQueueProcessor"},
 		TransferActiveQueueProcessorScope:                      {operation: "TransferActiveQueueProcessor"},
@@ -1509,6 +1638,12 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
 		MatchingDescribeTaskQueueScope:         {operation: "DescribeTaskQueue"},
 		MatchingListTaskQueuePartitionsScope:   {operation: "ListTaskQueuePartitions"},
+		MatchingGetPartitionCountScope:         {operation: "GetTaskQueuePartitionCount"},
+		MatchingUpdateVersionSetsScope:         {operation: "UpdateWorkerBuildIdCompatibility"},
+		MatchingGetVersionSetsScope:            {operation: "GetWorkerBuildIdCompatibility"},
+		MatchingUpdateTaskQueueStateScope:      {operation: "UpdateTaskQueueState"},
+		MatchingUpdateTaskQueueRateLimitScope:  {operation: "UpdateTaskQueueRateLimit"},
+		MatchingListWorkersScope:               {operation: "ListWorkers"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -1571,6 +1706,7 @@ const (
 	PersistenceErrNamespaceAlreadyExistsCounter
 	PersistenceErrBadRequestCounter
 	PersistenceSampledCounter
+	PersistenceHistoryCompressionRatio
 
 	ClientRequests
 	ClientFailures
@@ -1670,8 +1806,12 @@ const (
 	TaskLatency
 	TaskFailures
 	TaskDiscarded
+	TaskMovedToDLQCounter
+	TaskDLQFailures
+	TaskDLQNonEmptyGauge
 	TaskAttemptTimer
 	TaskStandbyRetryCounter
+	TaskQueueDrainingCounter
 	TaskNotActiveCounter
 	TaskLimitExceededCounter
 	TaskBatchCompleteCounter
@@ -1718,6 +1858,7 @@ const (
 	AcquireShardsCounter
 	AcquireShardsLatency
 	ShardClosedCounter
+	ShardHandoffCounter
 	ShardItemCreatedCounter
 	ShardItemRemovedCounter
 	ShardItemAcquisitionLatency
@@ -1761,6 +1902,8 @@ const (
 	CacheFailures
 	CacheLatency
 	CacheMissCounter
+	CacheSize
+	CacheEvictionCounter
 	AcquireLockFailedCounter
 	WorkflowContextCleared
 	MutableStateSize
@@ -1866,6 +2009,13 @@ const (
 	LocalToRemoteMatchPerTaskQueueCounter
 	RemoteToLocalMatchPerTaskQueueCounter
 	RemoteToRemoteMatchPerTaskQueueCounter
+	BacklogCountPerTaskQueueGauge
+	BacklogAgePerTaskQueueGauge
+	TaskAddRatePerTaskQueueGauge
+	TaskDispatchRatePerTaskQueueGauge
+	SyncMatchRatioPerTaskQueueGauge
+	ReadPartitionsPerTaskQueueGauge
+	WritePartitionsPerTaskQueueGauge
 
 	NumMatchingMetrics
 )
@@ -1969,6 +2119,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceErrNamespaceAlreadyExistsCounter:         {metricName: "persistence_errors_namespace_already_exists", metricType: Counter},
 		PersistenceErrBadRequestCounter:                     {metricName: "persistence_errors_bad_request", metricType: Counter},
 		PersistenceSampledCounter:                           {metricName: "persistence_sampled", metricType: Counter},
+		PersistenceHistoryCompressionRatio:                  {metricName: "persistence_history_compression_ratio", metricType: Timer, buckets: HistoryCompressionRatioBuckets},
 		ClientRequests:                                      {metricName: "client_requests", metricType: Counter},
 		ClientFailures:                                      {metricName: "client_errors", metricType: Counter},
 		ClientLatency:                                       {metricName: "client_latency", metricType: Timer},
@@ -2089,7 +2240,11 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		TaskAttemptTimer:                                  {metricName: "task_attempt", metricType: Timer},
 		TaskFailures:                                      {metricName: "task_errors", metricType: Counter},
 		TaskDiscarded:                                     {metricName: "task_errors_discarded", metricType: Counter},
+		TaskMovedToDLQCounter:                             {metricName: "task_errors_moved_to_dlq", metricType: Counter},
+		TaskDLQFailures:                                   {metricName: "task_errors_dlq_failed", metricType: Counter},
+		TaskDLQNonEmptyGauge:                              {metricName: "task_dlq_non_empty", metricType: Gauge},
 		TaskStandbyRetryCounter:                           {metricName: "task_errors_standby_retry_counter", metricType: Counter},
+		TaskQueueDrainingCounter:                          {metricName: "task_errors_task_queue_draining_counter", metricType: Counter},
 		TaskNotActiveCounter:                              {metricName: "task_errors_not_active_counter", metricType: Counter},
 		TaskLimitExceededCounter:                          {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
 		TaskProcessingLatency:                             {metricName: "task_latency_processing", metricType: Timer},
@@ -2133,6 +2288,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		AcquireShardsCounter:                              {metricName: "acquire_shards_count", metricType: Counter},
 		AcquireShardsLatency:                              {metricName: "acquire_shards_latency", metricType: Timer},
 		ShardClosedCounter:                                {metricName: "shard_closed_count", metricType: Counter},
+		ShardHandoffCounter:                               {metricName: "shard_handoff_count", metricType: Counter},
 		ShardItemCreatedCounter:                           {metricName: "sharditem_created_count", metricType: Counter},
 		ShardItemRemovedCounter:                           {metricName: "sharditem_removed_count", metricType: Counter},
 		ShardItemAcquisitionLatency:                       {metricName: "sharditem_acquisition_latency", metricType: Timer},
@@ -2176,6 +2332,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CacheFailures:                                     {metricName: "cache_errors", metricType: Counter},
 		CacheLatency:                                      {metricName: "cache_latency", metricType: Timer},
 		CacheMissCounter:                                  {metricName: "cache_miss", metricType: Counter},
+		CacheSize:                                         {metricName: "cache_size", metricType: Timer},
+		CacheEvictionCounter:                              {metricName: "cache_evictions", metricType: Counter},
 		AcquireLockFailedCounter:                          {metricName: "acquire_lock_failed", metricType: Counter},
 		WorkflowContextCleared:                            {metricName: "workflow_context_cleared", metricType: Counter},
 		MutableStateSize:                                  {metricName: "mutable_state_size", metricType: Timer},
@@ -2277,6 +2435,13 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		LocalToRemoteMatchPerTaskQueueCounter:     {metricName: "local_to_remote_matches_per_tl", metricRollupName: "local_to_remote_matches"},
 		RemoteToLocalMatchPerTaskQueueCounter:     {metricName: "remote_to_local_matches_per_tl", metricRollupName: "remote_to_local_matches"},
 		RemoteToRemoteMatchPerTaskQueueCounter:    {metricName: "remote_to_remote_matches_per_tl", metricRollupName: "remote_to_remote_matches"},
+		BacklogCountPerTaskQueueGauge:             {metricName: "approximate_backlog_count_per_tl", metricType: Gauge},
+		BacklogAgePerTaskQueueGauge:               {metricName: "approximate_backlog_age_seconds_per_tl", metricType: Gauge},
+		TaskAddRatePerTaskQueueGauge:              {metricName: "tasks_add_rate_per_tl", metricType: Gauge},
+		TaskDispatchRatePerTaskQueueGauge:         {metricName: "tasks_dispatch_rate_per_tl", metricType: Gauge},
+		SyncMatchRatioPerTaskQueueGauge:           {metricName: "sync_match_ratio_per_tl", metricType: Gauge},
+		ReadPartitionsPerTaskQueueGauge:           {metricName: "read_partitions_per_tl", metricType: Gauge},
+		WritePartitionsPerTaskQueueGauge:          {metricName: "write_partitions_per_tl", metricType: Gauge},
 	},
 	Worker: {
 		ReplicatorMessages:                            {metricName: "replicator_messages"},
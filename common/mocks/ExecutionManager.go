@@ -382,6 +382,108 @@ func (_m *ExecutionManager) RangeCompleteReplicationTask(request *persistence.Ra
 	return r0
 }
 
+// PutTransferTaskToDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) PutTransferTaskToDLQ(request *persistence.PutTransferTaskToDLQRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.PutTransferTaskToDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTransferTasksFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) GetTransferTasksFromDLQ(request *persistence.GetTransferTasksFromDLQRequest) (*persistence.GetTransferTasksFromDLQResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetTransferTasksFromDLQResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetTransferTasksFromDLQRequest) *persistence.GetTransferTasksFromDLQResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetTransferTasksFromDLQResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetTransferTasksFromDLQRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RangeDeleteTransferTaskFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) RangeDeleteTransferTaskFromDLQ(request *persistence.RangeDeleteTransferTaskFromDLQRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.RangeDeleteTransferTaskFromDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PutTimerTaskToDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) PutTimerTaskToDLQ(request *persistence.PutTimerTaskToDLQRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.PutTimerTaskToDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetTimerTasksFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) GetTimerTasksFromDLQ(request *persistence.GetTimerTasksFromDLQRequest) (*persistence.GetTimerTasksFromDLQResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.GetTimerTasksFromDLQResponse
+	if rf, ok := ret.Get(0).(func(*persistence.GetTimerTasksFromDLQRequest) *persistence.GetTimerTasksFromDLQResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.GetTimerTasksFromDLQResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.GetTimerTasksFromDLQRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RangeDeleteTimerTaskFromDLQ provides a mock function with given fields: request
+func (_m *ExecutionManager) RangeDeleteTimerTaskFromDLQ(request *persistence.RangeDeleteTimerTaskFromDLQRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.RangeDeleteTimerTaskFromDLQRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PutReplicationTaskToDLQ provides a mock function with given fields: request
 func (_m *ExecutionManager) PutReplicationTaskToDLQ(request *persistence.PutReplicationTaskToDLQRequest) error {
 	ret := _m.Called(request)
@@ -27,6 +27,8 @@ package namespace
 const (
 	// MinRetentionDays is the minimal retention days for any namespace
 	MinRetentionDays = 1
+	// MaxRetentionDays is the maximal retention days a single workflow can request
+	MaxRetentionDays = 3650
 
 	// MaxBadBinaries is the maximal number of bad client binaries stored in a namespace
 	MaxBadBinaries = 10
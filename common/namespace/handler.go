@@ -39,6 +39,7 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	"github.com/temporalio/temporal/common"
@@ -74,6 +75,14 @@ type (
 			ctx context.Context,
 			updateRequest *workflowservice.UpdateNamespaceRequest,
 		) (*workflowservice.UpdateNamespaceResponse, error)
+		UpdateNamespaceDefaultRetryPolicies(
+			ctx context.Context,
+			updateRequest *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest,
+		) (*adminservice.UpdateNamespaceDefaultRetryPoliciesResponse, error)
+		DescribeNamespaceDefaultRetryPolicies(
+			ctx context.Context,
+			describeRequest *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest,
+		) (*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, error)
 	}
 
 	// HandlerImpl is the namespace operation handler implementation
@@ -581,6 +590,116 @@ func (d *HandlerImpl) UpdateNamespace(
 	return response, nil
 }
 
+// UpdateNamespaceDefaultRetryPolicies updates the retry policies applied to activities and workflows
+// of the namespace which are scheduled or started without one
+func (d *HandlerImpl) UpdateNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	updateRequest *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest,
+) (*adminservice.UpdateNamespaceDefaultRetryPoliciesResponse, error) {
+
+	if err := common.ValidateRetryPolicy(updateRequest.GetDefaultActivityRetryPolicy()); err != nil {
+		return nil, err
+	}
+	if err := common.ValidateRetryPolicy(updateRequest.GetDefaultWorkflowRetryPolicy()); err != nil {
+		return nil, err
+	}
+
+	// see UpdateNamespace, the notification version must be read before the namespace
+	metadata, err := d.metadataMgr.GetMetadata()
+	if err != nil {
+		return nil, err
+	}
+	notificationVersion := metadata.NotificationVersion
+	getResponse, err := d.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: updateRequest.GetNamespace()})
+	if err != nil {
+		return nil, err
+	}
+
+	info := getResponse.Namespace.Info
+	config := getResponse.Namespace.Config
+	replicationConfig := getResponse.Namespace.ReplicationConfig
+	configVersion := getResponse.Namespace.ConfigVersion
+	failoverVersion := getResponse.Namespace.FailoverVersion
+	isGlobalNamespace := getResponse.IsGlobalNamespace
+
+	configurationChanged := false
+	if updateRequest.GetClearDefaultActivityRetryPolicy() {
+		configurationChanged = true
+		config.DefaultActivityRetryPolicy = nil
+	} else if updateRequest.DefaultActivityRetryPolicy != nil {
+		configurationChanged = true
+		config.DefaultActivityRetryPolicy = updateRequest.DefaultActivityRetryPolicy
+	}
+	if updateRequest.GetClearDefaultWorkflowRetryPolicy() {
+		configurationChanged = true
+		config.DefaultWorkflowRetryPolicy = nil
+	} else if updateRequest.DefaultWorkflowRetryPolicy != nil {
+		configurationChanged = true
+		config.DefaultWorkflowRetryPolicy = updateRequest.DefaultWorkflowRetryPolicy
+	}
+
+	if isGlobalNamespace && !d.clusterMetadata.IsMasterCluster() {
+		return nil, errNotMasterCluster
+	}
+
+	if configurationChanged {
+		configVersion++
+		err = d.metadataMgr.UpdateNamespace(&persistence.UpdateNamespaceRequest{
+			Namespace: &persistenceblobs.NamespaceDetail{
+				Info:                        info,
+				Config:                      config,
+				ReplicationConfig:           replicationConfig,
+				ConfigVersion:               configVersion,
+				FailoverVersion:             failoverVersion,
+				FailoverNotificationVersion: getResponse.Namespace.FailoverNotificationVersion,
+			},
+			NotificationVersion: notificationVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if isGlobalNamespace {
+			err = d.namespaceReplicator.HandleTransmissionTask(enumsgenpb.NAMESPACE_OPERATION_UPDATE,
+				info, config, replicationConfig, configVersion, failoverVersion, isGlobalNamespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		d.logger.Info("Update namespace default retry policies succeeded",
+			tag.WorkflowNamespace(info.Name),
+			tag.WorkflowNamespaceID(info.Id),
+		)
+	}
+
+	return &adminservice.UpdateNamespaceDefaultRetryPoliciesResponse{
+		DefaultActivityRetryPolicy: config.DefaultActivityRetryPolicy,
+		DefaultWorkflowRetryPolicy: config.DefaultWorkflowRetryPolicy,
+	}, nil
+}
+
+// DescribeNamespaceDefaultRetryPolicies returns the default retry policies of the namespace
+func (d *HandlerImpl) DescribeNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	describeRequest *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest,
+) (*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, error) {
+
+	if describeRequest.GetNamespace() == "" {
+		return nil, serviceerror.NewInvalidArgument("Namespace is empty.")
+	}
+
+	resp, err := d.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: describeRequest.GetNamespace()})
+	if err != nil {
+		return nil, err
+	}
+
+	return &adminservice.DescribeNamespaceDefaultRetryPoliciesResponse{
+		DefaultActivityRetryPolicy: resp.Namespace.Config.GetDefaultActivityRetryPolicy(),
+		DefaultWorkflowRetryPolicy: resp.Namespace.Config.GetDefaultWorkflowRetryPolicy(),
+	}, nil
+}
+
 // DeprecateNamespace deprecates a namespace
 func (d *HandlerImpl) DeprecateNamespace(
 	ctx context.Context,
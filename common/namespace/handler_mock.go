@@ -31,6 +31,7 @@ package namespace
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	adminservice "github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	workflowservice "go.temporal.io/temporal-proto/workflowservice/v1"
 	reflect "reflect"
 )
@@ -132,3 +133,33 @@ func (mr *MockHandlerMockRecorder) UpdateNamespace(ctx, updateRequest interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespace", reflect.TypeOf((*MockHandler)(nil).UpdateNamespace), ctx, updateRequest)
 }
+
+// UpdateNamespaceDefaultRetryPolicies mocks base method.
+func (m *MockHandler) UpdateNamespaceDefaultRetryPolicies(ctx context.Context, updateRequest *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest) (*adminservice.UpdateNamespaceDefaultRetryPoliciesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNamespaceDefaultRetryPolicies", ctx, updateRequest)
+	ret0, _ := ret[0].(*adminservice.UpdateNamespaceDefaultRetryPoliciesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNamespaceDefaultRetryPolicies indicates an expected call of UpdateNamespaceDefaultRetryPolicies.
+func (mr *MockHandlerMockRecorder) UpdateNamespaceDefaultRetryPolicies(ctx, updateRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespaceDefaultRetryPolicies", reflect.TypeOf((*MockHandler)(nil).UpdateNamespaceDefaultRetryPolicies), ctx, updateRequest)
+}
+
+// DescribeNamespaceDefaultRetryPolicies mocks base method.
+func (m *MockHandler) DescribeNamespaceDefaultRetryPolicies(ctx context.Context, describeRequest *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest) (*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeNamespaceDefaultRetryPolicies", ctx, describeRequest)
+	ret0, _ := ret[0].(*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNamespaceDefaultRetryPolicies indicates an expected call of DescribeNamespaceDefaultRetryPolicies.
+func (mr *MockHandlerMockRecorder) DescribeNamespaceDefaultRetryPolicies(ctx, describeRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNamespaceDefaultRetryPolicies", reflect.TypeOf((*MockHandler)(nil).DescribeNamespaceDefaultRetryPolicies), ctx, describeRequest)
+}
@@ -122,12 +122,14 @@ func (h *namespaceReplicationTaskExecutorImpl) handleNamespaceCreationReplicatio
 				Data:        task.Info.Data,
 			},
 			Config: &persistenceblobs.NamespaceConfig{
-				RetentionDays:            task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
-				EmitMetric:               task.Config.GetEmitMetric().GetValue(),
-				HistoryArchivalStatus:    task.Config.GetHistoryArchivalStatus(),
-				HistoryArchivalUri:       task.Config.GetHistoryArchivalUri(),
-				VisibilityArchivalStatus: task.Config.GetVisibilityArchivalStatus(),
-				VisibilityArchivalUri:    task.Config.GetVisibilityArchivalUri(),
+				RetentionDays:              task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
+				EmitMetric:                 task.Config.GetEmitMetric().GetValue(),
+				HistoryArchivalStatus:      task.Config.GetHistoryArchivalStatus(),
+				HistoryArchivalUri:         task.Config.GetHistoryArchivalUri(),
+				VisibilityArchivalStatus:   task.Config.GetVisibilityArchivalStatus(),
+				VisibilityArchivalUri:      task.Config.GetVisibilityArchivalUri(),
+				DefaultActivityRetryPolicy: task.GetDefaultActivityRetryPolicy(),
+				DefaultWorkflowRetryPolicy: task.GetDefaultWorkflowRetryPolicy(),
 			},
 			ReplicationConfig: &persistenceblobs.NamespaceReplicationConfig{
 				ActiveClusterName: task.ReplicationConfig.GetActiveClusterName(),
@@ -234,12 +236,14 @@ func (h *namespaceReplicationTaskExecutorImpl) handleNamespaceUpdateReplicationT
 			Data:        task.Info.Data,
 		}
 		request.Namespace.Config = &persistenceblobs.NamespaceConfig{
-			RetentionDays:            task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
-			EmitMetric:               task.Config.GetEmitMetric().GetValue(),
-			HistoryArchivalStatus:    task.Config.GetHistoryArchivalStatus(),
-			HistoryArchivalUri:       task.Config.GetHistoryArchivalUri(),
-			VisibilityArchivalStatus: task.Config.GetVisibilityArchivalStatus(),
-			VisibilityArchivalUri:    task.Config.GetVisibilityArchivalUri(),
+			RetentionDays:              task.Config.GetWorkflowExecutionRetentionPeriodInDays(),
+			EmitMetric:                 task.Config.GetEmitMetric().GetValue(),
+			HistoryArchivalStatus:      task.Config.GetHistoryArchivalStatus(),
+			HistoryArchivalUri:         task.Config.GetHistoryArchivalUri(),
+			VisibilityArchivalStatus:   task.Config.GetVisibilityArchivalStatus(),
+			VisibilityArchivalUri:      task.Config.GetVisibilityArchivalUri(),
+			DefaultActivityRetryPolicy: task.GetDefaultActivityRetryPolicy(),
+			DefaultWorkflowRetryPolicy: task.GetDefaultWorkflowRetryPolicy(),
 		}
 		if task.Config.GetBadBinaries() != nil {
 			request.Namespace.Config.BadBinaries = task.Config.GetBadBinaries()
@@ -96,8 +96,10 @@ func (namespaceReplicator *namespaceReplicatorImpl) HandleTransmissionTask(names
 				ActiveClusterName: replicationConfig.ActiveClusterName,
 				Clusters:          namespaceReplicator.convertClusterReplicationConfigToProto(replicationConfig.Clusters),
 			},
-			ConfigVersion:   configVersion,
-			FailoverVersion: failoverVersion,
+			ConfigVersion:              configVersion,
+			FailoverVersion:            failoverVersion,
+			DefaultActivityRetryPolicy: config.DefaultActivityRetryPolicy,
+			DefaultWorkflowRetryPolicy: config.DefaultWorkflowRetryPolicy,
 		},
 	}
 
@@ -79,6 +79,14 @@ const (
 	// Row Constants for Replication Task DLQ Row. Source cluster name will be used as WorkflowID.
 	rowTypeDLQNamespaceID = "10000000-6000-f000-f000-000000000000"
 	rowTypeDLQRunID       = "30000000-6000-f000-f000-000000000000"
+	// Row Constants for Transfer Task DLQ Row
+	rowTypeTransferDLQNamespaceID = "10000000-7000-f000-f000-000000000000"
+	rowTypeTransferDLQWorkflowID  = "20000000-7000-f000-f000-000000000000"
+	rowTypeTransferDLQRunID       = "30000000-7000-f000-f000-000000000000"
+	// Row Constants for Timer Task DLQ Row
+	rowTypeTimerDLQNamespaceID = "10000000-8000-f000-f000-000000000000"
+	rowTypeTimerDLQWorkflowID  = "20000000-8000-f000-f000-000000000000"
+	rowTypeTimerDLQRunID       = "30000000-8000-f000-f000-000000000000"
 	// Special TaskId constants
 	rowTypeExecutionTaskID = int64(-10)
 	rowTypeShardTaskID     = int64(-11)
@@ -95,6 +103,8 @@ const (
 	rowTypeTimerTask
 	rowTypeReplicationTask
 	rowTypeDLQ
+	rowTypeTransferDLQ
+	rowTypeTimerDLQ
 )
 
 const (
@@ -589,6 +599,17 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ? `
 
+	templateGetTimerTasksFromDLQQuery = `SELECT timer, timer_encoding ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and namespace_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id > ? ` +
+		`and task_id <= ?`
+
 	templateGetTimerTasksQuery = `SELECT timer, timer_encoding ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -1974,7 +1995,7 @@ func (d *cassandraPersistence) GetTransferTasks(request *p.GetTransferTasksReque
 	query := d.session.Query(templateGetTransferTasksQuery,
 		d.shardID,
 		rowTypeTransferTask,
-		rowTypeTransferNamespaceID,
+		transferTaskRowNamespaceID(request.IsolatedNamespaceID),
 		rowTypeTransferWorkflowID,
 		rowTypeTransferRunID,
 		defaultVisibilityTimestamp,
@@ -2113,7 +2134,7 @@ func (d *cassandraPersistence) RangeCompleteTransferTask(request *p.RangeComplet
 	query := d.session.Query(templateRangeCompleteTransferTaskQuery,
 		d.shardID,
 		rowTypeTransferTask,
-		rowTypeTransferNamespaceID,
+		transferTaskRowNamespaceID(request.IsolatedNamespaceID),
 		rowTypeTransferWorkflowID,
 		rowTypeTransferRunID,
 		defaultVisibilityTimestamp,
@@ -2206,7 +2227,7 @@ func (d *cassandraPersistence) RangeCompleteTimerTask(request *p.RangeCompleteTi
 	query := d.session.Query(templateRangeCompleteTimerTaskQuery,
 		d.shardID,
 		rowTypeTimerTask,
-		rowTypeTimerNamespaceID,
+		timerTaskRowNamespaceID(request.IsolatedNamespaceID),
 		rowTypeTimerWorkflowID,
 		rowTypeTimerRunID,
 		start,
@@ -2677,7 +2698,7 @@ func (d *cassandraPersistence) GetTimerIndexTasks(request *p.GetTimerIndexTasksR
 	query := d.session.Query(templateGetTimerTasksQuery,
 		d.shardID,
 		rowTypeTimerTask,
-		rowTypeTimerNamespaceID,
+		timerTaskRowNamespaceID(request.IsolatedNamespaceID),
 		rowTypeTimerWorkflowID,
 		rowTypeTimerRunID,
 		minTimestamp,
@@ -2807,6 +2828,201 @@ func (d *cassandraPersistence) RangeDeleteReplicationTaskFromDLQ(
 	return nil
 }
 
+func (d *cassandraPersistence) PutTransferTaskToDLQ(
+	request *p.PutTransferTaskToDLQRequest,
+) error {
+
+	task := request.TaskInfo
+	datablob, err := serialization.TransferTaskInfoToBlob(task)
+	if err != nil {
+		return convertCommonErrors("PutTransferTaskToDLQ", err)
+	}
+
+	query := d.session.Query(templateCreateTransferTaskQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQNamespaceID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		datablob.Data,
+		datablob.Encoding,
+		defaultVisibilityTimestamp,
+		task.GetTaskId())
+
+	err = query.Exec()
+	if err != nil {
+		return convertCommonErrors("PutTransferTaskToDLQ", err)
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) GetTransferTasksFromDLQ(
+	request *p.GetTransferTasksFromDLQRequest,
+) (*p.GetTransferTasksFromDLQResponse, error) {
+
+	query := d.session.Query(templateGetTransferTasksQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQNamespaceID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		defaultVisibilityTimestamp,
+		request.ReadLevel,
+		request.MaxReadLevel,
+	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, serviceerror.NewInternal("GetTransferTasksFromDLQ operation failed.  Not able to create query iterator.")
+	}
+
+	response := &p.GetTransferTasksFromDLQResponse{}
+	var data []byte
+	var encoding string
+
+	for iter.Scan(&data, &encoding) {
+		t, err := serialization.TransferTaskInfoFromBlob(data, encoding)
+		if err != nil {
+			return nil, convertCommonErrors("GetTransferTasksFromDLQ", err)
+		}
+
+		response.Tasks = append(response.Tasks, t)
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("GetTransferTasksFromDLQ", err)
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) RangeDeleteTransferTaskFromDLQ(
+	request *p.RangeDeleteTransferTaskFromDLQRequest,
+) error {
+
+	query := d.session.Query(templateRangeCompleteTransferTaskQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQNamespaceID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		defaultVisibilityTimestamp,
+		request.ExclusiveBeginTaskID,
+		request.InclusiveEndTaskID,
+	)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return serviceerror.NewResourceExhausted(fmt.Sprintf("RangeDeleteTransferTaskFromDLQ operation failed. Error: %v", err))
+		}
+		return serviceerror.NewInternal(fmt.Sprintf("RangeDeleteTransferTaskFromDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (d *cassandraPersistence) PutTimerTaskToDLQ(
+	request *p.PutTimerTaskToDLQRequest,
+) error {
+
+	task := request.TaskInfo
+	datablob, err := serialization.TimerTaskInfoToBlob(task)
+	if err != nil {
+		return convertCommonErrors("PutTimerTaskToDLQ", err)
+	}
+
+	// timer tasks in dlq are ordered by task id, so the default visibility timestamp is used
+	query := d.session.Query(templateCreateTimerTaskQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQNamespaceID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+		datablob.Data,
+		datablob.Encoding,
+		defaultVisibilityTimestamp,
+		task.GetTaskId())
+
+	err = query.Exec()
+	if err != nil {
+		return convertCommonErrors("PutTimerTaskToDLQ", err)
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) GetTimerTasksFromDLQ(
+	request *p.GetTimerTasksFromDLQRequest,
+) (*p.GetTimerTasksFromDLQResponse, error) {
+
+	query := d.session.Query(templateGetTimerTasksFromDLQQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQNamespaceID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+		defaultVisibilityTimestamp,
+		request.ReadLevel,
+		request.MaxReadLevel,
+	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, serviceerror.NewInternal("GetTimerTasksFromDLQ operation failed.  Not able to create query iterator.")
+	}
+
+	response := &p.GetTimerTasksFromDLQResponse{}
+	var data []byte
+	var encoding string
+
+	for iter.Scan(&data, &encoding) {
+		t, err := serialization.TimerTaskInfoFromBlob(data, encoding)
+		if err != nil {
+			return nil, convertCommonErrors("GetTimerTasksFromDLQ", err)
+		}
+
+		response.Timers = append(response.Timers, t)
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("GetTimerTasksFromDLQ", err)
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) RangeDeleteTimerTaskFromDLQ(
+	request *p.RangeDeleteTimerTaskFromDLQRequest,
+) error {
+
+	query := d.session.Query(templateRangeCompleteTransferTaskQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQNamespaceID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+		defaultVisibilityTimestamp,
+		request.ExclusiveBeginTaskID,
+		request.InclusiveEndTaskID,
+	)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return serviceerror.NewResourceExhausted(fmt.Sprintf("RangeDeleteTimerTaskFromDLQ operation failed. Error: %v", err))
+		}
+		return serviceerror.NewInternal(fmt.Sprintf("RangeDeleteTimerTaskFromDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
 func workflowExecutionFromRow(result map[string]interface{}) (*p.InternalWorkflowExecutionInfo, *p.ReplicationState, error) {
 	eiBytes, ok := result["execution"].([]byte)
 	if !ok {
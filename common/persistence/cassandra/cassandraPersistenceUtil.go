@@ -172,6 +172,8 @@ func applyWorkflowMutationBatch(
 		workflowMutation.TransferTasks,
 		workflowMutation.ReplicationTasks,
 		workflowMutation.TimerTasks,
+		workflowMutation.IsolatedTransferTasks,
+		workflowMutation.IsolatedTimerTasks,
 	)
 }
 
@@ -297,6 +299,8 @@ func applyWorkflowSnapshotBatchAsReset(
 		workflowSnapshot.TransferTasks,
 		workflowSnapshot.ReplicationTasks,
 		workflowSnapshot.TimerTasks,
+		workflowSnapshot.IsolatedTransferTasks,
+		workflowSnapshot.IsolatedTimerTasks,
 	)
 }
 
@@ -418,6 +422,8 @@ func applyWorkflowSnapshotBatchAsNew(
 		workflowSnapshot.TransferTasks,
 		workflowSnapshot.ReplicationTasks,
 		workflowSnapshot.TimerTasks,
+		workflowSnapshot.IsolatedTransferTasks,
+		workflowSnapshot.IsolatedTimerTasks,
 	)
 }
 
@@ -658,6 +664,8 @@ func applyTasks(
 	transferTasks []p.Task,
 	replicationTasks []p.Task,
 	timerTasks []p.Task,
+	isolatedTransferTasks bool,
+	isolatedTimerTasks bool,
 ) error {
 
 	if err := createTransferTasks(
@@ -667,6 +675,7 @@ func applyTasks(
 		namespaceID,
 		workflowID,
 		runID,
+		isolatedTransferTasks,
 	); err != nil {
 		return err
 	}
@@ -689,6 +698,7 @@ func applyTasks(
 		namespaceID,
 		workflowID,
 		runID,
+		isolatedTimerTasks,
 	)
 }
 
@@ -699,8 +709,13 @@ func createTransferTasks(
 	namespaceID string,
 	workflowID string,
 	runID string,
+	isolated bool,
 ) error {
 
+	queueNamespaceID := ""
+	if isolated {
+		queueNamespaceID = namespaceID
+	}
 	targetNamespaceID := namespaceID
 	for _, task := range transferTasks {
 		var taskQueue string
@@ -784,7 +799,7 @@ func createTransferTasks(
 		batch.Query(templateCreateTransferTaskQuery,
 			shardID,
 			rowTypeTransferTask,
-			rowTypeTransferNamespaceID,
+			transferTaskRowNamespaceID(queueNamespaceID),
 			rowTypeTransferWorkflowID,
 			rowTypeTransferRunID,
 			datablob.Data,
@@ -883,8 +898,14 @@ func createTimerTasks(
 	namespaceID string,
 	workflowID string,
 	runID string,
+	isolated bool,
 ) error {
 
+	queueNamespaceID := ""
+	if isolated {
+		queueNamespaceID = namespaceID
+	}
+
 	for _, task := range timerTasks {
 		var eventID int64
 		var attempt int64
@@ -954,7 +975,7 @@ func createTimerTasks(
 		batch.Query(templateCreateTimerTaskQuery,
 			shardID,
 			rowTypeTimerTask,
-			rowTypeTimerNamespaceID,
+			timerTaskRowNamespaceID(queueNamespaceID),
 			rowTypeTimerWorkflowID,
 			rowTypeTimerRunID,
 			datablob.Data,
@@ -1723,6 +1744,24 @@ func createReplicationInfoMap(
 	return rInfoMap
 }
 
+// transferTaskRowNamespaceID returns the namespace_id of the rows of the transfer queue shared by the namespaces
+// of the shard, or of the rows of the transfer queue of an isolated namespace
+func transferTaskRowNamespaceID(isolatedNamespaceID string) string {
+	if isolatedNamespaceID == "" {
+		return rowTypeTransferNamespaceID
+	}
+	return isolatedNamespaceID
+}
+
+// timerTaskRowNamespaceID returns the namespace_id of the rows of the timer queue shared by the namespaces
+// of the shard, or of the rows of the timer queue of an isolated namespace
+func timerTaskRowNamespaceID(isolatedNamespaceID string) string {
+	if isolatedNamespaceID == "" {
+		return rowTypeTimerNamespaceID
+	}
+	return isolatedNamespaceID
+}
+
 func isTimeoutError(err error) bool {
 	if err == gocql.ErrTimeoutNoResponse {
 		return true
@@ -162,6 +162,9 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	if f.config.FaultInjection != nil {
+		result = p.NewTaskPersistenceFaultInjectionClient(result, f.config.FaultInjection, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewTaskPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -178,6 +181,9 @@ func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	if f.config.FaultInjection != nil {
+		result = p.NewShardPersistenceFaultInjectionClient(result, f.config.FaultInjection, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewShardPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -195,6 +201,9 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 		return nil, err
 	}
 	result := p.NewHistoryV2ManagerImpl(store, f.logger, f.config.TransactionSizeLimit)
+	if f.config.FaultInjection != nil {
+		result = p.NewHistoryV2PersistenceFaultInjectionClient(result, f.config.FaultInjection, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewHistoryV2PersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -215,6 +224,9 @@ func (f *factoryImpl) NewMetadataManager() (p.MetadataManager, error) {
 	}
 
 	result := p.NewMetadataManagerImpl(store, f.logger, f.clusterName)
+	if f.config.FaultInjection != nil {
+		result = p.NewMetadataPersistenceFaultInjectionClient(result, f.config.FaultInjection, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewMetadataPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -235,6 +247,9 @@ func (f *factoryImpl) NewClusterMetadataManager() (p.ClusterMetadataManager, err
 	}
 
 	result := p.NewClusterMetadataManagerImpl(store, f.logger)
+	if f.config.FaultInjection != nil {
+		result = p.NewClusterMetadataPersistenceFaultInjectionClient(result, f.config.FaultInjection, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewClusterMetadataPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -252,6 +267,9 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 		return nil, err
 	}
 	result := p.NewExecutionManagerImpl(store, f.logger)
+	if f.config.FaultInjection != nil {
+		result = p.NewWorkflowExecutionPersistenceFaultInjectionClient(result, f.config.FaultInjection, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewWorkflowExecutionPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -274,6 +292,9 @@ func (f *factoryImpl) NewVisibilityManager() (p.VisibilityManager, error) {
 	}
 
 	result := p.NewVisibilityManagerImpl(store, f.logger)
+	if f.config.FaultInjection != nil {
+		result = p.NewVisibilityPersistenceFaultInjectionClient(result, f.config.FaultInjection, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewVisibilityPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -293,6 +314,9 @@ func (f *factoryImpl) NewNamespaceReplicationQueue() (p.NamespaceReplicationQueu
 	if err != nil {
 		return nil, err
 	}
+	if f.config.FaultInjection != nil {
+		result = p.NewQueuePersistenceFaultInjectionClient(result, f.config.FaultInjection, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewQueuePersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -244,6 +244,10 @@ type (
 		AutoResetPoints                    *workflowpb.ResetPoints
 		Memo                               map[string]*commonpb.Payload
 		SearchAttributes                   map[string]*commonpb.Payload
+		Priority                           int32
+		FairnessKey                        string
+		VisibilityHistorySize              int64
+		RetentionDays                      int32
 		// for retry
 		Attempt                int32
 		HasRetryPolicy         bool
@@ -533,6 +537,8 @@ type (
 		NonRetryableErrorTypes []string
 		LastFailure            *failurepb.Failure
 		LastWorkerIdentity     string
+		Priority               int32
+		FairnessKey            string
 		// Not written to database - This is used only for deduping heartbeat timer creation
 		LastHeartbeatTimeoutVisibilityInSeconds int64
 	}
@@ -737,6 +743,11 @@ type (
 		ReplicationTasks []Task
 		TimerTasks       []Task
 
+		// IsolatedTransferTasks and IsolatedTimerTasks write the tasks to the queues of the namespace of the
+		// workflow instead of the queues shared by the namespaces of the shard
+		IsolatedTransferTasks bool
+		IsolatedTimerTasks    bool
+
 		Condition int64
 		Checksum  checksum.Checksum
 	}
@@ -759,6 +770,11 @@ type (
 		ReplicationTasks []Task
 		TimerTasks       []Task
 
+		// IsolatedTransferTasks and IsolatedTimerTasks write the tasks to the queues of the namespace of the
+		// workflow instead of the queues shared by the namespaces of the shard
+		IsolatedTransferTasks bool
+		IsolatedTimerTasks    bool
+
 		Condition int64
 		Checksum  checksum.Checksum
 	}
@@ -794,6 +810,9 @@ type (
 		MaxReadLevel  int64
 		BatchSize     int
 		NextPageToken []byte
+
+		// IsolatedNamespaceID reads the queue of the isolated namespace instead of the queue shared by the namespaces
+		IsolatedNamespaceID string
 	}
 
 	// GetTransferTasksResponse is the response to GetTransferTasksRequest
@@ -836,6 +855,7 @@ type (
 	RangeCompleteTransferTaskRequest struct {
 		ExclusiveBeginTaskID int64
 		InclusiveEndTaskID   int64
+		IsolatedNamespaceID  string
 	}
 
 	// CompleteReplicationTaskRequest is used to complete a task in the replication task queue
@@ -876,10 +896,51 @@ type (
 	// GetReplicationTasksFromDLQResponse is the response for GetReplicationTasksFromDLQ
 	GetReplicationTasksFromDLQResponse = GetReplicationTasksResponse
 
+	// PutTransferTaskToDLQRequest is used to put a transfer task to dlq
+	PutTransferTaskToDLQRequest struct {
+		TaskInfo *persistenceblobs.TransferTaskInfo
+	}
+
+	// GetTransferTasksFromDLQRequest is used to get transfer tasks from dlq
+	GetTransferTasksFromDLQRequest = GetTransferTasksRequest
+
+	// GetTransferTasksFromDLQResponse is the response for GetTransferTasksFromDLQ
+	GetTransferTasksFromDLQResponse = GetTransferTasksResponse
+
+	// RangeDeleteTransferTaskFromDLQRequest is used to delete transfer tasks from DLQ
+	RangeDeleteTransferTaskFromDLQRequest struct {
+		ExclusiveBeginTaskID int64
+		InclusiveEndTaskID   int64
+	}
+
+	// PutTimerTaskToDLQRequest is used to put a timer task to dlq
+	PutTimerTaskToDLQRequest struct {
+		TaskInfo *persistenceblobs.TimerTaskInfo
+	}
+
+	// GetTimerTasksFromDLQRequest is used to get timer tasks from dlq.
+	// Unlike the timer queue, timer tasks in dlq are ordered by task ID.
+	GetTimerTasksFromDLQRequest struct {
+		ReadLevel     int64
+		MaxReadLevel  int64
+		BatchSize     int
+		NextPageToken []byte
+	}
+
+	// GetTimerTasksFromDLQResponse is the response for GetTimerTasksFromDLQ
+	GetTimerTasksFromDLQResponse = GetTimerIndexTasksResponse
+
+	// RangeDeleteTimerTaskFromDLQRequest is used to delete timer tasks from DLQ
+	RangeDeleteTimerTaskFromDLQRequest struct {
+		ExclusiveBeginTaskID int64
+		InclusiveEndTaskID   int64
+	}
+
 	// RangeCompleteTimerTaskRequest is used to complete a range of tasks in the timer task queue
 	RangeCompleteTimerTaskRequest struct {
 		InclusiveBeginTimestamp time.Time
 		ExclusiveEndTimestamp   time.Time
+		IsolatedNamespaceID     string
 	}
 
 	// CompleteTimerTaskRequest is used to complete a task in the timer task queue
@@ -994,6 +1055,9 @@ type (
 		MaxTimestamp  time.Time
 		BatchSize     int
 		NextPageToken []byte
+
+		// IsolatedNamespaceID reads the queue of the isolated namespace instead of the queue shared by the namespaces
+		IsolatedNamespaceID string
 	}
 
 	// GetTimerIndexTasksResponse is the response for GetTimerIndexTasks
@@ -1135,6 +1199,8 @@ type (
 	AppendHistoryNodesResponse struct {
 		// the size of the event data that has been appended
 		Size int
+		// the size of the event data before compression, same as Size for uncompressed encodings
+		UncompressedSize int
 	}
 
 	// ReadHistoryBranchRequest is used to read a history branch
@@ -1152,6 +1218,9 @@ type (
 		NextPageToken []byte
 		// The shard to get history branch data
 		ShardID *int
+		// ReadFromReplica allows the read to be served by a read replica of the store, it is only
+		// safe for branches that are no longer appended to, i.e. history of closed workflows
+		ReadFromReplica bool
 	}
 
 	// ReadHistoryBranchResponse is the response to ReadHistoryBranchRequest
@@ -1367,6 +1436,9 @@ type (
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
 		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
+		PutTransferTaskToDLQ(request *PutTransferTaskToDLQRequest) error
+		GetTransferTasksFromDLQ(request *GetTransferTasksFromDLQRequest) (*GetTransferTasksFromDLQResponse, error)
+		RangeDeleteTransferTaskFromDLQ(request *RangeDeleteTransferTaskFromDLQRequest) error
 
 		// Replication task related methods
 		GetReplicationTask(request *GetReplicationTaskRequest) (*GetReplicationTaskResponse, error)
@@ -1383,6 +1455,9 @@ type (
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
 		CompleteTimerTask(request *CompleteTimerTaskRequest) error
 		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+		PutTimerTaskToDLQ(request *PutTimerTaskToDLQRequest) error
+		GetTimerTasksFromDLQ(request *GetTimerTasksFromDLQRequest) (*GetTimerTasksFromDLQResponse, error)
+		RangeDeleteTimerTaskFromDLQ(request *RangeDeleteTimerTaskFromDLQRequest) error
 
 		// Scan operations
 		ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error)
@@ -128,6 +128,8 @@ func (v *esVisibilityStore) RecordWorkflowExecutionStarted(request *p.InternalRe
 		request.TaskQueue,
 		request.StartTimestamp,
 		request.ExecutionTimestamp,
+		0,
+		0,
 		request.TaskID,
 		request.Memo.Data,
 		request.Memo.GetEncoding(),
@@ -148,6 +150,7 @@ func (v *esVisibilityStore) RecordWorkflowExecutionClosed(request *p.InternalRec
 		request.CloseTimestamp,
 		request.Status,
 		request.HistoryLength,
+		request.HistorySizeBytes,
 		request.TaskID,
 		request.Memo.Data,
 		request.TaskQueue,
@@ -167,6 +170,8 @@ func (v *esVisibilityStore) UpsertWorkflowExecution(request *p.InternalUpsertWor
 		request.TaskQueue,
 		request.StartTimestamp,
 		request.ExecutionTimestamp,
+		request.HistoryLength,
+		request.HistorySizeBytes,
 		request.TaskID,
 		request.Memo.Data,
 		request.Memo.GetEncoding(),
@@ -895,8 +900,8 @@ func (v *esVisibilityStore) convertSearchResultToVisibilityRecord(hit *elastic.S
 }
 
 func getVisibilityMessage(namespaceID string, wid, rid string, workflowTypeName string, taskQueue string,
-	startTimeUnixNano, executionTimeUnixNano int64, taskID int64, memo []byte, encoding common.EncodingType,
-	searchAttributes map[string]*commonpb.Payload) *indexergenpb.Message {
+	startTimeUnixNano, executionTimeUnixNano int64, historyLength, historySizeBytes int64, taskID int64, memo []byte,
+	encoding common.EncodingType, searchAttributes map[string]*commonpb.Payload) *indexergenpb.Message {
 
 	msgType := enumsgenpb.MESSAGE_TYPE_INDEX
 	fields := map[string]*indexergenpb.Field{
@@ -905,6 +910,11 @@ func getVisibilityMessage(namespaceID string, wid, rid string, workflowTypeName
 		es.ExecutionTime: {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: executionTimeUnixNano}},
 		es.TaskQueue:     {Type: es.FieldTypeString, Data: &indexergenpb.Field_StringData{StringData: taskQueue}},
 	}
+	// history length and size of open workflows are only known when the record is upserted
+	if historyLength > 0 {
+		fields[es.HistoryLength] = &indexergenpb.Field{Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: historyLength}}
+		fields[es.HistorySizeBytes] = &indexergenpb.Field{Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: historySizeBytes}}
+	}
 	if len(memo) != 0 {
 		fields[es.Memo] = &indexergenpb.Field{Type: es.FieldTypeBinary, Data: &indexergenpb.Field_BinaryData{BinaryData: memo}}
 		fields[es.Encoding] = &indexergenpb.Field{Type: es.FieldTypeString, Data: &indexergenpb.Field_StringData{StringData: string(encoding)}}
@@ -929,18 +939,19 @@ func getVisibilityMessage(namespaceID string, wid, rid string, workflowTypeName
 
 func getVisibilityMessageForCloseExecution(namespaceID string, wid, rid string, workflowTypeName string,
 	startTimeUnixNano int64, executionTimeUnixNano int64, endTimeUnixNano int64, status enumspb.WorkflowExecutionStatus,
-	historyLength int64, taskID int64, memo []byte, taskQueue string, encoding common.EncodingType,
+	historyLength int64, historySizeBytes int64, taskID int64, memo []byte, taskQueue string, encoding common.EncodingType,
 	searchAttributes map[string]*commonpb.Payload) *indexergenpb.Message {
 
 	msgType := enumsgenpb.MESSAGE_TYPE_INDEX
 	fields := map[string]*indexergenpb.Field{
-		es.WorkflowType:    {Type: es.FieldTypeString, Data: &indexergenpb.Field_StringData{StringData: workflowTypeName}},
-		es.StartTime:       {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: startTimeUnixNano}},
-		es.ExecutionTime:   {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: executionTimeUnixNano}},
-		es.CloseTime:       {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: endTimeUnixNano}},
-		es.ExecutionStatus: {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: int64(status)}},
-		es.HistoryLength:   {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: historyLength}},
-		es.TaskQueue:       {Type: es.FieldTypeString, Data: &indexergenpb.Field_StringData{StringData: taskQueue}},
+		es.WorkflowType:     {Type: es.FieldTypeString, Data: &indexergenpb.Field_StringData{StringData: workflowTypeName}},
+		es.StartTime:        {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: startTimeUnixNano}},
+		es.ExecutionTime:    {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: executionTimeUnixNano}},
+		es.CloseTime:        {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: endTimeUnixNano}},
+		es.ExecutionStatus:  {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: int64(status)}},
+		es.HistoryLength:    {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: historyLength}},
+		es.HistorySizeBytes: {Type: es.FieldTypeInt, Data: &indexergenpb.Field_IntData{IntData: historySizeBytes}},
+		es.TaskQueue:        {Type: es.FieldTypeString, Data: &indexergenpb.Field_StringData{StringData: taskQueue}},
 	}
 	if len(memo) != 0 {
 		fields[es.Memo] = &indexergenpb.Field{Type: es.FieldTypeBinary, Data: &indexergenpb.Field_BinaryData{BinaryData: memo}}
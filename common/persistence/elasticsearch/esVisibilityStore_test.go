@@ -184,6 +184,7 @@ func (s *ESVisibilitySuite) TestRecordWorkflowExecutionClosed() {
 	request.CloseTimestamp = int64(999)
 	request.Status = enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED
 	request.HistoryLength = int64(20)
+	request.HistorySizeBytes = int64(2048)
 	s.mockProducer.On("Publish", mock.MatchedBy(func(input *indexergenpb.Message) bool {
 		fields := input.Fields
 		s.Equal(request.NamespaceID, input.GetNamespaceId())
@@ -198,12 +199,33 @@ func (s *ESVisibilitySuite) TestRecordWorkflowExecutionClosed() {
 		s.Equal(request.CloseTimestamp, fields[es.CloseTime].GetIntData())
 		s.EqualValues(request.Status, fields[es.ExecutionStatus].GetIntData())
 		s.Equal(request.HistoryLength, fields[es.HistoryLength].GetIntData())
+		s.Equal(request.HistorySizeBytes, fields[es.HistorySizeBytes].GetIntData())
 		return true
 	})).Return(nil).Once()
 	err := s.visibilityStore.RecordWorkflowExecutionClosed(request)
 	s.NoError(err)
 }
 
+func (s *ESVisibilitySuite) TestUpsertWorkflowExecution_HistorySize() {
+	request := &p.InternalUpsertWorkflowExecutionRequest{}
+	request.NamespaceID = "namespaceID"
+	request.WorkflowID = "wid"
+	request.RunID = "rid"
+	request.WorkflowTypeName = "wfType"
+	request.TaskID = int64(111)
+	request.Memo = &serialization.DataBlob{}
+	request.HistoryLength = int64(20)
+	request.HistorySizeBytes = int64(2048)
+	s.mockProducer.On("Publish", mock.MatchedBy(func(input *indexergenpb.Message) bool {
+		fields := input.Fields
+		s.Equal(request.HistoryLength, fields[es.HistoryLength].GetIntData())
+		s.Equal(request.HistorySizeBytes, fields[es.HistorySizeBytes].GetIntData())
+		return true
+	})).Return(nil).Once()
+	err := s.visibilityStore.UpsertWorkflowExecution(request)
+	s.NoError(err)
+}
+
 func (s *ESVisibilitySuite) TestRecordWorkflowExecutionClosed_EmptyRequest() {
 	// test empty request
 	request := &p.InternalRecordWorkflowExecutionClosedRequest{
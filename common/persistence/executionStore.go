@@ -182,6 +182,10 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 		AutoResetPoints:                    autoResetPoints,
 		SearchAttributes:                   info.SearchAttributes,
 		Memo:                               info.Memo,
+		Priority:                           info.Priority,
+		FairnessKey:                        info.FairnessKey,
+		VisibilityHistorySize:              info.VisibilityHistorySize,
+		RetentionDays:                      info.RetentionDays,
 	}
 	newStats := &ExecutionStats{
 		HistorySize: info.HistorySize,
@@ -299,6 +303,8 @@ func (m *executionManagerImpl) DeserializeActivityInfos(
 			NonRetryableErrorTypes:                  v.NonRetryableErrorTypes,
 			LastFailure:                             v.LastFailure,
 			LastWorkerIdentity:                      v.LastWorkerIdentity,
+			Priority:                                v.Priority,
+			FairnessKey:                             v.FairnessKey,
 			LastHeartbeatTimeoutVisibilityInSeconds: v.LastHeartbeatTimeoutVisibilityInSeconds,
 		}
 		newInfos[k] = a
@@ -419,6 +425,8 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(
 			NonRetryableErrorTypes:                  v.NonRetryableErrorTypes,
 			LastFailure:                             v.LastFailure,
 			LastWorkerIdentity:                      v.LastWorkerIdentity,
+			Priority:                                v.Priority,
+			FairnessKey:                             v.FairnessKey,
 			LastHeartbeatTimeoutVisibilityInSeconds: v.LastHeartbeatTimeoutVisibilityInSeconds,
 		}
 		newInfos = append(newInfos, i)
@@ -499,6 +507,10 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 		CronSchedule:                       info.CronSchedule,
 		Memo:                               info.Memo,
 		SearchAttributes:                   info.SearchAttributes,
+		Priority:                           info.Priority,
+		FairnessKey:                        info.FairnessKey,
+		VisibilityHistorySize:              info.VisibilityHistorySize,
+		RetentionDays:                      info.RetentionDays,
 
 		// attributes which are not related to mutable state
 		HistorySize: stats.HistorySize,
@@ -675,6 +687,9 @@ func (m *executionManagerImpl) SerializeWorkflowMutation(
 		ReplicationTasks: input.ReplicationTasks,
 		TimerTasks:       input.TimerTasks,
 
+		IsolatedTransferTasks: input.IsolatedTransferTasks,
+		IsolatedTimerTasks:    input.IsolatedTimerTasks,
+
 		Condition: input.Condition,
 		Checksum:  input.Checksum,
 	}, nil
@@ -733,6 +748,9 @@ func (m *executionManagerImpl) SerializeWorkflowSnapshot(
 		ReplicationTasks: input.ReplicationTasks,
 		TimerTasks:       input.TimerTasks,
 
+		IsolatedTransferTasks: input.IsolatedTransferTasks,
+		IsolatedTimerTasks:    input.IsolatedTimerTasks,
+
 		Condition: input.Condition,
 		Checksum:  input.Checksum,
 	}, nil
@@ -825,6 +843,24 @@ func (m *executionManagerImpl) RangeCompleteTransferTask(
 	return m.persistence.RangeCompleteTransferTask(request)
 }
 
+func (m *executionManagerImpl) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	return m.persistence.PutTransferTaskToDLQ(request)
+}
+
+func (m *executionManagerImpl) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	return m.persistence.GetTransferTasksFromDLQ(request)
+}
+
+func (m *executionManagerImpl) RangeDeleteTransferTaskFromDLQ(
+	request *RangeDeleteTransferTaskFromDLQRequest,
+) error {
+	return m.persistence.RangeDeleteTransferTaskFromDLQ(request)
+}
+
 // Replication task related methods
 func (m *executionManagerImpl) GetReplicationTask(
 	request *GetReplicationTaskRequest,
@@ -899,6 +935,24 @@ func (m *executionManagerImpl) RangeCompleteTimerTask(
 	return m.persistence.RangeCompleteTimerTask(request)
 }
 
+func (m *executionManagerImpl) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	return m.persistence.PutTimerTaskToDLQ(request)
+}
+
+func (m *executionManagerImpl) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	return m.persistence.GetTimerTasksFromDLQ(request)
+}
+
+func (m *executionManagerImpl) RangeDeleteTimerTaskFromDLQ(
+	request *RangeDeleteTimerTaskFromDLQRequest,
+) error {
+	return m.persistence.RangeDeleteTimerTaskFromDLQ(request)
+}
+
 func (m *executionManagerImpl) Close() {
 	m.persistence.Close()
 }
@@ -201,6 +201,10 @@ func (m *historyV2ManagerImpl) AppendHistoryNodes(
 		return nil, err
 	}
 	size := len(blob.Data)
+	uncompressedSize := size
+	if serialization.IsCompressedEncoding(blob.Encoding) {
+		uncompressedSize = (&historypb.History{Events: request.Events}).Size()
+	}
 	sizeLimit := m.transactionSizeLimit()
 	if size > sizeLimit {
 		return nil, &TransactionSizeLimitError{
@@ -225,7 +229,8 @@ func (m *historyV2ManagerImpl) AppendHistoryNodes(
 	err = m.persistence.AppendHistoryNodes(req)
 
 	return &AppendHistoryNodesResponse{
-		Size: size,
+		Size:             size,
+		UncompressedSize: uncompressedSize,
 	}, err
 }
 
@@ -271,6 +276,13 @@ func (m *historyV2ManagerImpl) ReadRawHistoryBranch(
 		return nil, err
 	}
 
+	// compressed encodings are internal to persistence, callers of raw history always get proto3 blobs
+	for i, blob := range dataBlobs {
+		if dataBlobs[i], err = blob.Decompress(); err != nil {
+			return nil, err
+		}
+	}
+
 	nextPageToken, err := m.serializeToken(token)
 	if err != nil {
 		return nil, err
@@ -377,6 +389,7 @@ func (m *historyV2ManagerImpl) readRawHistoryBranch(
 		LastTransactionID: token.LastTransactionID,
 		ShardID:           shardID,
 		PageSize:          pageSize,
+		ReadFromReplica:   request.ReadFromReplica,
 	}
 
 	resp, err := m.persistence.ReadHistoryBranch(req)
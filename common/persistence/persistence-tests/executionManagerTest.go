@@ -5293,6 +5293,179 @@ func (s *ExecutionManagerSuite) TestReplicationDLQ() {
 	s.Len(resp.Tasks, 0)
 }
 
+// TestTransferTaskDLQ test
+func (s *ExecutionManagerSuite) TestTransferTaskDLQ() {
+	for taskID := int64(1); taskID <= 3; taskID++ {
+		err := s.ExecutionManager.PutTransferTaskToDLQ(&p.PutTransferTaskToDLQRequest{
+			TaskInfo: &persistenceblobs.TransferTaskInfo{
+				NamespaceId: uuid.New(),
+				WorkflowId:  uuid.New(),
+				RunId:       uuid.New(),
+				TaskId:      taskID,
+			},
+		})
+		s.NoError(err)
+	}
+
+	resp, err := s.ExecutionManager.GetTransferTasksFromDLQ(&p.GetTransferTasksFromDLQRequest{
+		ReadLevel:    0,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    2,
+	})
+	s.NoError(err)
+	s.Len(resp.Tasks, 2)
+	s.Equal(int64(1), resp.Tasks[0].GetTaskId())
+	s.NotEmpty(resp.NextPageToken)
+
+	err = s.ExecutionManager.RangeDeleteTransferTaskFromDLQ(&p.RangeDeleteTransferTaskFromDLQRequest{
+		ExclusiveBeginTaskID: 0,
+		InclusiveEndTaskID:   2,
+	})
+	s.NoError(err)
+	resp, err = s.ExecutionManager.GetTransferTasksFromDLQ(&p.GetTransferTasksFromDLQRequest{
+		ReadLevel:    0,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    10,
+	})
+	s.NoError(err)
+	s.Len(resp.Tasks, 1)
+	s.Equal(int64(3), resp.Tasks[0].GetTaskId())
+}
+
+// TestTimerTaskDLQ test
+func (s *ExecutionManagerSuite) TestTimerTaskDLQ() {
+	for taskID := int64(1); taskID <= 3; taskID++ {
+		err := s.ExecutionManager.PutTimerTaskToDLQ(&p.PutTimerTaskToDLQRequest{
+			TaskInfo: &persistenceblobs.TimerTaskInfo{
+				NamespaceId:         uuid.New(),
+				WorkflowId:          uuid.New(),
+				RunId:               uuid.New(),
+				TaskId:              taskID,
+				VisibilityTimestamp: types.TimestampNow(),
+			},
+		})
+		s.NoError(err)
+	}
+
+	resp, err := s.ExecutionManager.GetTimerTasksFromDLQ(&p.GetTimerTasksFromDLQRequest{
+		ReadLevel:    0,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    10,
+	})
+	s.NoError(err)
+	s.Len(resp.Timers, 3)
+	s.Equal(int64(1), resp.Timers[0].GetTaskId())
+
+	err = s.ExecutionManager.RangeDeleteTimerTaskFromDLQ(&p.RangeDeleteTimerTaskFromDLQRequest{
+		ExclusiveBeginTaskID: 0,
+		InclusiveEndTaskID:   3,
+	})
+	s.NoError(err)
+	resp, err = s.ExecutionManager.GetTimerTasksFromDLQ(&p.GetTimerTasksFromDLQRequest{
+		ReadLevel:    0,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    10,
+	})
+	s.NoError(err)
+	s.Len(resp.Timers, 0)
+}
+
+// TestIsolatedTasks test
+func (s *ExecutionManagerSuite) TestIsolatedTasks() {
+	namespaceID := uuid.New()
+	workflowExecution := commonpb.WorkflowExecution{
+		WorkflowId: "isolated-tasks-test",
+		RunId:      uuid.New(),
+	}
+
+	task0, err := s.CreateWorkflowExecution(namespaceID, workflowExecution, "taskQueue", "wType", 20, 13, 3, 0, 2, nil)
+	s.NoError(err)
+	s.NotNil(task0, "Expected non empty task identifier.")
+	s.ClearTransferQueue()
+
+	state0, err := s.GetWorkflowExecutionInfo(namespaceID, workflowExecution)
+	s.NoError(err)
+	updatedInfo := copyWorkflowExecutionInfo(state0.ExecutionInfo)
+	updatedStats := copyExecutionStats(state0.ExecutionStats)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+	_, err = s.ExecutionManager.UpdateWorkflowExecution(&p.UpdateWorkflowExecutionRequest{
+		RangeID: s.ShardInfo.GetRangeId(),
+		UpdateWorkflowMutation: p.WorkflowMutation{
+			ExecutionInfo:         updatedInfo,
+			ExecutionStats:        updatedStats,
+			TransferTasks:         []p.Task{&p.CloseExecutionTask{TaskID: s.GetNextSequenceNumber()}},
+			TimerTasks:            []p.Task{&p.UserTimerTask{VisibilityTimestamp: time.Now(), TaskID: s.GetNextSequenceNumber(), EventID: 7}},
+			IsolatedTransferTasks: true,
+			IsolatedTimerTasks:    true,
+			Condition:             int64(3),
+		},
+		Encoding: pickRandomEncoding(),
+	})
+	s.NoError(err)
+
+	sharedTransferTasks, err := s.GetTransferTasks(10, true)
+	s.NoError(err)
+	s.Empty(sharedTransferTasks)
+	sharedTimerTasks, err := s.GetTimerIndexTasks(10, true)
+	s.NoError(err)
+	for _, timer := range sharedTimerTasks {
+		s.NotEqual(workflowExecution.GetRunId(), timer.GetRunId())
+	}
+
+	transferResp, err := s.ExecutionManager.GetTransferTasks(&p.GetTransferTasksRequest{
+		ReadLevel:           0,
+		MaxReadLevel:        math.MaxInt64,
+		BatchSize:           10,
+		IsolatedNamespaceID: namespaceID,
+	})
+	s.NoError(err)
+	s.Len(transferResp.Tasks, 1)
+	s.Equal(enumsgenpb.TASK_TYPE_TRANSFER_CLOSE_EXECUTION, transferResp.Tasks[0].TaskType)
+	s.Equal(workflowExecution.GetRunId(), transferResp.Tasks[0].GetRunId())
+
+	timerResp, err := s.ExecutionManager.GetTimerIndexTasks(&p.GetTimerIndexTasksRequest{
+		MinTimestamp:        time.Time{},
+		MaxTimestamp:        time.Unix(0, math.MaxInt64),
+		BatchSize:           10,
+		IsolatedNamespaceID: namespaceID,
+	})
+	s.NoError(err)
+	s.Len(timerResp.Timers, 1)
+	s.Equal(enumsgenpb.TASK_TYPE_USER_TIMER, timerResp.Timers[0].TaskType)
+	s.Equal(workflowExecution.GetRunId(), timerResp.Timers[0].GetRunId())
+
+	err = s.ExecutionManager.RangeCompleteTransferTask(&p.RangeCompleteTransferTaskRequest{
+		ExclusiveBeginTaskID: 0,
+		InclusiveEndTaskID:   transferResp.Tasks[0].GetTaskId(),
+		IsolatedNamespaceID:  namespaceID,
+	})
+	s.NoError(err)
+	err = s.ExecutionManager.RangeCompleteTimerTask(&p.RangeCompleteTimerTaskRequest{
+		InclusiveBeginTimestamp: time.Time{},
+		ExclusiveEndTimestamp:   time.Unix(0, math.MaxInt64),
+		IsolatedNamespaceID:     namespaceID,
+	})
+	s.NoError(err)
+
+	transferResp, err = s.ExecutionManager.GetTransferTasks(&p.GetTransferTasksRequest{
+		ReadLevel:           0,
+		MaxReadLevel:        math.MaxInt64,
+		BatchSize:           10,
+		IsolatedNamespaceID: namespaceID,
+	})
+	s.NoError(err)
+	s.Empty(transferResp.Tasks)
+	timerResp, err = s.ExecutionManager.GetTimerIndexTasks(&p.GetTimerIndexTasksRequest{
+		MinTimestamp:        time.Time{},
+		MaxTimestamp:        time.Unix(0, math.MaxInt64),
+		BatchSize:           10,
+		IsolatedNamespaceID: namespaceID,
+	})
+	s.NoError(err)
+	s.Empty(timerResp.Timers)
+}
+
 func copyWorkflowExecutionInfo(sourceInfo *p.WorkflowExecutionInfo) *p.WorkflowExecutionInfo {
 	return &p.WorkflowExecutionInfo{
 		NamespaceID:         sourceInfo.NamespaceID,
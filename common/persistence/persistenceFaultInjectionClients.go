@@ -0,0 +1,1055 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	// faultInjector decides, per operation, whether a persistence call should be delayed or failed
+	faultInjector struct {
+		config      *config.FaultInjectionConfig
+		shardScoped bool
+		shardID     int
+	}
+
+	shardFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence ShardManager
+		logger      log.Logger
+	}
+
+	workflowExecutionFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence ExecutionManager
+		logger      log.Logger
+	}
+
+	taskFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence TaskManager
+		logger      log.Logger
+	}
+
+	historyV2FaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence HistoryManager
+		logger      log.Logger
+	}
+
+	metadataFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence MetadataManager
+		logger      log.Logger
+	}
+
+	clusterMetadataFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence ClusterMetadataManager
+		logger      log.Logger
+	}
+
+	visibilityFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence VisibilityManager
+		logger      log.Logger
+	}
+
+	queueFaultInjectionPersistenceClient struct {
+		injector    *faultInjector
+		persistence Queue
+		logger      log.Logger
+	}
+)
+
+var _ ShardManager = (*shardFaultInjectionPersistenceClient)(nil)
+var _ ExecutionManager = (*workflowExecutionFaultInjectionPersistenceClient)(nil)
+var _ TaskManager = (*taskFaultInjectionPersistenceClient)(nil)
+var _ HistoryManager = (*historyV2FaultInjectionPersistenceClient)(nil)
+var _ MetadataManager = (*metadataFaultInjectionPersistenceClient)(nil)
+var _ ClusterMetadataManager = (*clusterMetadataFaultInjectionPersistenceClient)(nil)
+var _ VisibilityManager = (*visibilityFaultInjectionPersistenceClient)(nil)
+var _ Queue = (*queueFaultInjectionPersistenceClient)(nil)
+
+// NewShardPersistenceFaultInjectionClient creates a client to manage shards
+func NewShardPersistenceFaultInjectionClient(persistence ShardManager, config *config.FaultInjectionConfig, logger log.Logger) ShardManager {
+	return &shardFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config),
+		logger:      logger,
+	}
+}
+
+// NewWorkflowExecutionPersistenceFaultInjectionClient creates a client to manage executions
+func NewWorkflowExecutionPersistenceFaultInjectionClient(persistence ExecutionManager, config *config.FaultInjectionConfig, logger log.Logger) ExecutionManager {
+	return &workflowExecutionFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newShardFaultInjector(config, persistence.GetShardID()),
+		logger:      logger,
+	}
+}
+
+// NewTaskPersistenceFaultInjectionClient creates a client to manage tasks
+func NewTaskPersistenceFaultInjectionClient(persistence TaskManager, config *config.FaultInjectionConfig, logger log.Logger) TaskManager {
+	return &taskFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config),
+		logger:      logger,
+	}
+}
+
+// NewHistoryV2PersistenceFaultInjectionClient creates a HistoryManager client to manage workflow execution history
+func NewHistoryV2PersistenceFaultInjectionClient(persistence HistoryManager, config *config.FaultInjectionConfig, logger log.Logger) HistoryManager {
+	return &historyV2FaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config),
+		logger:      logger,
+	}
+}
+
+// NewMetadataPersistenceFaultInjectionClient creates a MetadataManager client to manage metadata
+func NewMetadataPersistenceFaultInjectionClient(persistence MetadataManager, config *config.FaultInjectionConfig, logger log.Logger) MetadataManager {
+	return &metadataFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config),
+		logger:      logger,
+	}
+}
+
+// NewClusterMetadataPersistenceFaultInjectionClient creates a ClusterMetadataManager client to manage cluster metadata
+func NewClusterMetadataPersistenceFaultInjectionClient(persistence ClusterMetadataManager, config *config.FaultInjectionConfig, logger log.Logger) ClusterMetadataManager {
+	return &clusterMetadataFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config),
+		logger:      logger,
+	}
+}
+
+// NewVisibilityPersistenceFaultInjectionClient creates a client to manage visibility
+func NewVisibilityPersistenceFaultInjectionClient(persistence VisibilityManager, config *config.FaultInjectionConfig, logger log.Logger) VisibilityManager {
+	return &visibilityFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config),
+		logger:      logger,
+	}
+}
+
+// NewQueuePersistenceFaultInjectionClient creates a client to manage queue
+func NewQueuePersistenceFaultInjectionClient(persistence Queue, config *config.FaultInjectionConfig, logger log.Logger) Queue {
+	return &queueFaultInjectionPersistenceClient{
+		persistence: persistence,
+		injector:    newFaultInjector(config),
+		logger:      logger,
+	}
+}
+
+func newFaultInjector(config *config.FaultInjectionConfig) *faultInjector {
+	return &faultInjector{
+		config: config,
+	}
+}
+
+func newShardFaultInjector(config *config.FaultInjectionConfig, shardID int) *faultInjector {
+	return &faultInjector{
+		config:      config,
+		shardScoped: true,
+		shardID:     shardID,
+	}
+}
+
+// inject delays the operation by the configured latency and then returns an error
+// with the configured probability for each error type, or nil if the operation should proceed
+func (f *faultInjector) inject(operation string) error {
+	if !f.config.Enabled() {
+		return nil
+	}
+
+	if latency := f.config.Latency(operation); latency > 0 {
+		time.Sleep(latency)
+	}
+
+	sample := rand.Float64()
+	if sample -= f.config.TimeoutRate(operation); sample < 0 {
+		return &TimeoutError{Msg: fmt.Sprintf("%v: injected timeout", operation)}
+	}
+	if sample -= f.config.ConditionFailedRate(operation); sample < 0 {
+		return &ConditionFailedError{Msg: fmt.Sprintf("%v: injected condition failure", operation)}
+	}
+	if f.shardScoped {
+		if sample -= f.config.ShardOwnershipLostRate(operation); sample < 0 {
+			return &ShardOwnershipLostError{
+				ShardID: f.shardID,
+				Msg:     fmt.Sprintf("%v: injected shard ownership lost", operation),
+			}
+		}
+	}
+	if sample -= f.config.UnavailableRate(operation); sample < 0 {
+		return serviceerror.NewUnavailable(fmt.Sprintf("%v: injected unavailable error", operation))
+	}
+	return nil
+}
+
+func (p *shardFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *shardFaultInjectionPersistenceClient) CreateShard(request *CreateShardRequest) error {
+	if err := p.injector.inject("CreateShard"); err != nil {
+		return err
+	}
+
+	err := p.persistence.CreateShard(request)
+	return err
+}
+
+func (p *shardFaultInjectionPersistenceClient) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
+	if err := p.injector.inject("GetShard"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetShard(request)
+	return response, err
+}
+
+func (p *shardFaultInjectionPersistenceClient) UpdateShard(request *UpdateShardRequest) error {
+	if err := p.injector.inject("UpdateShard"); err != nil {
+		return err
+	}
+
+	err := p.persistence.UpdateShard(request)
+	return err
+}
+
+func (p *shardFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	if err := p.injector.inject("CreateWorkflowExecution"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.CreateWorkflowExecution(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	if err := p.injector.inject("GetWorkflowExecution"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetWorkflowExecution(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	if err := p.injector.inject("UpdateWorkflowExecution"); err != nil {
+		return nil, err
+	}
+
+	resp, err := p.persistence.UpdateWorkflowExecution(request)
+	return resp, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) ConflictResolveWorkflowExecution(request *ConflictResolveWorkflowExecutionRequest) error {
+	if err := p.injector.inject("ConflictResolveWorkflowExecution"); err != nil {
+		return err
+	}
+
+	err := p.persistence.ConflictResolveWorkflowExecution(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+	if err := p.injector.inject("ResetWorkflowExecution"); err != nil {
+		return err
+	}
+
+	err := p.persistence.ResetWorkflowExecution(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	if err := p.injector.inject("DeleteWorkflowExecution"); err != nil {
+		return err
+	}
+
+	err := p.persistence.DeleteWorkflowExecution(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	if err := p.injector.inject("DeleteCurrentWorkflowExecution"); err != nil {
+		return err
+	}
+
+	err := p.persistence.DeleteCurrentWorkflowExecution(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	if err := p.injector.inject("GetCurrentExecution"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetCurrentExecution(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
+	if err := p.injector.inject("ListConcreteExecutions"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListConcreteExecutions(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetTransferTask(request *GetTransferTaskRequest) (*GetTransferTaskResponse, error) {
+	if err := p.injector.inject("GetTransferTask"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetTransferTask(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	if err := p.injector.inject("GetTransferTasks"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetTransferTasks(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetReplicationTask(request *GetReplicationTaskRequest) (*GetReplicationTaskResponse, error) {
+	if err := p.injector.inject("GetReplicationTask"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetReplicationTask(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	if err := p.injector.inject("GetReplicationTasks"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetReplicationTasks(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+	if err := p.injector.inject("CompleteTransferTask"); err != nil {
+		return err
+	}
+
+	err := p.persistence.CompleteTransferTask(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+	if err := p.injector.inject("RangeCompleteTransferTask"); err != nil {
+		return err
+	}
+
+	err := p.persistence.RangeCompleteTransferTask(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	if err := p.injector.inject("PutTransferTaskToDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.PutTransferTaskToDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	if err := p.injector.inject("GetTransferTasksFromDLQ"); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.GetTransferTasksFromDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) RangeDeleteTransferTaskFromDLQ(
+	request *RangeDeleteTransferTaskFromDLQRequest,
+) error {
+	if err := p.injector.inject("RangeDeleteTransferTaskFromDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.RangeDeleteTransferTaskFromDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+	if err := p.injector.inject("CompleteReplicationTask"); err != nil {
+		return err
+	}
+
+	err := p.persistence.CompleteReplicationTask(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	if err := p.injector.inject("RangeCompleteReplicationTask"); err != nil {
+		return err
+	}
+
+	err := p.persistence.RangeCompleteReplicationTask(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) PutReplicationTaskToDLQ(
+	request *PutReplicationTaskToDLQRequest,
+) error {
+	if err := p.injector.inject("PutReplicationTaskToDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.PutReplicationTaskToDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetReplicationTasksFromDLQ(
+	request *GetReplicationTasksFromDLQRequest,
+) (*GetReplicationTasksFromDLQResponse, error) {
+	if err := p.injector.inject("GetReplicationTasksFromDLQ"); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.GetReplicationTasksFromDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) DeleteReplicationTaskFromDLQ(
+	request *DeleteReplicationTaskFromDLQRequest,
+) error {
+	if err := p.injector.inject("DeleteReplicationTaskFromDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.DeleteReplicationTaskFromDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) RangeDeleteReplicationTaskFromDLQ(
+	request *RangeDeleteReplicationTaskFromDLQRequest,
+) error {
+	if err := p.injector.inject("RangeDeleteReplicationTaskFromDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.RangeDeleteReplicationTaskFromDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetTimerTask(request *GetTimerTaskRequest) (*GetTimerTaskResponse, error) {
+	if err := p.injector.inject("GetTimerTask"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetTimerTask(request)
+	return response, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	if err := p.injector.inject("GetTimerIndexTasks"); err != nil {
+		return nil, err
+	}
+
+	resonse, err := p.persistence.GetTimerIndexTasks(request)
+	return resonse, err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+	if err := p.injector.inject("CompleteTimerTask"); err != nil {
+		return err
+	}
+
+	err := p.persistence.CompleteTimerTask(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+	if err := p.injector.inject("RangeCompleteTimerTask"); err != nil {
+		return err
+	}
+
+	err := p.persistence.RangeCompleteTimerTask(request)
+	return err
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	if err := p.injector.inject("PutTimerTaskToDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.PutTimerTaskToDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	if err := p.injector.inject("GetTimerTasksFromDLQ"); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.GetTimerTasksFromDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) RangeDeleteTimerTaskFromDLQ(
+	request *RangeDeleteTimerTaskFromDLQRequest,
+) error {
+	if err := p.injector.inject("RangeDeleteTimerTaskFromDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.RangeDeleteTimerTaskFromDLQ(request)
+}
+
+func (p *workflowExecutionFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *taskFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *taskFaultInjectionPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
+	if err := p.injector.inject("CreateTasks"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.CreateTasks(request)
+	return response, err
+}
+
+func (p *taskFaultInjectionPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
+	if err := p.injector.inject("GetTasks"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetTasks(request)
+	return response, err
+}
+
+func (p *taskFaultInjectionPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
+	if err := p.injector.inject("CompleteTask"); err != nil {
+		return err
+	}
+
+	err := p.persistence.CompleteTask(request)
+	return err
+}
+
+func (p *taskFaultInjectionPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error) {
+	if err := p.injector.inject("CompleteTasksLessThan"); err != nil {
+		return 0, err
+	}
+	return p.persistence.CompleteTasksLessThan(request)
+}
+
+func (p *taskFaultInjectionPersistenceClient) LeaseTaskQueue(request *LeaseTaskQueueRequest) (*LeaseTaskQueueResponse, error) {
+	if err := p.injector.inject("LeaseTaskQueue"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.LeaseTaskQueue(request)
+	return response, err
+}
+
+func (p *taskFaultInjectionPersistenceClient) UpdateTaskQueue(request *UpdateTaskQueueRequest) (*UpdateTaskQueueResponse, error) {
+	if err := p.injector.inject("UpdateTaskQueue"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.UpdateTaskQueue(request)
+	return response, err
+}
+
+func (p *taskFaultInjectionPersistenceClient) ListTaskQueue(request *ListTaskQueueRequest) (*ListTaskQueueResponse, error) {
+	if err := p.injector.inject("ListTaskQueue"); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListTaskQueue(request)
+}
+
+func (p *taskFaultInjectionPersistenceClient) DeleteTaskQueue(request *DeleteTaskQueueRequest) error {
+	if err := p.injector.inject("DeleteTaskQueue"); err != nil {
+		return err
+	}
+	return p.persistence.DeleteTaskQueue(request)
+}
+
+func (p *taskFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *metadataFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *metadataFaultInjectionPersistenceClient) CreateNamespace(request *CreateNamespaceRequest) (*CreateNamespaceResponse, error) {
+	if err := p.injector.inject("CreateNamespace"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.CreateNamespace(request)
+	return response, err
+}
+
+func (p *metadataFaultInjectionPersistenceClient) GetNamespace(request *GetNamespaceRequest) (*GetNamespaceResponse, error) {
+	if err := p.injector.inject("GetNamespace"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetNamespace(request)
+	return response, err
+}
+
+func (p *metadataFaultInjectionPersistenceClient) UpdateNamespace(request *UpdateNamespaceRequest) error {
+	if err := p.injector.inject("UpdateNamespace"); err != nil {
+		return err
+	}
+
+	err := p.persistence.UpdateNamespace(request)
+	return err
+}
+
+func (p *metadataFaultInjectionPersistenceClient) DeleteNamespace(request *DeleteNamespaceRequest) error {
+	if err := p.injector.inject("DeleteNamespace"); err != nil {
+		return err
+	}
+
+	err := p.persistence.DeleteNamespace(request)
+	return err
+}
+
+func (p *metadataFaultInjectionPersistenceClient) DeleteNamespaceByName(request *DeleteNamespaceByNameRequest) error {
+	if err := p.injector.inject("DeleteNamespaceByName"); err != nil {
+		return err
+	}
+
+	err := p.persistence.DeleteNamespaceByName(request)
+	return err
+}
+
+func (p *metadataFaultInjectionPersistenceClient) ListNamespaces(request *ListNamespacesRequest) (*ListNamespacesResponse, error) {
+	if err := p.injector.inject("ListNamespaces"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListNamespaces(request)
+	return response, err
+}
+
+func (p *metadataFaultInjectionPersistenceClient) GetMetadata() (*GetMetadataResponse, error) {
+	if err := p.injector.inject("GetMetadata"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetMetadata()
+	return response, err
+}
+
+func (p *metadataFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
+	if err := p.injector.inject("RecordWorkflowExecutionStarted"); err != nil {
+		return err
+	}
+
+	err := p.persistence.RecordWorkflowExecutionStarted(request)
+	return err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
+	if err := p.injector.inject("RecordWorkflowExecutionClosed"); err != nil {
+		return err
+	}
+
+	err := p.persistence.RecordWorkflowExecutionClosed(request)
+	return err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) UpsertWorkflowExecution(request *UpsertWorkflowExecutionRequest) error {
+	if err := p.injector.inject("UpsertWorkflowExecution"); err != nil {
+		return err
+	}
+
+	err := p.persistence.UpsertWorkflowExecution(request)
+	return err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ListOpenWorkflowExecutions"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListOpenWorkflowExecutions(request)
+	return response, err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ListClosedWorkflowExecutions"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListClosedWorkflowExecutions(request)
+	return response, err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ListOpenWorkflowExecutionsByType"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListOpenWorkflowExecutionsByType(request)
+	return response, err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ListClosedWorkflowExecutionsByType"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListClosedWorkflowExecutionsByType(request)
+	return response, err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ListOpenWorkflowExecutionsByWorkflowID"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+	return response, err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ListClosedWorkflowExecutionsByWorkflowID"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+	return response, err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ListClosedWorkflowExecutionsByStatus"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+	return response, err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
+	if err := p.injector.inject("GetClosedWorkflowExecution"); err != nil {
+		return nil, err
+	}
+
+	response, err := p.persistence.GetClosedWorkflowExecution(request)
+	return response, err
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
+	if err := p.injector.inject("DeleteWorkflowExecution"); err != nil {
+		return err
+	}
+	return p.persistence.DeleteWorkflowExecution(request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ListWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ListWorkflowExecutions"); err != nil {
+		return nil, err
+	}
+	return p.persistence.ListWorkflowExecutions(request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) ScanWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("ScanWorkflowExecutions"); err != nil {
+		return nil, err
+	}
+	return p.persistence.ScanWorkflowExecutions(request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) CountWorkflowExecutions(request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
+	if err := p.injector.inject("CountWorkflowExecutions"); err != nil {
+		return nil, err
+	}
+	return p.persistence.CountWorkflowExecutions(request)
+}
+
+func (p *visibilityFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyV2FaultInjectionPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyV2FaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+// AppendHistoryNodes add(or override) a node to a history branch
+func (p *historyV2FaultInjectionPersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	if err := p.injector.inject("AppendHistoryNodes"); err != nil {
+		return nil, err
+	}
+	return p.persistence.AppendHistoryNodes(request)
+}
+
+// ReadHistoryBranch returns history node data for a branch
+func (p *historyV2FaultInjectionPersistenceClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	if err := p.injector.inject("ReadHistoryBranch"); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.ReadHistoryBranch(request)
+	return response, err
+}
+
+// ReadHistoryBranchByBatch returns history node data for a branch
+func (p *historyV2FaultInjectionPersistenceClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
+	if err := p.injector.inject("ReadHistoryBranchByBatch"); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.ReadHistoryBranchByBatch(request)
+	return response, err
+}
+
+// ReadHistoryBranchByBatch returns history node data for a branch
+func (p *historyV2FaultInjectionPersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	if err := p.injector.inject("ReadRawHistoryBranch"); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.ReadRawHistoryBranch(request)
+	return response, err
+}
+
+// ForkHistoryBranch forks a new branch from a old branch
+func (p *historyV2FaultInjectionPersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
+	if err := p.injector.inject("ForkHistoryBranch"); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.ForkHistoryBranch(request)
+	return response, err
+}
+
+// DeleteHistoryBranch removes a branch
+func (p *historyV2FaultInjectionPersistenceClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
+	if err := p.injector.inject("DeleteHistoryBranch"); err != nil {
+		return err
+	}
+	err := p.persistence.DeleteHistoryBranch(request)
+	return err
+}
+
+// GetHistoryTree returns all branch information of a tree
+func (p *historyV2FaultInjectionPersistenceClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	if err := p.injector.inject("GetHistoryTree"); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.GetHistoryTree(request)
+	return response, err
+}
+
+func (p *historyV2FaultInjectionPersistenceClient) GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
+	if err := p.injector.inject("GetAllHistoryTreeBranches"); err != nil {
+		return nil, err
+	}
+	response, err := p.persistence.GetAllHistoryTreeBranches(request)
+	return response, err
+}
+
+func (p *queueFaultInjectionPersistenceClient) EnqueueMessage(message []byte) error {
+	if err := p.injector.inject("EnqueueMessage"); err != nil {
+		return err
+	}
+
+	return p.persistence.EnqueueMessage(message)
+}
+
+func (p *queueFaultInjectionPersistenceClient) ReadMessages(lastMessageID int64, maxCount int) ([]*QueueMessage, error) {
+	if err := p.injector.inject("ReadMessages"); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.ReadMessages(lastMessageID, maxCount)
+}
+
+func (p *queueFaultInjectionPersistenceClient) UpdateAckLevel(messageID int64, clusterName string) error {
+	if err := p.injector.inject("UpdateAckLevel"); err != nil {
+		return err
+	}
+
+	return p.persistence.UpdateAckLevel(messageID, clusterName)
+}
+
+func (p *queueFaultInjectionPersistenceClient) GetAckLevels() (map[string]int64, error) {
+	if err := p.injector.inject("GetAckLevels"); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.GetAckLevels()
+}
+
+func (p *queueFaultInjectionPersistenceClient) DeleteMessagesBefore(messageID int64) error {
+	if err := p.injector.inject("DeleteMessagesBefore"); err != nil {
+		return err
+	}
+
+	return p.persistence.DeleteMessagesBefore(messageID)
+}
+
+func (p *queueFaultInjectionPersistenceClient) EnqueueMessageToDLQ(message []byte) (int64, error) {
+	if err := p.injector.inject("EnqueueMessageToDLQ"); err != nil {
+		return emptyMessageID, err
+	}
+
+	return p.persistence.EnqueueMessageToDLQ(message)
+}
+
+func (p *queueFaultInjectionPersistenceClient) ReadMessagesFromDLQ(firstMessageID int64, lastMessageID int64, pageSize int, pageToken []byte) ([]*QueueMessage, []byte, error) {
+	if err := p.injector.inject("ReadMessagesFromDLQ"); err != nil {
+		return nil, nil, err
+	}
+
+	return p.persistence.ReadMessagesFromDLQ(firstMessageID, lastMessageID, pageSize, pageToken)
+}
+
+func (p *queueFaultInjectionPersistenceClient) RangeDeleteMessagesFromDLQ(firstMessageID int64, lastMessageID int64) error {
+	if err := p.injector.inject("RangeDeleteMessagesFromDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.RangeDeleteMessagesFromDLQ(firstMessageID, lastMessageID)
+}
+func (p *queueFaultInjectionPersistenceClient) UpdateDLQAckLevel(messageID int64, clusterName string) error {
+	if err := p.injector.inject("UpdateDLQAckLevel"); err != nil {
+		return err
+	}
+
+	return p.persistence.UpdateDLQAckLevel(messageID, clusterName)
+}
+
+func (p *queueFaultInjectionPersistenceClient) GetDLQAckLevels() (map[string]int64, error) {
+	if err := p.injector.inject("GetDLQAckLevels"); err != nil {
+		return nil, err
+	}
+
+	return p.persistence.GetDLQAckLevels()
+}
+
+func (p *queueFaultInjectionPersistenceClient) DeleteMessageFromDLQ(messageID int64) error {
+	if err := p.injector.inject("DeleteMessageFromDLQ"); err != nil {
+		return err
+	}
+
+	return p.persistence.DeleteMessageFromDLQ(messageID)
+}
+
+func (p *queueFaultInjectionPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (c *clusterMetadataFaultInjectionPersistenceClient) Close() {
+	c.persistence.Close()
+}
+
+func (c *clusterMetadataFaultInjectionPersistenceClient) GetName() string {
+	return c.persistence.GetName()
+}
+
+func (c *clusterMetadataFaultInjectionPersistenceClient) InitializeImmutableClusterMetadata(request *InitializeImmutableClusterMetadataRequest) (*InitializeImmutableClusterMetadataResponse, error) {
+	if err := c.injector.inject("InitializeImmutableClusterMetadata"); err != nil {
+		return nil, err
+	}
+	return c.persistence.InitializeImmutableClusterMetadata(request)
+}
+
+func (c *clusterMetadataFaultInjectionPersistenceClient) GetImmutableClusterMetadata() (*GetImmutableClusterMetadataResponse, error) {
+	if err := c.injector.inject("GetImmutableClusterMetadata"); err != nil {
+		return nil, err
+	}
+	return c.persistence.GetImmutableClusterMetadata()
+}
+
+func (c *clusterMetadataFaultInjectionPersistenceClient) GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
+	if err := c.injector.inject("GetClusterMembers"); err != nil {
+		return nil, err
+	}
+	return c.persistence.GetClusterMembers(request)
+}
+
+func (c *clusterMetadataFaultInjectionPersistenceClient) UpsertClusterMembership(request *UpsertClusterMembershipRequest) error {
+	if err := c.injector.inject("UpsertClusterMembership"); err != nil {
+		return err
+	}
+	return c.persistence.UpsertClusterMembership(request)
+}
+
+func (c *clusterMetadataFaultInjectionPersistenceClient) PruneClusterMembership(request *PruneClusterMembershipRequest) error {
+	if err := c.injector.inject("PruneClusterMembership"); err != nil {
+		return err
+	}
+	return c.persistence.PruneClusterMembership(request)
+}
+
+func (c *metadataFaultInjectionPersistenceClient) InitializeSystemNamespaces(currentClusterName string) error {
+	if err := c.injector.inject("InitializeSystemNamespaces"); err != nil {
+		return err
+	}
+	return c.persistence.InitializeSystemNamespaces(currentClusterName)
+}
@@ -0,0 +1,117 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	faultInjectorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		enabled bool
+		rates   map[string]float64
+		config  *config.FaultInjectionConfig
+	}
+)
+
+func TestFaultInjectorSuite(t *testing.T) {
+	s := new(faultInjectorSuite)
+	suite.Run(t, s)
+}
+
+func (s *faultInjectorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.enabled = true
+	s.rates = make(map[string]float64)
+	rateFn := func(name string) func(string) float64 {
+		return func(operation string) float64 {
+			return s.rates[name+"/"+operation]
+		}
+	}
+	s.config = &config.FaultInjectionConfig{
+		Enabled:                func(...dynamicconfig.FilterOption) bool { return s.enabled },
+		TimeoutRate:            rateFn("timeout"),
+		ConditionFailedRate:    rateFn("conditionFailed"),
+		ShardOwnershipLostRate: rateFn("shardOwnershipLost"),
+		UnavailableRate:        rateFn("unavailable"),
+		Latency:                func(operation string) time.Duration { return 0 },
+	}
+}
+
+func (s *faultInjectorSuite) TestInject_Disabled() {
+	s.enabled = false
+	s.rates["timeout/GetShard"] = 1
+
+	s.NoError(newFaultInjector(s.config).inject("GetShard"))
+}
+
+func (s *faultInjectorSuite) TestInject_NoFault() {
+	s.NoError(newFaultInjector(s.config).inject("GetShard"))
+}
+
+func (s *faultInjectorSuite) TestInject_FilteredByOperation() {
+	s.rates["timeout/UpdateShard"] = 1
+
+	injector := newFaultInjector(s.config)
+	s.NoError(injector.inject("GetShard"))
+	s.IsType(&TimeoutError{}, injector.inject("UpdateShard"))
+}
+
+func (s *faultInjectorSuite) TestInject_ErrorTypes() {
+	injector := newFaultInjector(s.config)
+
+	s.rates["timeout/GetShard"] = 1
+	s.IsType(&TimeoutError{}, injector.inject("GetShard"))
+
+	s.rates["timeout/GetShard"] = 0
+	s.rates["conditionFailed/GetShard"] = 1
+	s.IsType(&ConditionFailedError{}, injector.inject("GetShard"))
+
+	s.rates["conditionFailed/GetShard"] = 0
+	s.rates["unavailable/GetShard"] = 1
+	s.IsType(&serviceerror.Unavailable{}, injector.inject("GetShard"))
+}
+
+func (s *faultInjectorSuite) TestInject_ShardOwnershipLost() {
+	s.rates["shardOwnershipLost/UpdateWorkflowExecution"] = 1
+
+	s.NoError(newFaultInjector(s.config).inject("UpdateWorkflowExecution"))
+
+	err := newShardFaultInjector(s.config, 10).inject("UpdateWorkflowExecution")
+	s.IsType(&ShardOwnershipLostError{}, err)
+	s.Equal(10, err.(*ShardOwnershipLostError).ShardID)
+}
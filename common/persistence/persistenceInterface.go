@@ -102,6 +102,9 @@ type (
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
 		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
+		PutTransferTaskToDLQ(request *PutTransferTaskToDLQRequest) error
+		GetTransferTasksFromDLQ(request *GetTransferTasksFromDLQRequest) (*GetTransferTasksFromDLQResponse, error)
+		RangeDeleteTransferTaskFromDLQ(request *RangeDeleteTransferTaskFromDLQRequest) error
 
 		// Replication task related methods
 		GetReplicationTask(request *GetReplicationTaskRequest) (*GetReplicationTaskResponse, error)
@@ -118,6 +121,9 @@ type (
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
 		CompleteTimerTask(request *CompleteTimerTaskRequest) error
 		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+		PutTimerTaskToDLQ(request *PutTimerTaskToDLQRequest) error
+		GetTimerTasksFromDLQ(request *GetTimerTasksFromDLQRequest) (*GetTimerTasksFromDLQResponse, error)
+		RangeDeleteTimerTaskFromDLQ(request *RangeDeleteTimerTaskFromDLQRequest) error
 
 		// Scan related methods
 		ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*InternalListConcreteExecutionsResponse, error)
@@ -248,6 +254,10 @@ type (
 		ClientFeatureVersion               string
 		ClientImpl                         string
 		AutoResetPoints                    *serialization.DataBlob
+		Priority                           int32
+		FairnessKey                        string
+		VisibilityHistorySize              int64
+		RetentionDays                      int32
 		// for retry
 		Attempt                int32
 		HasRetryPolicy         bool
@@ -318,6 +328,8 @@ type (
 		NonRetryableErrorTypes []string
 		LastFailure            *failurepb.Failure
 		LastWorkerIdentity     string
+		Priority               int32
+		FairnessKey            string
 		// Not written to database - This is used only for deduping heartbeat timer creation
 		LastHeartbeatTimeoutVisibilityInSeconds int64
 	}
@@ -415,6 +427,9 @@ type (
 		TimerTasks       []Task
 		ReplicationTasks []Task
 
+		IsolatedTransferTasks bool
+		IsolatedTimerTasks    bool
+
 		Condition int64
 
 		Checksum checksum.Checksum
@@ -439,6 +454,9 @@ type (
 		TimerTasks       []Task
 		ReplicationTasks []Task
 
+		IsolatedTransferTasks bool
+		IsolatedTimerTasks    bool
+
 		Condition int64
 
 		Checksum checksum.Checksum
@@ -533,6 +551,8 @@ type (
 		LastTransactionID int64
 		// Used in sharded data stores to identify which shard to use
 		ShardID int
+		// ReadFromReplica allows the read to be served by a read replica of the store
+		ReadFromReplica bool
 	}
 
 	// InternalCompleteForkBranchRequest is used to update some tree/branch meta data for forking
@@ -615,6 +635,7 @@ type (
 		CloseTimestamp     int64
 		Status             enumspb.WorkflowExecutionStatus
 		HistoryLength      int64
+		HistorySizeBytes   int64
 		RetentionSeconds   int64
 	}
 
@@ -627,6 +648,8 @@ type (
 		StartTimestamp     int64
 		ExecutionTimestamp int64
 		WorkflowTimeout    int64
+		HistoryLength      int64
+		HistorySizeBytes   int64
 		TaskID             int64
 		Memo               *serialization.DataBlob
 		TaskQueue          string
@@ -695,7 +718,7 @@ func NewDataBlob(data []byte, encodingType common.EncodingType) *serialization.D
 	if data == nil || len(data) == 0 {
 		return nil
 	}
-	if encodingType != common.EncodingTypeProto3 && data[0] == 'Y' {
+	if encodingType != common.EncodingTypeProto3 && !serialization.IsCompressedEncoding(encodingType) && data[0] == 'Y' {
 		panic(fmt.Sprintf("Invalid incoding: \"%v\"", encodingType))
 	}
 	return &serialization.DataBlob{
@@ -782,6 +805,10 @@ func InternalWorkflowExecutionInfoToProto(executionInfo *InternalWorkflowExecuti
 		AutoResetPointsEncoding:                 executionInfo.AutoResetPoints.GetEncoding().String(),
 		SearchAttributes:                        executionInfo.SearchAttributes,
 		Memo:                                    executionInfo.Memo,
+		Priority:                                executionInfo.Priority,
+		FairnessKey:                             executionInfo.FairnessKey,
+		VisibilityHistorySize:                   executionInfo.VisibilityHistorySize,
+		RetentionDays:                           executionInfo.RetentionDays,
 	}
 
 	if !executionInfo.ExpirationTime.IsZero() {
@@ -869,6 +896,10 @@ func ProtoWorkflowExecutionToPartialInternalExecution(info *persistenceblobs.Wor
 		NonRetryableErrorTypes:             info.GetRetryNonRetryableErrorTypes(),
 		SearchAttributes:                   info.GetSearchAttributes(),
 		Memo:                               info.GetMemo(),
+		Priority:                           info.GetPriority(),
+		FairnessKey:                        info.GetFairnessKey(),
+		VisibilityHistorySize:              info.GetVisibilityHistorySize(),
+		RetentionDays:                      info.GetRetentionDays(),
 	}
 
 	if info.GetRetryExpirationTimeNanos() != 0 {
@@ -936,6 +967,8 @@ func ProtoActivityInfoToInternalActivityInfo(decoded *persistenceblobs.ActivityI
 		NonRetryableErrorTypes:   decoded.GetRetryNonRetryableErrorTypes(),
 		LastFailure:              decoded.GetRetryLastFailure(),
 		LastWorkerIdentity:       decoded.GetRetryLastWorkerIdentity(),
+		Priority:                 decoded.GetPriority(),
+		FairnessKey:              decoded.GetFairnessKey(),
 	}
 	if decoded.GetRetryExpirationTimeNanos() != 0 {
 		info.ExpirationTime = time.Unix(0, decoded.GetRetryExpirationTimeNanos())
@@ -984,6 +1017,8 @@ func (v *InternalActivityInfo) ToProto() *persistenceblobs.ActivityInfo {
 		RetryNonRetryableErrorTypes:   v.NonRetryableErrorTypes,
 		RetryLastFailure:              v.LastFailure,
 		RetryLastWorkerIdentity:       v.LastWorkerIdentity,
+		Priority:                      v.Priority,
+		FairnessKey:                   v.FairnessKey,
 	}
 	if !v.ExpirationTime.IsZero() {
 		info.RetryExpirationTimeNanos = v.ExpirationTime.UnixNano()
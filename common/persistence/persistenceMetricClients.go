@@ -30,6 +30,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence/serialization"
 )
 
 type (
@@ -449,6 +450,54 @@ func (p *workflowExecutionPersistenceClient) RangeCompleteTransferTask(request *
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistencePutTransferTaskToDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePutTransferTaskToDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.PutTransferTaskToDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePutTransferTaskToDLQScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTransferTasksFromDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTransferTasksFromDLQScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTransferTasksFromDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTransferTasksFromDLQScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) RangeDeleteTransferTaskFromDLQ(
+	request *RangeDeleteTransferTaskFromDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistenceRangeDeleteTransferTaskFromDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceRangeDeleteTransferTaskFromDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.RangeDeleteTransferTaskFromDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRangeDeleteTransferTaskFromDLQScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
 	p.metricClient.IncCounter(metrics.PersistenceCompleteReplicationTaskScope, metrics.PersistenceRequests)
 
@@ -597,6 +646,54 @@ func (p *workflowExecutionPersistenceClient) RangeCompleteTimerTask(request *Ran
 	return err
 }
 
+func (p *workflowExecutionPersistenceClient) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistencePutTimerTaskToDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePutTimerTaskToDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.PutTimerTaskToDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePutTimerTaskToDLQScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTimerTasksFromDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTimerTasksFromDLQScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTimerTasksFromDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTimerTasksFromDLQScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) RangeDeleteTimerTaskFromDLQ(
+	request *RangeDeleteTimerTaskFromDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistenceRangeDeleteTimerTaskFromDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceRangeDeleteTimerTaskFromDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.RangeDeleteTimerTaskFromDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRangeDeleteTimerTaskFromDLQScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) updateErrorMetric(scope int, err error) {
 	switch err.(type) {
 	case *WorkflowExecutionAlreadyStartedError:
@@ -1131,6 +1228,11 @@ func (p *historyV2PersistenceClient) AppendHistoryNodes(request *AppendHistoryNo
 	sw.Stop()
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceAppendHistoryNodesScope, err)
+	} else if resp != nil && resp.Size > 0 && serialization.IsCompressedEncoding(request.Encoding) {
+		p.metricClient.Scope(metrics.PersistenceAppendHistoryNodesScope).RecordHistogramValue(
+			metrics.PersistenceHistoryCompressionRatio,
+			float64(resp.UncompressedSize)/float64(resp.Size),
+		)
 	}
 	return resp, err
 }
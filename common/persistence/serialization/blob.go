@@ -293,6 +293,10 @@ func (d *DataBlob) GetEncoding() common.EncodingType {
 	switch common.EncodingType(encodingStr) {
 	case common.EncodingTypeProto3:
 		return common.EncodingTypeProto3
+	case common.EncodingTypeProto3Snappy:
+		return common.EncodingTypeProto3Snappy
+	case common.EncodingTypeProto3Zstd:
+		return common.EncodingTypeProto3Zstd
 	case common.EncodingTypeGob:
 		return common.EncodingTypeGob
 	case common.EncodingTypeJSON:
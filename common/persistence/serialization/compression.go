@@ -0,0 +1,93 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package serialization
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/temporalio/temporal/common"
+)
+
+var (
+	// zstd encoder and decoder are safe for concurrent use through EncodeAll / DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// IsCompressedEncoding returns true if the encoding type is proto3 with compression on top of it
+func IsCompressedEncoding(encoding common.EncodingType) bool {
+	switch encoding {
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		return true
+	default:
+		return false
+	}
+}
+
+// Compress compresses proto3 encoded data using the algorithm of the given compressed encoding type
+func Compress(data []byte, encoding common.EncodingType) ([]byte, error) {
+	switch encoding {
+	case common.EncodingTypeProto3Snappy:
+		return snappy.Encode(nil, data), nil
+	case common.EncodingTypeProto3Zstd:
+		return zstdEncoder.EncodeAll(data, make([]byte, 0, len(data))), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression encoding type: %v", encoding)
+	}
+}
+
+// Decompress returns the proto3 encoded data of the given compressed data
+func Decompress(data []byte, encoding common.EncodingType) ([]byte, error) {
+	var result []byte
+	var err error
+	switch encoding {
+	case common.EncodingTypeProto3Snappy:
+		result, err = snappy.Decode(nil, data)
+	case common.EncodingTypeProto3Zstd:
+		result, err = zstdDecoder.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("unsupported compression encoding type: %v", encoding)
+	}
+	return result, decodeErr(encoding, err)
+}
+
+// Decompress returns the data blob with compression removed, i.e. a proto3 blob for compressed encodings.
+// Blobs of any other encoding are returned as is.
+func (d *DataBlob) Decompress() (*DataBlob, error) {
+	if d == nil || !IsCompressedEncoding(d.Encoding) {
+		return d, nil
+	}
+	data, err := Decompress(d.Data, d.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	return &DataBlob{
+		Encoding: common.EncodingTypeProto3,
+		Data:     data,
+	}, nil
+}
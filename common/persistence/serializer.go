@@ -119,6 +119,8 @@ func (t *serializerImpl) DeserializeBatchEvents(data *serialization.DataBlob) ([
 		// Thrift == Proto for this object so that we can maintain test behavior until thrift is gone
 		// Client API currently specifies encodingType on requests which span multiple of these objects
 		err = proto.Unmarshal(data.Data, events)
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		err = t.unmarshalCompressed(data, events)
 	default:
 		return nil, NewDeserializationError("DeserializeBatchEvents invalid encoding")
 	}
@@ -152,6 +154,8 @@ func (t *serializerImpl) DeserializeEvent(data *serialization.DataBlob) (*histor
 		// Thrift == Proto for this object so that we can maintain test behavior until thrift is gone
 		// Client API currently specifies encodingType on requests which span multiple of these objects
 		err = proto.Unmarshal(data.Data, event)
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		err = t.unmarshalCompressed(data, event)
 	default:
 		return nil, NewDeserializationError("DeserializeEvent invalid encoding")
 	}
@@ -187,6 +191,8 @@ func (t *serializerImpl) DeserializeResetPoints(data *serialization.DataBlob) (*
 		// Thrift == Proto for this object so that we can maintain test behavior until thrift is gone
 		// Client API currently specifies encodingType on requests which span multiple of these objects
 		err = proto.Unmarshal(data.Data, memo)
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		err = t.unmarshalCompressed(data, memo)
 	default:
 		return nil, NewDeserializationError("DeserializeResetPoints invalid encoding")
 	}
@@ -222,6 +228,8 @@ func (t *serializerImpl) DeserializeBadBinaries(data *serialization.DataBlob) (*
 		// Thrift == Proto for this object so that we can maintain test behavior until thrift is gone
 		// Client API currently specifies encodingType on requests which span multiple of these objects
 		err = proto.Unmarshal(data.Data, memo)
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		err = t.unmarshalCompressed(data, memo)
 	default:
 		return nil, NewDeserializationError("DeserializeBadBinaries invalid encoding")
 	}
@@ -259,6 +267,8 @@ func (t *serializerImpl) DeserializeVisibilityMemo(data *serialization.DataBlob)
 		// Thrift == Proto for this object so that we can maintain test behavior until thrift is gone
 		// Client API currently specifies encodingType on requests which span multiple of these objects
 		err = proto.Unmarshal(data.Data, memo)
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		err = t.unmarshalCompressed(data, memo)
 	default:
 		return nil, NewDeserializationError("DeserializeVisibilityMemo invalid encoding")
 	}
@@ -294,6 +304,8 @@ func (t *serializerImpl) DeserializeVersionHistories(data *serialization.DataBlo
 		// Thrift == Proto for this object so that we can maintain test behavior until thrift is gone
 		// Client API currently specifies encodingType on requests which span multiple of these objects
 		err = proto.Unmarshal(data.Data, memo)
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		err = t.unmarshalCompressed(data, memo)
 	default:
 		return nil, NewDeserializationError("DeserializeVersionHistories invalid encoding")
 	}
@@ -329,6 +341,8 @@ func (t *serializerImpl) DeserializeImmutableClusterMetadata(data *serialization
 		// Thrift == Proto for this object so that we can maintain test behavior until thrift is gone
 		// Client API currently specifies encodingType on requests which span multiple of these objects
 		err = proto.Unmarshal(data.Data, event)
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		err = t.unmarshalCompressed(data, event)
 	default:
 		return nil, NewDeserializationError("DeserializeImmutableClusterMetadata invalid encoding")
 	}
@@ -353,6 +367,11 @@ func (t *serializerImpl) serializeProto(p proto.Marshaler, encodingType common.E
 		// Thrift == Proto for this object so that we can maintain test behavior until thrift is gone
 		// Client API currently specifies encodingType on requests which span multiple of these objects
 		data, err = p.Marshal()
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		data, err = p.Marshal()
+		if err == nil {
+			data, err = serialization.Compress(data, encodingType)
+		}
 	case common.EncodingTypeJSON, common.EncodingTypeUnknown, common.EncodingTypeEmpty: // For backward-compatibility
 		encodingType = common.EncodingTypeJSON
 		pb, ok := p.(proto.Message)
@@ -379,6 +398,14 @@ func (t *serializerImpl) serializeProto(p proto.Marshaler, encodingType common.E
 	}, nil
 }
 
+func (t *serializerImpl) unmarshalCompressed(data *serialization.DataBlob, target proto.Message) error {
+	decompressed, err := serialization.Decompress(data.Data, data.Encoding)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(decompressed, target)
+}
+
 func (t *serializerImpl) serialize(input interface{}, encodingType common.EncodingType) (*serialization.DataBlob, error) {
 	if input == nil {
 		return nil, nil
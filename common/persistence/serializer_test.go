@@ -424,3 +424,47 @@ func (s *temporalSerializerSuite) TestSerializer() {
 	succ := common.AwaitWaitGroup(&doneWG, 10*time.Second)
 	s.True(succ, "test timed out")
 }
+
+func (s *temporalSerializerSuite) TestSerializer_Compression() {
+	serializer := NewPayloadSerializer()
+
+	event0 := &historypb.HistoryEvent{
+		EventId:   999,
+		Timestamp: time.Now().UnixNano(),
+		EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED,
+		Attributes: &historypb.HistoryEvent_ActivityTaskCompletedEventAttributes{
+			ActivityTaskCompletedEventAttributes: &historypb.ActivityTaskCompletedEventAttributes{
+				Result:           payloads.EncodeString("result-1-event-1"),
+				ScheduledEventId: 4,
+				StartedEventId:   5,
+				Identity:         "event-1",
+			},
+		},
+	}
+	history0 := &historypb.History{Events: []*historypb.HistoryEvent{event0, event0, event0}}
+
+	dsProto, err := serializer.SerializeBatchEvents(history0.Events, common.EncodingTypeProto3)
+	s.NoError(err)
+
+	for _, encoding := range []common.EncodingType{common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd} {
+		dsCompressed, err := serializer.SerializeBatchEvents(history0.Events, encoding)
+		s.NoError(err)
+		s.Equal(encoding, dsCompressed.GetEncoding())
+		s.True(len(dsCompressed.Data) < len(dsProto.Data))
+
+		events, err := serializer.DeserializeBatchEvents(dsCompressed)
+		s.NoError(err)
+		s.True(reflect.DeepEqual(history0, &historypb.History{Events: events}))
+
+		dCompressed, err := serializer.SerializeEvent(event0, encoding)
+		s.NoError(err)
+		event1, err := serializer.DeserializeEvent(dCompressed)
+		s.NoError(err)
+		s.True(reflect.DeepEqual(event0, event1))
+
+		dsDecompressed, err := dsCompressed.Decompress()
+		s.NoError(err)
+		s.Equal(common.EncodingTypeProto3, dsDecompressed.Encoding)
+		s.Equal(dsProto.Data, dsDecompressed.Data)
+	}
+}
@@ -37,10 +37,11 @@ import (
 type (
 	// Factory vends store objects backed by MySQL
 	Factory struct {
-		cfg         config.SQL
-		dbConn      dbConn
-		clusterName string
-		logger      log.Logger
+		cfg          config.SQL
+		dbConn       dbConn
+		replicaConns []dbConn
+		clusterName  string
+		logger       log.Logger
 	}
 
 	// dbConn represents a logical mysql connection - its a
@@ -57,11 +58,18 @@ type (
 // NewFactory returns an instance of a factory object which can be used to create
 // datastores backed by any kind of SQL store
 func NewFactory(cfg config.SQL, clusterName string, logger log.Logger) *Factory {
+	var replicaConns []dbConn
+	for _, replica := range cfg.Replicas {
+		replicaCfg := cfg
+		replicaCfg.ConnectAddr = replica.ConnectAddr
+		replicaConns = append(replicaConns, newRefCountedDBConn(&replicaCfg))
+	}
 	return &Factory{
-		cfg:         cfg,
-		clusterName: clusterName,
-		logger:      logger,
-		dbConn:      newRefCountedDBConn(&cfg),
+		cfg:          cfg,
+		clusterName:  clusterName,
+		logger:       logger,
+		dbConn:       newRefCountedDBConn(&cfg),
+		replicaConns: replicaConns,
 	}
 }
 
@@ -89,7 +97,15 @@ func (f *Factory) NewHistoryV2Store() (p.HistoryStore, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newHistoryV2Persistence(conn, f.logger)
+	var replicas []sqlplugin.DB
+	if f.cfg.ReplicaReadPolicy.ClosedWorkflowHistoryReads {
+		replicas, err = f.getReplicaConns()
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return newHistoryV2Persistence(conn, replicas, f.cfg.ReplicaReadPolicy.MaxReplicationLag, f.logger)
 }
 
 // NewMetadataStore returns a new metadata store
@@ -137,6 +153,25 @@ func (f *Factory) NewQueue(queueType p.QueueType) (p.Queue, error) {
 // Close closes the factory
 func (f *Factory) Close() {
 	f.dbConn.forceClose()
+	for i := range f.replicaConns {
+		f.replicaConns[i].forceClose()
+	}
+}
+
+// getReplicaConns returns a connection to every read replica
+func (f *Factory) getReplicaConns() ([]sqlplugin.DB, error) {
+	replicas := make([]sqlplugin.DB, 0, len(f.replicaConns))
+	for i := range f.replicaConns {
+		conn, err := f.replicaConns[i].get()
+		if err != nil {
+			for _, replica := range replicas {
+				replica.Close()
+			}
+			return nil, err
+		}
+		replicas = append(replicas, conn)
+	}
+	return replicas, nil
 }
 
 // newRefCountedDBConn returns a  logical mysql connection that
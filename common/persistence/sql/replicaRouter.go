@@ -0,0 +1,140 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+// replicaLagCheckInterval is how long the replication lag of a replica is trusted before it is checked again
+const replicaLagCheckInterval = time.Second
+
+type (
+	// replicaRouter spreads reads that tolerate replication lag across the read replicas.
+	// A replica is skipped while it lags behind the primary by more than the max replication lag,
+	// or while its lag can't be determined, and reads fall back to the primary when no replica
+	// is caught up. Replicas may still miss the most recent writes within the max lag, so callers
+	// which know what a result must contain, e.g. the event ids of a closed workflow, should detect
+	// stale results and re-read them from the primary
+	replicaRouter struct {
+		primary           sqlplugin.DB
+		replicas          []*replica
+		maxReplicationLag time.Duration
+		timeSource        clock.TimeSource
+		logger            log.Logger
+		next              uint32
+	}
+
+	replica struct {
+		sync.Mutex
+		db             sqlplugin.DB
+		lagCheckedTime time.Time
+		caughtUp       bool
+	}
+)
+
+func newReplicaRouter(
+	primary sqlplugin.DB,
+	replicaDBs []sqlplugin.DB,
+	maxReplicationLag time.Duration,
+	logger log.Logger,
+) *replicaRouter {
+
+	replicas := make([]*replica, 0, len(replicaDBs))
+	for _, db := range replicaDBs {
+		replicas = append(replicas, &replica{db: db})
+	}
+	return &replicaRouter{
+		primary:           primary,
+		replicas:          replicas,
+		maxReplicationLag: maxReplicationLag,
+		timeSource:        clock.NewRealTimeSource(),
+		logger:            logger,
+	}
+}
+
+// newReplicaDBs creates a connection to every read replica in the config
+func newReplicaDBs(cfg config.SQL) ([]sqlplugin.DB, error) {
+	var replicas []sqlplugin.DB
+	for _, replica := range cfg.Replicas {
+		replicaCfg := cfg
+		replicaCfg.ConnectAddr = replica.ConnectAddr
+		db, err := NewSQLDB(&replicaCfg)
+		if err != nil {
+			for _, r := range replicas {
+				r.Close()
+			}
+			return nil, err
+		}
+		replicas = append(replicas, db)
+	}
+	return replicas, nil
+}
+
+// reader returns the connection to serve a lag tolerant read, the primary if no replica is caught up
+func (r *replicaRouter) reader() sqlplugin.DB {
+	for range r.replicas {
+		next := atomic.AddUint32(&r.next, 1)
+		replica := r.replicas[next%uint32(len(r.replicas))]
+		if r.isCaughtUp(replica) {
+			return replica.db
+		}
+	}
+	return r.primary
+}
+
+// isCaughtUp returns whether the replica lags behind the primary by no more than the max replication lag
+func (r *replicaRouter) isCaughtUp(replica *replica) bool {
+	replica.Lock()
+	defer replica.Unlock()
+
+	now := r.timeSource.Now()
+	if now.Sub(replica.lagCheckedTime) < replicaLagCheckInterval {
+		return replica.caughtUp
+	}
+	replica.lagCheckedTime = now
+	lag, err := replica.db.ReplicationLag()
+	if err != nil {
+		r.logger.Warn("Failed to get replication lag of read replica.", tag.Error(err))
+		replica.caughtUp = false
+		return false
+	}
+	replica.caughtUp = lag <= r.maxReplicationLag
+	return replica.caughtUp
+}
+
+func (r *replicaRouter) close() {
+	for _, replica := range r.replicas {
+		replica.db.Close()
+	}
+}
@@ -0,0 +1,158 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/log"
+	p "github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
+)
+
+type (
+	replicaRouterSuite struct {
+		suite.Suite
+
+		timeSource *clock.EventTimeSource
+		primary    *testReplicaDB
+		replica    *testReplicaDB
+		router     *replicaRouter
+	}
+
+	// testReplicaDB only implements the methods used by the tests, others panic
+	testReplicaDB struct {
+		sqlplugin.DB
+
+		lag             time.Duration
+		lagErr          error
+		lagChecks       int
+		visibilityReads int
+	}
+)
+
+const testMaxReplicationLag = 10 * time.Second
+
+func TestReplicaRouterSuite(t *testing.T) {
+	suite.Run(t, new(replicaRouterSuite))
+}
+
+func (s *replicaRouterSuite) SetupTest() {
+	s.timeSource = clock.NewEventTimeSource()
+	s.timeSource.Update(time.Now())
+	s.primary = &testReplicaDB{}
+	s.replica = &testReplicaDB{}
+	s.router = newReplicaRouter(s.primary, []sqlplugin.DB{s.replica}, testMaxReplicationLag, log.NewNoop())
+	s.router.timeSource = s.timeSource
+}
+
+func (s *replicaRouterSuite) TestReader_NoReplicas() {
+	router := newReplicaRouter(s.primary, nil, testMaxReplicationLag, log.NewNoop())
+	s.Same(s.primary, router.reader())
+}
+
+func (s *replicaRouterSuite) TestReader_ReplicaCaughtUp() {
+	s.replica.lag = testMaxReplicationLag
+	s.Same(s.replica, s.router.reader())
+}
+
+func (s *replicaRouterSuite) TestReader_ReplicaLagExceeded() {
+	s.replica.lag = testMaxReplicationLag + time.Second
+	s.Same(s.primary, s.router.reader())
+}
+
+func (s *replicaRouterSuite) TestReader_ReplicaLagUnknown() {
+	s.replica.lagErr = errors.New("replication is not running")
+	s.Same(s.primary, s.router.reader())
+}
+
+func (s *replicaRouterSuite) TestReader_LagRechecked() {
+	s.replica.lag = testMaxReplicationLag + time.Second
+	s.Same(s.primary, s.router.reader())
+
+	// the lag is trusted until the check interval passes
+	s.replica.lag = 0
+	s.Same(s.primary, s.router.reader())
+	s.Equal(1, s.replica.lagChecks)
+
+	s.timeSource.Update(s.timeSource.Now().Add(replicaLagCheckInterval))
+	s.Same(s.replica, s.router.reader())
+	s.Equal(2, s.replica.lagChecks)
+}
+
+func (s *replicaRouterSuite) TestReader_SkipsLaggingReplica() {
+	laggingReplica := &testReplicaDB{lag: testMaxReplicationLag + time.Second}
+	router := newReplicaRouter(s.primary, []sqlplugin.DB{laggingReplica, s.replica}, testMaxReplicationLag, log.NewNoop())
+	router.timeSource = s.timeSource
+	for i := 0; i < 4; i++ {
+		s.Same(s.replica, router.reader())
+	}
+}
+
+func (s *replicaRouterSuite) TestListWorkflowExecutions_ReplicaCaughtUp() {
+	store := s.newVisibilityStore()
+	_, err := store.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{NamespaceID: "namespace-id", PageSize: 10})
+	s.NoError(err)
+	_, err = store.ListClosedWorkflowExecutions(&p.ListWorkflowExecutionsRequest{NamespaceID: "namespace-id", PageSize: 10})
+	s.NoError(err)
+	s.Equal(2, s.replica.visibilityReads)
+	s.Equal(0, s.primary.visibilityReads)
+}
+
+func (s *replicaRouterSuite) TestListWorkflowExecutions_ReplicaLagExceeded() {
+	s.replica.lag = testMaxReplicationLag + time.Second
+	store := s.newVisibilityStore()
+	_, err := store.ListOpenWorkflowExecutions(&p.ListWorkflowExecutionsRequest{NamespaceID: "namespace-id", PageSize: 10})
+	s.NoError(err)
+	_, err = store.ListClosedWorkflowExecutions(&p.ListWorkflowExecutionsRequest{NamespaceID: "namespace-id", PageSize: 10})
+	s.NoError(err)
+	s.Equal(0, s.replica.visibilityReads)
+	s.Equal(2, s.primary.visibilityReads)
+}
+
+func (s *replicaRouterSuite) newVisibilityStore() *sqlVisibilityStore {
+	return &sqlVisibilityStore{
+		sqlStore: sqlStore{
+			db:     s.primary,
+			logger: log.NewNoop(),
+		},
+		replicas: s.router,
+	}
+}
+
+func (db *testReplicaDB) ReplicationLag() (time.Duration, error) {
+	db.lagChecks++
+	return db.lag, db.lagErr
+}
+
+func (db *testReplicaDB) SelectFromVisibility(filter *sqlplugin.VisibilityFilter) ([]sqlplugin.VisibilityRow, error) {
+	db.visibilityReads++
+	return nil, nil
+}
@@ -757,6 +757,10 @@ func (m *sqlExecutionManager) GetTransferTasks(
 	request *p.GetTransferTasksRequest,
 ) (*p.GetTransferTasksResponse, error) {
 
+	if request.IsolatedNamespaceID != "" {
+		return m.getIsolatedTransferTasks(request)
+	}
+
 	rows, err := m.db.SelectFromTransferTasks(&sqlplugin.TransferTasksFilter{
 		ShardID: m.shardID, MinTaskID: &request.ReadLevel, MaxTaskID: &request.MaxReadLevel})
 	if err != nil {
@@ -776,6 +780,31 @@ func (m *sqlExecutionManager) GetTransferTasks(
 	return resp, nil
 }
 
+func (m *sqlExecutionManager) getIsolatedTransferTasks(
+	request *p.GetTransferTasksRequest,
+) (*p.GetTransferTasksResponse, error) {
+
+	rows, err := m.db.SelectFromIsolatedTransferTasks(&sqlplugin.IsolatedTransferTasksFilter{
+		ShardID:     m.shardID,
+		NamespaceID: primitives.MustParseUUID(request.IsolatedNamespaceID),
+		MinTaskID:   request.ReadLevel,
+		MaxTaskID:   request.MaxReadLevel,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTransferTasks operation failed. Select failed. Error: %v", err))
+	}
+	resp := &p.GetTransferTasksResponse{Tasks: make([]*persistenceblobs.TransferTaskInfo, len(rows))}
+	for i, row := range rows {
+		info, err := serialization.TransferTaskInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		resp.Tasks[i] = info
+	}
+
+	return resp, nil
+}
+
 func (m *sqlExecutionManager) CompleteTransferTask(
 	request *p.CompleteTransferTaskRequest,
 ) error {
@@ -793,6 +822,18 @@ func (m *sqlExecutionManager) RangeCompleteTransferTask(
 	request *p.RangeCompleteTransferTaskRequest,
 ) error {
 
+	if request.IsolatedNamespaceID != "" {
+		if _, err := m.db.RangeDeleteFromIsolatedTransferTasks(&sqlplugin.IsolatedTransferTasksFilter{
+			ShardID:     m.shardID,
+			NamespaceID: primitives.MustParseUUID(request.IsolatedNamespaceID),
+			MinTaskID:   request.ExclusiveBeginTaskID,
+			MaxTaskID:   request.InclusiveEndTaskID,
+		}); err != nil {
+			return serviceerror.NewInternal(fmt.Sprintf("RangeCompleteTransferTask operation failed. Error: %v", err))
+		}
+		return nil
+	}
+
 	if _, err := m.db.DeleteFromTransferTasks(&sqlplugin.TransferTasksFilter{
 		ShardID:   m.shardID,
 		MinTaskID: &request.ExclusiveBeginTaskID,
@@ -1043,14 +1084,7 @@ func (m *sqlExecutionManager) GetTimerIndexTasks(
 		}
 	}
 
-	rows, err := m.db.SelectFromTimerTasks(&sqlplugin.TimerTasksFilter{
-		ShardID:                m.shardID,
-		MinVisibilityTimestamp: &pageToken.Timestamp,
-		TaskID:                 pageToken.TaskID,
-		MaxVisibilityTimestamp: &request.MaxTimestamp,
-		PageSize:               convert.IntPtr(request.BatchSize + 1),
-	})
-
+	rows, err := m.selectTimerTasks(request, pageToken)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTimerTasks operation failed. Select failed. Error: %v", err))
 	}
@@ -1085,6 +1119,45 @@ func (m *sqlExecutionManager) GetTimerIndexTasks(
 	return resp, nil
 }
 
+func (m *sqlExecutionManager) selectTimerTasks(
+	request *p.GetTimerIndexTasksRequest,
+	pageToken *timerTaskPageToken,
+) ([]sqlplugin.TimerTasksRow, error) {
+
+	if request.IsolatedNamespaceID == "" {
+		return m.db.SelectFromTimerTasks(&sqlplugin.TimerTasksFilter{
+			ShardID:                m.shardID,
+			MinVisibilityTimestamp: &pageToken.Timestamp,
+			TaskID:                 pageToken.TaskID,
+			MaxVisibilityTimestamp: &request.MaxTimestamp,
+			PageSize:               convert.IntPtr(request.BatchSize + 1),
+		})
+	}
+
+	isolatedRows, err := m.db.SelectFromIsolatedTimerTasks(&sqlplugin.IsolatedTimerTasksFilter{
+		ShardID:                m.shardID,
+		NamespaceID:            primitives.MustParseUUID(request.IsolatedNamespaceID),
+		TaskID:                 pageToken.TaskID,
+		MinVisibilityTimestamp: pageToken.Timestamp,
+		MaxVisibilityTimestamp: request.MaxTimestamp,
+		PageSize:               request.BatchSize + 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sqlplugin.TimerTasksRow, len(isolatedRows))
+	for i, row := range isolatedRows {
+		rows[i] = sqlplugin.TimerTasksRow{
+			ShardID:             row.ShardID,
+			VisibilityTimestamp: row.VisibilityTimestamp,
+			TaskID:              row.TaskID,
+			Data:                row.Data,
+			DataEncoding:        row.DataEncoding,
+		}
+	}
+	return rows, nil
+}
+
 func (m *sqlExecutionManager) CompleteTimerTask(
 	request *p.CompleteTimerTaskRequest,
 ) error {
@@ -1105,6 +1178,18 @@ func (m *sqlExecutionManager) RangeCompleteTimerTask(
 
 	start := request.InclusiveBeginTimestamp
 	end := request.ExclusiveEndTimestamp
+	if request.IsolatedNamespaceID != "" {
+		if _, err := m.db.RangeDeleteFromIsolatedTimerTasks(&sqlplugin.IsolatedTimerTasksFilter{
+			ShardID:                m.shardID,
+			NamespaceID:            primitives.MustParseUUID(request.IsolatedNamespaceID),
+			MinVisibilityTimestamp: start,
+			MaxVisibilityTimestamp: end,
+		}); err != nil {
+			return serviceerror.NewInternal(fmt.Sprintf("CompleteTimerTask operation failed. Error: %v", err))
+		}
+		return nil
+	}
+
 	if _, err := m.db.DeleteFromTimerTasks(&sqlplugin.TimerTasksFilter{
 		ShardID:                m.shardID,
 		MinVisibilityTimestamp: &start,
@@ -1141,3 +1226,153 @@ func (m *sqlExecutionManager) PutReplicationTaskToDLQ(request *p.PutReplicationT
 
 	return nil
 }
+
+func (m *sqlExecutionManager) PutTransferTaskToDLQ(
+	request *p.PutTransferTaskToDLQRequest,
+) error {
+
+	task := request.TaskInfo
+	blob, err := serialization.TransferTaskInfoToBlob(task)
+	if err != nil {
+		return err
+	}
+
+	row := &sqlplugin.TaskDLQRow{
+		ShardID:      m.shardID,
+		TaskID:       task.GetTaskId(),
+		Data:         blob.Data,
+		DataEncoding: string(blob.Encoding),
+	}
+
+	// Tasks are immutable. So it's fine if we already persisted it before.
+	if _, err := m.db.InsertIntoTransferTasksDLQ(row); err != nil && !m.db.IsDupEntryError(err) {
+		return serviceerror.NewInternal(fmt.Sprintf("PutTransferTaskToDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) GetTransferTasksFromDLQ(
+	request *p.GetTransferTasksFromDLQRequest,
+) (*p.GetTransferTasksFromDLQResponse, error) {
+
+	readLevel := request.ReadLevel
+	if len(request.NextPageToken) > 0 {
+		var err error
+		if readLevel, err = deserializePageToken(request.NextPageToken); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := m.db.SelectFromTransferTasksDLQ(&sqlplugin.TaskDLQFilter{
+		ShardID:   m.shardID,
+		MinTaskID: readLevel,
+		MaxTaskID: request.MaxReadLevel,
+		PageSize:  request.BatchSize,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTransferTasksFromDLQ operation failed. Select failed. Error: %v", err))
+	}
+
+	resp := &p.GetTransferTasksFromDLQResponse{Tasks: make([]*persistenceblobs.TransferTaskInfo, len(rows))}
+	for i, row := range rows {
+		info, err := serialization.TransferTaskInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		resp.Tasks[i] = info
+	}
+	if len(rows) > 0 && len(rows) == request.BatchSize {
+		resp.NextPageToken = serializePageToken(rows[len(rows)-1].TaskID)
+	}
+
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) RangeDeleteTransferTaskFromDLQ(
+	request *p.RangeDeleteTransferTaskFromDLQRequest,
+) error {
+
+	if _, err := m.db.RangeDeleteFromTransferTasksDLQ(&sqlplugin.TaskDLQFilter{
+		ShardID:   m.shardID,
+		MinTaskID: request.ExclusiveBeginTaskID,
+		MaxTaskID: request.InclusiveEndTaskID,
+	}); err != nil {
+		return serviceerror.NewInternal(fmt.Sprintf("RangeDeleteTransferTaskFromDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) PutTimerTaskToDLQ(
+	request *p.PutTimerTaskToDLQRequest,
+) error {
+
+	task := request.TaskInfo
+	blob, err := serialization.TimerTaskInfoToBlob(task)
+	if err != nil {
+		return err
+	}
+
+	row := &sqlplugin.TaskDLQRow{
+		ShardID:      m.shardID,
+		TaskID:       task.GetTaskId(),
+		Data:         blob.Data,
+		DataEncoding: string(blob.Encoding),
+	}
+
+	// Tasks are immutable. So it's fine if we already persisted it before.
+	if _, err := m.db.InsertIntoTimerTasksDLQ(row); err != nil && !m.db.IsDupEntryError(err) {
+		return serviceerror.NewInternal(fmt.Sprintf("PutTimerTaskToDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) GetTimerTasksFromDLQ(
+	request *p.GetTimerTasksFromDLQRequest,
+) (*p.GetTimerTasksFromDLQResponse, error) {
+
+	readLevel := request.ReadLevel
+	if len(request.NextPageToken) > 0 {
+		var err error
+		if readLevel, err = deserializePageToken(request.NextPageToken); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := m.db.SelectFromTimerTasksDLQ(&sqlplugin.TaskDLQFilter{
+		ShardID:   m.shardID,
+		MinTaskID: readLevel,
+		MaxTaskID: request.MaxReadLevel,
+		PageSize:  request.BatchSize,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTimerTasksFromDLQ operation failed. Select failed. Error: %v", err))
+	}
+
+	resp := &p.GetTimerTasksFromDLQResponse{Timers: make([]*persistenceblobs.TimerTaskInfo, len(rows))}
+	for i, row := range rows {
+		info, err := serialization.TimerTaskInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		resp.Timers[i] = info
+	}
+	if len(rows) > 0 && len(rows) == request.BatchSize {
+		resp.NextPageToken = serializePageToken(rows[len(rows)-1].TaskID)
+	}
+
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) RangeDeleteTimerTaskFromDLQ(
+	request *p.RangeDeleteTimerTaskFromDLQRequest,
+) error {
+
+	if _, err := m.db.RangeDeleteFromTimerTasksDLQ(&sqlplugin.TaskDLQFilter{
+		ShardID:   m.shardID,
+		MinTaskID: request.ExclusiveBeginTaskID,
+		MaxTaskID: request.InclusiveEndTaskID,
+	}); err != nil {
+		return serviceerror.NewInternal(fmt.Sprintf("RangeDeleteTimerTaskFromDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
@@ -108,7 +108,9 @@ func applyWorkflowMutationTx(
 		runID,
 		workflowMutation.TransferTasks,
 		workflowMutation.ReplicationTasks,
-		workflowMutation.TimerTasks); err != nil {
+		workflowMutation.TimerTasks,
+		workflowMutation.IsolatedTransferTasks,
+		workflowMutation.IsolatedTimerTasks); err != nil {
 		return err
 	}
 
@@ -256,7 +258,9 @@ func applyWorkflowSnapshotTxAsReset(
 		runID,
 		workflowSnapshot.TransferTasks,
 		workflowSnapshot.ReplicationTasks,
-		workflowSnapshot.TimerTasks); err != nil {
+		workflowSnapshot.TimerTasks,
+		workflowSnapshot.IsolatedTransferTasks,
+		workflowSnapshot.IsolatedTimerTasks); err != nil {
 		return err
 	}
 
@@ -426,7 +430,9 @@ func (m *sqlExecutionManager) applyWorkflowSnapshotTxAsNew(
 		runID,
 		workflowSnapshot.TransferTasks,
 		workflowSnapshot.ReplicationTasks,
-		workflowSnapshot.TimerTasks); err != nil {
+		workflowSnapshot.TimerTasks,
+		workflowSnapshot.IsolatedTransferTasks,
+		workflowSnapshot.IsolatedTimerTasks); err != nil {
 		return err
 	}
 
@@ -502,6 +508,8 @@ func applyTasks(
 	transferTasks []p.Task,
 	replicationTasks []p.Task,
 	timerTasks []p.Task,
+	isolatedTransferTasks bool,
+	isolatedTimerTasks bool,
 ) error {
 
 	if err := createTransferTasks(tx,
@@ -509,7 +517,8 @@ func applyTasks(
 		shardID,
 		namespaceID,
 		workflowID,
-		runID); err != nil {
+		runID,
+		isolatedTransferTasks); err != nil {
 		return serviceerror.NewInternal(fmt.Sprintf("applyTasks failed. Failed to create transfer tasks. Error: %v", err))
 	}
 
@@ -527,7 +536,8 @@ func applyTasks(
 		shardID,
 		namespaceID,
 		workflowID,
-		runID); err != nil {
+		runID,
+		isolatedTimerTasks); err != nil {
 		return serviceerror.NewInternal(fmt.Sprintf("applyTasks failed. Failed to create timer tasks. Error: %v", err))
 	}
 
@@ -682,6 +692,7 @@ func createTransferTasks(
 	namespaceID string,
 	workflowID string,
 	runID string,
+	isolated bool,
 ) error {
 
 	if len(transferTasks) == 0 {
@@ -765,7 +776,7 @@ func createTransferTasks(
 		transferTasksRows[i].DataEncoding = string(blob.Encoding)
 	}
 
-	result, err := tx.InsertIntoTransferTasks(transferTasksRows)
+	result, err := insertTransferTasks(tx, transferTasksRows, namespaceID, isolated)
 	if err != nil {
 		return serviceerror.NewInternal(fmt.Sprintf("createTransferTasks failed. Error: %v", err))
 	}
@@ -883,6 +894,7 @@ func createTimerTasks(
 	namespaceID string,
 	workflowID string,
 	runID string,
+	isolated bool,
 ) error {
 
 	if len(timerTasks) > 0 {
@@ -948,7 +960,7 @@ func createTimerTasks(
 			timerTasksRows[i].DataEncoding = string(blob.Encoding)
 		}
 
-		result, err := tx.InsertIntoTimerTasks(timerTasksRows)
+		result, err := insertTimerTasks(tx, timerTasksRows, namespaceID, isolated)
 		if err != nil {
 			return serviceerror.NewInternal(fmt.Sprintf("createTimerTasks failed. Error: %v", err))
 		}
@@ -965,6 +977,57 @@ func createTimerTasks(
 	return nil
 }
 
+// insertTransferTasks writes the rows to the transfer queue of the namespace when isolated is set,
+// otherwise to the transfer queue shared by the namespaces of the shard
+func insertTransferTasks(
+	tx sqlplugin.Tx,
+	rows []sqlplugin.TransferTasksRow,
+	namespaceID string,
+	isolated bool,
+) (sql.Result, error) {
+
+	if !isolated {
+		return tx.InsertIntoTransferTasks(rows)
+	}
+	isolatedRows := make([]sqlplugin.IsolatedTransferTasksRow, len(rows))
+	for i, row := range rows {
+		isolatedRows[i] = sqlplugin.IsolatedTransferTasksRow{
+			ShardID:      row.ShardID,
+			NamespaceID:  primitives.MustParseUUID(namespaceID),
+			TaskID:       row.TaskID,
+			Data:         row.Data,
+			DataEncoding: row.DataEncoding,
+		}
+	}
+	return tx.InsertIntoIsolatedTransferTasks(isolatedRows)
+}
+
+// insertTimerTasks writes the rows to the timer queue of the namespace when isolated is set,
+// otherwise to the timer queue shared by the namespaces of the shard
+func insertTimerTasks(
+	tx sqlplugin.Tx,
+	rows []sqlplugin.TimerTasksRow,
+	namespaceID string,
+	isolated bool,
+) (sql.Result, error) {
+
+	if !isolated {
+		return tx.InsertIntoTimerTasks(rows)
+	}
+	isolatedRows := make([]sqlplugin.IsolatedTimerTasksRow, len(rows))
+	for i, row := range rows {
+		isolatedRows[i] = sqlplugin.IsolatedTimerTasksRow{
+			ShardID:             row.ShardID,
+			NamespaceID:         primitives.MustParseUUID(namespaceID),
+			VisibilityTimestamp: row.VisibilityTimestamp,
+			TaskID:              row.TaskID,
+			Data:                row.Data,
+			DataEncoding:        row.DataEncoding,
+		}
+	}
+	return tx.InsertIntoIsolatedTimerTasks(isolatedRows)
+}
+
 func assertNotCurrentExecution(
 	tx sqlplugin.Tx,
 	shardID int,
@@ -27,6 +27,7 @@ package sql
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"go.temporal.io/temporal-proto/serviceerror"
@@ -43,11 +44,14 @@ import (
 
 type sqlHistoryV2Manager struct {
 	sqlStore
+	replicas *replicaRouter
 }
 
 // newHistoryV2Persistence creates an instance of HistoryManager
 func newHistoryV2Persistence(
 	db sqlplugin.DB,
+	replicas []sqlplugin.DB,
+	maxReplicationLag time.Duration,
 	logger log.Logger,
 ) (p.HistoryStore, error) {
 
@@ -56,9 +60,16 @@ func newHistoryV2Persistence(
 			db:     db,
 			logger: logger,
 		},
+		replicas: newReplicaRouter(db, replicas, maxReplicationLag, logger),
 	}, nil
 }
 
+// Close closes the primary and replica connections
+func (m *sqlHistoryV2Manager) Close() {
+	m.sqlStore.Close()
+	m.replicas.close()
+}
+
 // AppendHistoryNodes add(or override) a node to a history branch
 func (m *sqlHistoryV2Manager) AppendHistoryNodes(
 	request *p.InternalAppendHistoryNodesRequest,
@@ -188,7 +199,11 @@ func (m *sqlHistoryV2Manager) ReadHistoryBranch(
 		ShardID:   request.ShardID,
 	}
 
-	rows, err := m.db.SelectFromHistoryNode(filter)
+	db := m.db
+	if request.ReadFromReplica {
+		db = m.replicas.reader()
+	}
+	rows, err := db.SelectFromHistoryNode(filter)
 	if err == sql.ErrNoRows || (err == nil && len(rows) == 0) {
 		return &p.InternalReadHistoryBranchResponse{}, nil
 	}
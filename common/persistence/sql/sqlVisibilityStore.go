@@ -44,6 +44,7 @@ import (
 type (
 	sqlVisibilityStore struct {
 		sqlStore
+		replicas *replicaRouter
 	}
 
 	visibilityPageToken struct {
@@ -58,14 +59,29 @@ func NewSQLVisibilityStore(cfg config.SQL, logger log.Logger) (p.VisibilityStore
 	if err != nil {
 		return nil, err
 	}
+	var replicas []sqlplugin.DB
+	if cfg.ReplicaReadPolicy.VisibilityReads {
+		replicas, err = newReplicaDBs(cfg)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
 	return &sqlVisibilityStore{
 		sqlStore: sqlStore{
 			db:     db,
 			logger: logger,
 		},
+		replicas: newReplicaRouter(db, replicas, cfg.ReplicaReadPolicy.MaxReplicationLag, logger),
 	}, nil
 }
 
+// Close closes the primary and replica connections
+func (s *sqlVisibilityStore) Close() {
+	s.sqlStore.Close()
+	s.replicas.close()
+}
+
 func (s *sqlVisibilityStore) RecordWorkflowExecutionStarted(request *p.InternalRecordWorkflowExecutionStartedRequest) error {
 	_, err := s.db.InsertIntoVisibility(&sqlplugin.VisibilityRow{
 		NamespaceID:      request.NamespaceID,
@@ -120,7 +136,7 @@ func (s *sqlVisibilityStore) ListOpenWorkflowExecutions(request *p.ListWorkflowE
 	return s.listWorkflowExecutions("ListOpenWorkflowExecutions", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
 		func(readLevel *visibilityPageToken) ([]sqlplugin.VisibilityRow, error) {
 			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqlplugin.VisibilityFilter{
+			return s.replicas.reader().SelectFromVisibility(&sqlplugin.VisibilityFilter{
 				NamespaceID:  request.NamespaceID,
 				MinStartTime: &minStartTime,
 				MaxStartTime: &readLevel.Time,
@@ -134,7 +150,7 @@ func (s *sqlVisibilityStore) ListClosedWorkflowExecutions(request *p.ListWorkflo
 	return s.listWorkflowExecutions("ListClosedWorkflowExecutions", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
 		func(readLevel *visibilityPageToken) ([]sqlplugin.VisibilityRow, error) {
 			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqlplugin.VisibilityFilter{
+			return s.replicas.reader().SelectFromVisibility(&sqlplugin.VisibilityFilter{
 				NamespaceID:  request.NamespaceID,
 				MinStartTime: &minStartTime,
 				MaxStartTime: &readLevel.Time,
@@ -149,7 +165,7 @@ func (s *sqlVisibilityStore) ListOpenWorkflowExecutionsByType(request *p.ListWor
 	return s.listWorkflowExecutions("ListOpenWorkflowExecutionsByType", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
 		func(readLevel *visibilityPageToken) ([]sqlplugin.VisibilityRow, error) {
 			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqlplugin.VisibilityFilter{
+			return s.replicas.reader().SelectFromVisibility(&sqlplugin.VisibilityFilter{
 				NamespaceID:      request.NamespaceID,
 				MinStartTime:     &minStartTime,
 				MaxStartTime:     &readLevel.Time,
@@ -164,7 +180,7 @@ func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByType(request *p.ListW
 	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByType", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
 		func(readLevel *visibilityPageToken) ([]sqlplugin.VisibilityRow, error) {
 			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqlplugin.VisibilityFilter{
+			return s.replicas.reader().SelectFromVisibility(&sqlplugin.VisibilityFilter{
 				NamespaceID:      request.NamespaceID,
 				MinStartTime:     &minStartTime,
 				MaxStartTime:     &readLevel.Time,
@@ -180,7 +196,7 @@ func (s *sqlVisibilityStore) ListOpenWorkflowExecutionsByWorkflowID(request *p.L
 	return s.listWorkflowExecutions("ListOpenWorkflowExecutionsByWorkflowID", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
 		func(readLevel *visibilityPageToken) ([]sqlplugin.VisibilityRow, error) {
 			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqlplugin.VisibilityFilter{
+			return s.replicas.reader().SelectFromVisibility(&sqlplugin.VisibilityFilter{
 				NamespaceID:  request.NamespaceID,
 				MinStartTime: &minStartTime,
 				MaxStartTime: &readLevel.Time,
@@ -195,7 +211,7 @@ func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByWorkflowID(request *p
 	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByWorkflowID", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
 		func(readLevel *visibilityPageToken) ([]sqlplugin.VisibilityRow, error) {
 			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqlplugin.VisibilityFilter{
+			return s.replicas.reader().SelectFromVisibility(&sqlplugin.VisibilityFilter{
 				NamespaceID:  request.NamespaceID,
 				MinStartTime: &minStartTime,
 				MaxStartTime: &readLevel.Time,
@@ -211,7 +227,7 @@ func (s *sqlVisibilityStore) ListClosedWorkflowExecutionsByStatus(request *p.Lis
 	return s.listWorkflowExecutions("ListClosedWorkflowExecutionsByStatus", request.NextPageToken, request.EarliestStartTime, request.LatestStartTime,
 		func(readLevel *visibilityPageToken) ([]sqlplugin.VisibilityRow, error) {
 			minStartTime := time.Unix(0, request.EarliestStartTime)
-			return s.db.SelectFromVisibility(&sqlplugin.VisibilityFilter{
+			return s.replicas.reader().SelectFromVisibility(&sqlplugin.VisibilityFilter{
 				NamespaceID:  request.NamespaceID,
 				MinStartTime: &minStartTime,
 				MaxStartTime: &readLevel.Time,
@@ -49,6 +49,20 @@ type (
 		ImmutableDataEncoding string
 	}
 
+	// SchemaColumnRow represents a table column as reported by the database catalog
+	SchemaColumnRow struct {
+		TableName  string
+		ColumnName string
+		ColumnType string
+	}
+
+	// SchemaIndexRow represents a table index as reported by the database catalog
+	SchemaIndexRow struct {
+		TableName string
+		IndexName string
+		IndexDef  string
+	}
+
 	// ClusterMembershipRow represents a row in the cluster_membership table
 	ClusterMembershipRow struct {
 		Role           persistence.ServiceType
@@ -287,6 +301,62 @@ type (
 		SourceClusterName string
 	}
 
+	// TaskDLQRow represents a row in transfer_tasks_dlq or timer_tasks_dlq table
+	TaskDLQRow struct {
+		ShardID      int
+		TaskID       int64
+		Data         []byte
+		DataEncoding string
+	}
+
+	// TaskDLQFilter contains the column names within transfer_tasks_dlq and timer_tasks_dlq
+	// tables that can be used to filter results through a WHERE clause
+	TaskDLQFilter struct {
+		ShardID   int
+		MinTaskID int64
+		MaxTaskID int64
+		PageSize  int
+	}
+
+	// IsolatedTransferTasksRow represents a row in isolated_transfer_tasks table
+	IsolatedTransferTasksRow struct {
+		ShardID      int
+		NamespaceID  primitives.UUID
+		TaskID       int64
+		Data         []byte
+		DataEncoding string
+	}
+
+	// IsolatedTransferTasksFilter contains the column names within isolated_transfer_tasks table that
+	// can be used to filter results through a WHERE clause
+	IsolatedTransferTasksFilter struct {
+		ShardID     int
+		NamespaceID primitives.UUID
+		MinTaskID   int64
+		MaxTaskID   int64
+	}
+
+	// IsolatedTimerTasksRow represents a row in isolated_timer_tasks table
+	IsolatedTimerTasksRow struct {
+		ShardID             int
+		NamespaceID         primitives.UUID
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Data                []byte
+		DataEncoding        string
+	}
+
+	// IsolatedTimerTasksFilter contains the column names within isolated_timer_tasks table that
+	// can be used to filter results through a WHERE clause
+	IsolatedTimerTasksFilter struct {
+		ShardID                int
+		NamespaceID            primitives.UUID
+		TaskID                 int64
+		MinVisibilityTimestamp time.Time
+		MaxVisibilityTimestamp time.Time
+		PageSize               int
+	}
+
 	// TimerTasksRow represents a row in timer_tasks table
 	TimerTasksRow struct {
 		ShardID             int
@@ -665,6 +735,38 @@ type (
 		// Required filter params - {sourceClusterName, shardID, taskID, inclusiveTaskID}
 		RangeDeleteMessageFromReplicationTasksDLQ(filter *ReplicationTasksDLQFilter) (sql.Result, error)
 
+		// InsertIntoTransferTasksDLQ puts the transfer task into DLQ
+		InsertIntoTransferTasksDLQ(row *TaskDLQRow) (sql.Result, error)
+		// SelectFromTransferTasksDLQ returns one or more rows from transfer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, maxTaskID, pageSize}
+		SelectFromTransferTasksDLQ(filter *TaskDLQFilter) ([]TaskDLQRow, error)
+		// RangeDeleteFromTransferTasksDLQ deletes one or more rows from transfer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, maxTaskID}
+		RangeDeleteFromTransferTasksDLQ(filter *TaskDLQFilter) (sql.Result, error)
+		// InsertIntoTimerTasksDLQ puts the timer task into DLQ
+		InsertIntoTimerTasksDLQ(row *TaskDLQRow) (sql.Result, error)
+		// SelectFromTimerTasksDLQ returns one or more rows from timer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, maxTaskID, pageSize}
+		SelectFromTimerTasksDLQ(filter *TaskDLQFilter) ([]TaskDLQRow, error)
+		// RangeDeleteFromTimerTasksDLQ deletes one or more rows from timer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, maxTaskID}
+		RangeDeleteFromTimerTasksDLQ(filter *TaskDLQFilter) (sql.Result, error)
+
+		InsertIntoIsolatedTransferTasks(rows []IsolatedTransferTasksRow) (sql.Result, error)
+		// SelectFromIsolatedTransferTasks returns rows that match filter criteria from isolated_transfer_tasks table
+		// Required filter params - {shardID, namespaceID, minTaskID, maxTaskID}
+		SelectFromIsolatedTransferTasks(filter *IsolatedTransferTasksFilter) ([]IsolatedTransferTasksRow, error)
+		// RangeDeleteFromIsolatedTransferTasks deletes one or more rows from isolated_transfer_tasks table
+		// Required filter params - {shardID, namespaceID, minTaskID, maxTaskID}
+		RangeDeleteFromIsolatedTransferTasks(filter *IsolatedTransferTasksFilter) (sql.Result, error)
+		InsertIntoIsolatedTimerTasks(rows []IsolatedTimerTasksRow) (sql.Result, error)
+		// SelectFromIsolatedTimerTasks returns one or more rows from isolated_timer_tasks table
+		// Required filter params - {shardID, namespaceID, taskID, minVisibilityTimestamp, maxVisibilityTimestamp, pageSize}
+		SelectFromIsolatedTimerTasks(filter *IsolatedTimerTasksFilter) ([]IsolatedTimerTasksRow, error)
+		// RangeDeleteFromIsolatedTimerTasks deletes one or more rows from isolated_timer_tasks table
+		// Required filter params - {shardID, namespaceID, minVisibilityTimestamp, maxVisibilityTimestamp}
+		RangeDeleteFromIsolatedTimerTasks(filter *IsolatedTimerTasksFilter) (sql.Result, error)
+
 		ReplaceIntoActivityInfoMaps(rows []ActivityInfoMapsRow) (sql.Result, error)
 		// SelectFromActivityInfoMaps returns one or more rows from activity_info_maps
 		// Required filter params - {shardID, namespaceID, workflowID, runID}
@@ -760,6 +862,8 @@ type (
 		UpdateSchemaVersion(database string, newVersion string, minCompatibleVersion string) error
 		WriteSchemaUpdateLog(oldVersion string, newVersion string, manifestMD5 string, desc string) error
 		ListTables(database string) ([]string, error)
+		ListColumns(database string) ([]SchemaColumnRow, error)
+		ListIndexes(database string) ([]SchemaIndexRow, error)
 		DropTable(table string) error
 		DropAllTables(database string) error
 		CreateDatabase(database string) error
@@ -781,6 +885,8 @@ type (
 		BeginTx() (Tx, error)
 		PluginName() string
 		IsDupEntryError(err error) bool
+		// ReplicationLag returns how far a read replica is behind its primary, 0 if the database is not a replica
+		ReplicationLag() (time.Duration, error)
 		Close() error
 	}
 
@@ -27,6 +27,8 @@ package mysql
 import (
 	"fmt"
 	"time"
+
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
 )
 
 const (
@@ -58,6 +60,14 @@ const (
 
 	listTablesQuery = "SHOW TABLES FROM %v"
 
+	listColumnsQuery = `SELECT table_name AS table_name, column_name AS column_name, ` +
+		`CONCAT(column_type, IF(is_nullable = 'NO', ' NOT NULL', '')) AS column_type ` +
+		`FROM information_schema.columns WHERE table_schema = ?`
+
+	listIndexesQuery = `SELECT table_name AS table_name, index_name AS index_name, ` +
+		`CONCAT(IF(non_unique = 0, 'UNIQUE ', ''), '(', GROUP_CONCAT(column_name ORDER BY seq_in_index), ')') AS index_def ` +
+		`FROM information_schema.statistics WHERE table_schema = ? GROUP BY table_name, index_name, non_unique`
+
 	dropTableQuery = "DROP TABLE %v"
 )
 
@@ -100,6 +110,20 @@ func (mdb *db) ListTables(database string) ([]string, error) {
 	return tables, err
 }
 
+// ListColumns returns the columns of all tables in this database
+func (mdb *db) ListColumns(database string) ([]sqlplugin.SchemaColumnRow, error) {
+	var rows []sqlplugin.SchemaColumnRow
+	err := mdb.db.Select(&rows, listColumnsQuery, database)
+	return rows, err
+}
+
+// ListIndexes returns the indexes of all tables in this database
+func (mdb *db) ListIndexes(database string) ([]sqlplugin.SchemaIndexRow, error) {
+	var rows []sqlplugin.SchemaIndexRow
+	err := mdb.db.Select(&rows, listIndexesQuery, database)
+	return rows, err
+}
+
 // DropTable drops a given table from the database
 func (mdb *db) DropTable(name string) error {
 	return mdb.Exec(fmt.Sprintf(dropTableQuery, name))
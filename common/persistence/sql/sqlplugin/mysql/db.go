@@ -25,6 +25,11 @@
 package mysql
 
 import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
 	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 
@@ -52,6 +57,35 @@ func (mdb *db) IsDupEntryError(err error) bool {
 	return ok && sqlErr.Number == ErrDupEntry
 }
 
+const (
+	showReplicaStatusQuery = `SHOW SLAVE STATUS`
+	secondsBehindPrimary   = "Seconds_Behind_Master"
+)
+
+var errReplicationNotRunning = errors.New("replication is not running")
+
+// ReplicationLag returns how far the replica is behind its primary, 0 if the database is not a replica
+func (mdb *db) ReplicationLag() (time.Duration, error) {
+	status := make(map[string]interface{})
+	err := mdb.db.QueryRowx(showReplicaStatusQuery).MapScan(status)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	// seconds behind master is NULL while replication is stopped
+	seconds, ok := status[secondsBehindPrimary].([]byte)
+	if !ok {
+		return 0, errReplicationNotRunning
+	}
+	lag, err := strconv.ParseInt(string(seconds), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(lag) * time.Second, nil
+}
+
 // newDB returns an instance of DB, which is a logical
 // connection to the underlying mysql database
 func newDB(xdb *sqlx.DB, tx *sqlx.Tx) *db {
@@ -156,6 +156,49 @@ VALUES     (:source_cluster_name,
 		AND shard_id = ? 
 		AND task_id > ?
 		AND task_id <= ?`
+
+	insertTransferTaskDLQQuery = `INSERT INTO transfer_tasks_dlq (shard_id, task_id, data, data_encoding) 
+VALUES (:shard_id, :task_id, :data, :data_encoding)`
+
+	getTransferTasksDLQQuery = `SELECT task_id, data, data_encoding FROM transfer_tasks_dlq WHERE 
+shard_id = ? AND
+task_id > ? AND
+task_id <= ?
+ORDER BY task_id LIMIT ?`
+
+	rangeDeleteTransferTaskFromDLQQuery = `DELETE FROM transfer_tasks_dlq WHERE shard_id = ? AND task_id > ? AND task_id <= ?`
+
+	insertTimerTaskDLQQuery = `INSERT INTO timer_tasks_dlq (shard_id, task_id, data, data_encoding) 
+VALUES (:shard_id, :task_id, :data, :data_encoding)`
+
+	getTimerTasksDLQQuery = `SELECT task_id, data, data_encoding FROM timer_tasks_dlq WHERE 
+shard_id = ? AND
+task_id > ? AND
+task_id <= ?
+ORDER BY task_id LIMIT ?`
+
+	rangeDeleteTimerTaskFromDLQQuery = `DELETE FROM timer_tasks_dlq WHERE shard_id = ? AND task_id > ? AND task_id <= ?`
+
+	createIsolatedTransferTasksQuery = `INSERT INTO isolated_transfer_tasks(shard_id, namespace_id, task_id, data, data_encoding) 
+ VALUES(:shard_id, :namespace_id, :task_id, :data, :data_encoding)`
+
+	getIsolatedTransferTasksQuery = `SELECT namespace_id, task_id, data, data_encoding FROM isolated_transfer_tasks 
+ WHERE shard_id = ? AND namespace_id = ? AND task_id > ? AND task_id <= ? ORDER BY shard_id, namespace_id, task_id`
+
+	rangeDeleteIsolatedTransferTaskQuery = `DELETE FROM isolated_transfer_tasks 
+ WHERE shard_id = ? AND namespace_id = ? AND task_id > ? AND task_id <= ?`
+
+	createIsolatedTimerTasksQuery = `INSERT INTO isolated_timer_tasks (shard_id, namespace_id, visibility_timestamp, task_id, data, data_encoding)
+  VALUES (:shard_id, :namespace_id, :visibility_timestamp, :task_id, :data, :data_encoding)`
+
+	getIsolatedTimerTasksQuery = `SELECT namespace_id, visibility_timestamp, task_id, data, data_encoding FROM isolated_timer_tasks 
+  WHERE shard_id = ? AND namespace_id = ? 
+  AND ((visibility_timestamp >= ? AND task_id >= ?) OR visibility_timestamp > ?) 
+  AND visibility_timestamp < ?
+  ORDER BY visibility_timestamp,task_id LIMIT ?`
+
+	rangeDeleteIsolatedTimerTaskQuery = `DELETE FROM isolated_timer_tasks 
+  WHERE shard_id = ? AND namespace_id = ? AND visibility_timestamp >= ? AND visibility_timestamp < ?`
 )
 
 // InsertIntoExecutions inserts a row into executions table
@@ -381,3 +424,126 @@ func (mdb *db) RangeDeleteMessageFromReplicationTasksDLQ(
 		filter.InclusiveEndTaskID,
 	)
 }
+
+// InsertIntoTransferTasksDLQ inserts a row into transfer_tasks_dlq table
+func (mdb *db) InsertIntoTransferTasksDLQ(row *sqlplugin.TaskDLQRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(insertTransferTaskDLQQuery, row)
+}
+
+// SelectFromTransferTasksDLQ reads one or more rows from transfer_tasks_dlq table
+func (mdb *db) SelectFromTransferTasksDLQ(filter *sqlplugin.TaskDLQFilter) ([]sqlplugin.TaskDLQRow, error) {
+	var rows []sqlplugin.TaskDLQRow
+	err := mdb.conn.Select(
+		&rows, getTransferTasksDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromTransferTasksDLQ deletes one or more rows from transfer_tasks_dlq table
+func (mdb *db) RangeDeleteFromTransferTasksDLQ(filter *sqlplugin.TaskDLQFilter) (sql.Result, error) {
+	return mdb.conn.Exec(
+		rangeDeleteTransferTaskFromDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
+
+// InsertIntoTimerTasksDLQ inserts a row into timer_tasks_dlq table
+func (mdb *db) InsertIntoTimerTasksDLQ(row *sqlplugin.TaskDLQRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(insertTimerTaskDLQQuery, row)
+}
+
+// SelectFromTimerTasksDLQ reads one or more rows from timer_tasks_dlq table
+func (mdb *db) SelectFromTimerTasksDLQ(filter *sqlplugin.TaskDLQFilter) ([]sqlplugin.TaskDLQRow, error) {
+	var rows []sqlplugin.TaskDLQRow
+	err := mdb.conn.Select(
+		&rows, getTimerTasksDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromTimerTasksDLQ deletes one or more rows from timer_tasks_dlq table
+func (mdb *db) RangeDeleteFromTimerTasksDLQ(filter *sqlplugin.TaskDLQFilter) (sql.Result, error) {
+	return mdb.conn.Exec(
+		rangeDeleteTimerTaskFromDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
+
+// InsertIntoIsolatedTransferTasks inserts one or more rows into isolated_transfer_tasks table
+func (mdb *db) InsertIntoIsolatedTransferTasks(rows []sqlplugin.IsolatedTransferTasksRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(createIsolatedTransferTasksQuery, rows)
+}
+
+// SelectFromIsolatedTransferTasks reads one or more rows from isolated_transfer_tasks table
+func (mdb *db) SelectFromIsolatedTransferTasks(filter *sqlplugin.IsolatedTransferTasksFilter) ([]sqlplugin.IsolatedTransferTasksRow, error) {
+	var rows []sqlplugin.IsolatedTransferTasksRow
+	err := mdb.conn.Select(
+		&rows, getIsolatedTransferTasksQuery,
+		filter.ShardID,
+		filter.NamespaceID,
+		filter.MinTaskID,
+		filter.MaxTaskID)
+	return rows, err
+}
+
+// RangeDeleteFromIsolatedTransferTasks deletes one or more rows from isolated_transfer_tasks table
+func (mdb *db) RangeDeleteFromIsolatedTransferTasks(filter *sqlplugin.IsolatedTransferTasksFilter) (sql.Result, error) {
+	return mdb.conn.Exec(
+		rangeDeleteIsolatedTransferTaskQuery,
+		filter.ShardID,
+		filter.NamespaceID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
+
+// InsertIntoIsolatedTimerTasks inserts one or more rows into isolated_timer_tasks table
+func (mdb *db) InsertIntoIsolatedTimerTasks(rows []sqlplugin.IsolatedTimerTasksRow) (sql.Result, error) {
+	for i := range rows {
+		rows[i].VisibilityTimestamp = mdb.converter.ToMySQLDateTime(rows[i].VisibilityTimestamp)
+	}
+	return mdb.conn.NamedExec(createIsolatedTimerTasksQuery, rows)
+}
+
+// SelectFromIsolatedTimerTasks reads one or more rows from isolated_timer_tasks table
+func (mdb *db) SelectFromIsolatedTimerTasks(filter *sqlplugin.IsolatedTimerTasksFilter) ([]sqlplugin.IsolatedTimerTasksRow, error) {
+	var rows []sqlplugin.IsolatedTimerTasksRow
+	minVisibilityTimestamp := mdb.converter.ToMySQLDateTime(filter.MinVisibilityTimestamp)
+	err := mdb.conn.Select(
+		&rows, getIsolatedTimerTasksQuery,
+		filter.ShardID,
+		filter.NamespaceID,
+		minVisibilityTimestamp,
+		filter.TaskID,
+		minVisibilityTimestamp,
+		mdb.converter.ToMySQLDateTime(filter.MaxVisibilityTimestamp),
+		filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].VisibilityTimestamp = mdb.converter.FromMySQLDateTime(rows[i].VisibilityTimestamp)
+	}
+	return rows, err
+}
+
+// RangeDeleteFromIsolatedTimerTasks deletes one or more rows from isolated_timer_tasks table
+func (mdb *db) RangeDeleteFromIsolatedTimerTasks(filter *sqlplugin.IsolatedTimerTasksFilter) (sql.Result, error) {
+	return mdb.conn.Exec(
+		rangeDeleteIsolatedTimerTaskQuery,
+		filter.ShardID,
+		filter.NamespaceID,
+		mdb.converter.ToMySQLDateTime(filter.MinVisibilityTimestamp),
+		mdb.converter.ToMySQLDateTime(filter.MaxVisibilityTimestamp),
+	)
+}
@@ -27,6 +27,8 @@ package postgres
 import (
 	"fmt"
 	"time"
+
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
 )
 
 const (
@@ -64,6 +66,15 @@ const (
 
 	listTablesQuery = "select table_name from information_schema.tables where table_schema='public'"
 
+	listColumnsQuery = `SELECT table_name, column_name, ` +
+		`CONCAT(udt_name, ` +
+		`CASE WHEN character_maximum_length IS NULL THEN '' ELSE '(' || character_maximum_length || ')' END, ` +
+		`CASE WHEN is_nullable = 'NO' THEN ' NOT NULL' ELSE '' END) AS column_type ` +
+		`FROM information_schema.columns WHERE table_schema = 'public'`
+
+	listIndexesQuery = `SELECT tablename AS table_name, indexname AS index_name, indexdef AS index_def ` +
+		`FROM pg_indexes WHERE schemaname = 'public'`
+
 	dropTableQuery = "DROP TABLE %v"
 )
 
@@ -106,6 +117,20 @@ func (pdb *db) ListTables(database string) ([]string, error) {
 	return tables, err
 }
 
+// ListColumns returns the columns of all tables in this database
+func (pdb *db) ListColumns(database string) ([]sqlplugin.SchemaColumnRow, error) {
+	var rows []sqlplugin.SchemaColumnRow
+	err := pdb.db.Select(&rows, listColumnsQuery)
+	return rows, err
+}
+
+// ListIndexes returns the indexes of all tables in this database
+func (pdb *db) ListIndexes(database string) ([]sqlplugin.SchemaIndexRow, error) {
+	var rows []sqlplugin.SchemaIndexRow
+	err := pdb.db.Select(&rows, listIndexesQuery)
+	return rows, err
+}
+
 // DropTable drops a given table from the database
 func (pdb *db) DropTable(name string) error {
 	return pdb.Exec(fmt.Sprintf(dropTableQuery, name))
@@ -25,12 +25,20 @@
 package postgres
 
 import (
+	"time"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 
 	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
 )
 
+// replicationLagQuery returns the seconds since the last transaction replayed by a standby, 0 on a primary
+// or on a standby which replayed everything it received, as the last replay gets old while the primary is idle
+const replicationLagQuery = `SELECT CASE ` +
+	`WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 ` +
+	`ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`
+
 // db represents a logical connection to mysql database
 type db struct {
 	db        *sqlx.DB
@@ -82,6 +90,15 @@ func (pdb *db) Rollback() error {
 	return pdb.tx.Rollback()
 }
 
+// ReplicationLag returns how far the standby is behind its primary, 0 if the database is not a standby
+func (pdb *db) ReplicationLag() (time.Duration, error) {
+	var seconds float64
+	if err := pdb.db.Get(&seconds, replicationLagQuery); err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
 // Close closes the connection to the mysql db
 func (pdb *db) Close() error {
 	return pdb.db.Close()
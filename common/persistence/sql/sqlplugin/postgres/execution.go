@@ -155,6 +155,49 @@ VALUES     (:source_cluster_name,
 		AND shard_id = $2 
 		AND task_id > $3
 		AND task_id <= $4`
+
+	insertTransferTaskDLQQuery = `INSERT INTO transfer_tasks_dlq (shard_id, task_id, data, data_encoding) 
+VALUES (:shard_id, :task_id, :data, :data_encoding)`
+
+	getTransferTasksDLQQuery = `SELECT task_id, data, data_encoding FROM transfer_tasks_dlq WHERE 
+shard_id = $1 AND
+task_id > $2 AND
+task_id <= $3
+ORDER BY task_id LIMIT $4`
+
+	rangeDeleteTransferTaskFromDLQQuery = `DELETE FROM transfer_tasks_dlq WHERE shard_id = $1 AND task_id > $2 AND task_id <= $3`
+
+	insertTimerTaskDLQQuery = `INSERT INTO timer_tasks_dlq (shard_id, task_id, data, data_encoding) 
+VALUES (:shard_id, :task_id, :data, :data_encoding)`
+
+	getTimerTasksDLQQuery = `SELECT task_id, data, data_encoding FROM timer_tasks_dlq WHERE 
+shard_id = $1 AND
+task_id > $2 AND
+task_id <= $3
+ORDER BY task_id LIMIT $4`
+
+	rangeDeleteTimerTaskFromDLQQuery = `DELETE FROM timer_tasks_dlq WHERE shard_id = $1 AND task_id > $2 AND task_id <= $3`
+
+	createIsolatedTransferTasksQuery = `INSERT INTO isolated_transfer_tasks(shard_id, namespace_id, task_id, data, data_encoding) 
+ VALUES(:shard_id, :namespace_id, :task_id, :data, :data_encoding)`
+
+	getIsolatedTransferTasksQuery = `SELECT namespace_id, task_id, data, data_encoding FROM isolated_transfer_tasks 
+ WHERE shard_id = $1 AND namespace_id = $2 AND task_id > $3 AND task_id <= $4 ORDER BY shard_id, namespace_id, task_id`
+
+	rangeDeleteIsolatedTransferTaskQuery = `DELETE FROM isolated_transfer_tasks 
+ WHERE shard_id = $1 AND namespace_id = $2 AND task_id > $3 AND task_id <= $4`
+
+	createIsolatedTimerTasksQuery = `INSERT INTO isolated_timer_tasks (shard_id, namespace_id, visibility_timestamp, task_id, data, data_encoding)
+  VALUES (:shard_id, :namespace_id, :visibility_timestamp, :task_id, :data, :data_encoding)`
+
+	getIsolatedTimerTasksQuery = `SELECT namespace_id, visibility_timestamp, task_id, data, data_encoding FROM isolated_timer_tasks 
+  WHERE shard_id = $1 AND namespace_id = $2 
+  AND ((visibility_timestamp >= $3 AND task_id >= $4) OR visibility_timestamp > $5) 
+  AND visibility_timestamp < $6
+  ORDER BY visibility_timestamp,task_id LIMIT $7`
+
+	rangeDeleteIsolatedTimerTaskQuery = `DELETE FROM isolated_timer_tasks 
+  WHERE shard_id = $1 AND namespace_id = $2 AND visibility_timestamp >= $3 AND visibility_timestamp < $4`
 )
 
 // InsertIntoExecutions inserts a row into executions table
@@ -380,3 +423,126 @@ func (pdb *db) RangeDeleteMessageFromReplicationTasksDLQ(
 		filter.InclusiveEndTaskID,
 	)
 }
+
+// InsertIntoTransferTasksDLQ inserts a row into transfer_tasks_dlq table
+func (pdb *db) InsertIntoTransferTasksDLQ(row *sqlplugin.TaskDLQRow) (sql.Result, error) {
+	return pdb.conn.NamedExec(insertTransferTaskDLQQuery, row)
+}
+
+// SelectFromTransferTasksDLQ reads one or more rows from transfer_tasks_dlq table
+func (pdb *db) SelectFromTransferTasksDLQ(filter *sqlplugin.TaskDLQFilter) ([]sqlplugin.TaskDLQRow, error) {
+	var rows []sqlplugin.TaskDLQRow
+	err := pdb.conn.Select(
+		&rows, getTransferTasksDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromTransferTasksDLQ deletes one or more rows from transfer_tasks_dlq table
+func (pdb *db) RangeDeleteFromTransferTasksDLQ(filter *sqlplugin.TaskDLQFilter) (sql.Result, error) {
+	return pdb.conn.Exec(
+		rangeDeleteTransferTaskFromDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
+
+// InsertIntoTimerTasksDLQ inserts a row into timer_tasks_dlq table
+func (pdb *db) InsertIntoTimerTasksDLQ(row *sqlplugin.TaskDLQRow) (sql.Result, error) {
+	return pdb.conn.NamedExec(insertTimerTaskDLQQuery, row)
+}
+
+// SelectFromTimerTasksDLQ reads one or more rows from timer_tasks_dlq table
+func (pdb *db) SelectFromTimerTasksDLQ(filter *sqlplugin.TaskDLQFilter) ([]sqlplugin.TaskDLQRow, error) {
+	var rows []sqlplugin.TaskDLQRow
+	err := pdb.conn.Select(
+		&rows, getTimerTasksDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromTimerTasksDLQ deletes one or more rows from timer_tasks_dlq table
+func (pdb *db) RangeDeleteFromTimerTasksDLQ(filter *sqlplugin.TaskDLQFilter) (sql.Result, error) {
+	return pdb.conn.Exec(
+		rangeDeleteTimerTaskFromDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
+
+// InsertIntoIsolatedTransferTasks inserts one or more rows into isolated_transfer_tasks table
+func (pdb *db) InsertIntoIsolatedTransferTasks(rows []sqlplugin.IsolatedTransferTasksRow) (sql.Result, error) {
+	return pdb.conn.NamedExec(createIsolatedTransferTasksQuery, rows)
+}
+
+// SelectFromIsolatedTransferTasks reads one or more rows from isolated_transfer_tasks table
+func (pdb *db) SelectFromIsolatedTransferTasks(filter *sqlplugin.IsolatedTransferTasksFilter) ([]sqlplugin.IsolatedTransferTasksRow, error) {
+	var rows []sqlplugin.IsolatedTransferTasksRow
+	err := pdb.conn.Select(
+		&rows, getIsolatedTransferTasksQuery,
+		filter.ShardID,
+		filter.NamespaceID,
+		filter.MinTaskID,
+		filter.MaxTaskID)
+	return rows, err
+}
+
+// RangeDeleteFromIsolatedTransferTasks deletes one or more rows from isolated_transfer_tasks table
+func (pdb *db) RangeDeleteFromIsolatedTransferTasks(filter *sqlplugin.IsolatedTransferTasksFilter) (sql.Result, error) {
+	return pdb.conn.Exec(
+		rangeDeleteIsolatedTransferTaskQuery,
+		filter.ShardID,
+		filter.NamespaceID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
+
+// InsertIntoIsolatedTimerTasks inserts one or more rows into isolated_timer_tasks table
+func (pdb *db) InsertIntoIsolatedTimerTasks(rows []sqlplugin.IsolatedTimerTasksRow) (sql.Result, error) {
+	for i := range rows {
+		rows[i].VisibilityTimestamp = pdb.converter.ToPostgresDateTime(rows[i].VisibilityTimestamp)
+	}
+	return pdb.conn.NamedExec(createIsolatedTimerTasksQuery, rows)
+}
+
+// SelectFromIsolatedTimerTasks reads one or more rows from isolated_timer_tasks table
+func (pdb *db) SelectFromIsolatedTimerTasks(filter *sqlplugin.IsolatedTimerTasksFilter) ([]sqlplugin.IsolatedTimerTasksRow, error) {
+	var rows []sqlplugin.IsolatedTimerTasksRow
+	minVisibilityTimestamp := pdb.converter.ToPostgresDateTime(filter.MinVisibilityTimestamp)
+	err := pdb.conn.Select(
+		&rows, getIsolatedTimerTasksQuery,
+		filter.ShardID,
+		filter.NamespaceID,
+		minVisibilityTimestamp,
+		filter.TaskID,
+		minVisibilityTimestamp,
+		pdb.converter.ToPostgresDateTime(filter.MaxVisibilityTimestamp),
+		filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].VisibilityTimestamp = pdb.converter.FromPostgresDateTime(rows[i].VisibilityTimestamp)
+	}
+	return rows, err
+}
+
+// RangeDeleteFromIsolatedTimerTasks deletes one or more rows from isolated_timer_tasks table
+func (pdb *db) RangeDeleteFromIsolatedTimerTasks(filter *sqlplugin.IsolatedTimerTasksFilter) (sql.Result, error) {
+	return pdb.conn.Exec(
+		rangeDeleteIsolatedTimerTaskQuery,
+		filter.ShardID,
+		filter.NamespaceID,
+		pdb.converter.ToPostgresDateTime(filter.MinVisibilityTimestamp),
+		pdb.converter.ToPostgresDateTime(filter.MaxVisibilityTimestamp),
+	)
+}
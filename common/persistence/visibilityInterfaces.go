@@ -68,6 +68,7 @@ type (
 		CloseTimestamp     int64
 		Status             enumspb.WorkflowExecutionStatus
 		HistoryLength      int64
+		HistorySizeBytes   int64
 		RetentionSeconds   int64
 		TaskID             int64 // not persisted, used as condition update version for ES
 		Memo               *commonpb.Memo
@@ -84,6 +85,8 @@ type (
 		StartTimestamp     int64
 		ExecutionTimestamp int64
 		WorkflowTimeout    int64 // not persisted, used for cassandra ttl
+		HistoryLength      int64 // only persisted by ES
+		HistorySizeBytes   int64 // only persisted by ES
 		TaskID             int64 // not persisted, used as condition update version for ES
 		Memo               *commonpb.Memo
 		TaskQueue          string
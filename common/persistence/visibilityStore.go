@@ -98,6 +98,7 @@ func (v *visibilityManagerImpl) RecordWorkflowExecutionClosed(request *RecordWor
 		CloseTimestamp:     request.CloseTimestamp,
 		Status:             request.Status,
 		HistoryLength:      request.HistoryLength,
+		HistorySizeBytes:   request.HistorySizeBytes,
 		RetentionSeconds:   request.RetentionSeconds,
 	}
 	return v.persistence.RecordWorkflowExecutionClosed(req)
@@ -111,6 +112,8 @@ func (v *visibilityManagerImpl) UpsertWorkflowExecution(request *UpsertWorkflowE
 		WorkflowTypeName:   request.WorkflowTypeName,
 		StartTimestamp:     request.StartTimestamp,
 		ExecutionTimestamp: request.ExecutionTimestamp,
+		HistoryLength:      request.HistoryLength,
+		HistorySizeBytes:   request.HistorySizeBytes,
 		TaskID:             request.TaskID,
 		Memo:               v.serializeMemo(request.Memo, request.NamespaceID, request.Execution.GetWorkflowId(), request.Execution.GetRunId()),
 		TaskQueue:          request.TaskQueue,
@@ -54,6 +54,7 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -155,6 +156,21 @@ func New(
 
 	ringpopChannel := params.RPCFactory.GetRingpopChannel()
 
+	dynamicCollection := dynamicconfig.NewCollection(params.DynamicConfig, logger)
+	// persistence clients are only wrapped with fault injection if it is enabled at startup,
+	// once wrapped, it can be turned off and on again through dynamic config
+	faultInjectionEnabled := dynamicCollection.GetBoolProperty(dynamicconfig.PersistenceFaultInjectionEnabled, false)
+	if faultInjectionEnabled() {
+		params.PersistenceConfig.FaultInjection = &config.FaultInjectionConfig{
+			Enabled:                faultInjectionEnabled,
+			TimeoutRate:            dynamicCollection.GetFloat64PropertyFilteredByOperation(dynamicconfig.PersistenceFaultInjectionTimeoutRate, 0),
+			ConditionFailedRate:    dynamicCollection.GetFloat64PropertyFilteredByOperation(dynamicconfig.PersistenceFaultInjectionConditionFailedRate, 0),
+			ShardOwnershipLostRate: dynamicCollection.GetFloat64PropertyFilteredByOperation(dynamicconfig.PersistenceFaultInjectionShardOwnershipLostRate, 0),
+			UnavailableRate:        dynamicCollection.GetFloat64PropertyFilteredByOperation(dynamicconfig.PersistenceFaultInjectionUnavailableRate, 0),
+			Latency:                dynamicCollection.GetDurationPropertyFilteredByOperation(dynamicconfig.PersistenceFaultInjectionLatency, 0),
+		}
+	}
+
 	persistenceBean, err := persistenceClient.NewBeanFromFactory(persistenceClient.NewFactory(
 		&params.PersistenceConfig,
 		func(...dynamicconfig.FilterOption) int {
@@ -189,7 +205,6 @@ func New(
 		return nil, err
 	}
 
-	dynamicCollection := dynamicconfig.NewCollection(params.DynamicConfig, logger)
 	clientBean, err := client.NewClientBean(
 		client.NewRPCClientFactory(
 			params.RPCFactory,
@@ -186,6 +186,8 @@ type (
 		VisibilityConfig *VisibilityConfig `yaml:"-" json:"-"`
 		// TransactionSizeLimit is the largest allowed transaction size
 		TransactionSizeLimit dynamicconfig.IntPropertyFn `yaml:"-" json:"-"`
+		// FaultInjection is config for persistence fault injection
+		FaultInjection *FaultInjectionConfig `yaml:"-" json:"-"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -220,6 +222,23 @@ type (
 		ValidSearchAttributes dynamicconfig.MapPropertyFn `yaml:"-" json:"-"`
 	}
 
+	// FaultInjectionConfig is config for injecting errors and latency into persistence operations,
+	// all rates and latencies are filtered by operation name, e.g. UpdateWorkflowExecution
+	FaultInjectionConfig struct {
+		// Enabled is whether fault injection is enabled
+		Enabled dynamicconfig.BoolPropertyFn `yaml:"-" json:"-"`
+		// TimeoutRate is the rate of operations failing with a timeout error
+		TimeoutRate dynamicconfig.FloatPropertyFnWithOperationFilter `yaml:"-" json:"-"`
+		// ConditionFailedRate is the rate of operations failing with a condition failed error
+		ConditionFailedRate dynamicconfig.FloatPropertyFnWithOperationFilter `yaml:"-" json:"-"`
+		// ShardOwnershipLostRate is the rate of execution operations failing with a shard ownership lost error
+		ShardOwnershipLostRate dynamicconfig.FloatPropertyFnWithOperationFilter `yaml:"-" json:"-"`
+		// UnavailableRate is the rate of operations failing with an unavailable error
+		UnavailableRate dynamicconfig.FloatPropertyFnWithOperationFilter `yaml:"-" json:"-"`
+		// Latency is the latency added to operations
+		Latency dynamicconfig.DurationPropertyFnWithOperationFilter `yaml:"-" json:"-"`
+	}
+
 	// Cassandra contains configuration to connect to Cassandra cluster
 	Cassandra struct {
 		// Hosts is a csv of cassandra endpoints
@@ -267,6 +286,30 @@ type (
 		NumShards int `yaml:"nShards"`
 		// TLS is the configuration for TLS connections
 		TLS *auth.TLS `yaml:"tls"`
+		// Replicas is the list of read replicas of this database, reads are
+		// only served by a replica when allowed by the ReplicaReadPolicy
+		Replicas []SQLReplica `yaml:"replicas"`
+		// ReplicaReadPolicy controls which reads can be served by Replicas
+		ReplicaReadPolicy SQLReplicaReadPolicy `yaml:"replicaReadPolicy"`
+	}
+
+	// SQLReplica is the configuration for connecting to a read replica of a SQL
+	// database, all settings other than the address are inherited from the primary
+	SQLReplica struct {
+		// ConnectAddr is the remote addr of the replica
+		ConnectAddr string `yaml:"connectAddr"`
+	}
+
+	// SQLReplicaReadPolicy controls which reads are routed to SQL read replicas
+	SQLReplicaReadPolicy struct {
+		// VisibilityReads routes visibility list queries to replicas
+		VisibilityReads bool `yaml:"visibilityReads"`
+		// ClosedWorkflowHistoryReads routes history reads of closed workflows to replicas, pages
+		// missing events a replica has not caught up with yet are re-read from the primary
+		ClosedWorkflowHistoryReads bool `yaml:"closedWorkflowHistoryReads"`
+		// MaxReplicationLag is how far a replica can be behind the primary and still serve reads,
+		// reads fall back to the primary when every replica lags more
+		MaxReplicationLag time.Duration `yaml:"maxReplicationLag"`
 	}
 
 	// CustomDatastoreConfig is the configuration for connecting to a custom datastore that is not supported by temporal core
@@ -24,7 +24,15 @@
 
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultSQLReplicaMaxReplicationLag is the default max replication lag of SQL read replicas serving reads
+	DefaultSQLReplicaMaxReplicationLag = 10 * time.Second
+)
 
 const (
 	// StoreTypeSQL refers to sql based storage as persistence store
@@ -58,6 +66,16 @@ func (c *Persistence) Validate() error {
 		if ds.SQL != nil && ds.SQL.NumShards == 0 {
 			ds.SQL.NumShards = 1
 		}
+		if ds.SQL != nil && len(ds.SQL.Replicas) > 0 {
+			for _, replica := range ds.SQL.Replicas {
+				if replica.ConnectAddr == "" {
+					return fmt.Errorf("persistence config: datastore %v: replica connectAddr must be specified", st)
+				}
+			}
+			if ds.SQL.ReplicaReadPolicy.MaxReplicationLag == 0 {
+				ds.SQL.ReplicaReadPolicy.MaxReplicationLag = DefaultSQLReplicaMaxReplicationLag
+			}
+		}
 	}
 	return nil
 }
@@ -101,6 +101,12 @@ type FloatPropertyFn func(opts ...FilterOption) float64
 // FloatPropertyFnWithShardIDFilter is a wrapper to get float property from dynamic config with shardID as filter
 type FloatPropertyFnWithShardIDFilter func(shardID int) float64
 
+// FloatPropertyFnWithOperationFilter is a wrapper to get float property from dynamic config with operation as filter
+type FloatPropertyFnWithOperationFilter func(operation string) float64
+
+// FloatPropertyFnWithTaskQueueInfoFilters is a wrapper to get float property from dynamic config with three filters: namespace, taskQueue, taskType
+type FloatPropertyFnWithTaskQueueInfoFilters func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) float64
+
 // DurationPropertyFn is a wrapper to get duration property from dynamic config
 type DurationPropertyFn func(opts ...FilterOption) time.Duration
 
@@ -116,6 +122,9 @@ type DurationPropertyFnWithTaskQueueInfoFilters func(namespace string, taskQueue
 // DurationPropertyFnWithShardIDFilter is a wrapper to get duration property from dynamic config with shardID as filter
 type DurationPropertyFnWithShardIDFilter func(shardID int) time.Duration
 
+// DurationPropertyFnWithOperationFilter is a wrapper to get duration property from dynamic config with operation as filter
+type DurationPropertyFnWithOperationFilter func(operation string) time.Duration
+
 // BoolPropertyFn is a wrapper to get bool property from dynamic config
 type BoolPropertyFn func(opts ...FilterOption) bool
 
@@ -242,6 +251,38 @@ func (c *Collection) GetFloat64PropertyFilteredByShardID(key Key, defaultValue f
 	}
 }
 
+// GetFloat64PropertyFilteredByOperation gets property with operation filter and asserts that it's a float64
+func (c *Collection) GetFloat64PropertyFilteredByOperation(key Key, defaultValue float64) FloatPropertyFnWithOperationFilter {
+	return func(operation string) float64 {
+		val, err := c.client.GetFloatValue(
+			key,
+			getFilterMap(OperationFilter(operation)),
+			defaultValue,
+		)
+		if err != nil {
+			c.logError(key, err)
+		}
+		c.logValue(key, val, defaultValue, float64CompareEquals)
+		return val
+	}
+}
+
+// GetFloat64PropertyFilteredByTaskQueueInfo gets property with taskQueueInfo as filters and asserts that it's a float64
+func (c *Collection) GetFloat64PropertyFilteredByTaskQueueInfo(key Key, defaultValue float64) FloatPropertyFnWithTaskQueueInfoFilters {
+	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) float64 {
+		val, err := c.client.GetFloatValue(
+			key,
+			getFilterMap(NamespaceFilter(namespace), TaskQueueFilter(taskQueue), TaskTypeFilter(taskType)),
+			defaultValue,
+		)
+		if err != nil {
+			c.logError(key, err)
+		}
+		c.logValue(key, val, defaultValue, float64CompareEquals)
+		return val
+	}
+}
+
 // GetDurationProperty gets property and asserts that it's a duration
 func (c *Collection) GetDurationProperty(key Key, defaultValue time.Duration) DurationPropertyFn {
 	return func(opts ...FilterOption) time.Duration {
@@ -310,6 +351,22 @@ func (c *Collection) GetDurationPropertyFilteredByShardID(key Key, defaultValue
 	}
 }
 
+// GetDurationPropertyFilteredByOperation gets property with operation filter and asserts that it's a duration
+func (c *Collection) GetDurationPropertyFilteredByOperation(key Key, defaultValue time.Duration) DurationPropertyFnWithOperationFilter {
+	return func(operation string) time.Duration {
+		val, err := c.client.GetDurationValue(
+			key,
+			getFilterMap(OperationFilter(operation)),
+			defaultValue,
+		)
+		if err != nil {
+			c.logError(key, err)
+		}
+		c.logValue(key, val, defaultValue, durationCompareEquals)
+		return val
+	}
+}
+
 // GetBoolProperty gets property and asserts that it's an bool
 func (c *Collection) GetBoolProperty(key Key, defaultValue bool) BoolPropertyFn {
 	return func(opts ...FilterOption) bool {
@@ -52,6 +52,16 @@ func GetFloatPropertyFn(value float64) func(opts ...FilterOption) float64 {
 	return func(...FilterOption) float64 { return value }
 }
 
+// GetFloatPropertyFnFilteredByOperation returns value as FloatPropertyFnWithOperationFilter
+func GetFloatPropertyFnFilteredByOperation(value float64) func(operation string) float64 {
+	return func(operation string) float64 { return value }
+}
+
+// GetFloatPropertyFilteredByTaskQueueInfo returns value as FloatPropertyFnWithTaskQueueInfoFilters
+func GetFloatPropertyFilteredByTaskQueueInfo(value float64) func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) float64 {
+	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) float64 { return value }
+}
+
 // GetBoolPropertyFn returns value as BoolPropertyFn
 func GetBoolPropertyFn(value bool) func(opts ...FilterOption) bool {
 	return func(...FilterOption) bool { return value }
@@ -77,6 +87,11 @@ func GetDurationPropertyFnFilteredByTaskQueueInfo(value time.Duration) func(name
 	return func(namespace string, taskQueue string, taskType enumspb.TaskQueueType) time.Duration { return value }
 }
 
+// GetDurationPropertyFnFilteredByOperation returns value as DurationPropertyFnWithOperationFilter
+func GetDurationPropertyFnFilteredByOperation(value time.Duration) func(operation string) time.Duration {
+	return func(operation string) time.Duration { return value }
+}
+
 // GetStringPropertyFn returns value as StringPropertyFn
 func GetStringPropertyFn(value string) func(opts ...FilterOption) string {
 	return func(...FilterOption) string { return value }
@@ -193,6 +193,16 @@ func (s *configSuite) TestGetFloat64Property() {
 	s.Equal(0.01, value())
 }
 
+func (s *configSuite) TestGetFloat64PropertyFilteredByTaskQueueInfo() {
+	key := testGetFloat64PropertyFilteredByTaskQueueInfoKey
+	namespace := "testNamespace"
+	taskQueue := "testTaskQueue"
+	value := s.cln.GetFloat64PropertyFilteredByTaskQueueInfo(key, 0)
+	s.Equal(float64(0), value(namespace, taskQueue, 0))
+	s.client.SetValue(key, 12.5)
+	s.Equal(12.5, value(namespace, taskQueue, 0))
+}
+
 func (s *configSuite) TestGetBoolProperty() {
 	key := testGetBoolPropertyKey
 	value := s.cln.GetBoolProperty(key, true)
@@ -57,6 +57,7 @@ var keys = map[Key]string{
 	testGetDurationPropertyFilteredByTaskQueueInfoKey: "testGetDurationPropertyFilteredByTaskQueueInfoKey",
 	testGetBoolPropertyFilteredByNamespaceIDKey:       "testGetBoolPropertyFilteredByNamespaceIDKey",
 	testGetBoolPropertyFilteredByTaskQueueInfoKey:     "testGetBoolPropertyFilteredByTaskQueueInfoKey",
+	testGetFloat64PropertyFilteredByTaskQueueInfoKey:  "testGetFloat64PropertyFilteredByTaskQueueInfoKey",
 
 	// system settings
 	EnableGlobalNamespace:                  "system.enableGlobalNamespace",
@@ -73,6 +74,8 @@ var keys = map[Key]string{
 	EnableNamespaceNotActiveAutoForwarding: "system.enableNamespaceNotActiveAutoForwarding",
 	TransactionSizeLimit:                   "system.transactionSizeLimit",
 	MinRetentionDays:                       "system.minRetentionDays",
+	MaxRetentionDays:                       "system.maxRetentionDays",
+	EnableReservedHeaderOptions:            "system.enableReservedHeaderOptions",
 	MaxWorkflowTaskTimeout:                 "system.maxWorkflowTaskTimeout",
 	DisallowQuery:                          "system.disallowQuery",
 	EnableBatcher:                          "worker.enableBatcher",
@@ -81,6 +84,14 @@ var keys = map[Key]string{
 	EnablePriorityTaskProcessor:            "system.enablePriorityTaskProcessor",
 	EnableAuthorization:                    "system.enableAuthorization",
 
+	// persistence fault injection settings
+	PersistenceFaultInjectionEnabled:                "system.persistenceFaultInjectionEnabled",
+	PersistenceFaultInjectionTimeoutRate:            "system.persistenceFaultInjectionTimeoutRate",
+	PersistenceFaultInjectionConditionFailedRate:    "system.persistenceFaultInjectionConditionFailedRate",
+	PersistenceFaultInjectionShardOwnershipLostRate: "system.persistenceFaultInjectionShardOwnershipLostRate",
+	PersistenceFaultInjectionUnavailableRate:        "system.persistenceFaultInjectionUnavailableRate",
+	PersistenceFaultInjectionLatency:                "system.persistenceFaultInjectionLatency",
+
 	// size limit
 	BlobSizeLimitError:     "limit.blobSize.error",
 	BlobSizeLimitWarn:      "limit.blobSize.warn",
@@ -90,6 +101,8 @@ var keys = map[Key]string{
 	HistoryCountLimitWarn:  "limit.historyCount.warn",
 	MaxIDLengthLimit:       "limit.maxIDLength",
 
+	HistorySizeVisibilityRefreshStep: "limit.historySize.visibilityRefreshStep",
+
 	// frontend settings
 	FrontendPersistenceMaxQPS:             "frontend.persistenceMaxQPS",
 	FrontendPersistenceGlobalMaxQPS:       "frontend.persistenceGlobalMaxQPS",
@@ -119,27 +132,40 @@ var keys = map[Key]string{
 	VisibilityArchivalQueryMaxQPS:         "frontend.visibilityArchivalQueryMaxQPS",
 
 	// matching settings
-	MatchingRPS:                             "matching.rps",
-	MatchingPersistenceMaxQPS:               "matching.persistenceMaxQPS",
-	MatchingPersistenceGlobalMaxQPS:         "matching.persistenceGlobalMaxQPS",
-	MatchingMinTaskThrottlingBurstSize:      "matching.minTaskThrottlingBurstSize",
-	MatchingGetTasksBatchSize:               "matching.getTasksBatchSize",
-	MatchingLongPollExpirationInterval:      "matching.longPollExpirationInterval",
-	MatchingEnableSyncMatch:                 "matching.enableSyncMatch",
-	MatchingUpdateAckInterval:               "matching.updateAckInterval",
-	MatchingIdleTaskqueueCheckInterval:      "matching.idleTaskqueueCheckInterval",
-	MaxTaskqueueIdleTime:                    "matching.maxTaskqueueIdleTime",
-	MatchingOutstandingTaskAppendsThreshold: "matching.outstandingTaskAppendsThreshold",
-	MatchingMaxTaskBatchSize:                "matching.maxTaskBatchSize",
-	MatchingMaxTaskDeleteBatchSize:          "matching.maxTaskDeleteBatchSize",
-	MatchingThrottledLogRPS:                 "matching.throttledLogRPS",
-	MatchingNumTaskqueueWritePartitions:     "matching.numTaskqueueWritePartitions",
-	MatchingNumTaskqueueReadPartitions:      "matching.numTaskqueueReadPartitions",
-	MatchingForwarderMaxOutstandingPolls:    "matching.forwarderMaxOutstandingPolls",
-	MatchingForwarderMaxOutstandingTasks:    "matching.forwarderMaxOutstandingTasks",
-	MatchingForwarderMaxRatePerSecond:       "matching.forwarderMaxRatePerSecond",
-	MatchingForwarderMaxChildrenPerNode:     "matching.forwarderMaxChildrenPerNode",
-	MatchingShutdownDrainDuration:           "matching.shutdownDrainDuration",
+	MatchingRPS:                                  "matching.rps",
+	MatchingPersistenceMaxQPS:                    "matching.persistenceMaxQPS",
+	MatchingPersistenceGlobalMaxQPS:              "matching.persistenceGlobalMaxQPS",
+	MatchingMinTaskThrottlingBurstSize:           "matching.minTaskThrottlingBurstSize",
+	MatchingGetTasksBatchSize:                    "matching.getTasksBatchSize",
+	MatchingLongPollExpirationInterval:           "matching.longPollExpirationInterval",
+	MatchingEnableSyncMatch:                      "matching.enableSyncMatch",
+	MatchingUpdateAckInterval:                    "matching.updateAckInterval",
+	MatchingIdleTaskqueueCheckInterval:           "matching.idleTaskqueueCheckInterval",
+	MaxTaskqueueIdleTime:                         "matching.maxTaskqueueIdleTime",
+	MatchingOutstandingTaskAppendsThreshold:      "matching.outstandingTaskAppendsThreshold",
+	MatchingMaxTaskBatchSize:                     "matching.maxTaskBatchSize",
+	MatchingMaxTaskDeleteBatchSize:               "matching.maxTaskDeleteBatchSize",
+	MatchingThrottledLogRPS:                      "matching.throttledLogRPS",
+	MatchingNumTaskqueueWritePartitions:          "matching.numTaskqueueWritePartitions",
+	MatchingNumTaskqueueReadPartitions:           "matching.numTaskqueueReadPartitions",
+	MatchingForwarderMaxOutstandingPolls:         "matching.forwarderMaxOutstandingPolls",
+	MatchingForwarderMaxOutstandingTasks:         "matching.forwarderMaxOutstandingTasks",
+	MatchingForwarderMaxRatePerSecond:            "matching.forwarderMaxRatePerSecond",
+	MatchingForwarderMaxChildrenPerNode:          "matching.forwarderMaxChildrenPerNode",
+	MatchingShutdownDrainDuration:                "matching.shutdownDrainDuration",
+	MatchingPriorityStarvationProtectionInterval: "matching.priorityStarvationProtectionInterval",
+	MatchingFairnessKeyWeights:                   "matching.fairnessKeyWeights",
+	MatchingFairnessKeyBuckets:                   "matching.fairnessKeyBuckets",
+	MatchingMaxSubqueues:                         "matching.maxSubqueues",
+	MatchingEnablePartitionAutoScaling:           "matching.enablePartitionAutoScaling",
+	MatchingPartitionScalingInterval:             "matching.partitionScalingInterval",
+	MatchingPartitionTargetRatePerSecond:         "matching.partitionTargetRatePerSecond",
+	MatchingMaxTaskqueuePartitions:               "matching.maxTaskqueuePartitions",
+	MatchingMaxVersionSetsPerTaskQueue:           "matching.maxVersionSetsPerTaskQueue",
+	MatchingMaxBuildIdsPerTaskQueue:              "matching.maxBuildIdsPerTaskQueue",
+	MatchingTaskQueueMaxTasksPerSecond:           "matching.taskQueueMaxTasksPerSecond",
+	MatchingWorkerHeartbeatTimeout:               "matching.workerHeartbeatTimeout",
+	MatchingWorkerRegistryRetention:              "matching.workerRegistryRetention",
 
 	// history settings
 	HistoryRPS:                                             "history.rps",
@@ -151,17 +177,22 @@ var keys = map[Key]string{
 	HistoryCacheInitialSize:                                "history.cacheInitialSize",
 	HistoryMaxAutoResetPoints:                              "history.historyMaxAutoResetPoints",
 	HistoryCacheMaxSize:                                    "history.cacheMaxSize",
+	HistoryCacheMaxSizeInBytes:                             "history.cacheMaxSizeInBytes",
 	HistoryCacheTTL:                                        "history.cacheTTL",
 	HistoryShutdownDrainDuration:                           "history.shutdownDrainDuration",
 	EventsCacheInitialSize:                                 "history.eventsCacheInitialSize",
 	EventsCacheMaxSize:                                     "history.eventsCacheMaxSize",
+	EventsCacheMaxSizeInBytes:                              "history.eventsCacheMaxSizeInBytes",
 	EventsCacheTTL:                                         "history.eventsCacheTTL",
 	AcquireShardInterval:                                   "history.acquireShardInterval",
 	AcquireShardConcurrency:                                "history.acquireShardConcurrency",
+	EnableShardHandoff:                                     "history.enableShardHandoff",
 	StandbyClusterDelay:                                    "history.standbyClusterDelay",
 	StandbyTaskMissingEventsResendDelay:                    "history.standbyTaskMissingEventsResendDelay",
 	StandbyTaskMissingEventsDiscardDelay:                   "history.standbyTaskMissingEventsDiscardDelay",
 	TaskProcessRPS:                                         "history.taskProcessRPS",
+	QueueProcessorIsolateNamespace:                         "history.queueProcessorIsolateNamespace",
+	QueueProcessorNamespaceIsolationRefreshInterval:        "history.queueProcessorNamespaceIsolationRefreshInterval",
 	TaskSchedulerType:                                      "history.taskSchedulerType",
 	TaskSchedulerWorkerCount:                               "history.taskSchedulerWorkerCount",
 	TaskSchedulerQueueSize:                                 "history.taskSchedulerQueueSize",
@@ -176,6 +207,7 @@ var keys = map[Key]string{
 	TimerProcessorUpdateAckIntervalJitterCoefficient:       "history.timerProcessorUpdateAckIntervalJitterCoefficient",
 	TimerProcessorCompleteTimerInterval:                    "history.timerProcessorCompleteTimerInterval",
 	TimerProcessorFailoverMaxPollRPS:                       "history.timerProcessorFailoverMaxPollRPS",
+	TimerProcessorIsolatedNamespaceMaxPollRPS:              "history.timerProcessorIsolatedNamespaceMaxPollRPS",
 	TimerProcessorMaxPollRPS:                               "history.timerProcessorMaxPollRPS",
 	TimerProcessorMaxPollInterval:                          "history.timerProcessorMaxPollInterval",
 	TimerProcessorMaxPollIntervalJitterCoefficient:         "history.timerProcessorMaxPollIntervalJitterCoefficient",
@@ -188,6 +220,7 @@ var keys = map[Key]string{
 	TimerProcessorArchivalTimeLimit:                        "history.timerProcessorArchivalTimeLimit",
 	TransferTaskBatchSize:                                  "history.transferTaskBatchSize",
 	TransferProcessorFailoverMaxPollRPS:                    "history.transferProcessorFailoverMaxPollRPS",
+	TransferProcessorIsolatedNamespaceMaxPollRPS:           "history.transferProcessorIsolatedNamespaceMaxPollRPS",
 	TransferProcessorMaxPollRPS:                            "history.transferProcessorMaxPollRPS",
 	TransferTaskWorkerCount:                                "history.transferTaskWorkerCount",
 	TransferTaskMaxRetryCount:                              "history.transferTaskMaxRetryCount",
@@ -258,6 +291,9 @@ var keys = map[Key]string{
 	MutableStateChecksumInvalidateBefore:                   "history.mutableStateChecksumInvalidateBefore",
 	ReplicationEventsFromCurrentCluster:                    "history.ReplicationEventsFromCurrentCluster",
 	EnableDropStuckTaskByNamespaceID:                       "history.DropStuckTaskByNamespace",
+	EnableTaskDLQByNamespaceID:                             "history.EnableTaskDLQByNamespace",
+	TaskQueueDrainingRetryInterval:                         "history.taskQueueDrainingRetryInterval",
+	TaskQueueDrainingMaxRetryInterval:                      "history.taskQueueDrainingMaxRetryInterval",
 	SkipReapplicationByNamespaceId:                         "history.SkipReapplicationByNamespaceId",
 
 	WorkerPersistenceMaxQPS:                         "worker.persistenceMaxQPS",
@@ -309,6 +345,7 @@ const (
 	testGetDurationPropertyFilteredByTaskQueueInfoKey
 	testGetBoolPropertyFilteredByNamespaceIDKey
 	testGetBoolPropertyFilteredByTaskQueueInfoKey
+	testGetFloat64PropertyFilteredByTaskQueueInfoKey
 
 	// EnableGlobalNamespace is key for enable global namespace
 	EnableGlobalNamespace
@@ -343,6 +380,11 @@ const (
 	TransactionSizeLimit
 	// MinRetentionDays is the minimal allowed retention days for namespace
 	MinRetentionDays
+	// MaxRetentionDays is the maximal retention days a workflow can override its namespace retention with
+	MaxRetentionDays
+	// EnableReservedHeaderOptions decides whether workflows of a namespace can set server options (task priority,
+	// fairness key, retention override, id reuse policy) through the reserved header keys, which are rejected otherwise
+	EnableReservedHeaderOptions
 	// MaxWorkflowTaskTimeout  is the maximum allowed decision start to close timeout
 	MaxWorkflowTaskTimeout
 	// DisallowQuery is the key to disallow query for a namespace
@@ -351,6 +393,20 @@ const (
 	EnablePriorityTaskProcessor
 	// EnableAuthorization is the key to enable authorization for a namespace
 	EnableAuthorization
+
+	// PersistenceFaultInjectionEnabled is the key to enable persistence fault injection, only for testing.
+	// It must be set at startup for persistence clients to be wrapped with fault injection
+	PersistenceFaultInjectionEnabled
+	// PersistenceFaultInjectionTimeoutRate is the rate [0.0, 1.0] of persistence operations failing with a timeout
+	PersistenceFaultInjectionTimeoutRate
+	// PersistenceFaultInjectionConditionFailedRate is the rate [0.0, 1.0] of persistence operations failing with a condition failure
+	PersistenceFaultInjectionConditionFailedRate
+	// PersistenceFaultInjectionShardOwnershipLostRate is the rate [0.0, 1.0] of execution operations failing with shard ownership lost
+	PersistenceFaultInjectionShardOwnershipLostRate
+	// PersistenceFaultInjectionUnavailableRate is the rate [0.0, 1.0] of persistence operations failing with unavailable error
+	PersistenceFaultInjectionUnavailableRate
+	// PersistenceFaultInjectionLatency is the latency added to each persistence operation
+	PersistenceFaultInjectionLatency
 	// BlobSizeLimitError is the per event blob size limit
 	BlobSizeLimitError
 	// BlobSizeLimitWarn is the per event blob size limit for warning
@@ -363,6 +419,9 @@ const (
 	HistoryCountLimitError
 	// HistoryCountLimitWarn is the per workflow execution history event count limit for warning
 	HistoryCountLimitWarn
+	// HistorySizeVisibilityRefreshStep is by how much the history size of a workflow execution past the warn limits
+	// grows before it is recorded again in visibility
+	HistorySizeVisibilityRefreshStep
 
 	// MaxIDLengthLimit is the length limit for various IDs, including: Namespace, TaskQueue, WorkflowID, ActivityID, TimerID,
 	// WorkflowType, ActivityType, SignalName, MarkerName, ErrorReason/FailureReason/CancelCause, Identity, RequestID
@@ -466,6 +525,42 @@ const (
 	MatchingForwarderMaxChildrenPerNode
 	// MatchingShutdownDrainDuration is the duration of traffic drain during shutdown
 	MatchingShutdownDrainDuration
+	// MatchingPriorityStarvationProtectionInterval is the number of backlog dispatches after which the
+	// oldest buffered task is dispatched regardless of its priority. 0 disables starvation protection
+	MatchingPriorityStarvationProtectionInterval
+	// MatchingFairnessKeyWeights is a map from task fairness key to the number of tasks dispatched for
+	// that key in each round robin turn. Keys not present in the map have a weight of 1
+	MatchingFairnessKeyWeights
+	// MatchingFairnessKeyBuckets is the number of buckets the fairness keys of a task queue partition are
+	// hashed into, the backlog of each bucket is read from persistence separately. 0 disables the buckets
+	MatchingFairnessKeyBuckets
+	// MatchingMaxSubqueues is the max number of subqueues a task queue partition persists besides its own backlog.
+	// Each subqueue is persisted with its own lease and ack level, so this bounds the task queue metadata writes
+	// of a partition. Once reached, tasks of a new fairness bucket share the subqueue of their priority, if any,
+	// and tasks of a new priority the backlog of the partition
+	MatchingMaxSubqueues
+	// MatchingEnablePartitionAutoScaling enables scaling the number of partitions of a task queue based on
+	// its add and dispatch rates. When enabled, the partition counts decided by the root partition take
+	// precedence over MatchingNumTaskqueueReadPartitions and MatchingNumTaskqueueWritePartitions.
+	// Enabling it takes effect on a root partition the next time it is loaded
+	MatchingEnablePartitionAutoScaling
+	// MatchingPartitionScalingInterval is the interval at which the root partition re-evaluates the partition counts
+	MatchingPartitionScalingInterval
+	// MatchingPartitionTargetRatePerSecond is the add or dispatch rate that a single partition is scaled for
+	MatchingPartitionTargetRatePerSecond
+	// MatchingMaxTaskqueuePartitions is the max number of partitions a task queue is automatically scaled to
+	MatchingMaxTaskqueuePartitions
+	// MatchingMaxVersionSetsPerTaskQueue is the max number of worker version sets of a task queue
+	MatchingMaxVersionSetsPerTaskQueue
+	// MatchingMaxBuildIdsPerTaskQueue is the max number of worker build ids across the version sets of a task queue
+	MatchingMaxBuildIdsPerTaskQueue
+	// MatchingTaskQueueMaxTasksPerSecond is the dispatch rate limit of a task queue, it takes precedence over
+	// the rate limit of its pollers unless an operator set one through the admin API, 0 means not set
+	MatchingTaskQueueMaxTasksPerSecond
+	// MatchingWorkerHeartbeatTimeout is the time after its last poll a worker is no longer considered alive
+	MatchingWorkerHeartbeatTimeout
+	// MatchingWorkerRegistryRetention is the time after its last poll a worker is removed from the worker registry
+	MatchingWorkerRegistryRetention
 
 	// key for history
 
@@ -485,6 +580,9 @@ const (
 	HistoryCacheInitialSize
 	// HistoryCacheMaxSize is max size of history cache
 	HistoryCacheMaxSize
+	// HistoryCacheMaxSizeInBytes is max size in bytes of history caches on a host, 0 bounds them by HistoryCacheMaxSize instead.
+	// It is a budget shared by the caches of all shards of the host, see EventsCacheMaxSizeInBytes
+	HistoryCacheMaxSizeInBytes
 	// HistoryCacheTTL is TTL of history cache
 	HistoryCacheTTL
 	// HistoryShutdownDrainDuration is the duration of traffic drain during shutdown
@@ -493,12 +591,18 @@ const (
 	EventsCacheInitialSize
 	// EventsCacheMaxSize is max size of events cache
 	EventsCacheMaxSize
+	// EventsCacheMaxSizeInBytes is max size in bytes of events caches on a host, 0 bounds them by EventsCacheMaxSize instead.
+	// It is a budget shared by the caches of all shards of the host: while it is exceeded, a cache evicts its least recently
+	// used events as it is used, so the caches of idle shards keep their events until they are used again or their shard moves
+	EventsCacheMaxSizeInBytes
 	// EventsCacheTTL is TTL of events cache
 	EventsCacheTTL
 	// AcquireShardInterval is interval that timer used to acquire shard
 	AcquireShardInterval
 	// AcquireShardConcurrency is number of goroutines that can be used to acquire shards in the shard controller.
 	AcquireShardConcurrency
+	// EnableShardHandoff is whether shards are handed off to their new owners when a host shuts down
+	EnableShardHandoff
 	// StandbyClusterDelay is the artificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay
 	// StandbyTaskMissingEventsResendDelay is the amount of time standby cluster's will wait (if events are missing)
@@ -509,6 +613,12 @@ const (
 	StandbyTaskMissingEventsDiscardDelay
 	// TaskProcessRPS is the task processing rate per second for each namespace
 	TaskProcessRPS
+	// QueueProcessorIsolateNamespace is whether transfer and timer tasks of a namespace are written to and processed
+	// from a queue partition of their own, so that a noisy namespace does not delay task processing for the other
+	// namespaces of a shard
+	QueueProcessorIsolateNamespace
+	// QueueProcessorNamespaceIsolationRefreshInterval is how often queue processors pick up changes to QueueProcessorIsolateNamespace
+	QueueProcessorNamespaceIsolationRefreshInterval
 	// TaskSchedulerType is the task scheduler type for priority task processor
 	TaskSchedulerType
 	// TaskSchedulerWorkerCount is the number of workers per shard in task scheduler
@@ -537,6 +647,8 @@ const (
 	TimerProcessorCompleteTimerInterval
 	// TimerProcessorFailoverMaxPollRPS is max poll rate per second for timer processor
 	TimerProcessorFailoverMaxPollRPS
+	// TimerProcessorIsolatedNamespaceMaxPollRPS is max poll rate per second for the timer queue of an isolated namespace
+	TimerProcessorIsolatedNamespaceMaxPollRPS
 	// TimerProcessorMaxPollRPS is max poll rate per second for timer processor
 	TimerProcessorMaxPollRPS
 	// TimerProcessorMaxPollInterval is max poll interval for timer processor
@@ -561,6 +673,8 @@ const (
 	TransferTaskBatchSize
 	// TransferProcessorFailoverMaxPollRPS is max poll rate per second for transferQueueProcessor
 	TransferProcessorFailoverMaxPollRPS
+	// TransferProcessorIsolatedNamespaceMaxPollRPS is max poll rate per second for the transfer queue of an isolated namespace
+	TransferProcessorIsolatedNamespaceMaxPollRPS
 	// TransferProcessorMaxPollRPS is max poll rate per second for transferQueueProcessor
 	TransferProcessorMaxPollRPS
 	// TransferTaskWorkerCount is number of worker for transferQueueProcessor
@@ -631,7 +745,7 @@ const (
 	ShardSyncMinInterval
 	// ShardSyncTimerJitterCoefficient is the sync shard jitter coefficient
 	ShardSyncTimerJitterCoefficient
-	// DefaultEventEncoding is the encoding type for history events
+	// DefaultEventEncoding is the encoding type for history events, one of proto3, proto3+snappy or proto3+zstd
 	DefaultEventEncoding
 	// NumArchiveSystemWorkflows is key for number of archive system workflows running in total
 	NumArchiveSystemWorkflows
@@ -672,6 +786,15 @@ const (
 
 	// EnableDropStuckTaskByNamespaceID is whether stuck timer/transfer task should be dropped for a namespace
 	EnableDropStuckTaskByNamespaceID
+	// EnableTaskDLQByNamespaceID is whether timer/transfer tasks of a namespace exceeding their max retry count
+	// are moved to the shard's task DLQ instead of being retried forever
+	EnableTaskDLQByNamespaceID
+	// TaskQueueDrainingRetryInterval is how long a transfer task first waits before retrying to add a task to a task queue
+	// that is being drained. Such tasks are retried until the task queue is resumed and never moved to the task DLQ
+	TaskQueueDrainingRetryInterval
+	// TaskQueueDrainingMaxRetryInterval is the maximum interval the retries of adding a task to a draining task queue
+	// back off to
+	TaskQueueDrainingMaxRetryInterval
 	// SkipReapplicationByNameSpaceId is whether skipping a event re-application for a namespace
 	SkipReapplicationByNamespaceId
 
@@ -788,7 +911,7 @@ const (
 type Filter int
 
 func (f Filter) String() string {
-	if f <= unknownFilter || f > TaskType {
+	if f <= unknownFilter || f >= lastFilterTypeForTest {
 		return filters[unknownFilter]
 	}
 	return filters[f]
@@ -801,6 +924,7 @@ var filters = []string{
 	"taskQueueName",
 	"taskType",
 	"shardID",
+	"operation",
 }
 
 const (
@@ -815,6 +939,8 @@ const (
 	TaskType
 	// ShardID is the shard id
 	ShardID
+	// Operation is the persistence operation name
+	Operation
 
 	// lastFilterTypeForTest must be the last one in this const group for testing purpose
 	lastFilterTypeForTest
@@ -857,3 +983,10 @@ func ShardIDFilter(shardID int) FilterOption {
 		filterMap[ShardID] = shardID
 	}
 }
+
+// OperationFilter filters by persistence operation name
+func OperationFilter(operation string) FilterOption {
+	return func(filterMap map[Filter]interface{}) {
+		filterMap[Operation] = operation
+	}
+}
@@ -35,14 +35,17 @@ import (
 
 	"github.com/dgryski/go-farm"
 	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/status"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	historypb "go.temporal.io/temporal-proto/history/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
+	"google.golang.org/grpc/codes"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -100,6 +103,8 @@ var (
 	ErrContextTimeoutTooShort = serviceerror.NewInvalidArgument("Context timeout is too short.")
 	// ErrContextTimeoutNotSet is error for not setting a context timeout when calling a long poll API
 	ErrContextTimeoutNotSet = serviceerror.NewInvalidArgument("Context timeout is not set.")
+	// ErrTaskQueueDraining is error for adding a task to a task queue partition that is being drained
+	ErrTaskQueueDraining = newTaskQueueDrainingError()
 )
 
 // AwaitWaitGroup calls Wait on the given wait
@@ -235,6 +240,30 @@ func IsWhitelistServiceTransientError(err error) bool {
 	return false
 }
 
+// newTaskQueueDrainingError creates a ResourceExhausted error carrying a TaskQueueDrainingFailure detail,
+// the detail is kept when the error is returned over RPC so callers can tell it from other throttling errors
+func newTaskQueueDrainingError() *serviceerror.ResourceExhausted {
+	st, err := status.New(codes.ResourceExhausted, "Task queue is draining, new tasks are not accepted.").
+		WithDetails(&taskqueuegenpb.TaskQueueDrainingFailure{})
+	if err != nil {
+		panic(err)
+	}
+	return serviceerror.FromStatus(st).(*serviceerror.ResourceExhausted)
+}
+
+// IsTaskQueueDrainingError checks if the error is ErrTaskQueueDraining, as returned by the matching service
+func IsTaskQueueDrainingError(err error) bool {
+	if _, ok := err.(*serviceerror.ResourceExhausted); !ok {
+		return false
+	}
+	for _, detail := range serviceerror.ToStatus(err).Details() {
+		if _, ok := detail.(*taskqueuegenpb.TaskQueueDrainingFailure); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // WorkflowIDToHistoryShard is used to map workflowID to a shardID
 func WorkflowIDToHistoryShard(workflowID string, numberOfShards int) int {
 	hash := farm.Fingerprint32([]byte(workflowID))
@@ -297,6 +326,8 @@ func CreateMatchingPollForDecisionTaskResponse(historyResponse *historyservice.R
 		ScheduledTimestamp:         historyResponse.ScheduledTimestamp,
 		StartedTimestamp:           historyResponse.StartedTimestamp,
 		Queries:                    historyResponse.Queries,
+		SuggestContinueAsNew:       historyResponse.SuggestContinueAsNew,
+		HistorySizeBytes:           historyResponse.HistorySizeBytes,
 	}
 
 	return matchingResp
@@ -390,6 +421,219 @@ func ValidateRetryPolicy(policy *commonpb.RetryPolicy) error {
 	return nil
 }
 
+// ValidateReservedHeaderFields rejects the fields under reserved header keys unless reserved header options
+// are enabled, in which case only the known reserved keys are accepted
+func ValidateReservedHeaderFields(header *commonpb.Header, enabled bool) error {
+	for key := range header.GetFields() {
+		if !strings.HasPrefix(key, ReservedHeaderKeyPrefix) {
+			continue
+		}
+		if !enabled {
+			return serviceerror.NewInvalidArgument(fmt.Sprintf("Header key %v is reserved by system.", key))
+		}
+		switch key {
+		case TaskPriorityHeaderKey, TaskFairnessKeyHeaderKey, WorkflowRetentionDaysHeaderKey, WorkflowIDReusePolicyHeaderKey:
+		default:
+			return serviceerror.NewInvalidArgument(fmt.Sprintf("Unknown reserved header key %v.", key))
+		}
+	}
+	return nil
+}
+
+// ValidateTaskPriority validates the task priority carried in the given header, if any
+func ValidateTaskPriority(header *commonpb.Header) error {
+	value, ok := header.GetFields()[TaskPriorityHeaderKey]
+	if !ok {
+		return nil
+	}
+	var priority int32
+	if err := payload.Decode(value, &priority); err != nil {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Invalid task priority header: %v.", err))
+	}
+	if priority < HighestTaskPriority || priority > LowestTaskPriority {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Task priority must be between %v and %v.", HighestTaskPriority, LowestTaskPriority))
+	}
+	return nil
+}
+
+// GetTaskPriority returns the task priority carried in the given header,
+// or 0 if the header does not specify a valid one
+func GetTaskPriority(header *commonpb.Header) int32 {
+	value, ok := header.GetFields()[TaskPriorityHeaderKey]
+	if !ok {
+		return 0
+	}
+	var priority int32
+	if err := payload.Decode(value, &priority); err != nil {
+		return 0
+	}
+	if priority < HighestTaskPriority || priority > LowestTaskPriority {
+		return 0
+	}
+	return priority
+}
+
+// ValidateTaskFairnessKey validates the task fairness key carried in the given header, if any
+func ValidateTaskFairnessKey(header *commonpb.Header, maxLength int) error {
+	value, ok := header.GetFields()[TaskFairnessKeyHeaderKey]
+	if !ok {
+		return nil
+	}
+	var fairnessKey string
+	if err := payload.Decode(value, &fairnessKey); err != nil {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Invalid task fairness key header: %v.", err))
+	}
+	if len(fairnessKey) > maxLength {
+		return serviceerror.NewInvalidArgument("Task fairness key exceeds length limit.")
+	}
+	return nil
+}
+
+// GetTaskFairnessKey returns the task fairness key carried in the given header,
+// or an empty string if the header does not specify one
+func GetTaskFairnessKey(header *commonpb.Header) string {
+	value, ok := header.GetFields()[TaskFairnessKeyHeaderKey]
+	if !ok {
+		return ""
+	}
+	var fairnessKey string
+	if err := payload.Decode(value, &fairnessKey); err != nil {
+		return ""
+	}
+	return fairnessKey
+}
+
+// ValidateWorkflowRetentionDays validates the workflow retention override carried in the given header, if any
+func ValidateWorkflowRetentionDays(header *commonpb.Header, minDays int, maxDays int) error {
+	value, ok := header.GetFields()[WorkflowRetentionDaysHeaderKey]
+	if !ok {
+		return nil
+	}
+	var retentionDays int32
+	if err := payload.Decode(value, &retentionDays); err != nil {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Invalid workflow retention header: %v.", err))
+	}
+	if int(retentionDays) < minDays || int(retentionDays) > maxDays {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Workflow retention days must be between %v and %v.", minDays, maxDays))
+	}
+	return nil
+}
+
+// GetWorkflowRetentionDays returns the workflow retention override carried in the given header,
+// or 0 if the header does not specify one
+func GetWorkflowRetentionDays(header *commonpb.Header) int32 {
+	value, ok := header.GetFields()[WorkflowRetentionDaysHeaderKey]
+	if !ok {
+		return 0
+	}
+	var retentionDays int32
+	if err := payload.Decode(value, &retentionDays); err != nil || retentionDays < 0 {
+		return 0
+	}
+	return retentionDays
+}
+
+// ValidateWorkflowIDReusePolicy validates the workflow id reuse policy carried in the given header, if any
+func ValidateWorkflowIDReusePolicy(header *commonpb.Header) error {
+	value, ok := header.GetFields()[WorkflowIDReusePolicyHeaderKey]
+	if !ok {
+		return nil
+	}
+	var policy string
+	if err := payload.Decode(value, &policy); err != nil {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Invalid workflow id reuse policy header: %v.", err))
+	}
+	if policy != WorkflowIDReusePolicyTerminateIfRunning {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Unknown workflow id reuse policy: %v.", policy))
+	}
+	return nil
+}
+
+// IsWorkflowIDReusePolicyTerminateIfRunning returns whether the given header carries
+// the TerminateIfRunning workflow id reuse policy
+func IsWorkflowIDReusePolicyTerminateIfRunning(header *commonpb.Header) bool {
+	value, ok := header.GetFields()[WorkflowIDReusePolicyHeaderKey]
+	if !ok {
+		return false
+	}
+	var policy string
+	if err := payload.Decode(value, &policy); err != nil {
+		return false
+	}
+	return policy == WorkflowIDReusePolicyTerminateIfRunning
+}
+
+// InheritTaskHeaderFields returns the given header with the given task priority and fairness key added,
+// unless the header already carries them or they are not set
+func InheritTaskHeaderFields(header *commonpb.Header, priority int32, fairnessKey string) *commonpb.Header {
+	fields := make(map[string]*commonpb.Payload, len(header.GetFields())+2)
+	for key, value := range header.GetFields() {
+		fields[key] = value
+	}
+	if _, ok := fields[TaskPriorityHeaderKey]; !ok && priority != 0 {
+		if value, err := payload.Encode(priority); err == nil {
+			fields[TaskPriorityHeaderKey] = value
+		}
+	}
+	if _, ok := fields[TaskFairnessKeyHeaderKey]; !ok && fairnessKey != "" {
+		fields[TaskFairnessKeyHeaderKey] = payload.EncodeString(fairnessKey)
+	}
+	if len(fields) == 0 {
+		return header
+	}
+	return &commonpb.Header{Fields: fields}
+}
+
+// StripReservedHeaderFields returns the given header without the fields under reserved header keys
+func StripReservedHeaderFields(header *commonpb.Header) *commonpb.Header {
+	var fields map[string]*commonpb.Payload
+	for key, value := range header.GetFields() {
+		if strings.HasPrefix(key, ReservedHeaderKeyPrefix) {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]*commonpb.Payload, len(header.GetFields()))
+		}
+		fields[key] = value
+	}
+	if len(fields) == len(header.GetFields()) {
+		return header
+	}
+	return &commonpb.Header{Fields: fields}
+}
+
+// StripReservedHeaderFieldsFromEvents removes the fields under reserved header keys from the headers of
+// the given history events, which must not be shared as they are updated in place
+func StripReservedHeaderFieldsFromEvents(events []*historypb.HistoryEvent) {
+	for _, event := range events {
+		switch event.GetEventType() {
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+			attributes := event.GetWorkflowExecutionStartedEventAttributes()
+			attributes.Header = StripReservedHeaderFields(attributes.GetHeader())
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+			attributes := event.GetWorkflowExecutionContinuedAsNewEventAttributes()
+			attributes.Header = StripReservedHeaderFields(attributes.GetHeader())
+		case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			attributes := event.GetActivityTaskScheduledEventAttributes()
+			attributes.Header = StripReservedHeaderFields(attributes.GetHeader())
+		case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+			attributes := event.GetStartChildWorkflowExecutionInitiatedEventAttributes()
+			attributes.Header = StripReservedHeaderFields(attributes.GetHeader())
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+			attributes := event.GetChildWorkflowExecutionStartedEventAttributes()
+			attributes.Header = StripReservedHeaderFields(attributes.GetHeader())
+		}
+	}
+}
+
+// NormalizeTaskPriority maps an unset task priority to DefaultTaskPriority
+func NormalizeTaskPriority(priority int32) int32 {
+	if priority < HighestTaskPriority || priority > LowestTaskPriority {
+		return DefaultTaskPriority
+	}
+	return priority
+}
+
 // CreateHistoryStartWorkflowRequest create a start workflow request for history
 func CreateHistoryStartWorkflowRequest(
 	namespaceID string,
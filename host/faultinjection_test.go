@@ -0,0 +1,185 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package host
+
+import (
+	"flag"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	decisionpb "go.temporal.io/temporal-proto/decision/v1"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+	historypb "go.temporal.io/temporal-proto/history/v1"
+	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
+
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/payloads"
+)
+
+type faultInjectionIntegrationSuite struct {
+	// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+	// not merely log an error
+	*require.Assertions
+	IntegrationBase
+}
+
+// This cluster injects persistence errors and latency into history and matching
+func (s *faultInjectionIntegrationSuite) SetupSuite() {
+	s.setupSuite("testdata/integration_faultinjection_cluster.yaml")
+}
+
+func (s *faultInjectionIntegrationSuite) TearDownSuite() {
+	s.tearDownSuite()
+}
+
+func (s *faultInjectionIntegrationSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func TestFaultInjectionIntegrationSuite(t *testing.T) {
+	flag.Parse()
+	suite.Run(t, new(faultInjectionIntegrationSuite))
+}
+
+func (s *faultInjectionIntegrationSuite) TestWorkflowCompletesDespitePersistenceFaults() {
+	id := "integration-fault-injection-test"
+	wt := "integration-fault-injection-test-type"
+	tq := "integration-fault-injection-test-taskqueue"
+	identity := "worker1"
+	activityName := "activity_type1"
+	activityCount := 5
+
+	taskQueue := &taskqueuepb.TaskQueue{Name: tq}
+	request := &workflowservice.StartWorkflowExecutionRequest{
+		RequestId:                  uuid.New(),
+		Namespace:                  s.namespace,
+		WorkflowId:                 id,
+		WorkflowType:               &commonpb.WorkflowType{Name: wt},
+		TaskQueue:                  taskQueue,
+		WorkflowRunTimeoutSeconds:  300,
+		WorkflowTaskTimeoutSeconds: 1,
+		Identity:                   identity,
+	}
+
+	// start is idempotent on request ID, so it is safe to retry on injected errors
+	var we *workflowservice.StartWorkflowExecutionResponse
+	var err error
+	for attempt := 0; attempt < 10; attempt++ {
+		if we, err = s.engine.StartWorkflowExecution(NewContext(), request); err == nil {
+			break
+		}
+		s.Logger.Info("StartWorkflowExecution failed, retrying", tag.Error(err))
+		time.Sleep(100 * time.Millisecond)
+	}
+	s.NoError(err)
+
+	dtHandler := func(execution *commonpb.WorkflowExecution, wt *commonpb.WorkflowType,
+		previousStartedEventID, startedEventID int64, history *historypb.History) ([]*decisionpb.Decision, error) {
+		scheduled, closed, completed := 0, 0, 0
+		for _, event := range history.Events {
+			switch event.GetEventType() {
+			case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+				scheduled++
+			case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+				closed++
+				completed++
+			case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED, enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+				closed++
+			}
+		}
+
+		if scheduled > closed {
+			// wait for the outstanding activity
+			return []*decisionpb.Decision{}, nil
+		}
+		if completed < activityCount {
+			return []*decisionpb.Decision{{
+				DecisionType: enumspb.DECISION_TYPE_SCHEDULE_ACTIVITY_TASK,
+				Attributes: &decisionpb.Decision_ScheduleActivityTaskDecisionAttributes{ScheduleActivityTaskDecisionAttributes: &decisionpb.ScheduleActivityTaskDecisionAttributes{
+					ActivityId:                    strconv.Itoa(scheduled),
+					ActivityType:                  &commonpb.ActivityType{Name: activityName},
+					TaskQueue:                     taskQueue,
+					Input:                         payloads.EncodeString("input"),
+					ScheduleToCloseTimeoutSeconds: 10,
+					ScheduleToStartTimeoutSeconds: 5,
+					StartToCloseTimeoutSeconds:    5,
+				}},
+			}}, nil
+		}
+
+		return []*decisionpb.Decision{{
+			DecisionType: enumspb.DECISION_TYPE_COMPLETE_WORKFLOW_EXECUTION,
+			Attributes: &decisionpb.Decision_CompleteWorkflowExecutionDecisionAttributes{CompleteWorkflowExecutionDecisionAttributes: &decisionpb.CompleteWorkflowExecutionDecisionAttributes{
+				Result: payloads.EncodeString("Done"),
+			}},
+		}}, nil
+	}
+
+	atHandler := func(execution *commonpb.WorkflowExecution, activityType *commonpb.ActivityType,
+		activityID string, input *commonpb.Payloads, taskToken []byte) (*commonpb.Payloads, bool, error) {
+		return payloads.EncodeString("Activity Result"), false, nil
+	}
+
+	poller := &TaskPoller{
+		Engine:          s.engine,
+		Namespace:       s.namespace,
+		TaskQueue:       taskQueue,
+		Identity:        identity,
+		DecisionHandler: dtHandler,
+		ActivityHandler: atHandler,
+		Logger:          s.Logger,
+		T:               s.T(),
+	}
+
+	// injected errors may fail any single poll or respond call, the workflow is expected to
+	// make progress anyway through task retries, timeouts and shard / task queue reloads
+	deadline := time.Now().Add(2 * time.Minute)
+	for time.Now().Before(deadline) {
+		descResp, err := s.engine.DescribeWorkflowExecution(NewContext(), &workflowservice.DescribeWorkflowExecutionRequest{
+			Namespace: s.namespace,
+			Execution: &commonpb.WorkflowExecution{
+				WorkflowId: id,
+				RunId:      we.GetRunId(),
+			},
+		})
+		if err == nil && descResp.WorkflowExecutionInfo.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			s.Equal(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, descResp.WorkflowExecutionInfo.GetStatus())
+			return
+		}
+
+		_, err = poller.PollAndProcessDecisionTask(false, false)
+		s.Logger.Info("PollAndProcessDecisionTask", tag.Error(err))
+		err = poller.PollAndProcessActivityTask(false)
+		s.Logger.Info("PollAndProcessActivityTask", tag.Error(err))
+	}
+	s.Fail("workflow did not complete before deadline")
+}
@@ -29,6 +29,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/uber-go/tally"
 	"github.com/uber/tchannel-go"
@@ -112,6 +113,7 @@ type (
 		workerConfig                     *WorkerConfig
 		mockAdminClient                  map[string]adminClient.Client
 		namespaceReplicationTaskExecutor namespace.ReplicationTaskExecutor
+		persistenceFaultInjection        *PersistenceFaultInjectionConfig
 	}
 
 	// HistoryConfig contains configs for history service
@@ -122,6 +124,15 @@ type (
 		HistoryCountLimitWarn  int
 	}
 
+	// PersistenceFaultInjectionConfig contains the persistence faults injected into history and matching service
+	PersistenceFaultInjectionConfig struct {
+		TimeoutRate            float64
+		ConditionFailedRate    float64
+		ShardOwnershipLostRate float64
+		UnavailableRate        float64
+		Latency                time.Duration
+	}
+
 	// TemporalParams contains everything needed to bootstrap Temporal
 	TemporalParams struct {
 		ClusterMetadata                  cluster.Metadata
@@ -146,6 +157,7 @@ type (
 		WorkerConfig                     *WorkerConfig
 		MockAdminClient                  map[string]adminClient.Client
 		NamespaceReplicationTaskExecutor namespace.ReplicationTaskExecutor
+		PersistenceFaultInjection        *PersistenceFaultInjectionConfig
 	}
 
 	membershipFactoryImpl struct {
@@ -179,6 +191,7 @@ func NewTemporal(params *TemporalParams) Temporal {
 		workerConfig:                     params.WorkerConfig,
 		mockAdminClient:                  params.MockAdminClient,
 		namespaceReplicationTaskExecutor: params.NamespaceReplicationTaskExecutor,
+		persistenceFaultInjection:        params.PersistenceFaultInjection,
 	}
 }
 
@@ -449,6 +462,7 @@ func (c *temporalImpl) startHistory(
 		params.MetricsClient = metrics.NewClient(params.MetricScope, metrics.GetMetricsServiceIdx(params.Name, c.logger))
 		integrationClient := newIntegrationConfigClient(dynamicconfig.NewNopClient())
 		c.overrideHistoryDynamicConfig(integrationClient)
+		c.overridePersistenceFaultInjectionDynamicConfig(integrationClient)
 		params.DynamicConfig = integrationClient
 
 		var err error
@@ -526,7 +540,9 @@ func (c *temporalImpl) startMatching(hosts map[string][]string, startWG *sync.Wa
 	}
 	params.ClusterMetadata = c.clusterMetadata
 	params.MetricsClient = metrics.NewClient(params.MetricScope, metrics.GetMetricsServiceIdx(params.Name, c.logger))
-	params.DynamicConfig = newIntegrationConfigClient(dynamicconfig.NewNopClient())
+	integrationClient := newIntegrationConfigClient(dynamicconfig.NewNopClient())
+	c.overridePersistenceFaultInjectionDynamicConfig(integrationClient)
+	params.DynamicConfig = integrationClient
 	params.ArchivalMetadata = c.archiverMetadata
 	params.ArchiverProvider = c.archiverProvider
 
@@ -729,6 +745,19 @@ func (c *temporalImpl) overrideHistoryDynamicConfig(client *dynamicClient) {
 	}
 }
 
+func (c *temporalImpl) overridePersistenceFaultInjectionDynamicConfig(client *dynamicClient) {
+	if c.persistenceFaultInjection == nil {
+		return
+	}
+
+	client.OverrideValue(dynamicconfig.PersistenceFaultInjectionEnabled, true)
+	client.OverrideValue(dynamicconfig.PersistenceFaultInjectionTimeoutRate, c.persistenceFaultInjection.TimeoutRate)
+	client.OverrideValue(dynamicconfig.PersistenceFaultInjectionConditionFailedRate, c.persistenceFaultInjection.ConditionFailedRate)
+	client.OverrideValue(dynamicconfig.PersistenceFaultInjectionShardOwnershipLostRate, c.persistenceFaultInjection.ShardOwnershipLostRate)
+	client.OverrideValue(dynamicconfig.PersistenceFaultInjectionUnavailableRate, c.persistenceFaultInjection.UnavailableRate)
+	client.OverrideValue(dynamicconfig.PersistenceFaultInjectionLatency, c.persistenceFaultInjection.Latency)
+}
+
 // copyPersistenceConfig makes a deepcopy of persistence config.
 // This is just a temp fix for the race condition of persistence config.
 // The race condition happens because all the services are using the same datastore map in the config.
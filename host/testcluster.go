@@ -72,18 +72,19 @@ type (
 
 	// TestClusterConfig are config for a test cluster
 	TestClusterConfig struct {
-		FrontendAddress       string
-		EnableNDC             bool
-		EnableArchival        bool
-		IsMasterCluster       bool
-		ClusterNo             int
-		ClusterMetadata       config.ClusterMetadata
-		MessagingClientConfig *MessagingClientConfig
-		Persistence           persistencetests.TestBaseOptions
-		HistoryConfig         *HistoryConfig
-		ESConfig              *elasticsearch.Config
-		WorkerConfig          *WorkerConfig
-		MockAdminClient       map[string]adminClient.Client
+		FrontendAddress           string
+		EnableNDC                 bool
+		EnableArchival            bool
+		IsMasterCluster           bool
+		ClusterNo                 int
+		ClusterMetadata           config.ClusterMetadata
+		MessagingClientConfig     *MessagingClientConfig
+		Persistence               persistencetests.TestBaseOptions
+		HistoryConfig             *HistoryConfig
+		ESConfig                  *elasticsearch.Config
+		WorkerConfig              *WorkerConfig
+		MockAdminClient           map[string]adminClient.Client
+		PersistenceFaultInjection *PersistenceFaultInjectionConfig
 	}
 
 	// MessagingClientConfig is the config for messaging config
@@ -195,6 +196,7 @@ func NewCluster(options *TestClusterConfig, logger log.Logger) (*TestCluster, er
 		HistoryConfig:                    options.HistoryConfig,
 		WorkerConfig:                     options.WorkerConfig,
 		MockAdminClient:                  options.MockAdminClient,
+		PersistenceFaultInjection:        options.PersistenceFaultInjection,
 		NamespaceReplicationTaskExecutor: namespace.NewReplicationTaskExecutor(testBase.MetadataManager, logger),
 	}
 
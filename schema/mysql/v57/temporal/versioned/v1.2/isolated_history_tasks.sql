@@ -0,0 +1,20 @@
+CREATE TABLE isolated_transfer_tasks (
+  shard_id INT NOT NULL,
+  namespace_id BINARY(16) NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BLOB NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, namespace_id, task_id)
+);
+
+CREATE TABLE isolated_timer_tasks (
+  shard_id INT NOT NULL,
+  namespace_id BINARY(16) NOT NULL,
+  visibility_timestamp DATETIME(6) NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BLOB NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, namespace_id, visibility_timestamp, task_id)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE isolated_transfer_tasks (
+  shard_id INTEGER NOT NULL,
+  namespace_id BYTEA NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BYTEA NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, namespace_id, task_id)
+);
+
+CREATE TABLE isolated_timer_tasks (
+  shard_id INTEGER NOT NULL,
+  namespace_id BYTEA NOT NULL,
+  visibility_timestamp TIMESTAMP NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BYTEA NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, namespace_id, visibility_timestamp, task_id)
+);
@@ -27,6 +27,7 @@ package frontend
 import (
 	"context"
 	"errors"
+	"sort"
 	"strconv"
 	"time"
 
@@ -36,13 +37,17 @@ import (
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	historypb "go.temporal.io/temporal-proto/history/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
+	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
 	versionpb "go.temporal.io/temporal-proto/version/v1"
+	"go.temporal.io/temporal-proto/workflowservice/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster/v1"
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
@@ -74,6 +79,7 @@ type (
 		config                *Config
 		namespaceDLQHandler   namespace.DLQMessageHandler
 		eventSerializder      persistence.PayloadSerializer
+		workflowHandler       *WorkflowHandler
 	}
 )
 
@@ -89,6 +95,7 @@ func NewAdminHandler(
 	resource resource.Resource,
 	params *resource.BootstrapParams,
 	config *Config,
+	workflowHandler *WorkflowHandler,
 ) *AdminHandler {
 
 	namespaceReplicationTaskExecutor := namespace.NewReplicationTaskExecutor(
@@ -106,6 +113,7 @@ func NewAdminHandler(
 			resource.GetLogger(),
 		),
 		eventSerializder: persistence.NewPayloadSerializer(),
+		workflowHandler:  workflowHandler,
 	}
 }
 
@@ -799,7 +807,9 @@ func (adh *AdminHandler) ReadDLQMessages(
 	var token []byte
 	var op func() error
 	switch request.GetType() {
-	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION:
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
 		resp, err := adh.GetHistoryClient().ReadDLQMessages(ctx, &historyservice.ReadDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -816,6 +826,8 @@ func (adh *AdminHandler) ReadDLQMessages(
 		return &adminservice.ReadDLQMessagesResponse{
 			Type:             resp.GetType(),
 			ReplicationTasks: resp.GetReplicationTasks(),
+			TransferTasks:    resp.GetTransferTasks(),
+			TimerTasks:       resp.GetTimerTasks(),
 			NextPageToken:    resp.GetNextPageToken(),
 		}, err
 	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_NAMESPACE:
@@ -866,7 +878,9 @@ func (adh *AdminHandler) PurgeDLQMessages(
 
 	var op func() error
 	switch request.GetType() {
-	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION:
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
 		resp, err := adh.GetHistoryClient().PurgeDLQMessages(ctx, &historyservice.PurgeDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -920,7 +934,9 @@ func (adh *AdminHandler) MergeDLQMessages(
 	var token []byte
 	var op func() error
 	switch request.GetType() {
-	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION:
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
 		resp, err := adh.GetHistoryClient().MergeDLQMessages(ctx, &historyservice.MergeDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -934,7 +950,7 @@ func (adh *AdminHandler) MergeDLQMessages(
 		}
 
 		return &adminservice.MergeDLQMessagesResponse{
-			NextPageToken: request.GetNextPageToken(),
+			NextPageToken: resp.GetNextPageToken(),
 		}, nil
 	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_NAMESPACE:
 
@@ -1030,6 +1046,513 @@ func (adh *AdminHandler) ResendReplicationTasks(
 	)
 }
 
+// DescribeTaskQueue returns approximate backlog and throughput statistics of a task queue,
+// aggregated across all of its partitions and optionally broken down per partition. It is an
+// admin API only, as the WorkflowService response of the same name cannot carry the statistics.
+func (adh *AdminHandler) DescribeTaskQueue(
+	ctx context.Context,
+	request *adminservice.DescribeTaskQueueRequest,
+) (_ *adminservice.DescribeTaskQueueResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeTaskQueueScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if request.GetTaskQueue().GetName() == "" {
+		return nil, adh.error(errTaskQueueNotSet, scope)
+	}
+	if request.GetTaskQueueType() == enumspb.TASK_QUEUE_TYPE_UNSPECIFIED {
+		return nil, adh.error(errTaskQueueTypeNotSet, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetMatchingClient().DescribeTaskQueue(ctx, &matchingservice.DescribeTaskQueueRequest{
+		NamespaceId: namespaceID,
+		DescRequest: &workflowservice.DescribeTaskQueueRequest{
+			Namespace:     request.GetNamespace(),
+			TaskQueue:     request.GetTaskQueue(),
+			TaskQueueType: request.GetTaskQueueType(),
+		},
+		IncludeTaskQueueStats: true,
+		// partition stats are needed to aggregate the stats of the whole task queue
+		IncludePartitions: true,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	response := &adminservice.DescribeTaskQueueResponse{
+		Stats:             resp.GetStats(),
+		State:             resp.GetState(),
+		MaxTasksPerSecond: resp.GetMaxTasksPerSecond(),
+	}
+	if request.GetIncludePartitions() {
+		response.Partitions = resp.GetPartitions()
+	}
+	return response, nil
+}
+
+// UpdateWorkerBuildIdCompatibility updates the compatible version sets of the decision task queue
+func (adh *AdminHandler) UpdateWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *adminservice.UpdateWorkerBuildIdCompatibilityRequest,
+) (_ *adminservice.UpdateWorkerBuildIdCompatibilityResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateBuildIdCompatibilityScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if request.GetTaskQueue() == "" {
+		return nil, adh.error(errTaskQueueNotSet, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	matchingRequest := &matchingservice.UpdateWorkerBuildIdCompatibilityRequest{
+		NamespaceId: namespaceID,
+		TaskQueue:   request.GetTaskQueue(),
+	}
+	switch op := request.GetOperation().(type) {
+	case *adminservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet_:
+		matchingRequest.Operation = &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet_{
+			AddNewBuildIdInNewDefaultSet: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet{
+				BuildId: op.AddNewBuildIdInNewDefaultSet.GetBuildId(),
+			},
+		}
+	case *adminservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewCompatibleBuildId_:
+		matchingRequest.Operation = &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewCompatibleBuildId_{
+			AddNewCompatibleBuildId: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewCompatibleBuildId{
+				NewBuildId:                op.AddNewCompatibleBuildId.GetNewBuildId(),
+				ExistingCompatibleBuildId: op.AddNewCompatibleBuildId.GetExistingCompatibleBuildId(),
+				MakeSetDefault:            op.AddNewCompatibleBuildId.GetMakeSetDefault(),
+			},
+		}
+	case *adminservice.UpdateWorkerBuildIdCompatibilityRequest_PromoteSetByBuildId:
+		matchingRequest.Operation = &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_PromoteSetByBuildId{
+			PromoteSetByBuildId: op.PromoteSetByBuildId,
+		}
+	default:
+		return nil, adh.error(errVersionOperationNotSet, scope)
+	}
+
+	if _, err := adh.GetMatchingClient().UpdateWorkerBuildIdCompatibility(ctx, matchingRequest); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpdateWorkerBuildIdCompatibilityResponse{}, nil
+}
+
+// GetWorkerBuildIdCompatibility returns the compatible version sets of the decision task queue
+func (adh *AdminHandler) GetWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *adminservice.GetWorkerBuildIdCompatibilityRequest,
+) (_ *adminservice.GetWorkerBuildIdCompatibilityResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminGetBuildIdCompatibilityScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if request.GetTaskQueue() == "" {
+		return nil, adh.error(errTaskQueueNotSet, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetMatchingClient().GetWorkerBuildIdCompatibility(ctx, &matchingservice.GetWorkerBuildIdCompatibilityRequest{
+		NamespaceId: namespaceID,
+		TaskQueue:   request.GetTaskQueue(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.GetWorkerBuildIdCompatibilityResponse{VersioningData: resp.GetVersioningData()}, nil
+}
+
+// PauseTaskQueue stops dispatching tasks of all partitions of a task queue while still accepting new ones,
+// or with drain set rejects new tasks while the backlog keeps being dispatched
+func (adh *AdminHandler) PauseTaskQueue(
+	ctx context.Context,
+	request *adminservice.PauseTaskQueueRequest,
+) (_ *adminservice.PauseTaskQueueResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminPauseTaskQueueScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	state := enumsgenpb.TASK_QUEUE_STATE_PAUSED
+	if request.GetDrain() {
+		state = enumsgenpb.TASK_QUEUE_STATE_DRAINING
+	}
+	if err := adh.updateTaskQueueState(ctx, request.GetNamespace(), request.GetTaskQueue(), request.GetTaskQueueType(), state); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.PauseTaskQueueResponse{}, nil
+}
+
+// ResumeTaskQueue resumes dispatching and accepting tasks of all partitions of a paused or draining task queue
+func (adh *AdminHandler) ResumeTaskQueue(
+	ctx context.Context,
+	request *adminservice.ResumeTaskQueueRequest,
+) (_ *adminservice.ResumeTaskQueueResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminResumeTaskQueueScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.updateTaskQueueState(
+		ctx,
+		request.GetNamespace(),
+		request.GetTaskQueue(),
+		request.GetTaskQueueType(),
+		enumsgenpb.TASK_QUEUE_STATE_ACTIVE,
+	); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ResumeTaskQueueResponse{}, nil
+}
+
+// UpdateTaskQueueRateLimit sets or clears the dispatch rate limit of all partitions of a task queue,
+// which takes precedence over the limit set by pollers
+func (adh *AdminHandler) UpdateTaskQueueRateLimit(
+	ctx context.Context,
+	request *adminservice.UpdateTaskQueueRateLimitRequest,
+) (_ *adminservice.UpdateTaskQueueRateLimitResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateTaskQueueRateLimitScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if request.TaskQueue.GetName() == "" {
+		return nil, adh.error(errTaskQueueNotSet, scope)
+	}
+	if request.GetTaskQueueType() == enumspb.TASK_QUEUE_TYPE_UNSPECIFIED {
+		return nil, adh.error(errTaskQueueTypeNotSet, scope)
+	}
+	if request.GetMaxTasksPerSecond().GetValue() < 0 {
+		return nil, adh.error(errInvalidMaxTasksPerSecond, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	_, err = adh.GetMatchingClient().UpdateTaskQueueRateLimit(ctx, &matchingservice.UpdateTaskQueueRateLimitRequest{
+		NamespaceId:       namespaceID,
+		TaskQueue:         &taskqueuepb.TaskQueue{Name: request.TaskQueue.GetName(), Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+		TaskQueueType:     request.GetTaskQueueType(),
+		MaxTasksPerSecond: request.GetMaxTasksPerSecond(),
+		IncludePartitions: true,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpdateTaskQueueRateLimitResponse{}, nil
+}
+
+// ListWorkers returns the workers polling task queues of a namespace. Every matching host registers the
+// workers polling the task queue partitions it owns, so the registries of all matching hosts are merged.
+// There is no WorkflowService counterpart, the external WorkflowService API cannot be extended with it.
+func (adh *AdminHandler) ListWorkers(
+	ctx context.Context,
+	request *adminservice.ListWorkersRequest,
+) (_ *adminservice.ListWorkersResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminListWorkersScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	resolver, err := adh.GetMembershipMonitor().GetResolver(common.MatchingServiceName)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	var workers []*taskqueuegenpb.WorkerInfo
+	for _, host := range resolver.Members() {
+		resp, err := adh.GetMatchingClient().ListWorkers(ctx, &matchingservice.ListWorkersRequest{
+			NamespaceId: namespaceID,
+			HostAddress: host.GetAddress(),
+		})
+		if err != nil {
+			// hosts leaving the ring only miss the workers polling their partitions
+			adh.GetLogger().Warn("Failed to list workers of matching host",
+				tag.Address(host.GetAddress()), tag.Error(err))
+			continue
+		}
+		workers = append(workers, resp.GetWorkers()...)
+	}
+
+	workers = mergeWorkerInfos(workers)
+	if request.GetTaskQueue() != "" {
+		workers = filterWorkersByTaskQueue(workers, request.GetTaskQueue())
+	}
+	return &adminservice.ListWorkersResponse{Workers: workers}, nil
+}
+
+// ResetWorkflowExecution resets a workflow like the WorkflowService API of the same name. In addition, the reset point
+// can be resolved by history from a reset type instead of being looked up by the caller, signals can be excluded from
+// reapplication, and a dry run returns the events the new run would start with without resetting. The options are
+// admin only, the WorkflowService reset request cannot carry them.
+func (adh *AdminHandler) ResetWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ResetWorkflowExecutionRequest,
+) (_ *adminservice.ResetWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminResetWorkflowExecutionScope)
+	defer sw.Stop()
+
+	resetRequest := request.GetResetRequest()
+	if resetRequest == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if resetRequest.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(resetRequest.WorkflowExecution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if request.GetResetType() == enumsgenpb.RESET_TYPE_BAD_BINARY && request.GetResetBadBinaryChecksum() == "" {
+		return nil, adh.error(errBadBinaryChecksumNotSet, scope)
+	}
+	for _, signalName := range request.GetExcludeSignalNames() {
+		if signalName == "" {
+			return nil, adh.error(errSignalNameNotSet, scope)
+		}
+	}
+
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(resetRequest.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetHistoryClient().ResetWorkflowExecution(ctx, &historyservice.ResetWorkflowExecutionRequest{
+		NamespaceId:            namespaceID,
+		ResetRequest:           resetRequest,
+		ResetType:              request.GetResetType(),
+		ResetBadBinaryChecksum: request.GetResetBadBinaryChecksum(),
+		ExcludeSignalNames:     request.GetExcludeSignalNames(),
+		DryRun:                 request.GetDryRun(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ResetWorkflowExecutionResponse{
+		RunId:                 resp.GetRunId(),
+		BaseRunId:             resp.GetBaseRunId(),
+		DecisionFinishEventId: resp.GetDecisionFinishEventId(),
+		HistoryPreview:        resp.GetHistoryPreview(),
+	}, nil
+}
+
+// PollForDecisionTask polls for a decision task, and returns along with the task the size of the workflow history and
+// whether the workflow should continue as new, as its history has grown past the namespace warn limits. Decision tasks
+// polled through the WorkflowService API carry both as response headers instead.
+func (adh *AdminHandler) PollForDecisionTask(
+	ctx context.Context,
+	request *adminservice.PollForDecisionTaskRequest,
+) (_ *adminservice.PollForDecisionTaskResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminPollForDecisionTaskScope)
+	defer sw.Stop()
+
+	if request.GetPollRequest() == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+
+	// errors are reported to scope by the workflow handler
+	pollResponse, matchingResponse, err := adh.workflowHandler.pollForDecisionTask(ctx, scope, request.GetPollRequest())
+	if err != nil {
+		return nil, err
+	}
+	return &adminservice.PollForDecisionTaskResponse{
+		PollResponse:         pollResponse,
+		SuggestContinueAsNew: matchingResponse.GetSuggestContinueAsNew(),
+		HistorySizeBytes:     matchingResponse.GetHistorySizeBytes(),
+	}, nil
+}
+
+// UpdateWorkflowExecutionMetadata upserts memo and search attributes of a workflow execution from outside the workflow,
+// e.g. to tag workflows affected by an incident. Only mutable state and visibility are updated, workers never see the
+// update as no event is added to history. There is no WorkflowService counterpart, the external WorkflowService API cannot be
+// extended with it.
+func (adh *AdminHandler) UpdateWorkflowExecutionMetadata(
+	ctx context.Context,
+	request *adminservice.UpdateWorkflowExecutionMetadataRequest,
+) (_ *adminservice.UpdateWorkflowExecutionMetadataResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateWorkflowExecutionMetadataScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	namespace := request.GetNamespace()
+	if namespace == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if len(request.GetMemo().GetFields()) == 0 && len(request.GetSearchAttributes().GetIndexedFields()) == 0 {
+		return nil, adh.error(errWorkflowMetadataNotSet, scope)
+	}
+	if len(request.GetIdentity()) > adh.config.MaxIDLengthLimit() {
+		return nil, adh.error(errIdentityTooLong, scope)
+	}
+	if err := adh.workflowHandler.searchAttributesValidator.ValidateSearchAttributes(request.SearchAttributes, namespace); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(namespace)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if err := common.CheckEventBlobSizeLimit(
+		request.GetMemo().Size()+request.GetSearchAttributes().Size(),
+		adh.config.BlobSizeLimitWarn(namespace),
+		adh.config.BlobSizeLimitError(namespace),
+		namespaceID,
+		request.Execution.GetWorkflowId(),
+		request.Execution.GetRunId(),
+		scope,
+		adh.GetThrottledLogger(),
+		tag.BlobSizeViolationOperation("UpdateWorkflowExecutionMetadata"),
+	); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	if _, err := adh.GetHistoryClient().UpdateWorkflowExecutionMetadata(ctx, &historyservice.UpdateWorkflowExecutionMetadataRequest{
+		NamespaceId:      namespaceID,
+		Execution:        request.Execution,
+		Memo:             request.Memo,
+		SearchAttributes: request.SearchAttributes,
+		Identity:         request.GetIdentity(),
+	}); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpdateWorkflowExecutionMetadataResponse{}, nil
+}
+
+// UpdateNamespaceDefaultRetryPolicies updates the retry policies applied to activities and workflows of the namespace
+// which are scheduled or started without one. The policies are kept out of the WorkflowService namespace APIs,
+// their namespace config is defined in the external temporal-proto module and cannot carry them.
+func (adh *AdminHandler) UpdateNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest,
+) (_ *adminservice.UpdateNamespaceDefaultRetryPoliciesResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateNamespaceRetryPoliciesScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.checkPermission(adh.config, request.SecurityToken); err != nil {
+		return nil, adh.error(errNoPermission, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+
+	resp, err := adh.workflowHandler.namespaceHandler.UpdateNamespaceDefaultRetryPolicies(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// DescribeNamespaceDefaultRetryPolicies returns the default retry policies of the namespace
+func (adh *AdminHandler) DescribeNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest,
+) (_ *adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+	scope, sw := adh.startRequestProfile(metrics.AdminDescribeNamespaceRetryPoliciesScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+
+	resp, err := adh.workflowHandler.namespaceHandler.DescribeNamespaceDefaultRetryPolicies(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+func (adh *AdminHandler) updateTaskQueueState(
+	ctx context.Context,
+	namespace string,
+	taskQueue *taskqueuepb.TaskQueue,
+	taskQueueType enumspb.TaskQueueType,
+	state enumsgenpb.TaskQueueState,
+) error {
+	if namespace == "" {
+		return errNamespaceNotSet
+	}
+	if taskQueue.GetName() == "" {
+		return errTaskQueueNotSet
+	}
+	if taskQueueType == enumspb.TASK_QUEUE_TYPE_UNSPECIFIED {
+		return errTaskQueueTypeNotSet
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(namespace)
+	if err != nil {
+		return err
+	}
+
+	_, err = adh.GetMatchingClient().UpdateTaskQueueState(ctx, &matchingservice.UpdateTaskQueueStateRequest{
+		NamespaceId:       namespaceID,
+		TaskQueue:         &taskqueuepb.TaskQueue{Name: taskQueue.GetName(), Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+		TaskQueueType:     taskQueueType,
+		State:             state,
+		IncludePartitions: true,
+	})
+	return err
+}
+
 func (adh *AdminHandler) validateGetWorkflowExecutionRawHistoryV2Request(
 	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
 ) error {
@@ -1220,3 +1743,73 @@ func (adh *AdminHandler) checkPermission(
 	}
 	return nil
 }
+
+// mergeWorkerInfos merges the workers registered by different matching hosts, a worker polls different
+// task queue partitions which are owned by different hosts
+func mergeWorkerInfos(workers []*taskqueuegenpb.WorkerInfo) []*taskqueuegenpb.WorkerInfo {
+	var result []*taskqueuegenpb.WorkerInfo
+	byIdentity := make(map[string]*taskqueuegenpb.WorkerInfo)
+	for _, worker := range workers {
+		merged, ok := byIdentity[worker.GetIdentity()]
+		if !ok {
+			merged = &taskqueuegenpb.WorkerInfo{Identity: worker.GetIdentity()}
+			byIdentity[worker.GetIdentity()] = merged
+			result = append(result, merged)
+		}
+		// sdk of the latest poll wins, a worker may have been restarted with a different version
+		if worker.GetLastPollTime() > merged.GetLastPollTime() {
+			merged.LastPollTime = worker.GetLastPollTime()
+			merged.SdkName = worker.GetSdkName()
+			merged.SdkVersion = worker.GetSdkVersion()
+		}
+		merged.Alive = merged.GetAlive() || worker.GetAlive()
+		for _, taskQueue := range worker.GetTaskQueues() {
+			merged.TaskQueues = mergeWorkerTaskQueue(merged.TaskQueues, taskQueue)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetIdentity() < result[j].GetIdentity()
+	})
+	for _, worker := range result {
+		taskQueues := worker.TaskQueues
+		sort.Slice(taskQueues, func(i, j int) bool {
+			if taskQueues[i].GetName() != taskQueues[j].GetName() {
+				return taskQueues[i].GetName() < taskQueues[j].GetName()
+			}
+			return taskQueues[i].GetTaskQueueType() < taskQueues[j].GetTaskQueueType()
+		})
+	}
+	return result
+}
+
+func mergeWorkerTaskQueue(
+	taskQueues []*taskqueuegenpb.WorkerTaskQueue,
+	taskQueue *taskqueuegenpb.WorkerTaskQueue,
+) []*taskqueuegenpb.WorkerTaskQueue {
+	for _, existing := range taskQueues {
+		if existing.GetName() == taskQueue.GetName() && existing.GetTaskQueueType() == taskQueue.GetTaskQueueType() {
+			if taskQueue.GetLastPollTime() > existing.GetLastPollTime() {
+				existing.LastPollTime = taskQueue.GetLastPollTime()
+			}
+			return taskQueues
+		}
+	}
+	return append(taskQueues, &taskqueuegenpb.WorkerTaskQueue{
+		Name:          taskQueue.GetName(),
+		TaskQueueType: taskQueue.GetTaskQueueType(),
+		LastPollTime:  taskQueue.GetLastPollTime(),
+	})
+}
+
+func filterWorkersByTaskQueue(workers []*taskqueuegenpb.WorkerInfo, taskQueue string) []*taskqueuegenpb.WorkerInfo {
+	var result []*taskqueuegenpb.WorkerInfo
+	for _, worker := range workers {
+		for _, polled := range worker.GetTaskQueues() {
+			if polled.GetName() == taskQueue {
+				result = append(result, worker)
+				break
+			}
+		}
+	}
+	return result
+}
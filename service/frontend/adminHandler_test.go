@@ -46,6 +46,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/definition"
@@ -104,7 +105,7 @@ func (s *adminHandlerSuite) SetupTest() {
 		EnableAdminProtection:        dynamicconfig.GetBoolPropertyFn(false),
 		EnableCleanupReplicationTask: dynamicconfig.GetBoolPropertyFn(false),
 	}
-	s.handler = NewAdminHandler(s.mockResource, params, config)
+	s.handler = NewAdminHandler(s.mockResource, params, config, nil)
 	s.handler.Start()
 }
 
@@ -607,3 +608,50 @@ func (s *adminHandlerSuite) Test_AddSearchAttribute_Permission() {
 		s.Nil(resp)
 	}
 }
+
+func (s *adminHandlerSuite) Test_MergeWorkerInfos() {
+	workers := mergeWorkerInfos([]*taskqueuegenpb.WorkerInfo{
+		{
+			Identity:     "worker-b",
+			SdkName:      "temporal-go",
+			SdkVersion:   "0.25.0",
+			LastPollTime: 100,
+			TaskQueues: []*taskqueuegenpb.WorkerTaskQueue{
+				{Name: "tq", TaskQueueType: enumspb.TASK_QUEUE_TYPE_DECISION, LastPollTime: 100},
+			},
+		},
+		{
+			Identity:     "worker-a",
+			SdkName:      "temporal-java",
+			SdkVersion:   "0.22.0",
+			LastPollTime: 50,
+			Alive:        true,
+		},
+		{
+			Identity:     "worker-b",
+			SdkName:      "temporal-go",
+			SdkVersion:   "0.26.0",
+			LastPollTime: 200,
+			Alive:        true,
+			TaskQueues: []*taskqueuegenpb.WorkerTaskQueue{
+				{Name: "tq", TaskQueueType: enumspb.TASK_QUEUE_TYPE_DECISION, LastPollTime: 200},
+				{Name: "tq", TaskQueueType: enumspb.TASK_QUEUE_TYPE_ACTIVITY, LastPollTime: 150},
+			},
+		},
+	})
+
+	s.Len(workers, 2)
+	s.Equal("worker-a", workers[0].GetIdentity())
+	s.Equal("worker-b", workers[1].GetIdentity())
+	s.Equal("0.26.0", workers[1].GetSdkVersion())
+	s.Equal(int64(200), workers[1].GetLastPollTime())
+	s.True(workers[1].GetAlive())
+	s.Equal([]*taskqueuegenpb.WorkerTaskQueue{
+		{Name: "tq", TaskQueueType: enumspb.TASK_QUEUE_TYPE_DECISION, LastPollTime: 200},
+		{Name: "tq", TaskQueueType: enumspb.TASK_QUEUE_TYPE_ACTIVITY, LastPollTime: 150},
+	}, workers[1].GetTaskQueues())
+
+	filtered := filterWorkersByTaskQueue(workers, "tq")
+	s.Len(filtered, 1)
+	s.Equal("worker-b", filtered[0].GetIdentity())
+}
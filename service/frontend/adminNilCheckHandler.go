@@ -206,3 +206,111 @@ func (adh *AdminNilCheckHandler) ResendReplicationTasks(ctx context.Context, req
 	}
 	return resp, err
 }
+
+// DescribeTaskQueue returns approximate backlog and throughput statistics of a task queue
+func (adh *AdminNilCheckHandler) DescribeTaskQueue(ctx context.Context, request *adminservice.DescribeTaskQueueRequest) (_ *adminservice.DescribeTaskQueueResponse, err error) {
+	resp, err := adh.parentHandler.DescribeTaskQueue(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DescribeTaskQueueResponse{}
+	}
+	return resp, err
+}
+
+// UpdateWorkerBuildIdCompatibility updates the compatible version sets of the decision task queue
+func (adh *AdminNilCheckHandler) UpdateWorkerBuildIdCompatibility(ctx context.Context, request *adminservice.UpdateWorkerBuildIdCompatibilityRequest) (_ *adminservice.UpdateWorkerBuildIdCompatibilityResponse, err error) {
+	resp, err := adh.parentHandler.UpdateWorkerBuildIdCompatibility(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UpdateWorkerBuildIdCompatibilityResponse{}
+	}
+	return resp, err
+}
+
+// GetWorkerBuildIdCompatibility returns the compatible version sets of the decision task queue
+func (adh *AdminNilCheckHandler) GetWorkerBuildIdCompatibility(ctx context.Context, request *adminservice.GetWorkerBuildIdCompatibilityRequest) (_ *adminservice.GetWorkerBuildIdCompatibilityResponse, err error) {
+	resp, err := adh.parentHandler.GetWorkerBuildIdCompatibility(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.GetWorkerBuildIdCompatibilityResponse{}
+	}
+	return resp, err
+}
+
+// PauseTaskQueue stops dispatching tasks of a task queue while still accepting new ones, or drains it
+func (adh *AdminNilCheckHandler) PauseTaskQueue(ctx context.Context, request *adminservice.PauseTaskQueueRequest) (_ *adminservice.PauseTaskQueueResponse, err error) {
+	resp, err := adh.parentHandler.PauseTaskQueue(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.PauseTaskQueueResponse{}
+	}
+	return resp, err
+}
+
+// ResumeTaskQueue resumes dispatching and accepting tasks of a paused or draining task queue
+func (adh *AdminNilCheckHandler) ResumeTaskQueue(ctx context.Context, request *adminservice.ResumeTaskQueueRequest) (_ *adminservice.ResumeTaskQueueResponse, err error) {
+	resp, err := adh.parentHandler.ResumeTaskQueue(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ResumeTaskQueueResponse{}
+	}
+	return resp, err
+}
+
+// UpdateTaskQueueRateLimit sets or clears the operator defined dispatch rate limit of a task queue
+func (adh *AdminNilCheckHandler) UpdateTaskQueueRateLimit(ctx context.Context, request *adminservice.UpdateTaskQueueRateLimitRequest) (_ *adminservice.UpdateTaskQueueRateLimitResponse, err error) {
+	resp, err := adh.parentHandler.UpdateTaskQueueRateLimit(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UpdateTaskQueueRateLimitResponse{}
+	}
+	return resp, err
+}
+
+// ListWorkers returns the workers polling task queues of a namespace
+func (adh *AdminNilCheckHandler) ListWorkers(ctx context.Context, request *adminservice.ListWorkersRequest) (_ *adminservice.ListWorkersResponse, err error) {
+	resp, err := adh.parentHandler.ListWorkers(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ListWorkersResponse{}
+	}
+	return resp, err
+}
+
+// ResetWorkflowExecution resets a workflow with the reset point optionally resolved on the server
+func (adh *AdminNilCheckHandler) ResetWorkflowExecution(ctx context.Context, request *adminservice.ResetWorkflowExecutionRequest) (*adminservice.ResetWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.ResetWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ResetWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// PollForDecisionTask polls for a decision task and returns the continue as new suggestion
+func (adh *AdminNilCheckHandler) PollForDecisionTask(ctx context.Context, request *adminservice.PollForDecisionTaskRequest) (*adminservice.PollForDecisionTaskResponse, error) {
+	resp, err := adh.parentHandler.PollForDecisionTask(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.PollForDecisionTaskResponse{}
+	}
+	return resp, err
+}
+
+// UpdateWorkflowExecutionMetadata upserts memo and search attributes of a workflow execution
+func (adh *AdminNilCheckHandler) UpdateWorkflowExecutionMetadata(ctx context.Context, request *adminservice.UpdateWorkflowExecutionMetadataRequest) (*adminservice.UpdateWorkflowExecutionMetadataResponse, error) {
+	resp, err := adh.parentHandler.UpdateWorkflowExecutionMetadata(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UpdateWorkflowExecutionMetadataResponse{}
+	}
+	return resp, err
+}
+
+// UpdateNamespaceDefaultRetryPolicies updates the default retry policies of a namespace
+func (adh *AdminNilCheckHandler) UpdateNamespaceDefaultRetryPolicies(ctx context.Context, request *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest) (*adminservice.UpdateNamespaceDefaultRetryPoliciesResponse, error) {
+	resp, err := adh.parentHandler.UpdateNamespaceDefaultRetryPolicies(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UpdateNamespaceDefaultRetryPoliciesResponse{}
+	}
+	return resp, err
+}
+
+// DescribeNamespaceDefaultRetryPolicies returns the default retry policies of a namespace
+func (adh *AdminNilCheckHandler) DescribeNamespaceDefaultRetryPolicies(ctx context.Context, request *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest) (*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, error) {
+	resp, err := adh.parentHandler.DescribeNamespaceDefaultRetryPolicies(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DescribeNamespaceDefaultRetryPoliciesResponse{}
+	}
+	return resp, err
+}
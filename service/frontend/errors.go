@@ -79,6 +79,10 @@ var (
 	errUnknownValueType                                   = serviceerror.NewInvalidArgument("Unknown value type, %v.")
 	errDLQTypeIsNotSupported                              = serviceerror.NewInvalidArgument("The DLQ type is not supported.")
 	errFailureMustHaveApplicationFailureInfo              = serviceerror.NewInvalidArgument("Failure must have ApplicationFailureInfo.")
+	errVersionOperationNotSet                             = serviceerror.NewInvalidArgument("Version set operation is not set on request.")
+	errInvalidMaxTasksPerSecond                           = serviceerror.NewInvalidArgument("MaxTasksPerSecond cannot be negative.")
+	errBadBinaryChecksumNotSet                            = serviceerror.NewInvalidArgument("Bad binary checksum is not set on request.")
+	errWorkflowMetadataNotSet                             = serviceerror.NewInvalidArgument("Memo or SearchAttributes must be set on request.")
 	errShuttingDown                                       = serviceerror.NewInternal("Shutting down")
 
 	errFailedUpdateDynamicConfig = serviceerror.NewInternal("Failed to update dynamic config, err: %v.")
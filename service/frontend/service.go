@@ -70,6 +70,8 @@ type Config struct {
 	MaxIDLengthLimit                dynamicconfig.IntPropertyFn
 	EnableClientVersionCheck        dynamicconfig.BoolPropertyFn
 	MinRetentionDays                dynamicconfig.IntPropertyFn
+	MaxRetentionDays                dynamicconfig.IntPropertyFnWithNamespaceFilter
+	EnableReservedHeaderOptions     dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	DisallowQuery                   dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	ShutdownDrainDuration           dynamicconfig.DurationPropertyFn
 
@@ -141,6 +143,8 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableReadFro
 		SearchAttributesSizeOfValueLimit:       dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesSizeOfValueLimit, 2*1024),
 		SearchAttributesTotalSizeLimit:         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.SearchAttributesTotalSizeLimit, 40*1024),
 		MinRetentionDays:                       dc.GetIntProperty(dynamicconfig.MinRetentionDays, namespace.MinRetentionDays),
+		MaxRetentionDays:                       dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaxRetentionDays, namespace.MaxRetentionDays),
+		EnableReservedHeaderOptions:            dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableReservedHeaderOptions, false),
 		VisibilityArchivalQueryMaxPageSize:     dc.GetIntProperty(dynamicconfig.VisibilityArchivalQueryMaxPageSize, 10000),
 		DisallowQuery:                          dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.DisallowQuery, false),
 		SendRawWorkflowHistory:                 dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.SendRawWorkflowHistory, false),
@@ -270,7 +274,7 @@ func (s *Service) Start() {
 	workflowservice.RegisterWorkflowServiceServer(s.server, workflowNilCheckHandler)
 	healthpb.RegisterHealthServer(s.server, s.handler)
 
-	s.adminHandler = NewAdminHandler(s, s.params, s.config)
+	s.adminHandler = NewAdminHandler(s, s.params, s.config, wfHandler.(*WorkflowHandler))
 	adminNilCheckHandler := NewAdminNilCheckHandler(s.adminHandler)
 
 	adminservice.RegisterAdminServiceServer(s.server, adminNilCheckHandler)
@@ -413,6 +413,33 @@ func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *
 		return nil, wh.error(err, scope)
 	}
 
+	if err := common.ValidateReservedHeaderFields(
+		request.GetHeader(),
+		wh.config.EnableReservedHeaderOptions(request.GetNamespace()),
+	); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := common.ValidateTaskPriority(request.GetHeader()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := common.ValidateTaskFairnessKey(request.GetHeader(), wh.config.MaxIDLengthLimit()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := common.ValidateWorkflowRetentionDays(
+		request.GetHeader(),
+		wh.config.MinRetentionDays(),
+		wh.config.MaxRetentionDays(request.GetNamespace()),
+	); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := common.ValidateWorkflowIDReusePolicy(request.GetHeader()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if err := backoff.ValidateSchedule(request.GetCronSchedule()); err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -681,6 +708,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 					nil,
 					continuationToken.TransientDecision,
 					continuationToken.BranchToken,
+					true,
 				)
 				if err != nil {
 					return nil, wh.error(err, scope)
@@ -727,6 +755,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 					continuationToken.PersistenceToken,
 					continuationToken.TransientDecision,
 					continuationToken.BranchToken,
+					!continuationToken.IsWorkflowRunning,
 				)
 			}
 
@@ -763,18 +792,43 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 func (wh *WorkflowHandler) PollForDecisionTask(ctx context.Context, request *workflowservice.PollForDecisionTaskRequest) (_ *workflowservice.PollForDecisionTaskResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	tagsForErrorLog := []tag.Tag{tag.WorkflowNamespace(request.GetNamespace())}
-	callTime := time.Now()
-
 	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendPollForDecisionTaskScope, request.GetNamespace())
 	defer sw.Stop()
 
+	resp, matchingResp, err := wh.pollForDecisionTask(ctx, scope, request)
+	if err != nil || matchingResp == nil {
+		return resp, err
+	}
+
+	// the WorkflowService API response cannot carry the continue as new suggestion, it is sent as response headers
+	if err := headers.SetContinueAsNewSuggestion(
+		ctx,
+		matchingResp.GetSuggestContinueAsNew(),
+		matchingResp.GetHistorySizeBytes(),
+	); err != nil {
+		wh.GetLogger().Debug("Unable to set continue as new suggestion headers.", tag.Error(err))
+	}
+	return resp, nil
+}
+
+// pollForDecisionTask polls matching for a decision task. Along with the response, the matching response is returned,
+// which carries details not part of the WorkflowService API, such as the continue as new suggestion. Returned errors are
+// already reported to the given scope.
+func (wh *WorkflowHandler) pollForDecisionTask(
+	ctx context.Context,
+	scope metrics.Scope,
+	request *workflowservice.PollForDecisionTaskRequest,
+) (*workflowservice.PollForDecisionTaskResponse, *matchingservice.PollForDecisionTaskResponse, error) {
+
+	tagsForErrorLog := []tag.Tag{tag.WorkflowNamespace(request.GetNamespace())}
+	callTime := time.Now()
+
 	if err := wh.versionChecker.ClientSupported(ctx, wh.config.EnableClientVersionCheck()); err != nil {
-		return nil, wh.error(err, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(err, scope, tagsForErrorLog...)
 	}
 
 	if request == nil {
-		return nil, wh.error(errRequestNotSet, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(errRequestNotSet, scope, tagsForErrorLog...)
 	}
 
 	wh.GetLogger().Debug("Received PollForDecisionTask")
@@ -783,34 +837,34 @@ func (wh *WorkflowHandler) PollForDecisionTask(ctx context.Context, request *wor
 		"PollForDecisionTask",
 		wh.GetThrottledLogger(),
 	); err != nil {
-		return nil, wh.error(err, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(err, scope, tagsForErrorLog...)
 	}
 
 	if request.GetNamespace() == "" {
-		return nil, wh.error(errNamespaceNotSet, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(errNamespaceNotSet, scope, tagsForErrorLog...)
 	}
 	if len(request.GetNamespace()) > wh.config.MaxIDLengthLimit() {
-		return nil, wh.error(errNamespaceTooLong, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(errNamespaceTooLong, scope, tagsForErrorLog...)
 	}
 
 	if len(request.GetIdentity()) > wh.config.MaxIDLengthLimit() {
-		return nil, wh.error(errIdentityTooLong, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(errIdentityTooLong, scope, tagsForErrorLog...)
 	}
 
 	if err := wh.validateTaskQueue(request.TaskQueue, scope); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	namespace := request.GetNamespace()
 	namespaceEntry, err := wh.GetNamespaceCache().GetNamespace(namespace)
 	if err != nil {
-		return nil, wh.error(err, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(err, scope, tagsForErrorLog...)
 	}
 	namespaceID := namespaceEntry.GetInfo().Id
 
 	wh.GetLogger().Debug("Poll for decision.", tag.WorkflowNamespace(namespace), tag.WorkflowNamespaceID(namespaceID))
 	if err := wh.checkBadBinary(namespaceEntry, request.GetBinaryChecksum()); err != nil {
-		return nil, wh.error(err, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(err, scope, tagsForErrorLog...)
 	}
 
 	pollerID := uuid.New()
@@ -839,11 +893,11 @@ func (wh *WorkflowHandler) PollForDecisionTask(ctx context.Context, request *wor
 				tag.WorkflowTaskQueueName(request.GetTaskQueue().GetName()),
 				tag.Value(ctxTimeout),
 				tag.Error(err))
-			return nil, wh.error(err, scope)
+			return nil, nil, wh.error(err, scope)
 		}
 
 		// Must be cancellation error.  Does'nt matter what we return here.  Client already went away.
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	tagsForErrorLog = append(tagsForErrorLog, []tag.Tag{tag.WorkflowID(
@@ -851,9 +905,9 @@ func (wh *WorkflowHandler) PollForDecisionTask(ctx context.Context, request *wor
 		tag.WorkflowRunID(matchingResp.GetWorkflowExecution().GetRunId())}...)
 	resp, err := wh.createPollForDecisionTaskResponse(ctx, scope, namespaceID, matchingResp, matchingResp.GetBranchToken())
 	if err != nil {
-		return nil, wh.error(err, scope, tagsForErrorLog...)
+		return nil, nil, wh.error(err, scope, tagsForErrorLog...)
 	}
-	return resp, nil
+	return resp, matchingResp, nil
 }
 
 // RespondDecisionTaskCompleted is called by application worker to complete a DecisionTask handed as a result of
@@ -1123,7 +1177,7 @@ func (wh *WorkflowHandler) PollForActivityTask(ctx context.Context, request *wor
 		HeartbeatDetails:                matchingResponse.HeartbeatDetails,
 		WorkflowType:                    matchingResponse.WorkflowType,
 		WorkflowNamespace:               matchingResponse.WorkflowNamespace,
-		Header:                          matchingResponse.Header,
+		Header:                          common.StripReservedHeaderFields(matchingResponse.Header),
 	}, nil
 }
 
@@ -2137,6 +2191,33 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	if err := common.ValidateReservedHeaderFields(
+		request.GetHeader(),
+		wh.config.EnableReservedHeaderOptions(request.GetNamespace()),
+	); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := common.ValidateTaskPriority(request.GetHeader()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := common.ValidateTaskFairnessKey(request.GetHeader(), wh.config.MaxIDLengthLimit()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := common.ValidateWorkflowRetentionDays(
+		request.GetHeader(),
+		wh.config.MinRetentionDays(),
+		wh.config.MaxRetentionDays(request.GetNamespace()),
+	); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := common.ValidateWorkflowIDReusePolicy(request.GetHeader()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if err := backoff.ValidateSchedule(request.GetCronSchedule()); err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -3248,36 +3329,40 @@ func (wh *WorkflowHandler) getHistory(
 	nextPageToken []byte,
 	transientDecision *historygenpb.TransientDecisionInfo,
 	branchToken []byte,
+	readFromReplica bool,
 ) (*historypb.History, []byte, error) {
 
-	var size int
-
 	isFirstPage := len(nextPageToken) == 0
 	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), wh.config.NumHistoryShards)
-	var err error
-	var historyEvents []*historypb.HistoryEvent
-	historyEvents, size, nextPageToken, err = persistence.ReadFullPageV2Events(wh.GetHistoryManager(), &persistence.ReadHistoryBranchRequest{
-		BranchToken:   branchToken,
-		MinEventID:    firstEventID,
-		MaxEventID:    nextEventID,
-		PageSize:      int(pageSize),
-		NextPageToken: nextPageToken,
-		ShardID:       convert.IntPtr(shardID),
-	})
+	newReadRequest := func(readFromReplica bool) *persistence.ReadHistoryBranchRequest {
+		return &persistence.ReadHistoryBranchRequest{
+			BranchToken:     branchToken,
+			MinEventID:      firstEventID,
+			MaxEventID:      nextEventID,
+			PageSize:        int(pageSize),
+			NextPageToken:   nextPageToken,
+			ShardID:         convert.IntPtr(shardID),
+			ReadFromReplica: readFromReplica,
+		}
+	}
+	historyEvents, size, pageToken, err := persistence.ReadFullPageV2Events(wh.GetHistoryManager(), newReadRequest(readFromReplica))
 	if err != nil {
 		return nil, nil, err
 	}
+	err = wh.verifyHistoryIsComplete(historyEvents, firstEventID, nextEventID-1, isFirstPage, len(pageToken) == 0, int(pageSize))
+	if readFromReplica && (err != nil || len(historyEvents) == 0) {
+		// a read replica can lag behind the primary, re-read the page from the primary before failing
+		historyEvents, size, pageToken, err = persistence.ReadFullPageV2Events(wh.GetHistoryManager(), newReadRequest(false))
+		if err != nil {
+			return nil, nil, err
+		}
+		err = wh.verifyHistoryIsComplete(historyEvents, firstEventID, nextEventID-1, isFirstPage, len(pageToken) == 0, int(pageSize))
+	}
+	nextPageToken = pageToken
 
 	scope.RecordTimer(metrics.HistorySize, time.Duration(size))
 
-	isLastPage := len(nextPageToken) == 0
-	if err := wh.verifyHistoryIsComplete(
-		historyEvents,
-		firstEventID,
-		nextEventID-1,
-		isFirstPage,
-		isLastPage,
-		int(pageSize)); err != nil {
+	if err != nil {
 		scope.IncCounter(metrics.ServiceErrIncompleteHistoryCounter)
 		wh.GetLogger().Error("getHistory: incomplete history",
 			tag.WorkflowNamespaceID(namespaceID),
@@ -3300,6 +3385,8 @@ func (wh *WorkflowHandler) getHistory(
 		historyEvents = append(historyEvents, transientDecision.ScheduledEvent, transientDecision.StartedEvent)
 	}
 
+	// the options of the server carried in reserved header fields are not meant for workflow code
+	common.StripReservedHeaderFieldsFromEvents(historyEvents)
 	executionHistory := &historypb.History{}
 	executionHistory.Events = historyEvents
 	return executionHistory, nextPageToken, nil
@@ -3466,6 +3553,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 			nil,
 			matchingResp.GetDecisionInfo(),
 			branchToken,
+			false,
 		)
 		if err != nil {
 			return nil, err
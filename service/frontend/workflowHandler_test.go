@@ -1030,12 +1030,64 @@ func (s *workflowHandlerSuite) TestGetHistory() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
 	scope := metrics.NoopScope(metrics.Frontend)
-	history, token, err := wh.getHistory(scope, namespaceID, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken)
+	history, token, err := wh.getHistory(scope, namespaceID, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken, false)
 	s.NoError(err)
 	s.NotNil(history)
 	s.Equal([]byte{}, token)
 }
 
+func (s *workflowHandlerSuite) TestGetHistory_StaleReplica() {
+	namespaceID := uuid.New()
+	firstEventID := int64(100)
+	nextEventID := int64(102)
+	branchToken := []byte{1}
+	we := commonpb.WorkflowExecution{
+		WorkflowId: "wid",
+		RunId:      "rid",
+	}
+	shardID := common.WorkflowIDToHistoryShard(we.WorkflowId, numHistoryShards)
+	req := &persistence.ReadHistoryBranchRequest{
+		BranchToken:     branchToken,
+		MinEventID:      firstEventID,
+		MaxEventID:      nextEventID,
+		PageSize:        10,
+		NextPageToken:   []byte{},
+		ShardID:         convert.IntPtr(shardID),
+		ReadFromReplica: true,
+	}
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", req).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: []*historypb.HistoryEvent{
+			{
+				EventId: int64(100),
+			},
+		},
+		NextPageToken: []byte{},
+		Size:          1,
+	}, nil).Once()
+	primaryReq := *req
+	primaryReq.ReadFromReplica = false
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", &primaryReq).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: []*historypb.HistoryEvent{
+			{
+				EventId: int64(100),
+			},
+			{
+				EventId: int64(101),
+			},
+		},
+		NextPageToken: []byte{},
+		Size:          2,
+	}, nil).Once()
+
+	wh := s.getWorkflowHandler(s.newConfig())
+
+	scope := metrics.NoopScope(metrics.Frontend)
+	history, token, err := wh.getHistory(scope, namespaceID, we, firstEventID, nextEventID, 10, []byte{}, nil, branchToken, true)
+	s.NoError(err)
+	s.Len(history.Events, 2)
+	s.Empty(token)
+}
+
 func (s *workflowHandlerSuite) TestListArchivedVisibility_Failure_InvalidRequest() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
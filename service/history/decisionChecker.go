@@ -46,12 +46,16 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
 	decisionAttrValidator struct {
 		namespaceCache            cache.NamespaceCache
 		maxIDLengthLimit          int
+		minRetentionDays          dynamicconfig.IntPropertyFn
+		maxRetentionDays          dynamicconfig.IntPropertyFnWithNamespaceFilter
+		enableReservedHeaders     dynamicconfig.BoolPropertyFnWithNamespaceFilter
 		searchAttributesValidator *validator.SearchAttributesValidator
 	}
 
@@ -65,6 +69,8 @@ type (
 		historyCountLimitWarn  int
 		historyCountLimitError int
 
+		historySizeVisibilityRefreshStep int
+
 		completedID    int64
 		mutableState   mutableState
 		executionStats *persistence.ExecutionStats
@@ -83,8 +89,11 @@ func newDecisionAttrValidator(
 	logger log.Logger,
 ) *decisionAttrValidator {
 	return &decisionAttrValidator{
-		namespaceCache:   namespaceCache,
-		maxIDLengthLimit: config.MaxIDLengthLimit(),
+		namespaceCache:        namespaceCache,
+		maxIDLengthLimit:      config.MaxIDLengthLimit(),
+		minRetentionDays:      config.MinRetentionDays,
+		maxRetentionDays:      config.MaxRetentionDays,
+		enableReservedHeaders: config.EnableReservedHeaderOptions,
 		searchAttributesValidator: validator.NewSearchAttributesValidator(
 			logger,
 			config.ValidSearchAttributes,
@@ -102,6 +111,7 @@ func newWorkflowSizeChecker(
 	historySizeLimitError int,
 	historyCountLimitWarn int,
 	historyCountLimitError int,
+	historySizeVisibilityRefreshStep int,
 	completedID int64,
 	mutableState mutableState,
 	executionStats *persistence.ExecutionStats,
@@ -120,6 +130,8 @@ func newWorkflowSizeChecker(
 		executionStats:         executionStats,
 		metricsScope:           metricsScope,
 		logger:                 logger,
+
+		historySizeVisibilityRefreshStep: historySizeVisibilityRefreshStep,
 	}
 }
 
@@ -187,6 +199,18 @@ func (c *workflowSizeChecker) failWorkflowSizeExceedsLimit() (bool, error) {
 			tag.WorkflowRunID(executionInfo.RunID),
 			tag.WorkflowHistorySize(historySize),
 			tag.WorkflowEventCount(historyCount))
+
+		// refresh visibility when the warn limit is first crossed, and again each time the history has grown
+		// by the refresh step since, so that HistoryLength and HistorySizeBytes can be queried
+		if executionInfo.VisibilityHistorySize == 0 ||
+			c.executionStats.HistorySize-executionInfo.VisibilityHistorySize >= int64(c.historySizeVisibilityRefreshStep) {
+			executionInfo.VisibilityHistorySize = c.executionStats.HistorySize
+			c.mutableState.AddTransferTasks(&persistence.UpsertWorkflowSearchAttributesTask{
+				// TaskID is set by shard
+				VisibilityTimestamp: time.Now(),
+				Version:             c.mutableState.GetCurrentVersion(),
+			})
+		}
 		return false, nil
 	}
 
@@ -224,10 +248,35 @@ func (v *decisionAttrValidator) validateActivityScheduleAttributes(
 		return serviceerror.NewInvalidArgument("ActivityType is not set on decision.")
 	}
 
+	targetNamespaceEntry, err := v.namespaceCache.GetNamespaceByID(targetNamespaceID)
+	if err != nil {
+		return err
+	}
+
+	// Activities scheduled without a retry policy use the default of the namespace they run in, if any
+	if attributes.RetryPolicy == nil {
+		attributes.RetryPolicy = copyRetryPolicy(targetNamespaceEntry.GetConfig().GetDefaultActivityRetryPolicy())
+	}
+
 	if err := common.ValidateRetryPolicy(attributes.RetryPolicy); err != nil {
 		return err
 	}
 
+	if err := common.ValidateReservedHeaderFields(
+		attributes.GetHeader(),
+		v.enableReservedHeaders(targetNamespaceEntry.GetInfo().Name),
+	); err != nil {
+		return err
+	}
+
+	if err := common.ValidateTaskPriority(attributes.GetHeader()); err != nil {
+		return err
+	}
+
+	if err := common.ValidateTaskFairnessKey(attributes.GetHeader(), v.maxIDLengthLimit); err != nil {
+		return err
+	}
+
 	if len(attributes.GetActivityId()) > v.maxIDLengthLimit {
 		return serviceerror.NewInvalidArgument("ActivityID exceeds length limit.")
 	}
@@ -540,7 +589,26 @@ func (v *decisionAttrValidator) validateContinueAsNewWorkflowExecutionAttributes
 	if err != nil {
 		return err
 	}
-	return v.searchAttributesValidator.ValidateSearchAttributes(attributes.GetSearchAttributes(), namespaceEntry.GetInfo().Name)
+	namespace := namespaceEntry.GetInfo().Name
+
+	// Inherit the namespace default retry policy if not provided on decision
+	if attributes.RetryPolicy == nil {
+		attributes.RetryPolicy = copyRetryPolicy(namespaceEntry.GetConfig().GetDefaultWorkflowRetryPolicy())
+	}
+
+	if err := common.ValidateReservedHeaderFields(attributes.GetHeader(), v.enableReservedHeaders(namespace)); err != nil {
+		return err
+	}
+
+	if err := common.ValidateWorkflowRetentionDays(
+		attributes.GetHeader(),
+		v.minRetentionDays(),
+		v.maxRetentionDays(namespace),
+	); err != nil {
+		return err
+	}
+
+	return v.searchAttributesValidator.ValidateSearchAttributes(attributes.GetSearchAttributes(), namespace)
 }
 
 func (v *decisionAttrValidator) validateStartChildExecutionAttributes(
@@ -581,6 +649,16 @@ func (v *decisionAttrValidator) validateStartChildExecutionAttributes(
 		return serviceerror.NewInvalidArgument("WorkflowType exceeds length limit.")
 	}
 
+	targetNamespaceEntry, err := v.namespaceCache.GetNamespaceByID(targetNamespaceID)
+	if err != nil {
+		return err
+	}
+
+	// Inherit the default retry policy of the child namespace if not provided on decision
+	if attributes.RetryPolicy == nil {
+		attributes.RetryPolicy = copyRetryPolicy(targetNamespaceEntry.GetConfig().GetDefaultWorkflowRetryPolicy())
+	}
+
 	if err := common.ValidateRetryPolicy(attributes.RetryPolicy); err != nil {
 		return err
 	}
@@ -589,6 +667,21 @@ func (v *decisionAttrValidator) validateStartChildExecutionAttributes(
 		return err
 	}
 
+	if err := common.ValidateReservedHeaderFields(
+		attributes.GetHeader(),
+		v.enableReservedHeaders(targetNamespaceEntry.GetInfo().Name),
+	); err != nil {
+		return err
+	}
+
+	if err := common.ValidateWorkflowRetentionDays(
+		attributes.GetHeader(),
+		v.minRetentionDays(),
+		v.maxRetentionDays(targetNamespaceEntry.GetInfo().Name),
+	); err != nil {
+		return err
+	}
+
 	// Inherit taskqueue from parent workflow execution if not provided on decision
 	taskQueue, err := v.validatedTaskQueue(attributes.TaskQueue, parentInfo.TaskQueue)
 	if err != nil {
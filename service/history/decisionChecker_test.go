@@ -30,6 +30,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 	decisionpb "go.temporal.io/temporal-proto/decision/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
@@ -37,12 +38,15 @@ import (
 	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -85,6 +89,9 @@ func (s *decisionAttrValidatorSuite) SetupTest() {
 		SearchAttributesNumberOfKeysLimit: dynamicconfig.GetIntPropertyFilteredByNamespace(100),
 		SearchAttributesSizeOfValueLimit:  dynamicconfig.GetIntPropertyFilteredByNamespace(2 * 1024),
 		SearchAttributesTotalSizeLimit:    dynamicconfig.GetIntPropertyFilteredByNamespace(40 * 1024),
+		MinRetentionDays:                  dynamicconfig.GetIntPropertyFn(1),
+		MaxRetentionDays:                  dynamicconfig.GetIntPropertyFilteredByNamespace(30),
+		EnableReservedHeaderOptions:       dynamicconfig.GetBoolPropertyFnFilteredByNamespace(true),
 	}
 	s.validator = newDecisionAttrValidator(
 		s.mockNamespaceCache,
@@ -507,6 +514,110 @@ func (s *decisionAttrValidatorSuite) TestValidateCrossNamespaceCall_GlobalToGlob
 	s.Nil(err)
 }
 
+func (s *decisionAttrValidatorSuite) TestValidateStartChildExecutionAttributes_RetentionDays() {
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Name: s.testNamespaceID},
+		nil,
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil).AnyTimes()
+
+	retentionHeader := func(days int32) *commonpb.Header {
+		value, err := payload.Encode(days)
+		s.NoError(err)
+		return &commonpb.Header{Fields: map[string]*commonpb.Payload{common.WorkflowRetentionDaysHeaderKey: value}}
+	}
+	parentInfo := &persistence.WorkflowExecutionInfo{TaskQueue: "parent-task-queue"}
+	attributes := &decisionpb.StartChildWorkflowExecutionDecisionAttributes{
+		WorkflowId:   "workflow-id",
+		WorkflowType: &commonpb.WorkflowType{Name: "workflow-type"},
+	}
+
+	err := s.validator.validateStartChildExecutionAttributes(s.testNamespaceID, s.testNamespaceID, attributes, parentInfo)
+	s.NoError(err)
+
+	attributes.Header = retentionHeader(0)
+	err = s.validator.validateStartChildExecutionAttributes(s.testNamespaceID, s.testNamespaceID, attributes, parentInfo)
+	s.EqualError(err, "Workflow retention days must be between 1 and 30.")
+
+	attributes.Header = retentionHeader(31)
+	err = s.validator.validateStartChildExecutionAttributes(s.testNamespaceID, s.testNamespaceID, attributes, parentInfo)
+	s.EqualError(err, "Workflow retention days must be between 1 and 30.")
+
+	attributes.Header = retentionHeader(30)
+	err = s.validator.validateStartChildExecutionAttributes(s.testNamespaceID, s.testNamespaceID, attributes, parentInfo)
+	s.NoError(err)
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateStartChildExecutionAttributes_ReservedHeader() {
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Name: s.testNamespaceID},
+		nil,
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil).AnyTimes()
+
+	value, err := payload.Encode(int32(10))
+	s.NoError(err)
+	parentInfo := &persistence.WorkflowExecutionInfo{TaskQueue: "parent-task-queue"}
+	attributes := &decisionpb.StartChildWorkflowExecutionDecisionAttributes{
+		WorkflowId:   "workflow-id",
+		WorkflowType: &commonpb.WorkflowType{Name: "workflow-type"},
+		Header:       &commonpb.Header{Fields: map[string]*commonpb.Payload{common.WorkflowRetentionDaysHeaderKey: value}},
+	}
+
+	err = s.validator.validateStartChildExecutionAttributes(s.testNamespaceID, s.testNamespaceID, attributes, parentInfo)
+	s.NoError(err)
+
+	attributes.Header.Fields[common.ReservedHeaderKeyPrefix+"unknown"] = value
+	err = s.validator.validateStartChildExecutionAttributes(s.testNamespaceID, s.testNamespaceID, attributes, parentInfo)
+	s.EqualError(err, "Unknown reserved header key _temporal_unknown.")
+
+	delete(attributes.Header.Fields, common.ReservedHeaderKeyPrefix+"unknown")
+	s.validator.enableReservedHeaders = dynamicconfig.GetBoolPropertyFnFilteredByNamespace(false)
+	err = s.validator.validateStartChildExecutionAttributes(s.testNamespaceID, s.testNamespaceID, attributes, parentInfo)
+	s.EqualError(err, "Header key _temporal_workflow_retention_days is reserved by system.")
+}
+
+func (s *decisionAttrValidatorSuite) TestValidateActivityScheduleAttributes_DefaultRetryPolicy() {
+	defaultRetryPolicy := &commonpb.RetryPolicy{
+		InitialIntervalInSeconds: 1,
+		BackoffCoefficient:       2,
+		MaximumAttempts:          5,
+		NonRetryableErrorTypes:   []string{"NonRetryableError"},
+	}
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Name: s.testNamespaceID},
+		&persistenceblobs.NamespaceConfig{DefaultActivityRetryPolicy: defaultRetryPolicy},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(s.testNamespaceID).Return(namespaceEntry, nil).AnyTimes()
+
+	newAttributes := func() *decisionpb.ScheduleActivityTaskDecisionAttributes {
+		return &decisionpb.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:                    "activity-id",
+			ActivityType:                  &commonpb.ActivityType{Name: "activity-type"},
+			TaskQueue:                     &taskqueuepb.TaskQueue{Name: "task-queue"},
+			ScheduleToCloseTimeoutSeconds: 10,
+		}
+	}
+
+	attributes := newAttributes()
+	err := s.validator.validateActivityScheduleAttributes(s.testNamespaceID, s.testNamespaceID, attributes, 100)
+	s.NoError(err)
+	s.Equal(defaultRetryPolicy, attributes.RetryPolicy)
+	s.False(defaultRetryPolicy == attributes.RetryPolicy)
+
+	attributes = newAttributes()
+	attributes.RetryPolicy = &commonpb.RetryPolicy{InitialIntervalInSeconds: 2, BackoffCoefficient: 1}
+	err = s.validator.validateActivityScheduleAttributes(s.testNamespaceID, s.testNamespaceID, attributes, 100)
+	s.NoError(err)
+	s.Equal(&commonpb.RetryPolicy{InitialIntervalInSeconds: 2, BackoffCoefficient: 1}, attributes.RetryPolicy)
+}
+
 func (s *decisionAttrValidatorSuite) TestValidateTaskQueueName() {
 	taskQueue := func(name string) *taskqueuepb.TaskQueue {
 		return &taskqueuepb.TaskQueue{Name: name, Kind: enumspb.TASK_QUEUE_KIND_NORMAL}
@@ -549,3 +660,47 @@ func (s *decisionAttrValidatorSuite) TestValidateTaskQueueName() {
 		})
 	}
 }
+
+func (s *decisionAttrValidatorSuite) TestWorkflowSizeChecker_WarnLimitRefreshesVisibility() {
+	mockMutableState := NewMockmutableState(s.controller)
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		NamespaceID: s.testNamespaceID,
+		WorkflowID:  "workflowID",
+		RunID:       "runID",
+	}
+	executionStats := &persistence.ExecutionStats{HistorySize: 100}
+	mockMutableState.EXPECT().GetExecutionInfo().Return(executionInfo).AnyTimes()
+	mockMutableState.EXPECT().GetNextEventID().Return(int64(11)).AnyTimes()
+	mockMutableState.EXPECT().GetCurrentVersion().Return(int64(1)).Times(2)
+	mockMutableState.EXPECT().AddTransferTasks(gomock.Any()).Times(2)
+
+	checker := newWorkflowSizeChecker(
+		100, 200,
+		1000, 2000,
+		5, 20,
+		500,
+		10,
+		mockMutableState,
+		executionStats,
+		metrics.NewClient(tally.NoopScope, metrics.History).Scope(metrics.HistoryRespondDecisionTaskCompletedScope),
+		log.NewNoop(),
+	)
+
+	failed, err := checker.failWorkflowSizeExceedsLimit()
+	s.NoError(err)
+	s.False(failed)
+	s.Equal(int64(100), executionInfo.VisibilityHistorySize)
+
+	// the history has not grown by the refresh step, no visibility refresh
+	executionStats.HistorySize = 599
+	failed, err = checker.failWorkflowSizeExceedsLimit()
+	s.NoError(err)
+	s.False(failed)
+	s.Equal(int64(100), executionInfo.VisibilityHistorySize)
+
+	executionStats.HistorySize = 600
+	failed, err = checker.failWorkflowSizeExceedsLimit()
+	s.NoError(err)
+	s.False(failed)
+	s.Equal(int64(600), executionInfo.VisibilityHistorySize)
+}
@@ -203,6 +203,7 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 					if err != nil {
 						return nil, err
 					}
+					handler.suggestContinueAsNew(resp, namespaceEntry.GetInfo().Name, context.getHistorySize())
 					updateAction.noop = true
 					return updateAction, nil
 				}
@@ -222,6 +223,7 @@ func (handler *decisionHandlerImpl) handleDecisionTaskStarted(
 			if err != nil {
 				return nil, err
 			}
+			handler.suggestContinueAsNew(resp, namespaceEntry.GetInfo().Name, context.getHistorySize())
 			return updateAction, nil
 		})
 
@@ -409,6 +411,7 @@ Update_History_Loop:
 				handler.config.HistorySizeLimitError(namespace),
 				handler.config.HistoryCountLimitWarn(namespace),
 				handler.config.HistoryCountLimitError(namespace),
+				handler.config.HistorySizeVisibilityRefreshStep(namespace),
 				completedEvent.GetEventId(),
 				msBuilder,
 				executionStats,
@@ -569,6 +572,7 @@ Update_History_Loop:
 			if err != nil {
 				return nil, err
 			}
+			handler.suggestContinueAsNew(resp.StartedResponse, namespaceEntry.GetInfo().Name, weContext.getHistorySize())
 			// sticky is always enabled when worker request for new decision task from RespondDecisionTaskCompleted
 			resp.StartedResponse.StickyExecutionEnabled = true
 		}
@@ -635,6 +639,20 @@ func (handler *decisionHandlerImpl) createRecordDecisionTaskStartedResponse(
 	return response, nil
 }
 
+// suggestContinueAsNew lets the worker know that the workflow history has grown past the warn limits,
+// so that the workflow can continue as new before it is failed for exceeding the error limits.
+func (handler *decisionHandlerImpl) suggestContinueAsNew(
+	response *historyservice.RecordDecisionTaskStartedResponse,
+	namespace string,
+	historySize int64,
+) {
+
+	historyCount := response.GetNextEventId() - common.FirstEventID
+	response.HistorySizeBytes = historySize
+	response.SuggestContinueAsNew = historySize > int64(handler.config.HistorySizeLimitWarn(namespace)) ||
+		historyCount > int64(handler.config.HistoryCountLimitWarn(namespace))
+}
+
 func (handler *decisionHandlerImpl) handleBufferedQueries(msBuilder mutableState, queryResults map[string]*querypb.WorkflowQueryResult, createNewDecisionTask bool, namespaceEntry *cache.NamespaceCacheEntry, decisionHeartbeating bool) {
 	queryRegistry := msBuilder.GetQueryRegistry()
 	if !queryRegistry.hasBufferedQuery() {
@@ -63,6 +63,7 @@ type (
 			runID string,
 			eventID int64,
 		)
+		DetachSizeBudget()
 	}
 
 	eventsCacheImpl struct {
@@ -91,26 +92,45 @@ var _ eventsCache = (*eventsCacheImpl)(nil)
 func newEventsCache(shardCtx ShardContext) eventsCache {
 	config := shardCtx.GetConfig()
 	shardID := convert.IntPtr(shardCtx.GetShardID())
-	return newEventsCacheWithOptions(config.EventsCacheInitialSize(), config.EventsCacheMaxSize(), config.EventsCacheTTL(),
+	maxSize := config.EventsCacheMaxSize()
+	var itemSizeFunc cache.ItemSizeFunc
+	budget := shardCtx.GetEventsCacheSizeBudget()
+	if budget != nil {
+		// a single shard may use the whole budget of the host, as long as the other shards leave room for it
+		maxSize = config.EventsCacheMaxSizeInBytes()
+		itemSizeFunc = eventSize
+	}
+	return newEventsCacheWithOptions(config.EventsCacheInitialSize(), maxSize, itemSizeFunc, budget, config.EventsCacheTTL(),
 		shardCtx.GetHistoryManager(), false, shardCtx.GetLogger(), shardCtx.GetMetricsClient(), shardID)
 }
 
-func newEventsCacheWithOptions(initialSize, maxSize int, ttl time.Duration,
-	eventsV2Mgr persistence.HistoryManager, disabled bool, logger log.Logger, metrics metrics.Client, shardID *int) *eventsCacheImpl {
+func newEventsCacheWithOptions(initialSize, maxSize int, itemSizeFunc cache.ItemSizeFunc, budget *cache.SizeBudget,
+	ttl time.Duration, eventsV2Mgr persistence.HistoryManager, disabled bool, logger log.Logger, metricsClient metrics.Client,
+	shardID *int) *eventsCacheImpl {
 	opts := &cache.Options{}
 	opts.InitialCapacity = initialSize
 	opts.TTL = ttl
+	opts.ItemSizeFunc = itemSizeFunc
+	opts.SizeBudget = budget
+	opts.EvictedFunc = func(_ interface{}, _ int) {
+		metricsClient.IncCounter(metrics.EventsCachePutEventScope, metrics.CacheEvictionCounter)
+	}
 
 	return &eventsCacheImpl{
 		Cache:         cache.New(maxSize, opts),
 		eventsV2Mgr:   eventsV2Mgr,
 		disabled:      disabled,
 		logger:        logger.WithTags(tag.ComponentEventsCache),
-		metricsClient: metrics,
+		metricsClient: metricsClient,
 		shardID:       shardID,
 	}
 }
 
+// eventSize measures the events stored in the cache by their encoded size
+func eventSize(value interface{}) int {
+	return value.(*historypb.HistoryEvent).Size()
+}
+
 func newEventKey(namespaceID, workflowID, runID string, eventID int64) eventKey {
 	return eventKey{
 		namespaceID: namespaceID,
@@ -149,6 +169,7 @@ func (e *eventsCacheImpl) getEvent(namespaceID, workflowID, runID string, firstE
 	}
 
 	e.Put(key, event)
+	e.metricsClient.RecordTimer(metrics.EventsCacheGetEventScope, metrics.CacheSize, time.Duration(e.TotalSize()))
 	return event, nil
 }
 
@@ -159,6 +180,7 @@ func (e *eventsCacheImpl) putEvent(namespaceID, workflowID, runID string, eventI
 
 	key := newEventKey(namespaceID, workflowID, runID, eventID)
 	e.Put(key, event)
+	e.metricsClient.RecordTimer(metrics.EventsCachePutEventScope, metrics.CacheSize, time.Duration(e.TotalSize()))
 }
 
 func (e *eventsCacheImpl) deleteEvent(namespaceID, workflowID, runID string, eventID int64) {
@@ -95,3 +95,15 @@ func (mr *MockeventsCacheMockRecorder) deleteEvent(namespaceID, workflowID, runI
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "deleteEvent", reflect.TypeOf((*MockeventsCache)(nil).deleteEvent), namespaceID, workflowID, runID, eventID)
 }
+
+// DetachSizeBudget mocks base method.
+func (m *MockeventsCache) DetachSizeBudget() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DetachSizeBudget")
+}
+
+// DetachSizeBudget indicates an expected call of DetachSizeBudget.
+func (mr *MockeventsCacheMockRecorder) DetachSizeBudget() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachSizeBudget", reflect.TypeOf((*MockeventsCache)(nil).DetachSizeBudget))
+}
@@ -725,6 +725,21 @@ func (h *Handler) CloseShard(_ context.Context, request *historyservice.CloseSha
 	return &historyservice.CloseShardResponse{}, nil
 }
 
+// AcquireShard acquires a shard handed off to this instance by its previous owner
+func (h *Handler) AcquireShard(_ context.Context, request *historyservice.AcquireShardRequest) (_ *historyservice.AcquireShardResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	if _, err := h.controller.getEngineForShard(int(request.GetShardId())); err != nil {
+		if _, ok := err.(*serviceerror.ShardOwnershipLost); ok {
+			// the membership ring of this host does not reflect the handoff yet, let the previous owner retry
+			return nil, serviceerror.NewUnavailable(err.Error())
+		}
+		return nil, h.convertError(err)
+	}
+	return &historyservice.AcquireShardResponse{}, nil
+}
+
 // DescribeMutableState - returns the internal analysis of workflow execution state
 func (h *Handler) DescribeMutableState(ctx context.Context, request *historyservice.DescribeMutableStateRequest) (_ *historyservice.DescribeMutableStateResponse, retError error) {
 	defer log.CapturePanic(h.GetLogger(), &retError)
@@ -1769,6 +1784,39 @@ func (h *Handler) RefreshWorkflowTasks(ctx context.Context, request *historyserv
 	return &historyservice.RefreshWorkflowTasksResponse{}, nil
 }
 
+// UpdateWorkflowExecutionMetadata upserts memo and search attributes of a workflow execution from outside the workflow
+func (h *Handler) UpdateWorkflowExecutionMetadata(ctx context.Context, request *historyservice.UpdateWorkflowExecutionMetadataRequest) (_ *historyservice.UpdateWorkflowExecutionMetadataResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUpdateWorkflowExecutionMetadataScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return nil, h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	workflowID := request.GetExecution().GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		return nil, h.error(err, scope, namespaceID, workflowID)
+	}
+
+	if err := engine.UpdateWorkflowExecutionMetadata(ctx, request); err != nil {
+		return nil, h.error(err, scope, namespaceID, workflowID)
+	}
+
+	return &historyservice.UpdateWorkflowExecutionMetadataResponse{}, nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
@@ -27,6 +27,7 @@ package history
 import (
 	"context"
 	"sync/atomic"
+	"time"
 
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
@@ -61,21 +62,39 @@ var noopReleaseFn releaseWorkflowExecutionFunc = func(err error) {}
 const (
 	cacheNotReleased int32 = 0
 	cacheReleased    int32 = 1
+
+	// workflowExecutionContextOverhead approximates the memory used by a cached workflow execution context
+	// in addition to its mutable state, so that contexts which are not loaded yet are accounted for as well
+	workflowExecutionContextOverhead = 1024
 )
 
 func newHistoryCache(shard ShardContext) *historyCache {
 	opts := &cache.Options{}
 	config := shard.GetConfig()
+	metricsClient := shard.GetMetricsClient()
 	opts.InitialCapacity = config.HistoryCacheInitialSize()
 	opts.TTL = config.HistoryCacheTTL()
 	opts.Pin = true
+	opts.EvictedFunc = func(_ interface{}, _ int) {
+		metricsClient.IncCounter(metrics.HistoryCacheGetOrCreateScope, metrics.CacheEvictionCounter)
+	}
+
+	maxSize := config.HistoryCacheMaxSize()
+	if budget := shard.GetHistoryCacheSizeBudget(); budget != nil {
+		// a single shard may use the whole budget of the host, as long as the other shards leave room for it
+		maxSize = config.HistoryCacheMaxSizeInBytes()
+		opts.SizeBudget = budget
+		opts.ItemSizeFunc = func(value interface{}) int {
+			return workflowExecutionContextOverhead + int(value.(workflowExecutionContext).getMutableStateSize())
+		}
+	}
 
 	return &historyCache{
-		Cache:            cache.New(config.HistoryCacheMaxSize(), opts),
+		Cache:            cache.New(maxSize, opts),
 		shard:            shard,
 		executionManager: shard.GetExecutionManager(),
 		logger:           shard.GetLogger().WithTags(tag.ComponentHistoryCache),
-		metricsClient:    shard.GetMetricsClient(),
+		metricsClient:    metricsClient,
 		config:           config,
 	}
 }
@@ -206,6 +225,7 @@ func (c *historyCache) getOrCreateWorkflowExecutionInternal(
 			return nil, nil, err
 		}
 		workflowCtx = elem.(workflowExecutionContext)
+		c.metricsClient.RecordTimer(scope, metrics.CacheSize, time.Duration(c.TotalSize()))
 	}
 
 	// TODO This will create a closure on every request.
@@ -36,6 +36,7 @@ import (
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
@@ -150,6 +151,42 @@ func (s *historyCacheSuite) TestHistoryCachePinning() {
 	release(err4)
 }
 
+func (s *historyCacheSuite) TestHistoryCacheMaxSizeInBytes() {
+	s.mockShard.GetConfig().HistoryCacheMaxSizeInBytes = dynamicconfig.GetIntPropertyFn(2 * workflowExecutionContextOverhead)
+	budget := cache.NewSizeBudget(2 * workflowExecutionContextOverhead)
+	s.mockShard.historyCacheSizeBudget = budget
+	namespaceID := "test_namespace_id"
+	s.cache = newHistoryCache(s.mockShard)
+	we1 := commonpb.WorkflowExecution{WorkflowId: "wf-cache-test-size", RunId: uuid.New()}
+	we2 := commonpb.WorkflowExecution{WorkflowId: "wf-cache-test-size", RunId: uuid.New()}
+	we3 := commonpb.WorkflowExecution{WorkflowId: "wf-cache-test-size", RunId: uuid.New()}
+
+	_, release1, err := s.cache.getOrCreateWorkflowExecutionForBackground(namespaceID, we1)
+	s.Nil(err)
+	_, release2, err := s.cache.getOrCreateWorkflowExecutionForBackground(namespaceID, we2)
+	s.Nil(err)
+	s.Equal(2*workflowExecutionContextOverhead, s.cache.TotalSize())
+
+	// Both contexts are pinned, so there is no room left for a third one
+	_, _, err = s.cache.getOrCreateWorkflowExecutionForBackground(namespaceID, we3)
+	s.NotNil(err)
+
+	release1(nil)
+	release2(nil)
+
+	// Once released the least recently used context is evicted to make room
+	_, release3, err := s.cache.getOrCreateWorkflowExecutionForBackground(namespaceID, we3)
+	s.Nil(err)
+	release3(nil)
+	s.Equal(2, s.cache.Size())
+	s.Equal(2*workflowExecutionContextOverhead, s.cache.TotalSize())
+	s.Equal(2*workflowExecutionContextOverhead, budget.TotalSize())
+
+	// The size is given back to the budget shared with the other shards when the cache is discarded
+	s.cache.DetachSizeBudget()
+	s.Equal(0, budget.TotalSize())
+}
+
 func (s *historyCacheSuite) TestHistoryCacheClear() {
 	s.mockShard.GetConfig().HistoryCacheMaxSize = dynamicconfig.GetIntPropertyFn(20)
 	namespaceID := "test_namespace_id"
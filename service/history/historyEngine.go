@@ -40,6 +40,7 @@ import (
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	failurepb "go.temporal.io/temporal-proto/failure/v1"
 	historypb "go.temporal.io/temporal-proto/history/v1"
+	namespacepb "go.temporal.io/temporal-proto/namespace/v1"
 	querypb "go.temporal.io/temporal-proto/query/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
 	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
@@ -65,6 +66,7 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/xdc"
@@ -120,6 +122,7 @@ type (
 		PurgeDLQMessages(ctx context.Context, messagesRequest *historyservice.PurgeDLQMessagesRequest) error
 		MergeDLQMessages(ctx context.Context, messagesRequest *historyservice.MergeDLQMessagesRequest) (*historyservice.MergeDLQMessagesResponse, error)
 		RefreshWorkflowTasks(ctx context.Context, namespaceUUID string, execution commonpb.WorkflowExecution) error
+		UpdateWorkflowExecutionMetadata(ctx context.Context, request *historyservice.UpdateWorkflowExecutionMetadataRequest) error
 
 		NotifyNewHistoryEvent(event *historyEventNotification)
 		NotifyNewTransferTasks(tasks []persistence.Task)
@@ -160,6 +163,16 @@ type (
 		rawMatchingClient         matching.Client
 		versionChecker            headers.VersionChecker
 		replicationDLQHandler     replicationDLQHandler
+		taskDLQHandler            taskDLQHandler
+	}
+
+	// resetBaseRun is what resolving a reset point needs to know about a run
+	resetBaseRun struct {
+		runID           string
+		branchToken     []byte
+		nextEventID     int64
+		continuedRunID  string
+		autoResetPoints *workflowpb.ResetPoints
 	}
 )
 
@@ -351,6 +364,7 @@ func NewEngineWithShardContext(
 	historyEngImpl.replicationTaskProcessors = replicationTaskProcessors
 	replicationMessageHandler := newReplicationDLQHandler(shard, replicationTaskExecutor)
 	historyEngImpl.replicationDLQHandler = replicationMessageHandler
+	historyEngImpl.taskDLQHandler = newTaskDLQHandler(shard, historyEngImpl.txProcessor, historyEngImpl.timerProcessor)
 
 	shard.SetEngine(historyEngImpl)
 	return historyEngImpl
@@ -401,6 +415,10 @@ func (e *historyEngineImpl) Stop() {
 
 	// unset the failover callback
 	e.shard.GetNamespaceCache().UnregisterNamespaceChangeCallback(e.shard.GetShardID())
+
+	// the caches of the shard are discarded, leave their room to the other shards of the host
+	e.historyCache.DetachSizeBudget()
+	e.shard.GetEventsCache().DetachSizeBudget()
 }
 
 func (e *historyEngineImpl) registerNamespaceFailoverCallback() {
@@ -548,14 +566,12 @@ func (e *historyEngineImpl) generateFirstDecisionTask(
 func (e *historyEngineImpl) StartWorkflowExecution(
 	ctx context.Context,
 	startRequest *historyservice.StartWorkflowExecutionRequest,
-) (resp *historyservice.StartWorkflowExecutionResponse, retError error) {
+) (*historyservice.StartWorkflowExecutionResponse, error) {
 
 	namespaceEntry, err := e.getActiveNamespaceEntry(startRequest.GetNamespaceId())
 	if err != nil {
 		return nil, err
 	}
-	namespaceID := namespaceEntry.GetInfo().Id
-
 	request := startRequest.StartRequest
 	err = validateStartWorkflowExecutionRequest(request, e.config.MaxIDLengthLimit())
 	if err != nil {
@@ -563,6 +579,42 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 	}
 	e.overrideStartWorkflowExecutionRequest(namespaceEntry, request, metrics.HistoryStartWorkflowExecutionScope)
 
+	if !common.IsWorkflowIDReusePolicyTerminateIfRunning(request.GetHeader()) {
+		return e.startWorkflowExecution(ctx, namespaceEntry, startRequest, request.GetWorkflowIdReusePolicy())
+	}
+
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		runID, err := e.terminateIfRunningAndStartWorkflowExecution(ctx, namespaceEntry, startRequest)
+		if err != nil {
+			return nil, err
+		}
+		if runID != "" {
+			return &historyservice.StartWorkflowExecutionResponse{
+				RunId: runID,
+			}, nil
+		}
+		// no running current run, closed runs are handled as allowed duplicates
+		resp, err := e.startWorkflowExecution(ctx, namespaceEntry, startRequest, enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE)
+		if _, ok := err.(*serviceerror.WorkflowExecutionAlreadyStarted); ok {
+			// a concurrent start request created a running run after the current run was checked, terminate it
+			continue
+		}
+		return resp, err
+	}
+	return nil, ErrMaxAttemptsExceeded
+}
+
+// startWorkflowExecution starts a new run of the workflow, applying the given workflow id reuse policy
+// if the workflow already has a current run
+func (e *historyEngineImpl) startWorkflowExecution(
+	ctx context.Context,
+	namespaceEntry *cache.NamespaceCacheEntry,
+	startRequest *historyservice.StartWorkflowExecutionRequest,
+	workflowIDReusePolicy enumspb.WorkflowIdReusePolicy,
+) (resp *historyservice.StartWorkflowExecutionResponse, retError error) {
+
+	namespaceID := namespaceEntry.GetInfo().Id
+	request := startRequest.StartRequest
 	workflowID := request.GetWorkflowId()
 	// grab the current context as a lock, nothing more
 	_, currentRelease, err := e.historyCache.getOrCreateCurrentWorkflowExecution(
@@ -653,7 +705,7 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 				t.Status,
 				namespaceID,
 				execution,
-				startRequest.StartRequest.GetWorkflowIdReusePolicy(),
+				workflowIDReusePolicy,
 			); err != nil {
 				return nil, err
 			}
@@ -1027,6 +1079,7 @@ func (e *historyEngineImpl) queryDirectlyThroughMatching(
 		NamespaceId:  namespaceID,
 		QueryRequest: queryRequest,
 		TaskQueue:    msResp.TaskQueue,
+		BuildId:      msResp.GetWorkerBuildId(),
 	}
 
 	nonStickyStopWatch := scope.StartTimer(metrics.DirectQueryDispatchNonStickyLatency)
@@ -1091,6 +1144,7 @@ func (e *historyEngineImpl) getMutableState(
 		WorkflowState:                         workflowState,
 		WorkflowStatus:                        workflowStatus,
 		IsStickyTaskQueueEnabled:              mutableState.IsStickyTaskQueueEnabled(),
+		WorkerBuildId:                         getWorkerBuildID(executionInfo),
 	}
 	replicationState := mutableState.GetReplicationState()
 	if replicationState != nil {
@@ -1241,8 +1295,21 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 			SearchAttributes: &commonpb.SearchAttributes{IndexedFields: executionInfo.SearchAttributes},
 			Status:           executionInfo.Status,
 		},
+		HistorySizeBytes: context.getHistorySize(),
 	}
 
+	// history size has no field in the public API, surface it as a system search attribute
+	historySizePayload, err := payload.Encode(result.HistorySizeBytes)
+	if err != nil {
+		return nil, err
+	}
+	searchAttributes := make(map[string]*commonpb.Payload, len(executionInfo.SearchAttributes)+1)
+	for key, value := range executionInfo.SearchAttributes {
+		searchAttributes[key] = value
+	}
+	searchAttributes[definition.HistorySizeBytes] = historySizePayload
+	result.WorkflowExecutionInfo.SearchAttributes.IndexedFields = searchAttributes
+
 	// TODO: we need to consider adding execution time to mutable state
 	// For now execution time will be calculated based on start time and cron schedule/retry policy
 	// each time DescribeWorkflowExecution is called.
@@ -1937,6 +2004,23 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 				break
 			}
 
+			if common.IsWorkflowIDReusePolicyTerminateIfRunning(sRequest.GetHeader()) {
+				startRequest := getStartRequest(namespaceID, sRequest)
+				if err := validateStartWorkflowExecutionRequest(startRequest.StartRequest, e.config.MaxIDLengthLimit()); err != nil {
+					return nil, err
+				}
+				e.overrideStartWorkflowExecutionRequest(namespaceEntry, startRequest.StartRequest, metrics.HistorySignalWorkflowExecutionScope)
+
+				runID, err := e.terminateAndStartWorkflowExecution(namespaceEntry, context, mutableState, startRequest, sRequest)
+				if err != nil {
+					if err == ErrConflict {
+						continue Just_Signal_Loop
+					}
+					return nil, err
+				}
+				return &historyservice.SignalWithStartWorkflowExecutionResponse{RunId: runID}, nil
+			}
+
 			executionInfo := mutableState.GetExecutionInfo()
 			maxAllowedSignals := e.config.MaximumSignalsPerExecution(namespaceEntry.GetInfo().Name)
 			if maxAllowedSignals > 0 && int(executionInfo.SignalCount) >= maxAllowedSignals {
@@ -2027,7 +2111,12 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 			)
 		}
 
-		err = e.applyWorkflowIDReusePolicyForSigWithStart(prevMutableState.GetExecutionInfo(), namespaceID, execution, request.WorkflowIdReusePolicy)
+		workflowIDReusePolicy := request.WorkflowIdReusePolicy
+		if common.IsWorkflowIDReusePolicyTerminateIfRunning(request.GetHeader()) {
+			// closed runs are handled as allowed duplicates
+			workflowIDReusePolicy = enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE
+		}
+		err = e.applyWorkflowIDReusePolicyForSigWithStart(prevMutableState.GetExecutionInfo(), namespaceID, execution, workflowIDReusePolicy)
 		if err != nil {
 			return nil, err
 		}
@@ -2107,6 +2196,138 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 	}, nil
 }
 
+// terminateIfRunningAndStartWorkflowExecution handles the TerminateIfRunning workflow id reuse policy of a start request,
+// if the current run of the workflow is running, it is terminated and the new run is started in a single transaction.
+// An empty run id is returned if there is no running current run.
+func (e *historyEngineImpl) terminateIfRunningAndStartWorkflowExecution(
+	ctx context.Context,
+	namespaceEntry *cache.NamespaceCacheEntry,
+	startRequest *historyservice.StartWorkflowExecutionRequest,
+) (retRunID string, retError error) {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: startRequest.StartRequest.GetWorkflowId(),
+	}
+	currentContext, currentRelease, err := e.historyCache.getOrCreateWorkflowExecution(
+		ctx,
+		namespaceEntry.GetInfo().Id,
+		execution,
+	)
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			return "", nil
+		}
+		return "", err
+	}
+	defer func() { currentRelease(retError) }()
+
+	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
+		currentMutableState, err := currentContext.loadWorkflowExecution()
+		if err != nil {
+			if _, ok := err.(*serviceerror.NotFound); ok {
+				return "", nil
+			}
+			return "", err
+		}
+		if !currentMutableState.IsWorkflowExecutionRunning() {
+			return "", nil
+		}
+
+		runID, err := e.terminateAndStartWorkflowExecution(namespaceEntry, currentContext, currentMutableState, startRequest, nil)
+		if err == ErrConflict {
+			continue
+		}
+		return runID, err
+	}
+	return "", ErrMaxAttemptsExceeded
+}
+
+// terminateAndStartWorkflowExecution terminates the running current run of the workflow and starts a new run,
+// signaled if a signal with start request is given, in a single transaction of the shard
+func (e *historyEngineImpl) terminateAndStartWorkflowExecution(
+	namespaceEntry *cache.NamespaceCacheEntry,
+	currentContext workflowExecutionContext,
+	currentMutableState mutableState,
+	startRequest *historyservice.StartWorkflowExecutionRequest,
+	signalWithStartRequest *workflowservice.SignalWithStartWorkflowExecutionRequest,
+) (string, error) {
+
+	namespaceID := namespaceEntry.GetInfo().Id
+	request := startRequest.StartRequest
+	currentExecutionInfo := currentMutableState.GetExecutionInfo()
+	if currentExecutionInfo.CreateRequestID == request.GetRequestId() {
+		// the running run was started by a previous attempt of this request
+		return currentExecutionInfo.RunID, nil
+	}
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: request.GetWorkflowId(),
+		RunId:      uuid.New(),
+	}
+	clusterMetadata := e.shard.GetService().GetClusterMetadata()
+	mutableState, err := e.createMutableState(clusterMetadata, namespaceEntry, execution.GetRunId())
+	if err != nil {
+		return "", err
+	}
+
+	currentLastWriteVersion, err := currentMutableState.GetLastWriteVersion()
+	if err != nil {
+		return "", err
+	}
+	if currentLastWriteVersion > mutableState.GetCurrentVersion() {
+		return "", serviceerror.NewNamespaceNotActive(
+			namespaceEntry.GetInfo().Name,
+			clusterMetadata.GetCurrentClusterName(),
+			clusterMetadata.ClusterNameForFailoverVersion(currentLastWriteVersion),
+		)
+	}
+
+	startEvent, err := mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		startRequest,
+	)
+	if err != nil {
+		return "", serviceerror.NewInternal("Failed to add workflow execution started event.")
+	}
+
+	if signalWithStartRequest != nil {
+		if _, err := mutableState.AddWorkflowExecutionSignaled(
+			signalWithStartRequest.GetSignalName(),
+			signalWithStartRequest.GetSignalInput(),
+			signalWithStartRequest.GetIdentity()); err != nil {
+			return "", serviceerror.NewInternal("Failed to add workflow execution signaled event.")
+		}
+	}
+
+	if err := e.generateFirstDecisionTask(
+		mutableState,
+		startRequest.ParentExecutionInfo,
+		startEvent,
+	); err != nil {
+		return "", err
+	}
+
+	if err := terminateWorkflow(
+		currentMutableState,
+		currentMutableState.GetNextEventID(),
+		fmt.Sprintf("Terminated by new run %v due to %v workflow id reuse policy.", execution.GetRunId(), common.WorkflowIDReusePolicyTerminateIfRunning),
+		nil,
+		request.GetIdentity(),
+	); err != nil {
+		return "", err
+	}
+
+	newContext := newWorkflowExecutionContext(namespaceID, execution, e.shard, e.executionManager, e.logger)
+	if err := currentContext.updateWorkflowExecutionWithNewAsActive(
+		e.timeSource.Now(),
+		newContext,
+		mutableState,
+	); err != nil {
+		return "", err
+	}
+	return execution.GetRunId(), nil
+}
+
 // RemoveSignalMutableState remove the signal request id in signal_requested for deduplicate
 func (e *historyEngineImpl) RemoveSignalMutableState(
 	ctx context.Context,
@@ -2288,6 +2509,28 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	workflowID := request.WorkflowExecution.GetWorkflowId()
 	baseRunID := request.WorkflowExecution.GetRunId()
 
+	if resetRequest.GetResetType() != enumsgenpb.RESET_TYPE_UNSPECIFIED {
+		resolvedRunID, decisionFinishEventID, err := e.resolveResetPoint(
+			ctx,
+			namespaceID,
+			*request.WorkflowExecution,
+			resetRequest.GetResetType(),
+			resetRequest.GetResetBadBinaryChecksum(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		// the legacy resetor reads the reset point from the request, so resolve it in a copy
+		resolvedRequest := *request
+		resolvedRequest.WorkflowExecution = &commonpb.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      resolvedRunID,
+		}
+		resolvedRequest.DecisionFinishEventId = decisionFinishEventID
+		request = &resolvedRequest
+		baseRunID = resolvedRunID
+	}
+
 	baseContext, baseReleaseFn, err := e.historyCache.getOrCreateWorkflowExecution(
 		ctx,
 		namespaceID,
@@ -2359,6 +2602,9 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 
 	// TODO when NDC is rolled out, remove this block
 	if baseMutableState.GetVersionHistories() == nil {
+		if resetRequest.GetDryRun() || len(resetRequest.GetExcludeSignalNames()) > 0 {
+			return nil, serviceerror.NewInvalidArgument("Dry run and signal exclusion are not supported for workflows without version histories.")
+		}
 		return e.resetor.ResetWorkflowExecution(
 			ctx,
 			request,
@@ -2383,7 +2629,15 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	baseCurrentBranchToken := baseCurrentVersionHistory.GetBranchToken()
 	baseNextEventID := baseMutableState.GetNextEventID()
 
-	if err := e.workflowResetter.resetWorkflow(
+	options := resetWorkflowOptions{dryRun: resetRequest.GetDryRun()}
+	if len(resetRequest.GetExcludeSignalNames()) > 0 {
+		options.excludeSignalNames = make(map[string]struct{}, len(resetRequest.GetExcludeSignalNames()))
+		for _, signalName := range resetRequest.GetExcludeSignalNames() {
+			options.excludeSignalNames[signalName] = struct{}{}
+		}
+	}
+
+	historyPreview, err := e.workflowResetter.resetWorkflowWithOptions(
 		ctx,
 		namespaceID,
 		workflowID,
@@ -2404,14 +2658,157 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 		),
 		request.GetReason(),
 		nil,
-	); err != nil {
+		options,
+	)
+	if err != nil {
 		return nil, err
 	}
+	if options.dryRun {
+		resetRunID = ""
+	}
 	return &historyservice.ResetWorkflowExecutionResponse{
-		RunId: resetRunID,
+		RunId:                 resetRunID,
+		BaseRunId:             baseMutableState.GetExecutionInfo().RunID,
+		DecisionFinishEventId: request.GetDecisionFinishEventId(),
+		HistoryPreview:        historyPreview,
 	}, nil
 }
 
+// resolveResetPoint returns the run and the decision finish event ID a reset of the given type goes back to
+func (e *historyEngineImpl) resolveResetPoint(
+	ctx context.Context,
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+	resetType enumsgenpb.ResetType,
+	badBinaryChecksum string,
+) (string, int64, error) {
+
+	run, err := e.loadResetBaseRun(ctx, namespaceID, execution)
+	if err != nil {
+		return "", 0, err
+	}
+
+	switch resetType {
+	case enumsgenpb.RESET_TYPE_FIRST_DECISION_COMPLETED:
+		decisionFinishEventID, err := e.findDecisionCompletedEventID(run, true)
+		return run.runID, decisionFinishEventID, err
+	case enumsgenpb.RESET_TYPE_LAST_DECISION_COMPLETED:
+		decisionFinishEventID, err := e.findDecisionCompletedEventID(run, false)
+		return run.runID, decisionFinishEventID, err
+	case enumsgenpb.RESET_TYPE_LAST_CONTINUED_AS_NEW:
+		if run.continuedRunID == "" {
+			return "", 0, serviceerror.NewInvalidArgument("Workflow run is not continued from another run.")
+		}
+		previousRun, err := e.loadResetBaseRun(ctx, namespaceID, commonpb.WorkflowExecution{
+			WorkflowId: execution.GetWorkflowId(),
+			RunId:      run.continuedRunID,
+		})
+		if err != nil {
+			return "", 0, err
+		}
+		decisionFinishEventID, err := e.findDecisionCompletedEventID(previousRun, false)
+		return previousRun.runID, decisionFinishEventID, err
+	case enumsgenpb.RESET_TYPE_BAD_BINARY:
+		_, point := FindAutoResetPoint(e.timeSource, &namespacepb.BadBinaries{
+			Binaries: map[string]*namespacepb.BadBinaryInfo{
+				badBinaryChecksum: {},
+			},
+		}, run.autoResetPoints)
+		if point == nil {
+			return "", 0, serviceerror.NewInvalidArgument(fmt.Sprintf("No resettable auto-reset point found for binary checksum %v.", badBinaryChecksum))
+		}
+		// auto-reset points are carried over by continue as new, the point may be in a previous run
+		runID := point.GetRunId()
+		if runID == "" {
+			runID = run.runID
+		}
+		return runID, point.GetFirstDecisionCompletedId(), nil
+	default:
+		return "", 0, serviceerror.NewInvalidArgument(fmt.Sprintf("Unknown reset type %v.", resetType))
+	}
+}
+
+// loadResetBaseRun reads from the mutable state of a run what resolving a reset point needs, without holding the lock
+// while history is read
+func (e *historyEngineImpl) loadResetBaseRun(
+	ctx context.Context,
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+) (_ *resetBaseRun, retError error) {
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := context.loadWorkflowExecution()
+	if err != nil {
+		return nil, err
+	}
+	branchToken, err := mutableState.GetCurrentBranchToken()
+	if err != nil {
+		return nil, err
+	}
+	startEvent, err := mutableState.GetStartEvent()
+	if err != nil {
+		return nil, err
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	return &resetBaseRun{
+		runID:           executionInfo.RunID,
+		branchToken:     branchToken,
+		nextEventID:     mutableState.GetNextEventID(),
+		continuedRunID:  startEvent.GetWorkflowExecutionStartedEventAttributes().GetContinuedExecutionRunId(),
+		autoResetPoints: executionInfo.AutoResetPoints,
+	}, nil
+}
+
+// findDecisionCompletedEventID returns the ID of the first or the last DecisionTaskCompleted event of a run
+func (e *historyEngineImpl) findDecisionCompletedEventID(
+	run *resetBaseRun,
+	first bool,
+) (int64, error) {
+
+	var decisionCompletedEventID int64
+	var token []byte
+	for {
+		_, historyBatches, nextToken, _, err := PaginateHistory(
+			e.historyV2Mgr,
+			true,
+			run.branchToken,
+			common.FirstEventID,
+			run.nextEventID,
+			token,
+			nDCDefaultPageSize,
+			convert.IntPtr(e.shard.GetShardID()),
+		)
+		if err != nil {
+			return 0, err
+		}
+		for _, history := range historyBatches {
+			for _, event := range history.Events {
+				if event.GetEventType() == enumspb.EVENT_TYPE_DECISION_TASK_COMPLETED {
+					decisionCompletedEventID = event.GetEventId()
+					if first {
+						return decisionCompletedEventID, nil
+					}
+				}
+			}
+		}
+		if len(nextToken) == 0 {
+			break
+		}
+		token = nextToken
+	}
+
+	if decisionCompletedEventID == 0 {
+		return 0, serviceerror.NewInvalidArgument("Workflow run has no completed decision to reset to.")
+	}
+	return decisionCompletedEventID, nil
+}
+
 func (e *historyEngineImpl) updateWorkflow(
 	ctx context.Context,
 	namespaceID string,
@@ -2648,6 +3045,10 @@ func (e *historyEngineImpl) overrideStartWorkflowExecutionRequest(
 ) {
 	namespace := namespaceEntry.GetInfo().Name
 
+	if request.RetryPolicy == nil {
+		request.RetryPolicy = copyRetryPolicy(namespaceEntry.GetConfig().GetDefaultWorkflowRetryPolicy())
+	}
+
 	executionTimeoutSeconds := request.GetWorkflowExecutionTimeoutSeconds()
 	if executionTimeoutSeconds == 0 {
 		executionTimeoutSeconds = convert.Int32Ceil(e.config.DefaultWorkflowExecutionTimeout(namespace).Seconds())
@@ -3057,6 +3458,25 @@ func (e *historyEngineImpl) ReadDLQMessages(
 	request *historyservice.ReadDLQMessagesRequest,
 ) (*historyservice.ReadDLQMessagesResponse, error) {
 
+	switch request.GetType() {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		transferTasks, timerTasks, token, err := e.taskDLQHandler.readMessages(
+			request.GetType(),
+			request.GetInclusiveEndMessageId(),
+			int(request.GetMaximumPageSize()),
+			request.GetNextPageToken(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &historyservice.ReadDLQMessagesResponse{
+			Type:          request.GetType(),
+			TransferTasks: transferTasks,
+			TimerTasks:    timerTasks,
+			NextPageToken: token,
+		}, nil
+	}
+
 	tasks, token, err := e.replicationDLQHandler.readMessages(
 		ctx,
 		request.GetSourceCluster(),
@@ -3079,6 +3499,14 @@ func (e *historyEngineImpl) PurgeDLQMessages(
 	request *historyservice.PurgeDLQMessagesRequest,
 ) error {
 
+	switch request.GetType() {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		return e.taskDLQHandler.purgeMessages(
+			request.GetType(),
+			request.GetInclusiveEndMessageId(),
+		)
+	}
+
 	return e.replicationDLQHandler.purgeMessages(
 		request.GetSourceCluster(),
 		request.GetInclusiveEndMessageId(),
@@ -3090,6 +3518,22 @@ func (e *historyEngineImpl) MergeDLQMessages(
 	request *historyservice.MergeDLQMessagesRequest,
 ) (*historyservice.MergeDLQMessagesResponse, error) {
 
+	switch request.GetType() {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		token, err := e.taskDLQHandler.mergeMessages(
+			request.GetType(),
+			request.GetInclusiveEndMessageId(),
+			int(request.GetMaximumPageSize()),
+			request.GetNextPageToken(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &historyservice.MergeDLQMessagesResponse{
+			NextPageToken: token,
+		}, nil
+	}
+
 	token, err := e.replicationDLQHandler.mergeMessages(
 		ctx,
 		request.GetSourceCluster(),
@@ -3153,6 +3597,77 @@ func (e *historyEngineImpl) RefreshWorkflowTasks(
 	return nil
 }
 
+// UpdateWorkflowExecutionMetadata upserts memo and search attributes of a workflow from outside the workflow. Only mutable
+// state and visibility are updated, no event is added to history, so workers of running workflows never see the update.
+func (e *historyEngineImpl) UpdateWorkflowExecutionMetadata(
+	ctx context.Context,
+	request *historyservice.UpdateWorkflowExecutionMetadataRequest,
+) (retError error) {
+
+	namespaceEntry, err := e.getActiveNamespaceEntry(request.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: request.GetExecution().GetWorkflowId(),
+		RunId:      request.GetExecution().GetRunId(),
+	}
+
+	workflowContext, err := e.loadWorkflowOnce(ctx, namespaceID, execution.GetWorkflowId(), execution.GetRunId())
+	if err != nil {
+		return err
+	}
+	defer func() { workflowContext.getReleaseFn()(retError) }()
+
+	if workflowContext.getMutableState().IsWorkflowExecutionRunning() {
+		return e.updateWorkflowHelper(
+			workflowContext,
+			func(context workflowExecutionContext, mutableState mutableState) (*updateWorkflowAction, error) {
+				if !mutableState.IsWorkflowExecutionRunning() {
+					return nil, ErrWorkflowCompleted
+				}
+				if err := mutableState.UpdateWorkflowExecutionMetadata(
+					request.GetMemo(),
+					request.GetSearchAttributes(),
+				); err != nil {
+					return nil, err
+				}
+				return &updateWorkflowAction{}, nil
+			})
+	}
+
+	// closed workflow, the close is recorded again in visibility with the updated metadata
+	if err := workflowContext.getMutableState().UpdateWorkflowExecutionMetadata(
+		request.GetMemo(),
+		request.GetSearchAttributes(),
+	); err != nil {
+		return err
+	}
+	now := e.shard.GetTimeSource().Now()
+
+	resp, err := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		NamespaceID: namespaceID,
+		WorkflowID:  execution.GetWorkflowId(),
+	})
+	if err != nil {
+		return err
+	}
+	updateMode := persistence.UpdateWorkflowModeBypassCurrent
+	if resp.RunID == workflowContext.getRunID() {
+		updateMode = persistence.UpdateWorkflowModeUpdateCurrent
+	}
+	return workflowContext.getContext().updateWorkflowExecutionWithNew(
+		now,
+		updateMode,
+		nil,
+		nil,
+		transactionPolicyActive,
+		nil,
+	)
+}
+
 func (e *historyEngineImpl) loadWorkflowOnce(
 	ctx context.Context,
 	namespaceID string,
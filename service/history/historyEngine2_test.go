@@ -58,6 +58,7 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/payloads"
 	p "github.com/temporalio/temporal/common/persistence"
 )
@@ -1004,6 +1005,63 @@ func (s *engine2Suite) TestStartWorkflowExecution_StillRunning_NonDeDup() {
 	s.Nil(resp)
 }
 
+func (s *engine2Suite) TestStartWorkflowExecution_TerminateIfRunning_ConcurrentStart() {
+	namespaceID := testNamespaceID
+	workflowID := "workflowID"
+	runID := testRunID
+	workflowType := "workflowType"
+	taskQueue := "testTaskQueue"
+	identity := "testIdentity"
+	policy, err := payload.Encode(common.WorkflowIDReusePolicyTerminateIfRunning)
+	s.NoError(err)
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.historyEngine.shard, s.mockEventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), runID)
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &p.GetWorkflowExecutionResponse{State: ms}
+	gceResponse := &p.GetCurrentExecutionResponse{RunID: runID}
+
+	// no current run when checked, but a concurrent start request creates one before this request does
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(nil, serviceerror.NewNotFound("")).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Times(3)
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(nil, &p.WorkflowExecutionAlreadyStartedError{
+		Msg:              "random message",
+		StartRequestID:   "oldRequestID",
+		RunID:            runID,
+		State:            enumsgenpb.WORKFLOW_EXECUTION_STATE_RUNNING,
+		Status:           enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		LastWriteVersion: common.EmptyVersion,
+	}).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(gceResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		return request.Mode == p.UpdateWorkflowModeUpdateCurrent &&
+			request.UpdateWorkflowMutation.ExecutionInfo.State == enumsgenpb.WORKFLOW_EXECUTION_STATE_COMPLETED &&
+			request.NewWorkflowSnapshot != nil
+	})).Return(&p.UpdateWorkflowExecutionResponse{
+		MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{},
+	}, nil).Once()
+
+	resp, err := s.historyEngine.StartWorkflowExecution(context.Background(), &historyservice.StartWorkflowExecutionRequest{
+		NamespaceId: namespaceID,
+		StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+			Namespace:                       namespaceID,
+			WorkflowId:                      workflowID,
+			WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+			TaskQueue:                       &taskqueuepb.TaskQueue{Name: taskQueue},
+			WorkflowExecutionTimeoutSeconds: 1,
+			WorkflowTaskTimeoutSeconds:      2,
+			Identity:                        identity,
+			RequestId:                       "newRequestID",
+			Header: &commonpb.Header{
+				Fields: map[string]*commonpb.Payload{common.WorkflowIDReusePolicyHeaderKey: policy},
+			},
+		},
+	})
+	s.Nil(err)
+	s.NotEqual(runID, resp.GetRunId())
+}
+
 func (s *engine2Suite) TestStartWorkflowExecution_NotRunning_PrevSuccess() {
 	namespaceID := testNamespaceID
 	workflowID := "workflowID"
@@ -1335,6 +1393,60 @@ func (s *engine2Suite) TestSignalWithStartWorkflowExecution_WorkflowNotRunning()
 	s.NotEqual(runID, resp.GetRunId())
 }
 
+func (s *engine2Suite) TestSignalWithStartWorkflowExecution_TerminateIfRunning() {
+	namespaceID := testNamespaceID
+	workflowID := "wId"
+	runID := testRunID
+	workflowType := "workflowType"
+	taskQueue := "testTaskQueue"
+	identity := "testIdentity"
+	signalName := "my signal name"
+	input := payloads.EncodeString("test input")
+	requestID := uuid.New()
+	policy, err := payload.Encode(common.WorkflowIDReusePolicyTerminateIfRunning)
+	s.NoError(err)
+	sRequest := &historyservice.SignalWithStartWorkflowExecutionRequest{
+		NamespaceId: namespaceID,
+		SignalWithStartRequest: &workflowservice.SignalWithStartWorkflowExecutionRequest{
+			Namespace:                       namespaceID,
+			WorkflowId:                      workflowID,
+			WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+			TaskQueue:                       &taskqueuepb.TaskQueue{Name: taskQueue},
+			Input:                           input,
+			WorkflowExecutionTimeoutSeconds: 1,
+			WorkflowTaskTimeoutSeconds:      2,
+			Identity:                        identity,
+			RequestId:                       requestID,
+			WorkflowIdReusePolicy:           enumspb.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+			SignalName:                      signalName,
+			Header: &commonpb.Header{
+				Fields: map[string]*commonpb.Payload{common.WorkflowIDReusePolicyHeaderKey: policy},
+			},
+		},
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.historyEngine.shard, s.mockEventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), runID)
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &p.GetWorkflowExecutionResponse{State: ms}
+	gceResponse := &p.GetCurrentExecutionResponse{RunID: runID}
+
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(gceResponse, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&p.AppendHistoryNodesResponse{Size: 0}, nil).Twice()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		return request.Mode == p.UpdateWorkflowModeUpdateCurrent &&
+			request.UpdateWorkflowMutation.ExecutionInfo.State == enumsgenpb.WORKFLOW_EXECUTION_STATE_COMPLETED &&
+			request.NewWorkflowSnapshot != nil
+	})).Return(&p.UpdateWorkflowExecutionResponse{
+		MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{},
+	}, nil).Once()
+
+	resp, err := s.historyEngine.SignalWithStartWorkflowExecution(context.Background(), sRequest)
+	s.Nil(err)
+	s.NotEqual(runID, resp.GetRunId())
+}
+
 func (s *engine2Suite) TestSignalWithStartWorkflowExecution_Start_DuplicateRequests() {
 	namespaceID := testNamespaceID
 	workflowID := "wId"
@@ -594,6 +594,20 @@ func (mr *MockEngineMockRecorder) RefreshWorkflowTasks(ctx, namespaceUUID, execu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshWorkflowTasks", reflect.TypeOf((*MockEngine)(nil).RefreshWorkflowTasks), ctx, namespaceUUID, execution)
 }
 
+// UpdateWorkflowExecutionMetadata mocks base method.
+func (m *MockEngine) UpdateWorkflowExecutionMetadata(ctx context.Context, request *historyservice.UpdateWorkflowExecutionMetadataRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkflowExecutionMetadata", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkflowExecutionMetadata indicates an expected call of UpdateWorkflowExecutionMetadata.
+func (mr *MockEngineMockRecorder) UpdateWorkflowExecutionMetadata(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkflowExecutionMetadata", reflect.TypeOf((*MockEngine)(nil).UpdateWorkflowExecutionMetadata), ctx, request)
+}
+
 // NotifyNewHistoryEvent mocks base method.
 func (m *MockEngine) NotifyNewHistoryEvent(event *historyEventNotification) {
 	m.ctrl.T.Helper()
@@ -4794,6 +4794,68 @@ func (s *engineSuite) TestReapplyEvents_IgnoreSameVersionEvents() {
 	s.NoError(err)
 }
 
+func (s *engineSuite) TestFindDecisionCompletedEventID() {
+	run := &resetBaseRun{
+		runID:       testRunID,
+		branchToken: []byte("some random branch token"),
+		nextEventID: 11,
+	}
+	shardID := s.mockHistoryEngine.shard.GetShardID()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", &persistence.ReadHistoryBranchRequest{
+		BranchToken: run.branchToken,
+		MinEventID:  common.FirstEventID,
+		MaxEventID:  run.nextEventID,
+		PageSize:    nDCDefaultPageSize,
+		ShardID:     &shardID,
+	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History: []*historypb.History{
+			{Events: []*historypb.HistoryEvent{
+				{EventId: 1, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+				{EventId: 2, EventType: enumspb.EVENT_TYPE_DECISION_TASK_SCHEDULED},
+				{EventId: 3, EventType: enumspb.EVENT_TYPE_DECISION_TASK_STARTED},
+				{EventId: 4, EventType: enumspb.EVENT_TYPE_DECISION_TASK_COMPLETED},
+			}},
+			{Events: []*historypb.HistoryEvent{
+				{EventId: 5, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED},
+				{EventId: 6, EventType: enumspb.EVENT_TYPE_DECISION_TASK_SCHEDULED},
+				{EventId: 7, EventType: enumspb.EVENT_TYPE_DECISION_TASK_STARTED},
+				{EventId: 8, EventType: enumspb.EVENT_TYPE_DECISION_TASK_COMPLETED},
+			}},
+			{Events: []*historypb.HistoryEvent{
+				{EventId: 9, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED},
+				{EventId: 10, EventType: enumspb.EVENT_TYPE_DECISION_TASK_SCHEDULED},
+			}},
+		},
+	}, nil).Twice()
+
+	firstEventID, err := s.mockHistoryEngine.findDecisionCompletedEventID(run, true)
+	s.NoError(err)
+	s.Equal(int64(4), firstEventID)
+
+	lastEventID, err := s.mockHistoryEngine.findDecisionCompletedEventID(run, false)
+	s.NoError(err)
+	s.Equal(int64(8), lastEventID)
+}
+
+func (s *engineSuite) TestFindDecisionCompletedEventID_NoDecisionCompleted() {
+	run := &resetBaseRun{
+		runID:       testRunID,
+		branchToken: []byte("some random branch token"),
+		nextEventID: 3,
+	}
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", mock.Anything).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History: []*historypb.History{
+			{Events: []*historypb.HistoryEvent{
+				{EventId: 1, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+				{EventId: 2, EventType: enumspb.EVENT_TYPE_DECISION_TASK_SCHEDULED},
+			}},
+		},
+	}, nil).Once()
+
+	_, err := s.mockHistoryEngine.findDecisionCompletedEventID(run, false)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
 func (s *engineSuite) TestReapplyEvents_ResetWorkflow() {
 	workflowExecution := commonpb.WorkflowExecution{
 		WorkflowId: "test-reapply-reset-workflow",
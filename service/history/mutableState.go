@@ -224,6 +224,7 @@ type (
 		UpdateReplicationStateLastEventID(int64, int64)
 		UpdateUserTimer(*persistenceblobs.TimerInfo) error
 		UpdateCurrentVersion(version int64, forceUpdate bool) error
+		UpdateWorkflowExecutionMetadata(memo *commonpb.Memo, searchAttributes *commonpb.SearchAttributes) error
 		UpdateWorkflowStateStatus(state enumsgenpb.WorkflowExecutionState, status enumspb.WorkflowExecutionStatus) error
 
 		AddTransferTasks(transferTasks ...persistence.Task)
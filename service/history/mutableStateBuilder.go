@@ -1694,6 +1694,9 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
 	// validateContinueAsNewWorkflowExecutionAttributes
 	runTimeout := attributes.GetWorkflowRunTimeoutSeconds()
 
+	// Workers never see the reserved task header fields, so the new run keeps those of this one
+	header := common.InheritTaskHeaderFields(attributes.Header, previousExecutionInfo.Priority, previousExecutionInfo.FairnessKey)
+
 	createRequest := &workflowservice.StartWorkflowExecutionRequest{
 		RequestId:                       uuid.New(),
 		Namespace:                       e.namespaceEntry.GetInfo().Name,
@@ -1704,7 +1707,7 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
 		WorkflowRunTimeoutSeconds:       runTimeout,
 		WorkflowTaskTimeoutSeconds:      taskTimeout,
 		Input:                           attributes.Input,
-		Header:                          attributes.Header,
+		Header:                          header,
 		RetryPolicy:                     attributes.RetryPolicy,
 		CronSchedule:                    attributes.CronSchedule,
 		Memo:                            attributes.Memo,
@@ -1854,6 +1857,9 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(
 
 	e.executionInfo.CronSchedule = event.GetCronSchedule()
 	e.executionInfo.ParentNamespaceID = parentNamespaceID
+	e.executionInfo.Priority = common.GetTaskPriority(event.GetHeader())
+	e.executionInfo.FairnessKey = common.GetTaskFairnessKey(event.GetHeader())
+	e.executionInfo.RetentionDays = common.GetWorkflowRetentionDays(event.GetHeader())
 
 	if event.ParentWorkflowExecution != nil {
 		e.executionInfo.ParentWorkflowID = event.ParentWorkflowExecution.GetWorkflowId()
@@ -2211,6 +2217,15 @@ func (e *mutableStateBuilder) ReplicateActivityTaskScheduledEvent(
 		TimerTaskStatus:          timerTaskStatusNone,
 		TaskQueue:                attributes.TaskQueue.GetName(),
 		HasRetryPolicy:           attributes.RetryPolicy != nil,
+		Priority:                 common.GetTaskPriority(attributes.GetHeader()),
+		FairnessKey:              common.GetTaskFairnessKey(attributes.GetHeader()),
+	}
+	// activities inherit the priority and fairness key of the workflow which scheduled them
+	if ai.Priority == 0 {
+		ai.Priority = e.executionInfo.Priority
+	}
+	if ai.FairnessKey == "" {
+		ai.FairnessKey = e.executionInfo.FairnessKey
 	}
 	ai.ExpirationTime = ai.ScheduledTime.Add(time.Duration(scheduleToCloseTimeout) * time.Second)
 	if ai.HasRetryPolicy {
@@ -3902,6 +3917,23 @@ func (e *mutableStateBuilder) UpdateWorkflowStateStatus(
 	return e.executionInfo.UpdateWorkflowStateStatus(state, status)
 }
 
+// UpdateWorkflowExecutionMetadata upserts memo and search attributes updated from outside the workflow. No event is
+// added to history, so that workers never see the update, which is why it applies to closed workflows as well. Being
+// only kept in mutable state, the update is neither replicated to other clusters nor carried over by a reset.
+func (e *mutableStateBuilder) UpdateWorkflowExecutionMetadata(
+	memo *commonpb.Memo,
+	searchAttributes *commonpb.SearchAttributes,
+) error {
+
+	// both may be shared with the started event, merge into copies
+	e.executionInfo.Memo = mergeMapOfPayload(copySearchAttributes(e.executionInfo.Memo), memo.GetFields())
+	e.executionInfo.SearchAttributes = mergeMapOfPayload(
+		copySearchAttributes(e.executionInfo.SearchAttributes),
+		searchAttributes.GetIndexedFields(),
+	)
+	return e.taskGenerator.generateWorkflowSearchAttrTasks(e.timeSource.Now())
+}
+
 func (e *mutableStateBuilder) StartTransaction(
 	namespaceEntry *cache.NamespaceCacheEntry,
 ) (bool, error) {
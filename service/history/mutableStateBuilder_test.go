@@ -545,6 +545,25 @@ func (s *mutableStateSuite) TestMergeMapOfPayload() {
 	s.Equal(2, len(resultMap))
 }
 
+func (s *mutableStateSuite) TestUpdateWorkflowExecutionMetadata() {
+	startMemo := map[string]*commonpb.Payload{"key": payload.EncodeString("val")}
+	s.msBuilder.executionInfo.Memo = startMemo
+	nextEventID := s.msBuilder.GetNextEventID()
+
+	err := s.msBuilder.UpdateWorkflowExecutionMetadata(
+		&commonpb.Memo{Fields: map[string]*commonpb.Payload{"key": payload.EncodeString("new val")}},
+		&commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{"CustomKeywordField": payload.EncodeString("keyword")}},
+	)
+	s.NoError(err)
+
+	s.Equal(nextEventID, s.msBuilder.GetNextEventID())
+	s.Equal(payload.EncodeString("new val"), s.msBuilder.executionInfo.Memo["key"])
+	s.Equal(payload.EncodeString("val"), startMemo["key"])
+	s.Equal(payload.EncodeString("keyword"), s.msBuilder.executionInfo.SearchAttributes["CustomKeywordField"])
+	s.Equal(1, len(s.msBuilder.insertTransferTasks))
+	s.IsType(&persistence.UpsertWorkflowSearchAttributesTask{}, s.msBuilder.insertTransferTasks[0])
+}
+
 func (s *mutableStateSuite) TestEventReapplied() {
 	runID := uuid.New()
 	eventID := int64(1)
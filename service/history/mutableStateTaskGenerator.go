@@ -176,6 +176,10 @@ func (r *mutableStateTaskGeneratorImpl) generateWorkflowCloseTasks(
 	default:
 		return err
 	}
+	// retention override specified when the workflow was started, already bounded at start time
+	if executionInfo.RetentionDays > 0 {
+		retentionInDays = executionInfo.RetentionDays
+	}
 
 	retentionDuration := time.Duration(retentionInDays) * time.Hour * 24
 	r.mutableState.AddTimerTasks(&persistence.DeleteHistoryEventTask{
@@ -25,6 +25,9 @@
 package history
 
 import (
+	"github.com/gogo/protobuf/proto"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	"github.com/temporalio/temporal/common/persistence"
 )
@@ -203,3 +206,28 @@ func convertSignalRequestedIDs(
 	}
 	return outputs
 }
+
+// getWorkerBuildID returns the build id (binary checksum) of the worker which last completed
+// a decision of the current run, or empty string if no decision of the run has been completed
+func getWorkerBuildID(
+	executionInfo *persistence.WorkflowExecutionInfo,
+) string {
+
+	points := executionInfo.AutoResetPoints.GetPoints()
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].GetRunId() == executionInfo.RunID {
+			return points[i].GetBinaryChecksum()
+		}
+	}
+	return ""
+}
+
+func copyRetryPolicy(
+	input *commonpb.RetryPolicy,
+) *commonpb.RetryPolicy {
+
+	if input == nil {
+		return nil
+	}
+	return proto.Clone(input).(*commonpb.RetryPolicy)
+}
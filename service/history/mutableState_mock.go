@@ -2324,6 +2324,20 @@ func (mr *MockmutableStateMockRecorder) UpdateCurrentVersion(version, forceUpdat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrentVersion", reflect.TypeOf((*MockmutableState)(nil).UpdateCurrentVersion), version, forceUpdate)
 }
 
+// UpdateWorkflowExecutionMetadata mocks base method.
+func (m *MockmutableState) UpdateWorkflowExecutionMetadata(memo *common.Memo, searchAttributes *common.SearchAttributes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkflowExecutionMetadata", memo, searchAttributes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkflowExecutionMetadata indicates an expected call of UpdateWorkflowExecutionMetadata.
+func (mr *MockmutableStateMockRecorder) UpdateWorkflowExecutionMetadata(memo, searchAttributes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkflowExecutionMetadata", reflect.TypeOf((*MockmutableState)(nil).UpdateWorkflowExecutionMetadata), memo, searchAttributes)
+}
+
 // UpdateWorkflowStateStatus mocks base method.
 func (m *MockmutableState) UpdateWorkflowStateStatus(state enums.WorkflowExecutionState, status enums0.WorkflowExecutionStatus) error {
 	m.ctrl.T.Helper()
@@ -118,11 +118,16 @@ type (
 
 	pushActivityToMatchingInfo struct {
 		activityScheduleToStartTimeout int32
+		priority                       int32
+		fairnessKey                    string
 	}
 
 	pushDecisionToMatchingInfo struct {
 		decisionScheduleToStartTimeout int32
 		taskqueue                      taskqueuepb.TaskQueue
+		priority                       int32
+		fairnessKey                    string
+		buildID                        string
 	}
 )
 
@@ -149,21 +154,31 @@ func newHistoryResendInfoFor2DC(
 
 func newPushActivityToMatchingInfo(
 	activityScheduleToStartTimeout int32,
+	priority int32,
+	fairnessKey string,
 ) *pushActivityToMatchingInfo {
 
 	return &pushActivityToMatchingInfo{
 		activityScheduleToStartTimeout: activityScheduleToStartTimeout,
+		priority:                       priority,
+		fairnessKey:                    fairnessKey,
 	}
 }
 
 func newPushDecisionToMatchingInfo(
 	decisionScheduleToStartTimeout int32,
 	taskqueue taskqueuepb.TaskQueue,
+	priority int32,
+	fairnessKey string,
+	buildID string,
 ) *pushDecisionToMatchingInfo {
 
 	return &pushDecisionToMatchingInfo{
 		decisionScheduleToStartTimeout: decisionScheduleToStartTimeout,
 		taskqueue:                      taskqueue,
+		priority:                       priority,
+		fairnessKey:                    fairnessKey,
+		buildID:                        buildID,
 	}
 }
 
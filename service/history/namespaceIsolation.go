@@ -0,0 +1,85 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+type (
+	// namespaceIsolation decides which namespaces have their tasks written to and processed from a queue partition of
+	// their own instead of the queue shared by all namespaces of the shard, so the backlog of a noisy namespace only
+	// holds back its own partition. The namespaces currently isolated are tracked by the shard, which writes the tasks
+	// of a namespace to its partition only while the namespace is active in the current cluster. The partitions are
+	// read by active processors only: the tasks left in the partition of a namespace failing over to another cluster
+	// are dropped by the active task filter instead of being verified by a standby processor.
+	namespaceIsolation struct {
+		shard  ShardContext
+		config *Config
+	}
+)
+
+func newNamespaceIsolation(
+	shard ShardContext,
+) *namespaceIsolation {
+
+	return &namespaceIsolation{
+		shard:  shard,
+		config: shard.GetConfig(),
+	}
+}
+
+func (n *namespaceIsolation) shouldIsolate(
+	namespaceID string,
+) bool {
+
+	namespace, err := n.shard.GetNamespaceCache().GetNamespaceName(namespaceID)
+	if err != nil {
+		return false
+	}
+	return n.config.QueueProcessorIsolateNamespace(namespace)
+}
+
+// refresh compares the namespaces configured to be isolated with the given isolated namespaces, and returns the
+// namespaces to isolate and the ones to release.
+func (n *namespaceIsolation) refresh(
+	isolatedNamespaceIDs map[string]struct{},
+) (isolate []string, release []string) {
+
+	shouldIsolate := make(map[string]struct{})
+	for namespaceID, entry := range n.shard.GetNamespaceCache().GetAllNamespace() {
+		if n.config.QueueProcessorIsolateNamespace(entry.GetInfo().Name) {
+			shouldIsolate[namespaceID] = struct{}{}
+		}
+	}
+
+	for namespaceID := range shouldIsolate {
+		if _, ok := isolatedNamespaceIDs[namespaceID]; !ok {
+			isolate = append(isolate, namespaceID)
+		}
+	}
+	for namespaceID := range isolatedNamespaceIDs {
+		if _, ok := shouldIsolate[namespaceID]; !ok {
+			release = append(release, namespaceID)
+		}
+	}
+	return isolate, release
+}
@@ -0,0 +1,258 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	namespaceIsolationSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller         *gomock.Controller
+		mockShard          *shardContextTest
+		mockNamespaceCache *cache.MockNamespaceCache
+
+		isolatedNamespaces map[string]bool
+		namespaceIsolation *namespaceIsolation
+	}
+)
+
+func TestNamespaceIsolationSuite(t *testing.T) {
+	s := new(namespaceIsolationSuite)
+	suite.Run(t, s)
+}
+
+func (s *namespaceIsolationSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.isolatedNamespaces = make(map[string]bool)
+	config := NewDynamicConfigForTest()
+	config.QueueProcessorIsolateNamespace = func(namespace string) bool {
+		return s.isolatedNamespaces[namespace]
+	}
+
+	s.controller = gomock.NewController(s.T())
+	s.mockShard = newTestShardContext(
+		s.controller,
+		&persistence.ShardInfoWithFailover{
+			ShardInfo: &persistenceblobs.ShardInfo{
+				ShardId:                           0,
+				RangeId:                           1,
+				IsolatedNamespaceTransferAckLevel: make(map[string]int64),
+			}},
+		config,
+	)
+	s.mockNamespaceCache = s.mockShard.resource.NamespaceCache
+	s.mockNamespaceCache.EXPECT().GetAllNamespace().Return(map[string]*cache.NamespaceCacheEntry{
+		"namespace-id-1": s.newNamespaceEntry("namespace-id-1", "namespace-1"),
+		"namespace-id-2": s.newNamespaceEntry("namespace-id-2", "namespace-2"),
+		"namespace-id-3": s.newNamespaceEntry("namespace-id-3", "namespace-3"),
+	}).AnyTimes()
+
+	s.namespaceIsolation = newNamespaceIsolation(s.mockShard)
+}
+
+func (s *namespaceIsolationSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockShard.Finish(s.T())
+}
+
+func (s *namespaceIsolationSuite) TestRefresh() {
+	isolate, release := s.namespaceIsolation.refresh(map[string]struct{}{})
+	s.Empty(isolate)
+	s.Empty(release)
+
+	s.isolatedNamespaces["namespace-1"] = true
+	s.isolatedNamespaces["namespace-2"] = true
+	isolate, release = s.namespaceIsolation.refresh(map[string]struct{}{})
+	sort.Strings(isolate)
+	s.Equal([]string{"namespace-id-1", "namespace-id-2"}, isolate)
+	s.Empty(release)
+
+	// unchanged config results in no transitions
+	isolate, release = s.namespaceIsolation.refresh(map[string]struct{}{
+		"namespace-id-1": {},
+		"namespace-id-2": {},
+	})
+	s.Empty(isolate)
+	s.Empty(release)
+
+	delete(s.isolatedNamespaces, "namespace-1")
+	s.isolatedNamespaces["namespace-3"] = true
+	isolate, release = s.namespaceIsolation.refresh(map[string]struct{}{
+		"namespace-id-1": {},
+		"namespace-id-2": {},
+	})
+	s.Equal([]string{"namespace-id-3"}, isolate)
+	s.Equal([]string{"namespace-id-1"}, release)
+}
+
+func (s *namespaceIsolationSuite) TestShouldIsolate() {
+	s.isolatedNamespaces["namespace-1"] = true
+	s.mockNamespaceCache.EXPECT().GetNamespaceName("namespace-id-1").Return("namespace-1", nil)
+	s.mockNamespaceCache.EXPECT().GetNamespaceName("namespace-id-2").Return("namespace-2", nil)
+
+	s.True(s.namespaceIsolation.shouldIsolate("namespace-id-1"))
+	s.False(s.namespaceIsolation.shouldIsolate("namespace-id-2"))
+}
+
+func (s *namespaceIsolationSuite) TestUpdateWorkflowExecution_IsolatedTasks() {
+	s.mockShard.resource.ShardMgr.On("UpdateShard", mock.Anything).Return(nil).Once()
+	s.mockShard.resource.ClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID("namespace-id-1").Return(
+		s.newNamespaceEntry("namespace-id-1", "namespace-1"), nil,
+	).Times(2)
+	s.NoError(s.mockShard.IsolateTransferNamespace("namespace-id-1", 0))
+
+	s.mockShard.resource.ExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+		return request.UpdateWorkflowMutation.IsolatedTransferTasks && !request.UpdateWorkflowMutation.IsolatedTimerTasks
+	})).Return(&persistence.UpdateWorkflowExecutionResponse{}, nil).Once()
+	_, err := s.mockShard.UpdateWorkflowExecution(&persistence.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: persistence.WorkflowMutation{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{NamespaceID: "namespace-id-1", WorkflowID: "workflow-id"},
+		},
+	})
+	s.NoError(err)
+
+	// once released, no task is written to the partition
+	s.mockShard.ReleaseTransferNamespace("namespace-id-1")
+	s.mockShard.resource.ExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+		return !request.UpdateWorkflowMutation.IsolatedTransferTasks && !request.UpdateWorkflowMutation.IsolatedTimerTasks
+	})).Return(&persistence.UpdateWorkflowExecutionResponse{}, nil).Once()
+	_, err = s.mockShard.UpdateWorkflowExecution(&persistence.UpdateWorkflowExecutionRequest{
+		UpdateWorkflowMutation: persistence.WorkflowMutation{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{NamespaceID: "namespace-id-1", WorkflowID: "workflow-id"},
+		},
+	})
+	s.NoError(err)
+}
+
+func (s *namespaceIsolationSuite) TestRefreshIsolatedNamespaces() {
+	s.mockShard.resource.ShardMgr.On("UpdateShard", mock.Anything).Return(nil).Maybe()
+	s.mockShard.resource.ExecutionMgr.On("GetTransferTasks", mock.MatchedBy(func(request *persistence.GetTransferTasksRequest) bool {
+		return request.IsolatedNamespaceID == "namespace-id-1"
+	})).Return(&persistence.GetTransferTasksResponse{}, nil)
+	processor := s.newTestTransferQueueProcessor()
+
+	s.isolatedNamespaces["namespace-1"] = true
+	processor.refreshIsolatedNamespaces()
+	s.Contains(s.mockShard.GetIsolatedTransferNamespaces(), "namespace-id-1")
+	s.Contains(s.mockShard.GetAllIsolatedNamespaceTransferAckLevels(), "namespace-id-1")
+	isolatedTaskProcessor := processor.isolatedTaskProcessors["namespace-id-1"]
+	s.NotNil(isolatedTaskProcessor)
+
+	// the partition of a released namespace is removed once drained
+	delete(s.isolatedNamespaces, "namespace-1")
+	processor.refreshIsolatedNamespaces()
+	s.Empty(s.mockShard.GetIsolatedTransferNamespaces())
+	s.Empty(s.mockShard.GetAllIsolatedNamespaceTransferAckLevels())
+	s.Empty(processor.isolatedTaskProcessors)
+	s.Empty(processor.isolatedAckLevels)
+	s.Equal(common.DaemonStatusStopped, atomic.LoadInt32(&isolatedTaskProcessor.status))
+}
+
+func (s *namespaceIsolationSuite) TestRefreshIsolatedNamespaces_NotDrained() {
+	s.mockShard.resource.ShardMgr.On("UpdateShard", mock.Anything).Return(nil).Maybe()
+	s.mockShard.resource.ExecutionMgr.On("GetTransferTasks", mock.MatchedBy(func(request *persistence.GetTransferTasksRequest) bool {
+		return request.IsolatedNamespaceID == "namespace-id-1" && request.BatchSize == 1
+	})).Return(&persistence.GetTransferTasksResponse{
+		Tasks: []*persistenceblobs.TransferTaskInfo{{NamespaceId: "namespace-id-1", TaskId: 1}},
+	}, nil)
+	s.mockShard.resource.ExecutionMgr.On("GetTransferTasks", mock.Anything).Return(&persistence.GetTransferTasksResponse{}, nil).Maybe()
+	processor := s.newTestTransferQueueProcessor()
+	processor.isolationLock.Lock()
+	processor.startIsolatedTaskProcessorLocked("namespace-id-1", 0)
+	processor.isolationLock.Unlock()
+
+	processor.refreshIsolatedNamespaces()
+	s.Contains(processor.isolatedTaskProcessors, "namespace-id-1")
+	processor.Stop()
+}
+
+func (s *namespaceIsolationSuite) newTestTransferQueueProcessor() *transferQueueProcessorImpl {
+	s.mockShard.resource.ClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	logger := s.mockShard.GetLogger()
+	h := &historyEngineImpl{
+		shard:         s.mockShard,
+		historyCache:  newHistoryCache(s.mockShard),
+		logger:        logger,
+		metricsClient: s.mockShard.GetMetricsClient(),
+	}
+	taskAllocator := newTaskAllocator(s.mockShard)
+	return &transferQueueProcessorImpl{
+		currentClusterName: cluster.TestCurrentClusterName,
+		shard:              s.mockShard,
+		taskAllocator:      taskAllocator,
+		config:             s.mockShard.GetConfig(),
+		metricsClient:      s.mockShard.GetMetricsClient(),
+		historyService:     h,
+		matchingClient:     s.mockShard.resource.MatchingClient,
+		historyClient:      s.mockShard.resource.HistoryClient,
+		logger:             logger,
+		shutdownChan:       make(chan struct{}),
+		activeTaskProcessor: newTransferQueueActiveProcessor(
+			s.mockShard,
+			h,
+			nil,
+			s.mockShard.resource.MatchingClient,
+			s.mockShard.resource.HistoryClient,
+			taskAllocator,
+			nil,
+			logger,
+		),
+		namespaceIsolation:     s.namespaceIsolation,
+		isolatedTaskProcessors: make(map[string]*transferQueueActiveProcessorImpl),
+		isolatedAckLevels:      make(map[string]int64),
+	}
+}
+
+func (s *namespaceIsolationSuite) newNamespaceEntry(
+	namespaceID string,
+	namespace string,
+) *cache.NamespaceCacheEntry {
+
+	return cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Id: namespaceID, Name: namespace},
+		&persistenceblobs.NamespaceConfig{},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+}
@@ -285,6 +285,14 @@ func (h *NilCheckHandler) CloseShard(ctx context.Context, request *historyservic
 	return resp, err
 }
 
+func (h *NilCheckHandler) AcquireShard(ctx context.Context, request *historyservice.AcquireShardRequest) (_ *historyservice.AcquireShardResponse, retError error) {
+	resp, err := h.parentHandler.AcquireShard(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.AcquireShardResponse{}
+	}
+	return resp, err
+}
+
 func (h *NilCheckHandler) RemoveTask(ctx context.Context, request *historyservice.RemoveTaskRequest) (_ *historyservice.RemoveTaskResponse, retError error) {
 	resp, err := h.parentHandler.RemoveTask(ctx, request)
 	if resp == nil && err == nil {
@@ -356,3 +364,11 @@ func (h *NilCheckHandler) RefreshWorkflowTasks(ctx context.Context, request *his
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) UpdateWorkflowExecutionMetadata(ctx context.Context, request *historyservice.UpdateWorkflowExecutionMetadataRequest) (*historyservice.UpdateWorkflowExecutionMetadataResponse, error) {
+	resp, err := h.parentHandler.UpdateWorkflowExecutionMetadata(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.UpdateWorkflowExecutionMetadataResponse{}
+	}
+	return resp, err
+}
@@ -211,7 +211,11 @@ MoveAckLevelLoop:
 		// this means in failover mode, all possible failover transfer tasks
 		// are processed and we are free to shundown
 		a.logger.Debug("Queue ack manager shutdown.")
-		a.finishedChan <- struct{}{}
+		select {
+		case a.finishedChan <- struct{}{}:
+		default:
+			// the processor has already been notified, this happens when the ack level is updated on shutdown
+		}
 		err := a.processor.queueShutdown()
 		if err != nil {
 			a.logger.Error("Error shutdown queue", tag.Error(err))
@@ -176,6 +176,11 @@ func (p *queueProcessorBase) Stop() {
 	if p.taskProcessor != nil {
 		p.taskProcessor.stop()
 	}
+
+	// record the ack level reached so far, so that it is persisted if the shard is handed off
+	if err := p.ackMgr.updateQueueAckLevel(); err != nil && err != ErrShardClosed {
+		p.logger.Warn("Failed to update ack level on shutdown", tag.Error(err))
+	}
 }
 
 func (p *queueProcessorBase) notifyNewTask() {
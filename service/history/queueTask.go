@@ -31,6 +31,7 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/collection"
@@ -59,6 +60,9 @@ type (
 		taskExecutor  queueTaskExecutor
 		maxRetryCount dynamicconfig.IntPropertyFn
 
+		// number of times the task was rejected by a draining task queue, not counted in attempt
+		drainingAttempt int
+
 		// TODO: following two fields should be removed after new task lifecycle is implemented
 		taskFilter        taskFilter
 		shouldProcessTask bool
@@ -178,7 +182,7 @@ func (t *timerQueueTask) Nack() {
 
 	// don't move redispatchQueue to queueTaskBase as we need to
 	// redispatch timeQueueTask, not queueTaskBase
-	t.redispatchQueue.Add(t)
+	t.redispatch(t.redispatchQueue, t)
 }
 
 func (t *timerQueueTask) GetQueueType() queueType {
@@ -196,7 +200,7 @@ func (t *transferQueueTask) Nack() {
 
 	// don't move redispatchQueue to queueTaskBase as we need to
 	// redispatch transferQueueTask, not queueTaskBase
-	t.redispatchQueue.Add(t)
+	t.redispatch(t.redispatchQueue, t)
 }
 
 func (t *transferQueueTask) GetQueueType() queueType {
@@ -231,6 +235,11 @@ func (t *queueTaskBase) HandleErr(
 	err error,
 ) (retErr error) {
 	defer func() {
+		if common.IsTaskQueueDrainingError(retErr) {
+			t.drainingAttempt++
+			return
+		}
+		t.drainingAttempt = 0
 		if retErr != nil {
 			t.attempt++
 			if t.attempt > t.maxRetryCount() {
@@ -259,6 +268,13 @@ func (t *queueTaskBase) HandleErr(
 		err = nil
 	}
 
+	// this is a transient error, adding the task is retried until the task queue is resumed,
+	// the attempts are not counted so that the task is never moved to the DLQ
+	if common.IsTaskQueueDrainingError(err) {
+		t.scope.IncCounter(metrics.TaskQueueDrainingCounter)
+		return err
+	}
+
 	// this is a transient error
 	// TODO remove this error check special case
 	//  since the new task life cycle will not give up until task processed / verified
@@ -278,6 +294,18 @@ func (t *queueTaskBase) HandleErr(
 		return nil
 	}
 
+	if t.attempt >= t.maxRetryCount() &&
+		t.shard.GetConfig().EnableTaskDLQByNamespaceID(t.GetNamespaceId()) { // use namespaceID here to avoid accessing namespaceCache
+		if dlqErr := moveTaskToDLQ(t.shard, t.queueTaskInfo); dlqErr != nil {
+			t.scope.IncCounter(metrics.TaskDLQFailures)
+			t.logger.Error("Fail to move task to DLQ", tag.Error(dlqErr))
+		} else {
+			t.scope.IncCounter(metrics.TaskMovedToDLQCounter)
+			t.logger.Error("Task moved to DLQ after exceeding max retry count", tag.Error(err), tag.LifeCycleProcessingFailed)
+			return nil
+		}
+	}
+
 	t.logger.Error("Fail to process task", tag.Error(err), tag.LifeCycleProcessingFailed)
 	return err
 }
@@ -285,7 +313,8 @@ func (t *queueTaskBase) HandleErr(
 func (t *queueTaskBase) RetryErr(
 	err error,
 ) bool {
-	return true
+	// tasks rejected by a draining task queue are nacked and redispatched after a backoff instead
+	return !common.IsTaskQueueDrainingError(err)
 }
 
 func (t *queueTaskBase) Ack() {
@@ -307,6 +336,21 @@ func (t *queueTaskBase) Nack() {
 	t.state = task.TaskStateNacked
 }
 
+// redispatch adds the nacked task to the redispatch queue, after a backoff if it was rejected by a draining task queue
+func (t *queueTaskBase) redispatch(
+	redispatchQueue collection.Queue,
+	queueTask queueTask,
+) {
+	if t.drainingAttempt == 0 {
+		redispatchQueue.Add(queueTask)
+		return
+	}
+
+	time.AfterFunc(taskQueueDrainingBackoff(t.shard.GetConfig(), t.drainingAttempt), func() {
+		redispatchQueue.Add(queueTask)
+	})
+}
+
 func (t *queueTaskBase) State() task.State {
 	t.Lock()
 	defer t.Unlock()
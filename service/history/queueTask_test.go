@@ -36,8 +36,10 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/collection"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/metrics"
@@ -145,6 +147,66 @@ func (s *queueTaskSuite) TestHandleErr_ErrTaskRetry() {
 	s.Equal(ErrTaskRetry, queueTaskBase.HandleErr(err))
 }
 
+func (s *queueTaskSuite) TestHandleErr_TaskQueueDraining() {
+	timerTask := &persistenceblobs.TimerTaskInfo{
+		NamespaceId: "some random namespaceID",
+		TaskId:      123,
+	}
+	queueTaskBase := newQueueTaskBase(
+		s.mockShard,
+		timerTask,
+		s.scope,
+		s.logger,
+		func(task queueTaskInfo) (bool, error) {
+			return true, nil
+		},
+		s.mockQueueTaskExecutor,
+		s.timeSource,
+		dynamicconfig.GetIntPropertyFn(0),
+	)
+
+	// the task is not moved to the DLQ however many times the task queue rejects it,
+	// and it is nacked to be redispatched after a backoff instead of being retried in place
+	err := serviceerror.FromStatus(serviceerror.ToStatus(common.ErrTaskQueueDraining))
+	s.Equal(err, queueTaskBase.HandleErr(err))
+	s.Equal(err, queueTaskBase.HandleErr(err))
+	s.False(queueTaskBase.RetryErr(err))
+	s.Equal(0, queueTaskBase.attempt)
+	s.Equal(2, queueTaskBase.drainingAttempt)
+
+	// other throttling errors are not mistaken for it
+	s.False(common.IsTaskQueueDrainingError(serviceerror.NewResourceExhausted(common.ErrTaskQueueDraining.Message)))
+}
+
+func (s *queueTaskSuite) TestNack_TaskQueueDraining_RedispatchedAfterBackoff() {
+	s.mockShard.GetConfig().TaskQueueDrainingRetryInterval = dynamicconfig.GetDurationPropertyFn(100 * time.Millisecond)
+	s.mockShard.GetConfig().TaskQueueDrainingMaxRetryInterval = dynamicconfig.GetDurationPropertyFn(100 * time.Millisecond)
+	redispatchQueue := collection.NewConcurrentQueue()
+	queueTask := newTransferQueueTask(
+		s.mockShard,
+		&persistenceblobs.TransferTaskInfo{TaskId: 123},
+		s.scope,
+		s.logger,
+		func(task queueTaskInfo) (bool, error) {
+			return true, nil
+		},
+		s.mockQueueTaskExecutor,
+		redispatchQueue,
+		s.timeSource,
+		s.maxRetryCount,
+		nil,
+	).(*transferQueueTask)
+
+	err := queueTask.HandleErr(common.ErrTaskQueueDraining)
+	s.Equal(common.ErrTaskQueueDraining, err)
+	queueTask.Nack()
+	s.Equal(0, redispatchQueue.Len())
+	s.Eventually(func() bool {
+		return redispatchQueue.Len() == 1
+	}, time.Second, 10*time.Millisecond)
+	s.Same(queueTask, redispatchQueue.Remove())
+}
+
 func (s *queueTaskSuite) TestHandleErr_ErrTaskDiscarded() {
 	queueTaskBase := s.newTestQueueTaskBase(func(task queueTaskInfo) (bool, error) {
 		return true, nil
@@ -186,6 +248,58 @@ func (s *queueTaskSuite) TestHandleErr_UnknownErr() {
 	s.Equal(err, queueTaskBase.HandleErr(err))
 }
 
+func (s *queueTaskSuite) TestHandleErr_MaxRetryExceeded_MoveToDLQ() {
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		NamespaceId: "some random namespaceID",
+		TaskId:      123,
+	}
+	queueTaskBase := newQueueTaskBase(
+		s.mockShard,
+		transferTask,
+		s.scope,
+		s.logger,
+		func(task queueTaskInfo) (bool, error) {
+			return true, nil
+		},
+		s.mockQueueTaskExecutor,
+		s.timeSource,
+		dynamicconfig.GetIntPropertyFn(1),
+	)
+
+	err := errors.New("some random error")
+	s.Equal(err, queueTaskBase.HandleErr(err))
+
+	s.mockShard.resource.ExecutionMgr.On("PutTransferTaskToDLQ", &persistence.PutTransferTaskToDLQRequest{
+		TaskInfo: transferTask,
+	}).Return(nil).Once()
+	s.NoError(queueTaskBase.HandleErr(err))
+}
+
+func (s *queueTaskSuite) TestHandleErr_MaxRetryExceeded_MoveToDLQFailed() {
+	timerTask := &persistenceblobs.TimerTaskInfo{
+		NamespaceId: "some random namespaceID",
+		TaskId:      123,
+	}
+	queueTaskBase := newQueueTaskBase(
+		s.mockShard,
+		timerTask,
+		s.scope,
+		s.logger,
+		func(task queueTaskInfo) (bool, error) {
+			return true, nil
+		},
+		s.mockQueueTaskExecutor,
+		s.timeSource,
+		dynamicconfig.GetIntPropertyFn(0),
+	)
+
+	err := errors.New("some random error")
+	s.mockShard.resource.ExecutionMgr.On("PutTimerTaskToDLQ", &persistence.PutTimerTaskToDLQRequest{
+		TaskInfo: timerTask,
+	}).Return(errors.New("some random persistence error")).Once()
+	s.Equal(err, queueTaskBase.HandleErr(err))
+}
+
 func (s *queueTaskSuite) TestTaskState() {
 	queueTaskBase := s.newTestQueueTaskBase(func(task queueTaskInfo) (bool, error) {
 		return true, nil
@@ -38,6 +38,7 @@ import (
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/namespace"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	espersistence "github.com/temporalio/temporal/common/persistence/elasticsearch"
@@ -54,6 +55,9 @@ type Config struct {
 	EnableNDC                       dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	RPS                             dynamicconfig.IntPropertyFn
 	MaxIDLengthLimit                dynamicconfig.IntPropertyFn
+	MinRetentionDays                dynamicconfig.IntPropertyFn
+	MaxRetentionDays                dynamicconfig.IntPropertyFnWithNamespaceFilter
+	EnableReservedHeaderOptions     dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	PersistenceMaxQPS               dynamicconfig.IntPropertyFn
 	PersistenceGlobalMaxQPS         dynamicconfig.IntPropertyFn
 	EnableVisibilitySampling        dynamicconfig.BoolPropertyFn
@@ -72,17 +76,24 @@ type Config struct {
 	HistoryCacheInitialSize dynamicconfig.IntPropertyFn
 	HistoryCacheMaxSize     dynamicconfig.IntPropertyFn
 	HistoryCacheTTL         dynamicconfig.DurationPropertyFn
+	// HistoryCacheMaxSizeInBytes bounds the total size of the history caches of all shards on a host,
+	// if 0, the default, each cache is bounded by HistoryCacheMaxSize entries instead. Change requires host restart
+	HistoryCacheMaxSizeInBytes dynamicconfig.IntPropertyFn
 
 	// EventsCache settings
 	// Change of these configs require shard restart
 	EventsCacheInitialSize dynamicconfig.IntPropertyFn
 	EventsCacheMaxSize     dynamicconfig.IntPropertyFn
 	EventsCacheTTL         dynamicconfig.DurationPropertyFn
+	// EventsCacheMaxSizeInBytes bounds the total size of the events caches of all shards on a host,
+	// if 0, the default, each cache is bounded by EventsCacheMaxSize entries instead. Change requires host restart
+	EventsCacheMaxSizeInBytes dynamicconfig.IntPropertyFn
 
 	// ShardController settings
 	RangeSizeBits           uint
 	AcquireShardInterval    dynamicconfig.DurationPropertyFn
 	AcquireShardConcurrency dynamicconfig.IntPropertyFn
+	EnableShardHandoff      dynamicconfig.BoolPropertyFn
 
 	// the artificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay                  dynamicconfig.DurationPropertyFn
@@ -97,6 +108,10 @@ type Config struct {
 	TaskSchedulerQueueSize         dynamicconfig.IntPropertyFn
 	TaskSchedulerRoundRobinWeights dynamicconfig.MapPropertyFn
 
+	// Namespace isolation settings, for the namespaces whose tasks are processed from their own queue partitions
+	QueueProcessorIsolateNamespace                  dynamicconfig.BoolPropertyFnWithNamespaceFilter
+	QueueProcessorNamespaceIsolationRefreshInterval dynamicconfig.DurationPropertyFn
+
 	// TimerQueueProcessor settings
 	TimerTaskBatchSize                                dynamicconfig.IntPropertyFn
 	TimerTaskWorkerCount                              dynamicconfig.IntPropertyFn
@@ -107,6 +122,7 @@ type Config struct {
 	TimerProcessorUpdateAckIntervalJitterCoefficient  dynamicconfig.FloatPropertyFn
 	TimerProcessorCompleteTimerInterval               dynamicconfig.DurationPropertyFn
 	TimerProcessorFailoverMaxPollRPS                  dynamicconfig.IntPropertyFn
+	TimerProcessorIsolatedNamespaceMaxPollRPS         dynamicconfig.IntPropertyFn
 	TimerProcessorMaxPollRPS                          dynamicconfig.IntPropertyFn
 	TimerProcessorMaxPollInterval                     dynamicconfig.DurationPropertyFn
 	TimerProcessorMaxPollIntervalJitterCoefficient    dynamicconfig.FloatPropertyFn
@@ -124,6 +140,7 @@ type Config struct {
 	TransferTaskMaxRetryCount                            dynamicconfig.IntPropertyFn
 	TransferProcessorCompleteTransferFailureRetryCount   dynamicconfig.IntPropertyFn
 	TransferProcessorFailoverMaxPollRPS                  dynamicconfig.IntPropertyFn
+	TransferProcessorIsolatedNamespaceMaxPollRPS         dynamicconfig.IntPropertyFn
 	TransferProcessorMaxPollRPS                          dynamicconfig.IntPropertyFn
 	TransferProcessorMaxPollInterval                     dynamicconfig.DurationPropertyFn
 	TransferProcessorMaxPollIntervalJitterCoefficient    dynamicconfig.FloatPropertyFn
@@ -192,6 +209,8 @@ type Config struct {
 	HistorySizeLimitWarn   dynamicconfig.IntPropertyFnWithNamespaceFilter
 	HistoryCountLimitError dynamicconfig.IntPropertyFnWithNamespaceFilter
 	HistoryCountLimitWarn  dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// HistorySizeVisibilityRefreshStep is the history growth past the warn limits between visibility refreshes
+	HistorySizeVisibilityRefreshStep dynamicconfig.IntPropertyFnWithNamespaceFilter
 
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicconfig.MapPropertyFn
@@ -245,8 +264,11 @@ type Config struct {
 	//Crocess DC Replication configuration
 	ReplicationEventsFromCurrentCluster dynamicconfig.BoolPropertyFnWithNamespaceFilter
 
-	EnableDropStuckTaskByNamespaceID dynamicconfig.BoolPropertyFnWithNamespaceIDFilter
-	SkipReapplicationByNamespaceId   dynamicconfig.BoolPropertyFnWithNamespaceIDFilter
+	EnableDropStuckTaskByNamespaceID  dynamicconfig.BoolPropertyFnWithNamespaceIDFilter
+	EnableTaskDLQByNamespaceID        dynamicconfig.BoolPropertyFnWithNamespaceIDFilter
+	TaskQueueDrainingRetryInterval    dynamicconfig.DurationPropertyFn
+	TaskQueueDrainingMaxRetryInterval dynamicconfig.DurationPropertyFn
+	SkipReapplicationByNamespaceId    dynamicconfig.BoolPropertyFnWithNamespaceIDFilter
 }
 
 const (
@@ -260,6 +282,9 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		EnableNDC:                            dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableNDC, false),
 		RPS:                                  dc.GetIntProperty(dynamicconfig.HistoryRPS, 3000),
 		MaxIDLengthLimit:                     dc.GetIntProperty(dynamicconfig.MaxIDLengthLimit, 1000),
+		MinRetentionDays:                     dc.GetIntProperty(dynamicconfig.MinRetentionDays, namespace.MinRetentionDays),
+		MaxRetentionDays:                     dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MaxRetentionDays, namespace.MaxRetentionDays),
+		EnableReservedHeaderOptions:          dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableReservedHeaderOptions, false),
 		PersistenceMaxQPS:                    dc.GetIntProperty(dynamicconfig.HistoryPersistenceMaxQPS, 9000),
 		PersistenceGlobalMaxQPS:              dc.GetIntProperty(dynamicconfig.HistoryPersistenceGlobalMaxQPS, 0),
 		ShutdownDrainDuration:                dc.GetDurationProperty(dynamicconfig.HistoryShutdownDrainDuration, 0),
@@ -275,18 +300,24 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		HistoryCacheInitialSize:              dc.GetIntProperty(dynamicconfig.HistoryCacheInitialSize, 128),
 		HistoryCacheMaxSize:                  dc.GetIntProperty(dynamicconfig.HistoryCacheMaxSize, 512),
 		HistoryCacheTTL:                      dc.GetDurationProperty(dynamicconfig.HistoryCacheTTL, time.Hour),
+		HistoryCacheMaxSizeInBytes:           dc.GetIntProperty(dynamicconfig.HistoryCacheMaxSizeInBytes, 0),
 		EventsCacheInitialSize:               dc.GetIntProperty(dynamicconfig.EventsCacheInitialSize, 128),
 		EventsCacheMaxSize:                   dc.GetIntProperty(dynamicconfig.EventsCacheMaxSize, 512),
 		EventsCacheTTL:                       dc.GetDurationProperty(dynamicconfig.EventsCacheTTL, time.Hour),
+		EventsCacheMaxSizeInBytes:            dc.GetIntProperty(dynamicconfig.EventsCacheMaxSizeInBytes, 0),
 		RangeSizeBits:                        20, // 20 bits for sequencer, 2^20 sequence number for any range
 		AcquireShardInterval:                 dc.GetDurationProperty(dynamicconfig.AcquireShardInterval, time.Minute),
 		AcquireShardConcurrency:              dc.GetIntProperty(dynamicconfig.AcquireShardConcurrency, 1),
+		EnableShardHandoff:                   dc.GetBoolProperty(dynamicconfig.EnableShardHandoff, true),
 		StandbyClusterDelay:                  dc.GetDurationProperty(dynamicconfig.StandbyClusterDelay, 5*time.Minute),
 		StandbyTaskMissingEventsResendDelay:  dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsResendDelay, 15*time.Minute),
 		StandbyTaskMissingEventsDiscardDelay: dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsDiscardDelay, 25*time.Minute),
 
 		TaskProcessRPS: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.TaskProcessRPS, 1000),
 
+		QueueProcessorIsolateNamespace:                  dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.QueueProcessorIsolateNamespace, false),
+		QueueProcessorNamespaceIsolationRefreshInterval: dc.GetDurationProperty(dynamicconfig.QueueProcessorNamespaceIsolationRefreshInterval, 30*time.Second),
+
 		EnablePriorityTaskProcessor:    dc.GetBoolProperty(dynamicconfig.EnablePriorityTaskProcessor, false),
 		TaskSchedulerType:              dc.GetIntProperty(dynamicconfig.TaskSchedulerType, int(task.SchedulerTypeWRR)),
 		TaskSchedulerWorkerCount:       dc.GetIntProperty(dynamicconfig.TaskSchedulerWorkerCount, 20),
@@ -302,6 +333,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		TimerProcessorUpdateAckIntervalJitterCoefficient:  dc.GetFloat64Property(dynamicconfig.TimerProcessorUpdateAckIntervalJitterCoefficient, 0.15),
 		TimerProcessorCompleteTimerInterval:               dc.GetDurationProperty(dynamicconfig.TimerProcessorCompleteTimerInterval, 60*time.Second),
 		TimerProcessorFailoverMaxPollRPS:                  dc.GetIntProperty(dynamicconfig.TimerProcessorFailoverMaxPollRPS, 1),
+		TimerProcessorIsolatedNamespaceMaxPollRPS:         dc.GetIntProperty(dynamicconfig.TimerProcessorIsolatedNamespaceMaxPollRPS, 10),
 		TimerProcessorMaxPollRPS:                          dc.GetIntProperty(dynamicconfig.TimerProcessorMaxPollRPS, 20),
 		TimerProcessorMaxPollInterval:                     dc.GetDurationProperty(dynamicconfig.TimerProcessorMaxPollInterval, 5*time.Minute),
 		TimerProcessorMaxPollIntervalJitterCoefficient:    dc.GetFloat64Property(dynamicconfig.TimerProcessorMaxPollIntervalJitterCoefficient, 0.15),
@@ -315,6 +347,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 
 		TransferTaskBatchSize:                                dc.GetIntProperty(dynamicconfig.TransferTaskBatchSize, 100),
 		TransferProcessorFailoverMaxPollRPS:                  dc.GetIntProperty(dynamicconfig.TransferProcessorFailoverMaxPollRPS, 1),
+		TransferProcessorIsolatedNamespaceMaxPollRPS:         dc.GetIntProperty(dynamicconfig.TransferProcessorIsolatedNamespaceMaxPollRPS, 10),
 		TransferProcessorMaxPollRPS:                          dc.GetIntProperty(dynamicconfig.TransferProcessorMaxPollRPS, 20),
 		TransferTaskWorkerCount:                              dc.GetIntProperty(dynamicconfig.TransferTaskWorkerCount, 10),
 		TransferTaskMaxRetryCount:                            dc.GetIntProperty(dynamicconfig.TransferTaskMaxRetryCount, 100),
@@ -371,6 +404,8 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		HistoryCountLimitError: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitError, 200*1024),
 		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitWarn, 50*1024),
 
+		HistorySizeVisibilityRefreshStep: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistorySizeVisibilityRefreshStep, 10*1024*1024),
+
 		ThrottledLogRPS:   dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 4),
 		EnableStickyQuery: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableStickyQuery, true),
 
@@ -404,8 +439,11 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 
 		ReplicationEventsFromCurrentCluster: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.ReplicationEventsFromCurrentCluster, false),
 
-		EnableDropStuckTaskByNamespaceID: dc.GetBoolPropertyFnWithNamespaceIDFilter(dynamicconfig.EnableDropStuckTaskByNamespaceID, false),
-		SkipReapplicationByNamespaceId:   dc.GetBoolPropertyFnWithNamespaceIDFilter(dynamicconfig.SkipReapplicationByNamespaceId, false),
+		EnableDropStuckTaskByNamespaceID:  dc.GetBoolPropertyFnWithNamespaceIDFilter(dynamicconfig.EnableDropStuckTaskByNamespaceID, false),
+		EnableTaskDLQByNamespaceID:        dc.GetBoolPropertyFnWithNamespaceIDFilter(dynamicconfig.EnableTaskDLQByNamespaceID, true),
+		TaskQueueDrainingRetryInterval:    dc.GetDurationProperty(dynamicconfig.TaskQueueDrainingRetryInterval, time.Second),
+		TaskQueueDrainingMaxRetryInterval: dc.GetDurationProperty(dynamicconfig.TaskQueueDrainingMaxRetryInterval, time.Minute),
+		SkipReapplicationByNamespaceId:    dc.GetBoolPropertyFnWithNamespaceIDFilter(dynamicconfig.SkipReapplicationByNamespaceId, false),
 	}
 
 	return cfg
@@ -531,6 +569,7 @@ func (s *Service) Stop() {
 	// 1. remove self from the membership ring
 	// 2. wait for other members to discover we are going down
 	// 3. stop acquiring new shards (periodically or based on other membership changes)
+	//    and hand off owned shards to their new owners, if enabled
 	// 4. wait for shard ownership to transfer (and inflight requests to drain) while still accepting new requests
 	// 5. Reject all requests arriving at rpc handler to avoid taking on more work except for RespondXXXCompleted and
 	//    RecordXXStarted APIs - for these APIs, most of the work is already one and rejecting at last stage is
@@ -552,6 +591,10 @@ func (s *Service) Stop() {
 
 	s.GetLogger().Info("ShutdownHandler: Initiating shardController shutdown")
 	s.handler.controller.PrepareToStop()
+	if s.config.EnableShardHandoff() {
+		s.GetLogger().Info("ShutdownHandler: Handing off shards to new owners")
+		s.handler.controller.handoffShards()
+	}
 	s.GetLogger().Info("ShutdownHandler: Waiting for traffic to drain")
 	remainingTime = s.sleep(shardOwnershipTransferDelay, remainingTime)
 
@@ -60,6 +60,8 @@ type (
 		GetClusterMetadata() cluster.Metadata
 		GetConfig() *Config
 		GetEventsCache() eventsCache
+		GetHistoryCacheSizeBudget() *cache.SizeBudget
+		GetEventsCacheSizeBudget() *cache.SizeBudget
 		GetLogger() log.Logger
 		GetThrottledLogger() log.Logger
 		GetMetricsClient() metrics.Client
@@ -106,6 +108,19 @@ type (
 		DeleteTimerFailoverLevel(failoverID string) error
 		GetAllTimerFailoverLevels() map[string]persistence.TimerFailoverLevel
 
+		GetAllIsolatedNamespaceTransferAckLevels() map[string]int64
+		UpdateIsolatedNamespaceTransferAckLevel(namespaceID string, ackLevel int64) error
+		DeleteIsolatedNamespaceTransferAckLevel(namespaceID string) error
+		GetAllIsolatedNamespaceTimerAckLevels() map[string]time.Time
+		UpdateIsolatedNamespaceTimerAckLevel(namespaceID string, ackLevel time.Time) error
+		DeleteIsolatedNamespaceTimerAckLevel(namespaceID string) error
+		GetIsolatedTransferNamespaces() map[string]struct{}
+		IsolateTransferNamespace(namespaceID string, ackLevel int64) error
+		ReleaseTransferNamespace(namespaceID string)
+		GetIsolatedTimerNamespaces() map[string]struct{}
+		IsolateTimerNamespace(namespaceID string, ackLevel time.Time) error
+		ReleaseTimerNamespace(namespaceID string)
+
 		GetNamespaceNotificationVersion() int64
 		UpdateNamespaceNotificationVersion(namespaceNotificationVersion int64) error
 
@@ -131,6 +146,9 @@ type (
 		throttledLogger  log.Logger
 		engine           Engine
 
+		historyCacheSizeBudget *cache.SizeBudget
+		eventsCacheSizeBudget  *cache.SizeBudget
+
 		sync.RWMutex
 		lastUpdated               time.Time
 		shardInfo                 *persistence.ShardInfoWithFailover
@@ -141,6 +159,9 @@ type (
 
 		// exist only in memory
 		remoteClusterCurrentTime map[string]time.Time
+		// namespaces whose transfer / timer tasks are written to the queue partitions of the namespace
+		isolatedTransferNamespaces map[string]struct{}
+		isolatedTimerNamespaces    map[string]struct{}
 
 		// true if previous owner was different from the acquirer's identity.
 		previousShardOwnerWasDifferent bool
@@ -429,6 +450,148 @@ func (s *shardContextImpl) GetAllTimerFailoverLevels() map[string]persistence.Ti
 	return ret
 }
 
+func (s *shardContextImpl) GetAllIsolatedNamespaceTransferAckLevels() map[string]int64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	ret := map[string]int64{}
+	for k, v := range s.shardInfo.IsolatedNamespaceTransferAckLevel {
+		ret[k] = v
+	}
+	return ret
+}
+
+func (s *shardContextImpl) UpdateIsolatedNamespaceTransferAckLevel(namespaceID string, ackLevel int64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.shardInfo.IsolatedNamespaceTransferAckLevel[namespaceID] = ackLevel
+	return s.updateShardInfoLocked()
+}
+
+func (s *shardContextImpl) DeleteIsolatedNamespaceTransferAckLevel(namespaceID string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.shardInfo.IsolatedNamespaceTransferAckLevel, namespaceID)
+	return s.updateShardInfoLocked()
+}
+
+func (s *shardContextImpl) GetAllIsolatedNamespaceTimerAckLevels() map[string]time.Time {
+	s.RLock()
+	defer s.RUnlock()
+
+	ret := map[string]time.Time{}
+	for k, v := range s.shardInfo.IsolatedNamespaceTimerAckLevel {
+		ret[k], _ = types.TimestampFromProto(v)
+	}
+	return ret
+}
+
+func (s *shardContextImpl) UpdateIsolatedNamespaceTimerAckLevel(namespaceID string, ackLevel time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	pTime, err := types.TimestampProto(ackLevel)
+	if err != nil {
+		return err
+	}
+
+	s.shardInfo.IsolatedNamespaceTimerAckLevel[namespaceID] = pTime
+	return s.updateShardInfoLocked()
+}
+
+func (s *shardContextImpl) DeleteIsolatedNamespaceTimerAckLevel(namespaceID string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.shardInfo.IsolatedNamespaceTimerAckLevel, namespaceID)
+	return s.updateShardInfoLocked()
+}
+
+func (s *shardContextImpl) GetIsolatedTransferNamespaces() map[string]struct{} {
+	s.RLock()
+	defer s.RUnlock()
+
+	ret := map[string]struct{}{}
+	for k := range s.isolatedTransferNamespaces {
+		ret[k] = struct{}{}
+	}
+	return ret
+}
+
+// IsolateTransferNamespace persists the ack level of the transfer queue partition of the namespace, and writes the
+// transfer tasks of the namespace to the partition from then on. The ack level is persisted right away, so that the
+// partition is processed by the next owner of the shard.
+func (s *shardContextImpl) IsolateTransferNamespace(namespaceID string, ackLevel int64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.shardInfo.IsolatedNamespaceTransferAckLevel[namespaceID] = ackLevel
+	if err := s.flushShardInfoLocked(); err != nil {
+		delete(s.shardInfo.IsolatedNamespaceTransferAckLevel, namespaceID)
+		return err
+	}
+	if s.isolatedTransferNamespaces == nil {
+		s.isolatedTransferNamespaces = make(map[string]struct{})
+	}
+	s.isolatedTransferNamespaces[namespaceID] = struct{}{}
+	return nil
+}
+
+// ReleaseTransferNamespace writes the transfer tasks of the namespace to the shared queue from then on. Workflow
+// updates hold the shard lock until they are persisted, so no task is written to the partition once this returns.
+func (s *shardContextImpl) ReleaseTransferNamespace(namespaceID string) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.isolatedTransferNamespaces, namespaceID)
+}
+
+func (s *shardContextImpl) GetIsolatedTimerNamespaces() map[string]struct{} {
+	s.RLock()
+	defer s.RUnlock()
+
+	ret := map[string]struct{}{}
+	for k := range s.isolatedTimerNamespaces {
+		ret[k] = struct{}{}
+	}
+	return ret
+}
+
+// IsolateTimerNamespace persists the ack level of the timer queue partition of the namespace, and writes the timer
+// tasks of the namespace to the partition from then on. The ack level is persisted right away, so that the partition
+// is processed by the next owner of the shard.
+func (s *shardContextImpl) IsolateTimerNamespace(namespaceID string, ackLevel time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	pTime, err := types.TimestampProto(ackLevel)
+	if err != nil {
+		return err
+	}
+
+	s.shardInfo.IsolatedNamespaceTimerAckLevel[namespaceID] = pTime
+	if err := s.flushShardInfoLocked(); err != nil {
+		delete(s.shardInfo.IsolatedNamespaceTimerAckLevel, namespaceID)
+		return err
+	}
+	if s.isolatedTimerNamespaces == nil {
+		s.isolatedTimerNamespaces = make(map[string]struct{})
+	}
+	s.isolatedTimerNamespaces[namespaceID] = struct{}{}
+	return nil
+}
+
+// ReleaseTimerNamespace writes the timer tasks of the namespace to the shared queue from then on. Workflow updates
+// hold the shard lock until they are persisted, so no task is written to the partition once this returns.
+func (s *shardContextImpl) ReleaseTimerNamespace(namespaceID string) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.isolatedTimerNamespaces, namespaceID)
+}
+
 func (s *shardContextImpl) GetNamespaceNotificationVersion() int64 {
 	s.RLock()
 	defer s.RUnlock()
@@ -492,6 +655,9 @@ func (s *shardContextImpl) CreateWorkflowExecution(
 		return nil, err
 	}
 	defer s.updateMaxReadLevelLocked(transferMaxReadLevel)
+	isolatedTransferTasks, isolatedTimerTasks := s.isolatedQueuesLocked(namespaceEntry)
+	request.NewWorkflowSnapshot.IsolatedTransferTasks = isolatedTransferTasks
+	request.NewWorkflowSnapshot.IsolatedTimerTasks = isolatedTimerTasks
 
 Create_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
@@ -587,6 +753,13 @@ func (s *shardContextImpl) UpdateWorkflowExecution(
 		}
 	}
 	defer s.updateMaxReadLevelLocked(transferMaxReadLevel)
+	isolatedTransferTasks, isolatedTimerTasks := s.isolatedQueuesLocked(namespaceEntry)
+	request.UpdateWorkflowMutation.IsolatedTransferTasks = isolatedTransferTasks
+	request.UpdateWorkflowMutation.IsolatedTimerTasks = isolatedTimerTasks
+	if request.NewWorkflowSnapshot != nil {
+		request.NewWorkflowSnapshot.IsolatedTransferTasks = isolatedTransferTasks
+		request.NewWorkflowSnapshot.IsolatedTimerTasks = isolatedTimerTasks
+	}
 
 Update_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
@@ -673,6 +846,13 @@ func (s *shardContextImpl) ResetWorkflowExecution(request *persistence.ResetWork
 		return err
 	}
 	defer s.updateMaxReadLevelLocked(transferMaxReadLevel)
+	isolatedTransferTasks, isolatedTimerTasks := s.isolatedQueuesLocked(namespaceEntry)
+	if request.CurrentWorkflowMutation != nil {
+		request.CurrentWorkflowMutation.IsolatedTransferTasks = isolatedTransferTasks
+		request.CurrentWorkflowMutation.IsolatedTimerTasks = isolatedTimerTasks
+	}
+	request.NewWorkflowSnapshot.IsolatedTransferTasks = isolatedTransferTasks
+	request.NewWorkflowSnapshot.IsolatedTimerTasks = isolatedTimerTasks
 
 Reset_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
@@ -774,6 +954,17 @@ func (s *shardContextImpl) ConflictResolveWorkflowExecution(
 		}
 	}
 	defer s.updateMaxReadLevelLocked(transferMaxReadLevel)
+	isolatedTransferTasks, isolatedTimerTasks := s.isolatedQueuesLocked(namespaceEntry)
+	if request.CurrentWorkflowMutation != nil {
+		request.CurrentWorkflowMutation.IsolatedTransferTasks = isolatedTransferTasks
+		request.CurrentWorkflowMutation.IsolatedTimerTasks = isolatedTimerTasks
+	}
+	request.ResetWorkflowSnapshot.IsolatedTransferTasks = isolatedTransferTasks
+	request.ResetWorkflowSnapshot.IsolatedTimerTasks = isolatedTimerTasks
+	if request.NewWorkflowSnapshot != nil {
+		request.NewWorkflowSnapshot.IsolatedTransferTasks = isolatedTransferTasks
+		request.NewWorkflowSnapshot.IsolatedTimerTasks = isolatedTimerTasks
+	}
 
 Reset_Loop:
 	for attempt := 0; attempt < conditionalRetryCount; attempt++ {
@@ -875,6 +1066,16 @@ func (s *shardContextImpl) GetEventsCache() eventsCache {
 	return s.eventsCache
 }
 
+// GetHistoryCacheSizeBudget returns the budget shared by the history caches of all shards on the host, if any
+func (s *shardContextImpl) GetHistoryCacheSizeBudget() *cache.SizeBudget {
+	return s.historyCacheSizeBudget
+}
+
+// GetEventsCacheSizeBudget returns the budget shared by the events caches of all shards on the host, if any
+func (s *shardContextImpl) GetEventsCacheSizeBudget() *cache.SizeBudget {
+	return s.eventsCacheSizeBudget
+}
+
 func (s *shardContextImpl) GetLogger() log.Logger {
 	return s.logger
 }
@@ -980,28 +1181,67 @@ func (s *shardContextImpl) updateShardInfoLocked() error {
 		return ErrShardClosed
 	}
 
-	var err error
 	now := clock.NewRealTimeSource().Now()
 	if s.lastUpdated.Add(s.config.ShardUpdateMinInterval()).After(now) {
 		return nil
 	}
-	updatedShardInfo := copyShardInfo(s.shardInfo)
-	s.emitShardInfoMetricsLogsLocked()
 
-	err = s.GetShardManager().UpdateShard(&persistence.UpdateShardRequest{
-		ShardInfo:       updatedShardInfo.ShardInfo,
-		PreviousRangeID: s.shardInfo.GetRangeId(),
-	})
+	return s.persistShardInfoOrCloseLocked(now)
+}
 
+// flushShardInfoLocked persists the shard info regardless of ShardUpdateMinInterval
+func (s *shardContextImpl) flushShardInfoLocked() error {
+	if s.isClosed() {
+		return ErrShardClosed
+	}
+
+	return s.persistShardInfoOrCloseLocked(clock.NewRealTimeSource().Now())
+}
+
+func (s *shardContextImpl) persistShardInfoOrCloseLocked(now time.Time) error {
+	err := s.persistShardInfoLocked(now)
 	if err != nil {
 		// Shard is stolen, trigger history engine shutdown
 		if _, ok := err.(*persistence.ShardOwnershipLostError); ok {
 			s.closeShard()
 		}
-	} else {
+	}
+
+	return err
+}
+
+func (s *shardContextImpl) persistShardInfoLocked(now time.Time) error {
+	updatedShardInfo := copyShardInfo(s.shardInfo)
+	s.emitShardInfoMetricsLogsLocked()
+
+	err := s.GetShardManager().UpdateShard(&persistence.UpdateShardRequest{
+		ShardInfo:       updatedShardInfo.ShardInfo,
+		PreviousRangeID: s.shardInfo.GetRangeId(),
+	})
+	if err == nil {
 		s.lastUpdated = now
 	}
+	return err
+}
 
+// handoff persists the latest shard info regardless of when it was last updated and closes the shard, so that
+// the next owner resumes from the ack levels reached on this host. Unlike closeShard, the shard controller is not
+// notified as it is the one handing off the shard.
+func (s *shardContextImpl) handoff() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.isClosed() {
+		return ErrShardClosed
+	}
+
+	s.logger.Info("Handoff shard")
+	err := s.persistShardInfoLocked(clock.NewRealTimeSource().Now())
+
+	// fails any writes that may start after this point.
+	atomic.StoreInt32(&s.closed, 1)
+	s.shardInfo.RangeId = -1
+	atomic.StoreInt64(&s.rangeID, s.shardInfo.RangeId)
 	return err
 }
 
@@ -1092,6 +1332,25 @@ func (s *shardContextImpl) allocateTaskIDsLocked(
 		timerTasks)
 }
 
+// isolatedQueuesLocked returns whether the transfer and timer tasks of the namespace are written to the queue
+// partitions of the namespace. Only namespaces active in the current cluster are isolated, as the standby processors
+// read the shared queues only.
+func (s *shardContextImpl) isolatedQueuesLocked(
+	namespaceEntry *cache.NamespaceCacheEntry,
+) (bool, bool) {
+
+	namespaceID := namespaceEntry.GetInfo().Id
+	_, isolatedTransfer := s.isolatedTransferNamespaces[namespaceID]
+	_, isolatedTimer := s.isolatedTimerNamespaces[namespaceID]
+	if !isolatedTransfer && !isolatedTimer {
+		return false, false
+	}
+	if namespaceEntry.GetReplicationConfig().ActiveClusterName != s.GetClusterMetadata().GetCurrentClusterName() {
+		return false, false
+	}
+	return isolatedTransfer, isolatedTimer
+}
+
 func (s *shardContextImpl) allocateTransferIDsLocked(
 	tasks []persistence.Task,
 	transferMaxReadLevel *int64,
@@ -1184,7 +1443,7 @@ func (s *shardContextImpl) GetLastUpdatedTime() time.Time {
 func acquireShard(
 	shardItem *historyShardsItem,
 	closeCallback func(int, *historyShardsItem),
-) (ShardContext, error) {
+) (*shardContextImpl, error) {
 
 	var shardInfo *persistence.ShardInfoWithFailover
 
@@ -1273,6 +1532,8 @@ func acquireShard(
 		logger:                         shardItem.logger,
 		throttledLogger:                shardItem.throttledLogger,
 		previousShardOwnerWasDifferent: ownershipChanged,
+		historyCacheSizeBudget:         shardItem.historyCacheSizeBudget,
+		eventsCacheSizeBudget:          shardItem.eventsCacheSizeBudget,
 	}
 	shardContext.eventsCache = newEventsCache(shardContext)
 
@@ -1307,20 +1568,30 @@ func copyShardInfo(shardInfo *persistence.ShardInfoWithFailover) *persistence.Sh
 	for k, v := range shardInfo.ClusterReplicationLevel {
 		clusterReplicationLevel[k] = v
 	}
+	isolatedNamespaceTransferAckLevel := make(map[string]int64)
+	for k, v := range shardInfo.IsolatedNamespaceTransferAckLevel {
+		isolatedNamespaceTransferAckLevel[k] = v
+	}
+	isolatedNamespaceTimerAckLevel := make(map[string]*types.Timestamp)
+	for k, v := range shardInfo.IsolatedNamespaceTimerAckLevel {
+		isolatedNamespaceTimerAckLevel[k] = v
+	}
 	shardInfoCopy := &persistence.ShardInfoWithFailover{
 		ShardInfo: &persistenceblobs.ShardInfo{
-			ShardId:                      shardInfo.GetShardId(),
-			Owner:                        shardInfo.Owner,
-			RangeId:                      shardInfo.GetRangeId(),
-			StolenSinceRenew:             shardInfo.StolenSinceRenew,
-			ReplicationAckLevel:          shardInfo.ReplicationAckLevel,
-			TransferAckLevel:             shardInfo.TransferAckLevel,
-			TimerAckLevel:                shardInfo.TimerAckLevel,
-			ClusterTransferAckLevel:      clusterTransferAckLevel,
-			ClusterTimerAckLevel:         clusterTimerAckLevel,
-			NamespaceNotificationVersion: shardInfo.NamespaceNotificationVersion,
-			ClusterReplicationLevel:      clusterReplicationLevel,
-			UpdatedAt:                    shardInfo.UpdatedAt,
+			ShardId:                           shardInfo.GetShardId(),
+			Owner:                             shardInfo.Owner,
+			RangeId:                           shardInfo.GetRangeId(),
+			StolenSinceRenew:                  shardInfo.StolenSinceRenew,
+			ReplicationAckLevel:               shardInfo.ReplicationAckLevel,
+			TransferAckLevel:                  shardInfo.TransferAckLevel,
+			TimerAckLevel:                     shardInfo.TimerAckLevel,
+			ClusterTransferAckLevel:           clusterTransferAckLevel,
+			ClusterTimerAckLevel:              clusterTimerAckLevel,
+			NamespaceNotificationVersion:      shardInfo.NamespaceNotificationVersion,
+			ClusterReplicationLevel:           clusterReplicationLevel,
+			UpdatedAt:                         shardInfo.UpdatedAt,
+			IsolatedNamespaceTransferAckLevel: isolatedNamespaceTransferAckLevel,
+			IsolatedNamespaceTimerAckLevel:    isolatedNamespaceTimerAckLevel,
 		},
 		TransferFailoverLevels: transferFailoverLevels,
 		TimerFailoverLevels:    timerFailoverLevels,
@@ -25,12 +25,15 @@
 package history
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/membership"
@@ -41,6 +44,8 @@ import (
 
 const (
 	shardControllerMembershipUpdateListenerName = "ShardController"
+
+	shardHandoffTimeout = 5 * time.Second
 )
 
 type (
@@ -58,6 +63,10 @@ type (
 		config             *Config
 		metricsScope       metrics.Scope
 
+		// budgets shared by the caches of all shards, nil if the caches are bounded by their number of entries
+		historyCacheSizeBudget *cache.SizeBudget
+		eventsCacheSizeBudget  *cache.SizeBudget
+
 		sync.RWMutex
 		historyShards map[int]*historyShardsItem
 	}
@@ -73,9 +82,13 @@ type (
 		throttledLogger log.Logger
 		engineFactory   EngineFactory
 
+		historyCacheSizeBudget *cache.SizeBudget
+		eventsCacheSizeBudget  *cache.SizeBudget
+
 		sync.RWMutex
 		status historyShardsItemStatus
 		engine Engine
+		shard  *shardContextImpl
 	}
 )
 
@@ -91,6 +104,14 @@ func newShardController(
 	config *Config,
 ) *shardController {
 	hostIdentity := resource.GetHostInfo().Identity()
+	var historyCacheSizeBudget, eventsCacheSizeBudget *cache.SizeBudget
+	if maxSizeInBytes := config.HistoryCacheMaxSizeInBytes(); maxSizeInBytes > 0 {
+		historyCacheSizeBudget = cache.NewSizeBudget(maxSizeInBytes)
+	}
+	if maxSizeInBytes := config.EventsCacheMaxSizeInBytes(); maxSizeInBytes > 0 {
+		eventsCacheSizeBudget = cache.NewSizeBudget(maxSizeInBytes)
+	}
+
 	return &shardController{
 		Resource:           resource,
 		status:             common.DaemonStatusInitialized,
@@ -102,6 +123,9 @@ func newShardController(
 		throttledLogger:    resource.GetThrottledLogger().WithTags(tag.ComponentShardController, tag.Address(hostIdentity)),
 		config:             config,
 		metricsScope:       resource.GetMetricsClient().Scope(metrics.HistoryShardControllerScope),
+
+		historyCacheSizeBudget: historyCacheSizeBudget,
+		eventsCacheSizeBudget:  eventsCacheSizeBudget,
 	}
 }
 
@@ -110,6 +134,8 @@ func newHistoryShardsItem(
 	shardID int,
 	factory EngineFactory,
 	config *Config,
+	historyCacheSizeBudget *cache.SizeBudget,
+	eventsCacheSizeBudget *cache.SizeBudget,
 ) (*historyShardsItem, error) {
 
 	hostIdentity := resource.GetHostInfo().Identity()
@@ -121,6 +147,9 @@ func newHistoryShardsItem(
 		config:          config,
 		logger:          resource.GetLogger().WithTags(tag.ShardID(shardID), tag.Address(hostIdentity)),
 		throttledLogger: resource.GetThrottledLogger().WithTags(tag.ShardID(shardID), tag.Address(hostIdentity)),
+
+		historyCacheSizeBudget: historyCacheSizeBudget,
+		eventsCacheSizeBudget:  eventsCacheSizeBudget,
 	}
 	shardItem.logger = shardItem.logger.WithTags(tag.ShardItem(shardItem))
 	shardItem.throttledLogger = shardItem.throttledLogger.WithTags(tag.ShardItem(shardItem))
@@ -232,32 +261,36 @@ func (c *shardController) getOrCreateHistoryShardItem(shardID int) (*historyShar
 		// if item not valid then process to create a new one
 	}
 
-	if c.isShuttingDown() || atomic.LoadInt32(&c.status) == common.DaemonStatusStopped {
-		return nil, fmt.Errorf("shardController for host '%v' shutting down", c.GetHostInfo().Identity())
-	}
 	info, err := c.GetHistoryServiceResolver().Lookup(string(shardID))
 	if err != nil {
 		return nil, err
 	}
 
-	if info.Identity() == c.GetHostInfo().Identity() {
-		shardItem, err := newHistoryShardsItem(
-			c.Resource,
-			shardID,
-			c.engineFactory,
-			c.config,
-		)
-		if err != nil {
-			return nil, err
-		}
-		c.historyShards[shardID] = shardItem
-		c.metricsScope.IncCounter(metrics.ShardItemCreatedCounter)
+	if info.Identity() != c.GetHostInfo().Identity() {
+		// redirect callers to the new owner, including when shutting down after the shard has been handed off
+		return nil, createShardOwnershipLostError(c.GetHostInfo().Identity(), info.GetAddress())
+	}
 
-		shardItem.logger.Info("", tag.LifeCycleStarted, tag.ComponentShardItem)
-		return shardItem, nil
+	if c.isShuttingDown() || atomic.LoadInt32(&c.status) == common.DaemonStatusStopped {
+		return nil, fmt.Errorf("shardController for host '%v' shutting down", c.GetHostInfo().Identity())
 	}
 
-	return nil, createShardOwnershipLostError(c.GetHostInfo().Identity(), info.GetAddress())
+	shardItem, err := newHistoryShardsItem(
+		c.Resource,
+		shardID,
+		c.engineFactory,
+		c.config,
+		c.historyCacheSizeBudget,
+		c.eventsCacheSizeBudget,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.historyShards[shardID] = shardItem
+	c.metricsScope.IncCounter(metrics.ShardItemCreatedCounter)
+
+	shardItem.logger.Info("", tag.LifeCycleStarted, tag.ComponentShardItem)
+	return shardItem, nil
 }
 
 func (c *shardController) removeHistoryShardItem(shardID int, shardItem *historyShardsItem) (*historyShardsItem, error) {
@@ -374,6 +407,70 @@ func (c *shardController) doShutdown() {
 	c.historyShards = nil
 }
 
+// handoffShards hands off the shards owned by this host to their new owners. It is called on shutdown once this
+// host has been evicted from the membership ring, so that the new owners acquire the shards right away instead of
+// waiting for the membership change to propagate or for the next acquireShards.
+func (c *shardController) handoffShards() {
+	c.RLock()
+	items := make(map[int]*historyShardsItem, len(c.historyShards))
+	for shardID, item := range c.historyShards {
+		items[shardID] = item
+	}
+	c.RUnlock()
+
+	concurrency := common.MaxInt(c.config.AcquireShardConcurrency(), 1)
+	shardActionCh := make(chan int, concurrency)
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for shardID := range shardActionCh {
+				c.handoffShard(shardID, items[shardID])
+			}
+		}()
+	}
+	for shardID := range items {
+		shardActionCh <- shardID
+	}
+	close(shardActionCh)
+	wg.Wait()
+
+	c.metricsScope.UpdateGauge(metrics.NumShardsGauge, float64(c.numShards()))
+}
+
+func (c *shardController) handoffShard(shardID int, item *historyShardsItem) {
+	info, err := c.GetHistoryServiceResolver().Lookup(string(shardID))
+	if err != nil {
+		c.logger.Error("Error looking up host for shardID", tag.Error(err), tag.OperationFailed, tag.ShardID(shardID))
+		return
+	}
+	if info.Identity() == c.GetHostInfo().Identity() {
+		// the ring does not reflect the eviction of this host yet, the shard is released on shutdown instead
+		return
+	}
+
+	// requests for the shard are redirected to the new owner from this point on
+	if _, err := c.removeHistoryShardItem(shardID, item); err != nil {
+		// the shard has been closed in the meantime
+		return
+	}
+	if err := item.handoffEngine(); err != nil {
+		c.logger.Warn("Failed to persist shard info on handoff", tag.Error(err), tag.ShardID(shardID))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shardHandoffTimeout)
+	defer cancel()
+	_, err = c.GetHistoryClient().AcquireShard(ctx, &historyservice.AcquireShardRequest{ShardId: int32(shardID)})
+	if err != nil {
+		// the new owner still acquires the shard once it observes the membership change
+		c.logger.Warn("Failed to notify new owner of shard handoff",
+			tag.Error(err), tag.ShardID(shardID), tag.Address(info.GetAddress()))
+		return
+	}
+	c.metricsScope.IncCounter(metrics.ShardHandoffCounter)
+}
+
 func (c *shardController) numShards() int {
 	nShards := 0
 	c.RLock()
@@ -420,6 +517,7 @@ func (i *historyShardsItem) getOrCreateEngine(
 			i.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardItemAcquisitionLatency,
 				context.GetCurrentTime(i.GetClusterMetadata().GetCurrentClusterName()).Sub(context.GetLastUpdatedTime()))
 		}
+		i.shard = context
 		i.engine = i.engineFactory.CreateEngine(context)
 		i.engine.Start()
 		i.logger.Info("", tag.LifeCycleStarted, tag.ComponentShardEngine)
@@ -445,6 +543,7 @@ func (i *historyShardsItem) stopEngine() {
 		i.logger.Info("", tag.LifeCycleStopping, tag.ComponentShardEngine)
 		i.engine.Stop()
 		i.engine = nil
+		i.shard = nil
 		i.logger.Info("", tag.LifeCycleStopped, tag.ComponentShardEngine)
 		i.status = historyShardsItemStatusStopped
 	case historyShardsItemStatusStopped:
@@ -454,6 +553,32 @@ func (i *historyShardsItem) stopEngine() {
 	}
 }
 
+// handoffEngine stops the engine, which makes the queue processors record their ack levels, then persists the
+// shard info and closes the shard so that no more writes for it are accepted by this host.
+func (i *historyShardsItem) handoffEngine() error {
+	i.Lock()
+	defer i.Unlock()
+
+	switch i.status {
+	case historyShardsItemStatusInitialized:
+		i.status = historyShardsItemStatusStopped
+		return nil
+	case historyShardsItemStatusStarted:
+		i.logger.Info("", tag.LifeCycleStopping, tag.ComponentShardEngine)
+		i.engine.Stop()
+		err := i.shard.handoff()
+		i.engine = nil
+		i.shard = nil
+		i.logger.Info("", tag.LifeCycleStopped, tag.ComponentShardEngine)
+		i.status = historyShardsItemStatusStopped
+		return err
+	case historyShardsItemStatusStopped:
+		return nil
+	default:
+		panic(i.logInvalidStatus())
+	}
+}
+
 func (i *historyShardsItem) isValid() bool {
 	i.RLock()
 	defer i.RUnlock()
@@ -33,12 +33,14 @@ import (
 
 	"github.com/gogo/protobuf/types"
 
+	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
@@ -157,7 +159,9 @@ func (s *shardControllerSuite) TestAcquireShardSuccess() {
 						cluster.TestCurrentClusterName:     currentClusterTimerAck,
 						cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 					},
-					ClusterReplicationLevel: map[string]int64{},
+					ClusterReplicationLevel:           map[string]int64{},
+					IsolatedNamespaceTransferAckLevel: map[string]int64{},
+					IsolatedNamespaceTimerAckLevel:    map[string]*types.Timestamp{},
 				},
 				PreviousRangeID: 5,
 			}).Return(nil).Once()
@@ -237,7 +241,9 @@ func (s *shardControllerSuite) TestAcquireShardsConcurrently() {
 						cluster.TestCurrentClusterName:     currentClusterTimerAck,
 						cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 					},
-					ClusterReplicationLevel: map[string]int64{},
+					ClusterReplicationLevel:           map[string]int64{},
+					IsolatedNamespaceTransferAckLevel: map[string]int64{},
+					IsolatedNamespaceTimerAckLevel:    map[string]*types.Timestamp{},
 				},
 				PreviousRangeID: 5,
 			}).Return(nil).Once()
@@ -324,7 +330,9 @@ func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 					cluster.TestCurrentClusterName:     currentClusterTimerAck,
 					cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 				},
-				ClusterReplicationLevel: map[string]int64{},
+				ClusterReplicationLevel:           map[string]int64{},
+				IsolatedNamespaceTransferAckLevel: map[string]int64{},
+				IsolatedNamespaceTimerAckLevel:    map[string]*types.Timestamp{},
 			},
 			PreviousRangeID: 5,
 		}).Return(nil).Once()
@@ -396,7 +404,9 @@ func (s *shardControllerSuite) TestAcquireShardRenewLookupFailed() {
 					cluster.TestCurrentClusterName:     currentClusterTimerAck,
 					cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 				},
-				ClusterReplicationLevel: map[string]int64{},
+				ClusterReplicationLevel:           map[string]int64{},
+				IsolatedNamespaceTransferAckLevel: map[string]int64{},
+				IsolatedNamespaceTimerAckLevel:    map[string]*types.Timestamp{},
 			},
 			PreviousRangeID: 5,
 		}).Return(nil).Once()
@@ -553,6 +563,48 @@ func (s *shardControllerSuite) TestShardControllerClosed() {
 	workerWG.Wait()
 }
 
+func (s *shardControllerSuite) TestShardControllerHandoff() {
+	numShards := 2
+	s.config.NumberOfShards = numShards
+	s.shardController = newShardController(s.mockResource, s.mockEngineFactory, s.config)
+	historyEngines := make(map[int]*MockEngine)
+	for shardID := 0; shardID < numShards; shardID++ {
+		mockEngine := NewMockEngine(s.controller)
+		historyEngines[shardID] = mockEngine
+		s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+	}
+
+	s.mockServiceResolver.EXPECT().AddListener(shardControllerMembershipUpdateListenerName, gomock.Any()).Return(nil).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
+	s.shardController.Start()
+	s.Equal(numShards, s.shardController.numShards())
+
+	newOwner := membership.NewHostInfo("newhost", nil)
+	for shardID := 0; shardID < numShards; shardID++ {
+		historyEngines[shardID].EXPECT().Stop().Times(1)
+		s.mockServiceResolver.EXPECT().Lookup(string(shardID)).Return(newOwner, nil).AnyTimes()
+		s.mockResource.HistoryClient.EXPECT().AcquireShard(gomock.Any(), &historyservice.AcquireShardRequest{
+			ShardId: int32(shardID),
+		}).Return(&historyservice.AcquireShardResponse{}, nil).Times(1)
+	}
+	// the shard info is persisted with the range ID acquired by this host before the shard is handed off
+	s.mockShardManager.On("UpdateShard", mock.MatchedBy(func(request *persistence.UpdateShardRequest) bool {
+		return request.PreviousRangeID == 6
+	})).Return(nil).Times(numShards)
+
+	s.shardController.PrepareToStop()
+	s.shardController.handoffShards()
+	s.Equal(0, s.shardController.numShards())
+
+	// requests for handed off shards are redirected to the new owner
+	_, err := s.shardController.getEngineForShard(0)
+	s.IsType(&serviceerror.ShardOwnershipLost{}, err)
+
+	s.mockServiceResolver.EXPECT().RemoveListener(shardControllerMembershipUpdateListenerName).Return(nil).AnyTimes()
+	s.shardController.Stop()
+}
+
 func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine *MockEngine, currentRangeID,
 	newRangeID int64) {
 
@@ -603,7 +655,9 @@ func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine
 				cluster.TestCurrentClusterName:     currentClusterTimerAck,
 				cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 			},
-			ClusterReplicationLevel: map[string]int64{},
+			ClusterReplicationLevel:           map[string]int64{},
+			IsolatedNamespaceTransferAckLevel: map[string]int64{},
+			IsolatedNamespaceTimerAckLevel:    map[string]*types.Timestamp{},
 		},
 		PreviousRangeID: currentRangeID,
 	}).Return(nil).Once()
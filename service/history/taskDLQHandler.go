@@ -0,0 +1,296 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../LICENSE -package $GOPACKAGE -source $GOFILE -destination taskDLQHandler_mock.go -self_package github.com/temporalio/temporal/service/history
+
+package history
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	// taskDLQHandler is the interface handles transfer and timer task DLQ messages
+	taskDLQHandler interface {
+		readMessages(
+			dlqType enumsgenpb.DeadLetterQueueType,
+			lastMessageID int64,
+			pageSize int,
+			pageToken []byte,
+		) ([]*persistenceblobs.TransferTaskInfo, []*persistenceblobs.TimerTaskInfo, []byte, error)
+		purgeMessages(
+			dlqType enumsgenpb.DeadLetterQueueType,
+			lastMessageID int64,
+		) error
+		mergeMessages(
+			dlqType enumsgenpb.DeadLetterQueueType,
+			lastMessageID int64,
+			pageSize int,
+			pageToken []byte,
+		) ([]byte, error)
+	}
+
+	taskDLQHandlerImpl struct {
+		shard          ShardContext
+		txProcessor    transferQueueProcessor
+		timerProcessor timerQueueProcessor
+		logger         log.Logger
+	}
+)
+
+// task IDs are always positive, so the DLQs are always read and deleted from the beginning:
+// tasks leave the DLQ only by being merged or purged, there is no separate ack level.
+const taskDLQMinReadLevel = int64(0)
+
+func newTaskDLQHandler(
+	shard ShardContext,
+	txProcessor transferQueueProcessor,
+	timerProcessor timerQueueProcessor,
+) taskDLQHandler {
+
+	return &taskDLQHandlerImpl{
+		shard:          shard,
+		txProcessor:    txProcessor,
+		timerProcessor: timerProcessor,
+		logger:         shard.GetLogger(),
+	}
+}
+
+func (r *taskDLQHandlerImpl) readMessages(
+	dlqType enumsgenpb.DeadLetterQueueType,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]*persistenceblobs.TransferTaskInfo, []*persistenceblobs.TimerTaskInfo, []byte, error) {
+
+	switch dlqType {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER:
+		resp, err := r.shard.GetExecutionManager().GetTransferTasksFromDLQ(&persistence.GetTransferTasksFromDLQRequest{
+			ReadLevel:     taskDLQMinReadLevel,
+			MaxReadLevel:  lastMessageID,
+			BatchSize:     pageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return resp.Tasks, nil, resp.NextPageToken, nil
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		resp, err := r.shard.GetExecutionManager().GetTimerTasksFromDLQ(&persistence.GetTimerTasksFromDLQRequest{
+			ReadLevel:     taskDLQMinReadLevel,
+			MaxReadLevel:  lastMessageID,
+			BatchSize:     pageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return nil, resp.Timers, resp.NextPageToken, nil
+	default:
+		return nil, nil, nil, serviceerror.NewInvalidArgument(fmt.Sprintf("DLQ type %v is not a task DLQ.", dlqType))
+	}
+}
+
+func (r *taskDLQHandlerImpl) purgeMessages(
+	dlqType enumsgenpb.DeadLetterQueueType,
+	lastMessageID int64,
+) error {
+
+	return r.rangeDeleteMessages(dlqType, taskDLQMinReadLevel, lastMessageID)
+}
+
+func (r *taskDLQHandlerImpl) mergeMessages(
+	dlqType enumsgenpb.DeadLetterQueueType,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]byte, error) {
+
+	transferTasks, timerTasks, token, err := r.readMessages(dlqType, lastMessageID, pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// tasks are executed in task ID order, so on failure everything before the failed task can be removed
+	mergedTaskID := taskDLQMinReadLevel
+	var mergeErr error
+	for _, task := range transferTasks {
+		if mergeErr = r.handleTaskResult(task, r.txProcessor.ExecuteDLQTask(task)); mergeErr != nil {
+			break
+		}
+		mergedTaskID = task.GetTaskId()
+	}
+	for _, task := range timerTasks {
+		if mergeErr = r.handleTaskResult(task, r.timerProcessor.ExecuteDLQTask(task)); mergeErr != nil {
+			break
+		}
+		mergedTaskID = task.GetTaskId()
+	}
+
+	if mergedTaskID > taskDLQMinReadLevel {
+		if err := r.rangeDeleteMessages(dlqType, taskDLQMinReadLevel, mergedTaskID); err != nil {
+			return nil, err
+		}
+	}
+	if mergeErr != nil {
+		return nil, mergeErr
+	}
+	return token, nil
+}
+
+func (r *taskDLQHandlerImpl) handleTaskResult(
+	task queueTaskInfo,
+	err error,
+) error {
+
+	switch err.(type) {
+	case nil:
+		return nil
+	case *serviceerror.NotFound:
+		// the workflow is gone, nothing left to do for this task
+		return nil
+	}
+	if err == ErrTaskDiscarded {
+		return nil
+	}
+
+	r.shard.GetMetricsClient().IncCounter(getDLQMetricsScope(task), metrics.TaskDLQFailures)
+	r.logger.Error("Failed to merge task from DLQ.",
+		tag.WorkflowNamespaceID(task.GetNamespaceId()),
+		tag.WorkflowID(task.GetWorkflowId()),
+		tag.WorkflowRunID(task.GetRunId()),
+		tag.TaskID(task.GetTaskId()),
+		tag.TaskType(task.GetTaskType()),
+		tag.Error(err),
+	)
+	return err
+}
+
+func (r *taskDLQHandlerImpl) rangeDeleteMessages(
+	dlqType enumsgenpb.DeadLetterQueueType,
+	exclusiveBeginTaskID int64,
+	inclusiveEndTaskID int64,
+) error {
+
+	switch dlqType {
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER:
+		return r.shard.GetExecutionManager().RangeDeleteTransferTaskFromDLQ(&persistence.RangeDeleteTransferTaskFromDLQRequest{
+			ExclusiveBeginTaskID: exclusiveBeginTaskID,
+			InclusiveEndTaskID:   inclusiveEndTaskID,
+		})
+	case enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER:
+		return r.shard.GetExecutionManager().RangeDeleteTimerTaskFromDLQ(&persistence.RangeDeleteTimerTaskFromDLQRequest{
+			ExclusiveBeginTaskID: exclusiveBeginTaskID,
+			InclusiveEndTaskID:   inclusiveEndTaskID,
+		})
+	default:
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("DLQ type %v is not a task DLQ.", dlqType))
+	}
+}
+
+// moveTaskToDLQ persists a transfer or timer task which keeps failing into the task DLQ of its shard,
+// so it stops holding back the ack level of the queue and can be inspected, merged or purged by operators.
+func moveTaskToDLQ(
+	shard ShardContext,
+	task queueTaskInfo,
+) error {
+
+	switch task := task.(type) {
+	case *persistenceblobs.TransferTaskInfo:
+		return shard.GetExecutionManager().PutTransferTaskToDLQ(&persistence.PutTransferTaskToDLQRequest{
+			TaskInfo: task,
+		})
+	case *persistenceblobs.TimerTaskInfo:
+		return shard.GetExecutionManager().PutTimerTaskToDLQ(&persistence.PutTimerTaskToDLQRequest{
+			TaskInfo: task,
+		})
+	default:
+		return fmt.Errorf("unknown task type %T", task)
+	}
+}
+
+// emitTaskDLQStats emits whether the transfer or timer task DLQ of the shard holds any tasks.
+func emitTaskDLQStats(
+	shard ShardContext,
+	queueType queueType,
+) error {
+
+	var scope int
+	var isEmpty bool
+	switch queueType {
+	case transferQueueType:
+		resp, err := shard.GetExecutionManager().GetTransferTasksFromDLQ(&persistence.GetTransferTasksFromDLQRequest{
+			ReadLevel:    taskDLQMinReadLevel,
+			MaxReadLevel: math.MaxInt64,
+			BatchSize:    1,
+		})
+		if err != nil {
+			return err
+		}
+		scope = metrics.TransferQueueProcessorScope
+		isEmpty = len(resp.Tasks) == 0
+	case timerQueueType:
+		resp, err := shard.GetExecutionManager().GetTimerTasksFromDLQ(&persistence.GetTimerTasksFromDLQRequest{
+			ReadLevel:    taskDLQMinReadLevel,
+			MaxReadLevel: math.MaxInt64,
+			BatchSize:    1,
+		})
+		if err != nil {
+			return err
+		}
+		scope = metrics.TimerQueueProcessorScope
+		isEmpty = len(resp.Timers) == 0
+	default:
+		return fmt.Errorf("unknown queue type %v", queueType)
+	}
+
+	nonEmpty := 0.0
+	if !isEmpty {
+		nonEmpty = 1.0
+	}
+	shard.GetMetricsClient().Scope(scope).Tagged(metrics.InstanceTag(strconv.Itoa(shard.GetShardID()))).
+		UpdateGauge(metrics.TaskDLQNonEmptyGauge, nonEmpty)
+	return nil
+}
+
+func getDLQMetricsScope(
+	task queueTaskInfo,
+) int {
+
+	if _, ok := task.(*persistenceblobs.TimerTaskInfo); ok {
+		return metrics.TimerQueueProcessorScope
+	}
+	return metrics.TransferQueueProcessorScope
+}
@@ -0,0 +1,171 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	taskDLQHandlerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller         *gomock.Controller
+		mockShard          *shardContextTest
+		mockTxProcessor    *MocktransferQueueProcessor
+		mockTimerProcessor *MocktimerQueueProcessor
+
+		taskDLQHandler *taskDLQHandlerImpl
+	}
+)
+
+func TestTaskDLQHandlerSuite(t *testing.T) {
+	s := new(taskDLQHandlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *taskDLQHandlerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockShard = newTestShardContext(
+		s.controller,
+		&persistence.ShardInfoWithFailover{
+			ShardInfo: &persistenceblobs.ShardInfo{
+				ShardId: 10,
+				RangeId: 1,
+			}},
+		NewDynamicConfigForTest(),
+	)
+	s.mockTxProcessor = NewMocktransferQueueProcessor(s.controller)
+	s.mockTimerProcessor = NewMocktimerQueueProcessor(s.controller)
+
+	s.taskDLQHandler = newTaskDLQHandler(
+		s.mockShard,
+		s.mockTxProcessor,
+		s.mockTimerProcessor,
+	).(*taskDLQHandlerImpl)
+}
+
+func (s *taskDLQHandlerSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockShard.Finish(s.T())
+}
+
+func (s *taskDLQHandlerSuite) TestReadMessages_Transfer() {
+	tasks := []*persistenceblobs.TransferTaskInfo{{TaskId: 1}, {TaskId: 2}}
+	s.mockShard.resource.ExecutionMgr.On("GetTransferTasksFromDLQ", &persistence.GetTransferTasksFromDLQRequest{
+		ReadLevel:     taskDLQMinReadLevel,
+		MaxReadLevel:  math.MaxInt64,
+		BatchSize:     10,
+		NextPageToken: []byte{1},
+	}).Return(&persistence.GetTransferTasksFromDLQResponse{
+		Tasks:         tasks,
+		NextPageToken: []byte{2},
+	}, nil).Once()
+
+	transferTasks, timerTasks, token, err := s.taskDLQHandler.readMessages(
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER,
+		math.MaxInt64,
+		10,
+		[]byte{1},
+	)
+	s.NoError(err)
+	s.Equal(tasks, transferTasks)
+	s.Empty(timerTasks)
+	s.Equal([]byte{2}, token)
+}
+
+func (s *taskDLQHandlerSuite) TestReadMessages_NotTaskDLQ() {
+	_, _, _, err := s.taskDLQHandler.readMessages(
+		enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION,
+		math.MaxInt64,
+		10,
+		nil,
+	)
+	s.Error(err)
+}
+
+func (s *taskDLQHandlerSuite) TestPurgeMessages_Timer() {
+	s.mockShard.resource.ExecutionMgr.On("RangeDeleteTimerTaskFromDLQ", &persistence.RangeDeleteTimerTaskFromDLQRequest{
+		ExclusiveBeginTaskID: taskDLQMinReadLevel,
+		InclusiveEndTaskID:   100,
+	}).Return(nil).Once()
+
+	s.NoError(s.taskDLQHandler.purgeMessages(enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER, 100))
+}
+
+func (s *taskDLQHandlerSuite) TestMergeMessages_Timer() {
+	timers := []*persistenceblobs.TimerTaskInfo{{TaskId: 1}, {TaskId: 2}}
+	s.mockShard.resource.ExecutionMgr.On("GetTimerTasksFromDLQ", &persistence.GetTimerTasksFromDLQRequest{
+		ReadLevel:    taskDLQMinReadLevel,
+		MaxReadLevel: 100,
+		BatchSize:    10,
+	}).Return(&persistence.GetTimerTasksFromDLQResponse{
+		Timers: timers,
+	}, nil).Once()
+	s.mockTimerProcessor.EXPECT().ExecuteDLQTask(timers[0]).Return(nil).Times(1)
+	s.mockTimerProcessor.EXPECT().ExecuteDLQTask(timers[1]).Return(nil).Times(1)
+	s.mockShard.resource.ExecutionMgr.On("RangeDeleteTimerTaskFromDLQ", &persistence.RangeDeleteTimerTaskFromDLQRequest{
+		ExclusiveBeginTaskID: taskDLQMinReadLevel,
+		InclusiveEndTaskID:   2,
+	}).Return(nil).Once()
+
+	token, err := s.taskDLQHandler.mergeMessages(enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER, 100, 10, nil)
+	s.NoError(err)
+	s.Empty(token)
+}
+
+func (s *taskDLQHandlerSuite) TestMergeMessages_Transfer_PartialFailure() {
+	tasks := []*persistenceblobs.TransferTaskInfo{{TaskId: 1}, {TaskId: 2}, {TaskId: 3}}
+	s.mockShard.resource.ExecutionMgr.On("GetTransferTasksFromDLQ", &persistence.GetTransferTasksFromDLQRequest{
+		ReadLevel:    taskDLQMinReadLevel,
+		MaxReadLevel: 100,
+		BatchSize:    10,
+	}).Return(&persistence.GetTransferTasksFromDLQResponse{
+		Tasks: tasks,
+	}, nil).Once()
+	executeErr := errors.New("some random error")
+	s.mockTxProcessor.EXPECT().ExecuteDLQTask(tasks[0]).Return(nil).Times(1)
+	s.mockTxProcessor.EXPECT().ExecuteDLQTask(tasks[1]).Return(executeErr).Times(1)
+	s.mockShard.resource.ExecutionMgr.On("RangeDeleteTransferTaskFromDLQ", &persistence.RangeDeleteTransferTaskFromDLQRequest{
+		ExclusiveBeginTaskID: taskDLQMinReadLevel,
+		InclusiveEndTaskID:   1,
+	}).Return(nil).Once()
+
+	_, err := s.taskDLQHandler.mergeMessages(enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER, 100, 10, nil)
+	s.Equal(executeErr, err)
+}
@@ -57,6 +57,8 @@ type (
 		attempt   int
 		startTime time.Time
 		logger    log.Logger
+		// number of times the task was rejected by a draining task queue, not counted in attempt
+		drainingAttempt int
 
 		// used by 2DC task life cycle
 		// TODO remove when NDC task life cycle is implemented
@@ -208,7 +210,9 @@ FilterLoop:
 	op := func() error {
 		scope, err = t.processTaskOnce(notificationChan, task)
 		err := t.handleTaskError(scope, task, notificationChan, err)
-		if err != nil {
+		if common.IsTaskQueueDrainingError(err) {
+			task.drainingAttempt++
+		} else if err != nil {
 			task.attempt++
 			if task.attempt >= t.config.TimerTaskMaxRetryCount() {
 				scope.RecordTimer(metrics.TaskAttemptTimer, time.Duration(task.attempt))
@@ -223,7 +227,8 @@ FilterLoop:
 		case <-t.shutdownCh:
 			return false
 		default:
-			return true
+			// tasks rejected by a draining task queue are added back after a backoff instead
+			return !common.IsTaskQueueDrainingError(err)
 		}
 	}
 
@@ -238,10 +243,25 @@ FilterLoop:
 				t.ackTaskOnce(scope, task)
 				return
 			}
+			if common.IsTaskQueueDrainingError(err) {
+				// this must return without ack, the task is processed again once added back
+				t.addTaskAfterBackoff(task)
+				return
+			}
 		}
 	}
 }
 
+// addTaskAfterBackoff adds back a task rejected by a draining task queue once its backoff elapsed,
+// without holding a worker meanwhile
+func (t *taskProcessor) addTaskAfterBackoff(
+	task *taskInfo,
+) {
+	time.AfterFunc(taskQueueDrainingBackoff(t.config, task.drainingAttempt), func() {
+		t.addTask(task)
+	})
+}
+
 func (t *taskProcessor) processTaskOnce(
 	notificationChan <-chan struct{},
 	task *taskInfo,
@@ -299,6 +319,13 @@ func (t *taskProcessor) handleTaskError(
 		err = nil
 	}
 
+	// this is a transient error, adding the task is retried until the task queue is resumed,
+	// the attempts are not counted so that the task is never moved to the DLQ
+	if common.IsTaskQueueDrainingError(err) {
+		scope.IncCounter(metrics.TaskQueueDrainingCounter)
+		return err
+	}
+
 	// this is a transient error
 	// TODO remove this error check special case
 	//  since the new task life cycle will not give up until task processed / verified
@@ -318,6 +345,18 @@ func (t *taskProcessor) handleTaskError(
 		return nil
 	}
 
+	if task.attempt >= t.config.TimerTaskMaxRetryCount() &&
+		t.config.EnableTaskDLQByNamespaceID(task.task.GetNamespaceId()) { // use namespaceID here to avoid accessing namespaceCache
+		if dlqErr := moveTaskToDLQ(t.shard, task.task); dlqErr != nil {
+			scope.IncCounter(metrics.TaskDLQFailures)
+			task.logger.Error("Fail to move task to DLQ", tag.Error(dlqErr))
+		} else {
+			scope.IncCounter(metrics.TaskMovedToDLQCounter)
+			task.logger.Error("Task moved to DLQ after exceeding max retry count", tag.Error(err), tag.LifeCycleProcessingFailed)
+			return nil
+		}
+	}
+
 	task.logger.Error("Fail to process task", tag.Error(err), tag.LifeCycleProcessingFailed)
 	return err
 }
@@ -344,3 +383,19 @@ func (t *taskProcessor) getNamespaceTagByID(namespaceID string) metrics.Tag {
 	}
 	return metrics.NamespaceTag(namespace)
 }
+
+// taskQueueDrainingBackoff returns how long a task rejected by a draining task queue the given number
+// of times waits before it is processed again
+func taskQueueDrainingBackoff(
+	config *Config,
+	drainingAttempt int,
+) time.Duration {
+	maxInterval := config.TaskQueueDrainingMaxRetryInterval()
+	policy := backoff.NewExponentialRetryPolicy(config.TaskQueueDrainingRetryInterval())
+	policy.SetMaximumInterval(maxInterval)
+	policy.SetExpirationInterval(backoff.NoInterval)
+	if delay := policy.ComputeNextDelay(0, drainingAttempt-1); delay > 0 {
+		return delay
+	}
+	return maxInterval
+}
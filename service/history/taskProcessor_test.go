@@ -37,10 +37,12 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
 type (
@@ -193,6 +195,43 @@ func (s *taskProcessorSuite) TestProcessTaskAndAck_NamespaceTrue_ProcessErrNoErr
 	)
 }
 
+func (s *taskProcessorSuite) TestProcessTaskAndAck_TaskQueueDraining_AddedBackAfterBackoff() {
+	// errors are copied when returned by the matching service
+	err := serviceerror.FromStatus(serviceerror.ToStatus(common.ErrTaskQueueDraining))
+	s.taskProcessor.config.TimerTaskMaxRetryCount = dynamicconfig.GetIntPropertyFn(1)
+	s.taskProcessor.config.TaskQueueDrainingRetryInterval = dynamicconfig.GetDurationPropertyFn(time.Millisecond)
+	s.taskProcessor.config.TaskQueueDrainingMaxRetryInterval = dynamicconfig.GetDurationPropertyFn(time.Millisecond)
+	task := newTaskInfo(s.mockProcessor, &persistenceblobs.TimerTaskInfo{TaskId: 12345, VisibilityTimestamp: types.TimestampNow()}, s.logger)
+	var taskFilter taskFilter = func(task queueTaskInfo) (bool, error) {
+		return true, nil
+	}
+	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Twice()
+	s.mockProcessor.On("process", task).Return(s.scopeIdx, err).Once()
+	s.mockProcessor.On("process", task).Return(s.scopeIdx, nil).Once()
+	s.mockProcessor.On("complete", task).Once()
+	s.mockShard.resource.NamespaceCache.EXPECT().GetNamespaceName(gomock.Any()).Return(testNamespace, nil).Times(2)
+
+	// the worker is released without acking the task, which is added back after the backoff
+	s.taskProcessor.processTaskAndAck(
+		s.notificationChan,
+		task,
+	)
+	s.Equal(0, task.attempt)
+	s.Equal(1, task.drainingAttempt)
+	select {
+	case addedTask := <-s.taskProcessor.tasksCh:
+		s.Same(task, addedTask)
+	case <-time.After(time.Second):
+		s.Fail("task not added back")
+	}
+
+	s.taskProcessor.processTaskAndAck(
+		s.notificationChan,
+		task,
+	)
+	s.Equal(0, task.attempt)
+}
+
 func (s *taskProcessorSuite) TestHandleTaskError_EntityNotExists() {
 	err := serviceerror.NewNotFound("")
 
@@ -216,6 +255,25 @@ func (s *taskProcessorSuite) TestHandleTaskError_ErrTaskRetry() {
 	s.Equal(ErrTaskRetry, err)
 }
 
+func (s *taskProcessorSuite) TestHandleTaskError_TaskQueueDraining() {
+	err := common.ErrTaskQueueDraining
+
+	taskInfo := newTaskInfo(s.mockProcessor, nil, s.logger)
+	s.Equal(err, s.taskProcessor.handleTaskError(s.scope, taskInfo, s.notificationChan, err))
+}
+
+func (s *taskProcessorSuite) TestTaskQueueDrainingBackoff() {
+	config := s.taskProcessor.config
+	config.TaskQueueDrainingRetryInterval = dynamicconfig.GetDurationPropertyFn(time.Second)
+	config.TaskQueueDrainingMaxRetryInterval = dynamicconfig.GetDurationPropertyFn(time.Minute)
+
+	s.True(taskQueueDrainingBackoff(config, 1) <= time.Second)
+	s.True(taskQueueDrainingBackoff(config, 3) > time.Second)
+	s.True(taskQueueDrainingBackoff(config, 3) <= 4*time.Second)
+	s.True(taskQueueDrainingBackoff(config, 100) <= time.Minute)
+	s.True(taskQueueDrainingBackoff(config, 100) >= 48*time.Second)
+}
+
 func (s *taskProcessorSuite) TestHandleTaskError_ErrTaskDiscarded() {
 	err := ErrTaskDiscarded
 
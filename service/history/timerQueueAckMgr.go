@@ -86,6 +86,8 @@ type (
 		pageToken     []byte
 
 		clusterName string
+		// isolatedNamespaceID is set for the ack managers reading the queue partition of an isolated namespace
+		isolatedNamespaceID string
 	}
 	// for each cluster, the ack level is the point in time when
 	// all timers before the ack level are processed.
@@ -379,7 +381,11 @@ MoveAckLevelLoop:
 		// this means in failover mode, all possible failover timer tasks
 		// are processed and we are free to shutdown
 		t.logger.Debug("Timer ack manager shutdown")
-		t.finishedChan <- struct{}{}
+		select {
+		case t.finishedChan <- struct{}{}:
+		default:
+			// the processor has already been notified, this happens when the ack level is updated on shutdown
+		}
 		err := t.timerQueueShutdown()
 		if err != nil {
 			t.logger.Error("Error shutting down timer queue", tag.Error(err))
@@ -400,10 +406,11 @@ MoveAckLevelLoop:
 // all timer tasks are in this queue and filter will be applied.
 func (t *timerQueueAckMgrImpl) getTimerTasks(minTimestamp time.Time, maxTimestamp time.Time, batchSize int, pageToken []byte) ([]*persistenceblobs.TimerTaskInfo, []byte, error) {
 	request := &persistence.GetTimerIndexTasksRequest{
-		MinTimestamp:  minTimestamp,
-		MaxTimestamp:  maxTimestamp,
-		BatchSize:     batchSize,
-		NextPageToken: pageToken,
+		MinTimestamp:        minTimestamp,
+		MaxTimestamp:        maxTimestamp,
+		BatchSize:           batchSize,
+		NextPageToken:       pageToken,
+		IsolatedNamespaceID: t.isolatedNamespaceID,
 	}
 
 	retryCount := t.config.TimerProcessorGetFailureRetryCount()
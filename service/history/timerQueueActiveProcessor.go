@@ -257,6 +257,108 @@ func newTimerQueueFailoverProcessor(
 	return updateShardAckLevel, processor
 }
 
+// newTimerQueueIsolatedProcessor creates a processor for the timer queue partition of a single isolated namespace,
+// starting at minLevel.
+func newTimerQueueIsolatedProcessor(
+	shard ShardContext,
+	historyService *historyEngineImpl,
+	namespaceID string,
+	minLevel time.Time,
+	matchingClient matching.Client,
+	taskAllocator taskAllocator,
+	queueTaskProcessor queueTaskProcessor,
+	logger log.Logger,
+) *timerQueueActiveProcessorImpl {
+
+	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
+	timeNow := func() time.Time {
+		return shard.GetCurrentTime(currentClusterName)
+	}
+	updateShardAckLevel := func(ackLevel timerKey) error {
+		return shard.UpdateIsolatedNamespaceTimerAckLevel(namespaceID, ackLevel.VisibilityTimestamp)
+	}
+
+	logger = logger.WithTags(
+		tag.ClusterName(currentClusterName),
+		tag.WorkflowNamespaceID(namespaceID),
+	)
+	timerTaskFilter := func(taskInfo queueTaskInfo) (bool, error) {
+		timer, ok := taskInfo.(*persistenceblobs.TimerTaskInfo)
+		if !ok {
+			return false, errUnexpectedQueueTask
+		}
+		if timer.GetNamespaceId() != namespaceID {
+			return false, nil
+		}
+		return taskAllocator.verifyActiveTask(timer.GetNamespaceId(), timer)
+	}
+
+	timerQueueAckMgr := newTimerQueueAckMgr(
+		metrics.TimerActiveQueueProcessorScope,
+		shard,
+		historyService.metricsClient,
+		minLevel,
+		timeNow,
+		updateShardAckLevel,
+		logger,
+		currentClusterName,
+	)
+	timerQueueAckMgr.isolatedNamespaceID = namespaceID
+
+	timerGate := NewLocalTimerGate(shard.GetTimeSource())
+
+	redispatchQueue := collection.NewConcurrentQueue()
+
+	processor := &timerQueueActiveProcessorImpl{
+		shard:              shard,
+		timerTaskFilter:    timerTaskFilter,
+		now:                timeNow,
+		logger:             logger,
+		metricsClient:      historyService.metricsClient,
+		currentClusterName: currentClusterName,
+	}
+	processor.taskExecutor = newTimerQueueActiveTaskExecutor(
+		shard,
+		historyService,
+		processor,
+		logger,
+		historyService.metricsClient,
+		shard.GetConfig(),
+	)
+	timerQueueTaskInitializer := func(taskInfo queueTaskInfo) queueTask {
+		return newTimerQueueTask(
+			shard,
+			taskInfo,
+			historyService.metricsClient.Scope(
+				getTimerTaskMetricScope(taskInfo.GetTaskType(), true),
+			),
+			initializeLoggerForTask(shard.GetShardID(), taskInfo, logger),
+			timerTaskFilter,
+			processor.taskExecutor,
+			redispatchQueue,
+			shard.GetTimeSource(),
+			shard.GetConfig().TimerTaskMaxRetryCount,
+			timerQueueAckMgr,
+		)
+	}
+	processor.timerQueueProcessorBase = newTimerQueueProcessorBase(
+		metrics.TimerActiveQueueProcessorScope,
+		shard,
+		historyService,
+		processor,
+		queueTaskProcessor,
+		timerQueueAckMgr,
+		redispatchQueue,
+		timerQueueTaskInitializer,
+		timerGate,
+		shard.GetConfig().TimerProcessorIsolatedNamespaceMaxPollRPS,
+		logger,
+		shard.GetMetricsClient().Scope(metrics.TimerActiveQueueProcessorScope),
+	)
+
+	return processor
+}
+
 func (t *timerQueueActiveProcessorImpl) Start() {
 	t.timerQueueProcessorBase.Start()
 }
@@ -439,6 +439,8 @@ func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 		Name: activityInfo.TaskQueue,
 	}
 	scheduleToStartTimeout := activityInfo.ScheduleToStartTimeout
+	priority := activityInfo.Priority
+	fairnessKey := activityInfo.FairnessKey
 
 	release(nil) // release earlier as we don't need the lock anymore
 
@@ -449,6 +451,8 @@ func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 		TaskQueue:                     taskQueue,
 		ScheduleId:                    scheduledID,
 		ScheduleToStartTimeoutSeconds: scheduleToStartTimeout,
+		Priority:                      priority,
+		FairnessKey:                   fairnessKey,
 	})
 
 	return retError
@@ -30,10 +30,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
@@ -54,6 +56,7 @@ type (
 		NotifyNewTimers(clusterName string, timerTask []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskProcessing()
+		ExecuteDLQTask(task *persistenceblobs.TimerTaskInfo) error
 	}
 
 	timeNow                 func() time.Time
@@ -76,6 +79,12 @@ type (
 		queueTaskProcessor       queueTaskProcessor
 		activeTimerProcessor     *timerQueueActiveProcessorImpl
 		standbyTimerProcessors   map[string]*timerQueueStandbyProcessorImpl
+		namespaceIsolation       *namespaceIsolation
+
+		isolationLock           sync.RWMutex
+		isolatedTimerProcessors map[string]*timerQueueActiveProcessorImpl
+		// isolatedAckLevels are the levels up to which the timers of the queue partitions are completed
+		isolatedAckLevels map[string]time.Time
 	}
 )
 
@@ -154,7 +163,10 @@ func newTimerQueueProcessor(
 			queueTaskProcessor,
 			logger,
 		),
-		standbyTimerProcessors: standbyTimerProcessors,
+		standbyTimerProcessors:  standbyTimerProcessors,
+		namespaceIsolation:      newNamespaceIsolation(shard),
+		isolatedTimerProcessors: make(map[string]*timerQueueActiveProcessorImpl),
+		isolatedAckLevels:       make(map[string]time.Time),
 	}
 }
 
@@ -162,6 +174,7 @@ func (t *timerQueueProcessorImpl) Start() {
 	if !atomic.CompareAndSwapInt32(&t.isStarted, 0, 1) {
 		return
 	}
+	t.restoreIsolatedNamespaces()
 	t.activeTimerProcessor.Start()
 	if t.isGlobalNamespaceEnabled {
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
@@ -169,6 +182,7 @@ func (t *timerQueueProcessorImpl) Start() {
 		}
 	}
 	go t.completeTimersLoop()
+	go t.namespaceIsolationLoop()
 }
 
 func (t *timerQueueProcessorImpl) Stop() {
@@ -176,6 +190,11 @@ func (t *timerQueueProcessorImpl) Stop() {
 		return
 	}
 	t.activeTimerProcessor.Stop()
+	t.isolationLock.RLock()
+	for _, isolatedTimerProcessor := range t.isolatedTimerProcessors {
+		isolatedTimerProcessor.Stop()
+	}
+	t.isolationLock.RUnlock()
 	if t.isGlobalNamespaceEnabled {
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 			standbyTimerProcessor.Stop()
@@ -193,6 +212,11 @@ func (t *timerQueueProcessorImpl) NotifyNewTimers(
 
 	if clusterName == t.currentClusterName {
 		t.activeTimerProcessor.notifyNewTimers(timerTasks)
+		t.isolationLock.RLock()
+		for _, isolatedTimerProcessor := range t.isolatedTimerProcessors {
+			isolatedTimerProcessor.notifyNewTimers(timerTasks)
+		}
+		t.isolationLock.RUnlock()
 		return
 	}
 
@@ -263,6 +287,168 @@ func (t *timerQueueProcessorImpl) UnlockTaskProcessing() {
 	t.taskAllocator.unlock()
 }
 
+// ExecuteDLQTask executes a timer task merged back from the task DLQ with the executor of the cluster
+// its namespace is active in, tasks of namespaces no longer handled by any cluster are dropped
+func (t *timerQueueProcessorImpl) ExecuteDLQTask(
+	task *persistenceblobs.TimerTaskInfo,
+) error {
+
+	ok, err := t.taskAllocator.verifyActiveTask(task.GetNamespaceId(), task)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return t.activeTimerProcessor.taskExecutor.execute(task, true)
+	}
+
+	for clusterName, standbyTimerProcessor := range t.standbyTimerProcessors {
+		ok, err := t.taskAllocator.verifyStandbyTask(clusterName, task.GetNamespaceId(), task)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return standbyTimerProcessor.taskExecutor.execute(task, true)
+		}
+	}
+	return nil
+}
+
+// restoreIsolatedNamespaces resumes processing the queue partitions persisted in shard info from their ack level.
+// Namespaces which are still configured to be isolated keep writing their timers to their partition, the partitions
+// of the others are removed by refreshIsolatedNamespaces once drained.
+func (t *timerQueueProcessorImpl) restoreIsolatedNamespaces() {
+	t.isolationLock.Lock()
+	defer t.isolationLock.Unlock()
+
+	for namespaceID, ackLevel := range t.shard.GetAllIsolatedNamespaceTimerAckLevels() {
+		if t.namespaceIsolation.shouldIsolate(namespaceID) {
+			if err := t.shard.IsolateTimerNamespace(namespaceID, ackLevel); err != nil {
+				t.logger.Error("Error isolate namespace timer tasks", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+			}
+		}
+		t.startIsolatedTimerProcessorLocked(namespaceID, ackLevel)
+	}
+}
+
+func (t *timerQueueProcessorImpl) namespaceIsolationLoop() {
+	timer := time.NewTimer(t.config.QueueProcessorNamespaceIsolationRefreshInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-t.shutdownChan:
+			return
+		case <-timer.C:
+			t.refreshIsolatedNamespaces()
+			timer.Reset(t.config.QueueProcessorNamespaceIsolationRefreshInterval())
+		}
+	}
+}
+
+func (t *timerQueueProcessorImpl) refreshIsolatedNamespaces() {
+	t.isolationLock.Lock()
+	defer t.isolationLock.Unlock()
+
+	if atomic.LoadInt32(&t.isStopped) == 1 {
+		return
+	}
+
+	isolate, release := t.namespaceIsolation.refresh(t.shard.GetIsolatedTimerNamespaces())
+	for _, namespaceID := range isolate {
+		// the partition is empty unless it is still processed since the namespace was released, new timers are
+		// never before the read level of the shared queue, see allocateTimerIDsLocked
+		isolatedTimerProcessor, ok := t.isolatedTimerProcessors[namespaceID]
+		ackLevel := t.activeTimerProcessor.getAckLevel().VisibilityTimestamp
+		if ok {
+			ackLevel = isolatedTimerProcessor.getAckLevel().VisibilityTimestamp
+		}
+		t.logger.Info("Isolate namespace timer tasks", tag.WorkflowNamespaceID(namespaceID), tag.AckLevel(ackLevel))
+		if err := t.shard.IsolateTimerNamespace(namespaceID, ackLevel); err != nil {
+			t.logger.Error("Error isolate namespace timer tasks", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+			continue
+		}
+		if !ok {
+			t.startIsolatedTimerProcessorLocked(namespaceID, ackLevel)
+		}
+	}
+	for _, namespaceID := range release {
+		t.logger.Info("Release isolated namespace timer tasks", tag.WorkflowNamespaceID(namespaceID))
+		t.shard.ReleaseTimerNamespace(namespaceID)
+	}
+
+	// the partitions of released namespaces are processed until they are drained
+	isolatedNamespaceIDs := t.shard.GetIsolatedTimerNamespaces()
+	for namespaceID, isolatedTimerProcessor := range t.isolatedTimerProcessors {
+		if _, ok := isolatedNamespaceIDs[namespaceID]; ok {
+			continue
+		}
+		if err := t.removeDrainedTimerProcessorLocked(namespaceID, isolatedTimerProcessor); err != nil {
+			t.logger.Error("Error remove drained namespace timer tasks", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+		}
+	}
+}
+
+func (t *timerQueueProcessorImpl) startIsolatedTimerProcessorLocked(
+	namespaceID string,
+	ackLevel time.Time,
+) {
+
+	isolatedTimerProcessor := newTimerQueueIsolatedProcessor(
+		t.shard,
+		t.historyService,
+		namespaceID,
+		ackLevel,
+		t.matchingClient,
+		t.taskAllocator,
+		t.queueTaskProcessor,
+		t.logger,
+	)
+	t.isolatedTimerProcessors[namespaceID] = isolatedTimerProcessor
+	t.isolatedAckLevels[namespaceID] = ackLevel
+	isolatedTimerProcessor.Start()
+}
+
+// removeDrainedTimerProcessorLocked stops the processor of the partition of a released namespace and deletes the ack
+// level of the partition, if all the timers of the partition are processed.
+func (t *timerQueueProcessorImpl) removeDrainedTimerProcessorLocked(
+	namespaceID string,
+	isolatedTimerProcessor *timerQueueActiveProcessorImpl,
+) error {
+
+	ackLevel := isolatedTimerProcessor.getAckLevel()
+	response, err := t.shard.GetExecutionManager().GetTimerIndexTasks(&persistence.GetTimerIndexTasksRequest{
+		MinTimestamp:        ackLevel.VisibilityTimestamp,
+		MaxTimestamp:        maximumTime,
+		BatchSize:           t.config.TimerTaskBatchSize(),
+		IsolatedNamespaceID: namespaceID,
+	})
+	if err != nil {
+		return err
+	}
+	// the timers up to the ack level are processed, but may not be completed yet
+	if len(response.NextPageToken) != 0 {
+		return nil
+	}
+	for _, timer := range response.Timers {
+		if compareTimerIDLess(&ackLevel, timerKeyFromGogoTime(timer.GetVisibilityTimestamp(), timer.GetTaskId())) {
+			return nil
+		}
+	}
+
+	t.logger.Info("Remove drained namespace timer tasks", tag.WorkflowNamespaceID(namespaceID), tag.AckLevel(ackLevel))
+	isolatedTimerProcessor.Stop()
+	delete(t.isolatedTimerProcessors, namespaceID)
+	if err := t.shard.GetExecutionManager().RangeCompleteTimerTask(&persistence.RangeCompleteTimerTaskRequest{
+		InclusiveBeginTimestamp: t.isolatedAckLevels[namespaceID],
+		ExclusiveEndTimestamp:   maximumTime,
+		IsolatedNamespaceID:     namespaceID,
+	}); err != nil {
+		t.logger.Warn("Failed to complete drained namespace timer tasks", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+	}
+	delete(t.isolatedAckLevels, namespaceID)
+	return t.shard.DeleteIsolatedNamespaceTimerAckLevel(namespaceID)
+}
+
 func (t *timerQueueProcessorImpl) completeTimersLoop() {
 	timer := time.NewTimer(t.config.TimerProcessorCompleteTimerInterval())
 	defer timer.Stop()
@@ -289,12 +475,19 @@ func (t *timerQueueProcessorImpl) completeTimersLoop() {
 					break CompleteLoop
 				}
 			}
+			if err := emitTaskDLQStats(t.shard, timerQueueType); err != nil {
+				t.logger.Warn("Failed to read timer task DLQ", tag.Error(err))
+			}
 			timer.Reset(t.config.TimerProcessorCompleteTimerInterval())
 		}
 	}
 }
 
 func (t *timerQueueProcessorImpl) completeTimers() error {
+	if err := t.completeIsolatedTimers(); err != nil {
+		return err
+	}
+
 	lowerAckLevel := t.ackLevel
 	upperAckLevel := t.activeTimerProcessor.getAckLevel()
 
@@ -334,3 +527,39 @@ func (t *timerQueueProcessorImpl) completeTimers() error {
 
 	return t.shard.UpdateTimerAckLevel(t.ackLevel.VisibilityTimestamp)
 }
+
+// completeIsolatedTimers completes the timers of each queue partition up to the ack level of its processor
+func (t *timerQueueProcessorImpl) completeIsolatedTimers() error {
+	t.isolationLock.RLock()
+	lowerAckLevels := make(map[string]time.Time, len(t.isolatedTimerProcessors))
+	upperAckLevels := make(map[string]time.Time, len(t.isolatedTimerProcessors))
+	for namespaceID, isolatedTimerProcessor := range t.isolatedTimerProcessors {
+		lowerAckLevels[namespaceID] = t.isolatedAckLevels[namespaceID]
+		upperAckLevels[namespaceID] = isolatedTimerProcessor.getAckLevel().VisibilityTimestamp
+	}
+	t.isolationLock.RUnlock()
+
+	for namespaceID, upperAckLevel := range upperAckLevels {
+		lowerAckLevel := lowerAckLevels[namespaceID]
+		if !lowerAckLevel.Before(upperAckLevel) {
+			continue
+		}
+
+		t.metricsClient.IncCounter(metrics.TimerQueueProcessorScope, metrics.TaskBatchCompleteCounter)
+		if err := t.shard.GetExecutionManager().RangeCompleteTimerTask(&persistence.RangeCompleteTimerTaskRequest{
+			InclusiveBeginTimestamp: lowerAckLevel,
+			ExclusiveEndTimestamp:   upperAckLevel,
+			IsolatedNamespaceID:     namespaceID,
+		}); err != nil {
+			return err
+		}
+
+		t.isolationLock.Lock()
+		// the partition may have been removed in the meantime
+		if _, ok := t.isolatedTimerProcessors[namespaceID]; ok && t.isolatedAckLevels[namespaceID].Before(upperAckLevel) {
+			t.isolatedAckLevels[namespaceID] = upperAckLevel
+		}
+		t.isolationLock.Unlock()
+	}
+	return nil
+}
@@ -180,6 +180,11 @@ func (t *timerQueueProcessorBase) Stop() {
 	if t.taskProcessor != nil {
 		t.taskProcessor.stop()
 	}
+
+	// record the ack level reached so far, so that it is persisted if the shard is handed off
+	if err := t.timerQueueAckMgr.updateAckLevel(); err != nil && err != ErrShardClosed {
+		t.logger.Warn("Failed to update ack level on shutdown", tag.Error(err))
+	}
 	t.logger.Info("Timer queue processor stopped.")
 }
 
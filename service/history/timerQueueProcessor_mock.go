@@ -30,6 +30,7 @@ package history
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	persistenceblobs "github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	persistence "github.com/temporalio/temporal/common/persistence"
 	reflect "reflect"
 )
@@ -128,3 +129,17 @@ func (mr *MocktimerQueueProcessorMockRecorder) UnlockTaskProcessing() *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskProcessing", reflect.TypeOf((*MocktimerQueueProcessor)(nil).UnlockTaskProcessing))
 }
+
+// ExecuteDLQTask mocks base method.
+func (m *MocktimerQueueProcessor) ExecuteDLQTask(task *persistenceblobs.TimerTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteDLQTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteDLQTask indicates an expected call of ExecuteDLQTask.
+func (mr *MocktimerQueueProcessorMockRecorder) ExecuteDLQTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteDLQTask", reflect.TypeOf((*MocktimerQueueProcessor)(nil).ExecuteDLQTask), task)
+}
@@ -304,6 +304,133 @@ func newTransferQueueFailoverProcessor(
 	return updateTransferAckLevel, processor
 }
 
+// newTransferQueueIsolatedProcessor creates a processor for the transfer queue partition of a single isolated
+// namespace, starting at minLevel.
+func newTransferQueueIsolatedProcessor(
+	shard ShardContext,
+	historyService *historyEngineImpl,
+	visibilityMgr persistence.VisibilityManager,
+	matchingClient matching.Client,
+	historyClient history.Client,
+	namespaceID string,
+	minLevel int64,
+	taskAllocator taskAllocator,
+	queueTaskProcessor queueTaskProcessor,
+	logger log.Logger,
+) *transferQueueActiveProcessorImpl {
+
+	config := shard.GetConfig()
+	options := &QueueProcessorOptions{
+		BatchSize:                           config.TransferTaskBatchSize,
+		WorkerCount:                         config.TransferTaskWorkerCount,
+		MaxPollRPS:                          config.TransferProcessorIsolatedNamespaceMaxPollRPS,
+		MaxPollInterval:                     config.TransferProcessorMaxPollInterval,
+		MaxPollIntervalJitterCoefficient:    config.TransferProcessorMaxPollIntervalJitterCoefficient,
+		UpdateAckInterval:                   config.TransferProcessorUpdateAckInterval,
+		UpdateAckIntervalJitterCoefficient:  config.TransferProcessorUpdateAckIntervalJitterCoefficient,
+		MaxRetryCount:                       config.TransferTaskMaxRetryCount,
+		RedispatchInterval:                  config.TransferProcessorRedispatchInterval,
+		RedispatchIntervalJitterCoefficient: config.TransferProcessorRedispatchIntervalJitterCoefficient,
+		MaxRedispatchQueueSize:              config.TransferProcessorMaxRedispatchQueueSize,
+		EnablePriorityTaskProcessor:         config.TransferProcessorEnablePriorityTaskProcessor,
+		MetricScope:                         metrics.TransferActiveQueueProcessorScope,
+	}
+	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
+	logger = logger.WithTags(
+		tag.ClusterName(currentClusterName),
+		tag.WorkflowNamespaceID(namespaceID),
+	)
+
+	transferTaskFilter := func(taskInfo queueTaskInfo) (bool, error) {
+		task, ok := taskInfo.(*persistenceblobs.TransferTaskInfo)
+		if !ok {
+			return false, errUnexpectedQueueTask
+		}
+		if task.GetNamespaceId() != namespaceID {
+			return false, nil
+		}
+		return taskAllocator.verifyActiveTask(task.GetNamespaceId(), task)
+	}
+	maxReadAckLevel := func() int64 {
+		return shard.GetTransferMaxReadLevel()
+	}
+	updateTransferAckLevel := func(ackLevel int64) error {
+		return shard.UpdateIsolatedNamespaceTransferAckLevel(namespaceID, ackLevel)
+	}
+	transferQueueShutdown := func() error {
+		return nil
+	}
+
+	processor := &transferQueueActiveProcessorImpl{
+		currentClusterName: currentClusterName,
+		shard:              shard,
+		logger:             logger,
+		metricsClient:      historyService.metricsClient,
+		transferTaskFilter: transferTaskFilter,
+		taskExecutor: newTransferQueueActiveTaskExecutor(
+			shard,
+			historyService,
+			logger,
+			historyService.metricsClient,
+			config,
+		),
+		transferQueueProcessorBase: newTransferQueueProcessorBase(
+			shard,
+			options,
+			maxReadAckLevel,
+			updateTransferAckLevel,
+			transferQueueShutdown,
+			logger,
+		),
+	}
+
+	processor.transferQueueProcessorBase.isolatedNamespaceID = namespaceID
+
+	queueAckMgr := newQueueAckMgr(
+		shard,
+		options,
+		processor,
+		minLevel,
+		logger,
+	)
+
+	redispatchQueue := collection.NewConcurrentQueue()
+
+	transferQueueTaskInitializer := func(taskInfo queueTaskInfo) queueTask {
+		return newTransferQueueTask(
+			shard,
+			taskInfo,
+			historyService.metricsClient.Scope(
+				getTransferTaskMetricsScope(taskInfo.GetTaskType(), true),
+			),
+			initializeLoggerForTask(shard.GetShardID(), taskInfo, logger),
+			transferTaskFilter,
+			processor.taskExecutor,
+			redispatchQueue,
+			shard.GetTimeSource(),
+			options.MaxRetryCount,
+			queueAckMgr,
+		)
+	}
+
+	queueProcessorBase := newQueueProcessorBase(
+		currentClusterName,
+		shard,
+		options,
+		processor,
+		queueTaskProcessor,
+		queueAckMgr,
+		redispatchQueue,
+		historyService.historyCache,
+		transferQueueTaskInitializer,
+		logger,
+		shard.GetMetricsClient().Scope(metrics.TransferActiveQueueProcessorScope),
+	)
+	processor.queueAckMgr = queueAckMgr
+	processor.queueProcessorBase = queueProcessorBase
+	return processor
+}
+
 func (t *transferQueueActiveProcessorImpl) getTaskFilter() taskFilter {
 	return t.transferTaskFilter
 }
@@ -155,10 +155,12 @@ func (t *transferQueueActiveTaskExecutor) processActivityTask(
 	}
 
 	timeout := common.MinInt32(ai.ScheduleToStartTimeout, common.MaxTaskTimeout)
+	priority := ai.Priority
+	fairnessKey := ai.FairnessKey
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	return t.pushActivity(task, timeout)
+	return t.pushActivity(task, timeout, priority, fairnessKey)
 }
 
 func (t *transferQueueActiveTaskExecutor) processDecisionTask(
@@ -194,6 +196,9 @@ func (t *transferQueueActiveTaskExecutor) processDecisionTask(
 	executionInfo := mutableState.GetExecutionInfo()
 	runTimeout := executionInfo.WorkflowRunTimeout
 	taskTimeout := common.MinInt32(runTimeout, common.MaxTaskTimeout)
+	priority := executionInfo.Priority
+	fairnessKey := executionInfo.FairnessKey
+	buildID := getWorkerBuildID(executionInfo)
 
 	// NOTE: previously this section check whether mutable state has enabled
 	// sticky decision, if so convert the decision to a sticky decision.
@@ -213,7 +218,7 @@ func (t *transferQueueActiveTaskExecutor) processDecisionTask(
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	return t.pushDecision(task, taskQueue, taskTimeout)
+	return t.pushDecision(task, taskQueue, taskTimeout, priority, fairnessKey, buildID)
 }
 
 func (t *transferQueueActiveTaskExecutor) processCloseExecution(
@@ -262,6 +267,7 @@ func (t *transferQueueActiveTaskExecutor) processCloseExecution(
 	workflowCloseTimestamp := wfCloseTime
 	workflowStatus := executionInfo.Status
 	workflowHistoryLength := mutableState.GetNextEventID() - 1
+	workflowHistorySize := weContext.getHistorySize()
 
 	startEvent, err := mutableState.GetStartEvent()
 	if err != nil {
@@ -287,10 +293,13 @@ func (t *transferQueueActiveTaskExecutor) processCloseExecution(
 		workflowCloseTimestamp,
 		workflowStatus,
 		workflowHistoryLength,
+		workflowHistorySize,
 		task.GetTaskId(),
 		visibilityMemo,
 		executionInfo.TaskQueue,
+		executionInfo.RetentionDays,
 		searchAttr,
+		false,
 	)
 	if err != nil {
 		return err
@@ -638,6 +647,57 @@ func (t *transferQueueActiveTaskExecutor) processUpsertWorkflowSearchAttributes(
 	return t.processRecordWorkflowStartedOrUpsertHelper(task, false)
 }
 
+func (t *transferQueueActiveTaskExecutor) processRecordWorkflowClosedOnUpsert(
+	task *persistenceblobs.TransferTaskInfo,
+	context workflowExecutionContext,
+	mutableState mutableState,
+	release releaseWorkflowExecutionFunc,
+) error {
+
+	executionInfo := mutableState.GetExecutionInfo()
+	startEvent, err := mutableState.GetStartEvent()
+	if err != nil {
+		return err
+	}
+	completionEvent, err := mutableState.GetCompletionEvent()
+	if err != nil {
+		return err
+	}
+	wfTypeName := executionInfo.WorkflowTypeName
+	startTimestamp := startEvent.GetTimestamp()
+	executionTimestamp := getWorkflowExecutionTimestamp(mutableState, startEvent)
+	closeTimestamp := completionEvent.GetTimestamp()
+	status := executionInfo.Status
+	historyLength := mutableState.GetNextEventID() - 1
+	historySize := context.getHistorySize()
+	visibilityMemo := getWorkflowMemo(copySearchAttributes(executionInfo.Memo))
+	searchAttr := copySearchAttributes(executionInfo.SearchAttributes)
+	taskQueue := executionInfo.TaskQueue
+	retentionDays := executionInfo.RetentionDays
+
+	// release the context lock since we no longer need mutable state builder and
+	// the rest of logic is making RPC call, which takes time.
+	release(nil)
+	return t.recordWorkflowClosed(
+		task.GetNamespaceId(),
+		task.GetWorkflowId(),
+		task.GetRunId(),
+		wfTypeName,
+		startTimestamp,
+		executionTimestamp.UnixNano(),
+		closeTimestamp,
+		status,
+		historyLength,
+		historySize,
+		task.GetTaskId(),
+		visibilityMemo,
+		taskQueue,
+		retentionDays,
+		searchAttr,
+		true,
+	)
+}
+
 func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHelper(
 	task *persistenceblobs.TransferTaskInfo,
 	recordStart bool,
@@ -655,9 +715,17 @@ func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHe
 	if err != nil {
 		return err
 	}
-	if mutableState == nil || !mutableState.IsWorkflowExecutionRunning() {
+	if mutableState == nil {
 		return nil
 	}
+	if !mutableState.IsWorkflowExecutionRunning() {
+		if recordStart {
+			return nil
+		}
+		// metadata of closed workflows can be updated from outside the workflow,
+		// which is synced by recording the close again
+		return t.processRecordWorkflowClosedOnUpsert(task, context, mutableState, release)
+	}
 
 	// verify task version for RecordWorkflowStarted.
 	// upsert doesn't require verifyTask, because it is just a sync of mutableState.
@@ -683,6 +751,8 @@ func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHe
 	executionTimestamp := getWorkflowExecutionTimestamp(mutableState, startEvent)
 	visibilityMemo := getWorkflowMemo(executionInfo.Memo)
 	searchAttr := copySearchAttributes(executionInfo.SearchAttributes)
+	historyLength := mutableState.GetNextEventID() - 1
+	historySize := context.getHistorySize()
 
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
@@ -711,6 +781,8 @@ func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHe
 		startTimestamp,
 		executionTimestamp.UnixNano(),
 		runTimeout,
+		historyLength,
+		historySize,
 		task.GetTaskId(),
 		executionInfo.TaskQueue,
 		visibilityMemo,
@@ -726,6 +726,65 @@ func (s *transferQueueActiveTaskExecutorSuite) TestProcessCloseExecution_NoParen
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessCloseExecution_RetentionDays() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskQueueName := "some random task queue"
+	retentionDays, err := payload.Encode(int32(5))
+	s.NoError(err)
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err = mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+				TaskQueue:                       &taskqueuepb.TaskQueue{Name: taskQueueName},
+				WorkflowExecutionTimeoutSeconds: 2,
+				WorkflowTaskTimeoutSeconds:      1,
+				Header: &commonpb.Header{Fields: map[string]*commonpb.Payload{
+					common.WorkflowRetentionDaysHeaderKey: retentionDays,
+				}},
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskQueueName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+
+	taskID := int64(59)
+	event = addCompleteWorkflowEvent(mutableState, event.GetEventId(), nil)
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:     s.version,
+		NamespaceId: s.namespaceID,
+		WorkflowId:  execution.GetWorkflowId(),
+		RunId:       execution.GetRunId(),
+		TaskId:      taskID,
+		TaskQueue:   taskQueueName,
+		TaskType:    enumsgenpb.TASK_TYPE_TRANSFER_CLOSE_EXECUTION,
+		ScheduleId:  event.GetEventId(),
+	}
+
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.MatchedBy(func(request *persistence.RecordWorkflowExecutionClosedRequest) bool {
+		return request.RetentionSeconds == int64(5*secondsInDay)
+	})).Return(nil).Once()
+	s.mockArchivalMetadata.On("GetVisibilityConfig").Return(archiver.NewDisabledArchvialConfig())
+
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveTaskExecutorSuite) TestProcessCloseExecution_NoParent_HasFewChildren() {
 
 	execution := commonpb.WorkflowExecution{
@@ -1846,6 +1905,65 @@ func (s *transferQueueActiveTaskExecutorSuite) TestProcessUpsertWorkflowSearchAt
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveTaskExecutorSuite) TestProcessUpsertWorkflowSearchAttributes_Closed() {
+
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "some random workflow ID",
+		RunId:      uuid.New(),
+	}
+	workflowType := "some random workflow type"
+	taskQueueName := "some random task queue"
+	retentionDays, err := payload.Encode(int32(5))
+	s.NoError(err)
+
+	mutableState := newMutableStateBuilderWithReplicationStateWithEventV2(s.mockShard, s.mockShard.GetEventsCache(), s.logger, s.version, execution.GetRunId())
+	_, err = mutableState.AddWorkflowExecutionStartedEvent(
+		execution,
+		&historyservice.StartWorkflowExecutionRequest{
+			NamespaceId: s.namespaceID,
+			StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+				WorkflowType:                    &commonpb.WorkflowType{Name: workflowType},
+				TaskQueue:                       &taskqueuepb.TaskQueue{Name: taskQueueName},
+				WorkflowExecutionTimeoutSeconds: 2,
+				WorkflowTaskTimeoutSeconds:      1,
+				Header: &commonpb.Header{Fields: map[string]*commonpb.Payload{
+					common.WorkflowRetentionDaysHeaderKey: retentionDays,
+				}},
+			},
+		},
+	)
+	s.Nil(err)
+
+	di := addDecisionTaskScheduledEvent(mutableState)
+	event := addDecisionTaskStartedEvent(mutableState, di.ScheduleID, taskQueueName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(mutableState, di.ScheduleID, di.StartedID, "some random identity")
+	event = addCompleteWorkflowEvent(mutableState, event.GetEventId(), nil)
+
+	transferTask := &persistenceblobs.TransferTaskInfo{
+		Version:     s.version,
+		NamespaceId: s.namespaceID,
+		WorkflowId:  execution.GetWorkflowId(),
+		RunId:       execution.GetRunId(),
+		TaskId:      int64(59),
+		TaskQueue:   taskQueueName,
+		TaskType:    enumsgenpb.TASK_TYPE_TRANSFER_UPSERT_WORKFLOW_SEARCH_ATTRIBUTES,
+		ScheduleId:  event.GetEventId(),
+	}
+
+	// the metadata is updated a day after the close, the record keeps expiring 5 days after the close
+	s.timeSource.Update(s.now.Add(24 * time.Hour))
+	persistenceMutableState := s.createPersistenceMutableState(mutableState, event.GetEventId(), event.GetVersion())
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionClosed", mock.MatchedBy(func(request *persistence.RecordWorkflowExecutionClosedRequest) bool {
+		return request.CloseTimestamp == event.GetTimestamp() && request.RetentionSeconds == int64(4*secondsInDay)
+	})).Return(nil).Once()
+	s.mockArchivalMetadata.On("GetVisibilityConfig").Return(archiver.NewDisabledArchvialConfig())
+
+	err = s.transferQueueActiveTaskExecutor.execute(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveTaskExecutorSuite) TestCopySearchAttributes() {
 	var input map[string]*commonpb.Payload
 	s.Nil(copySearchAttributes(input))
@@ -2054,6 +2172,7 @@ func (s *transferQueueActiveTaskExecutorSuite) createUpsertWorkflowSearchAttribu
 		WorkflowTypeName: executionInfo.WorkflowTypeName,
 		StartTimestamp:   startEvent.GetTimestamp(),
 		WorkflowTimeout:  int64(executionInfo.WorkflowRunTimeout),
+		HistoryLength:    mutableState.GetNextEventID() - 1,
 		TaskID:           task.GetTaskId(),
 		TaskQueue:        task.TaskQueue,
 	}
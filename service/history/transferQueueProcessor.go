@@ -30,10 +30,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
@@ -55,6 +58,7 @@ type (
 		NotifyNewTask(clusterName string, transferTasks []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskPrrocessing()
+		ExecuteDLQTask(task *persistenceblobs.TransferTaskInfo) error
 	}
 
 	taskFilter func(task queueTaskInfo) (bool, error)
@@ -78,6 +82,12 @@ type (
 		queueTaskProcessor       queueTaskProcessor
 		activeTaskProcessor      *transferQueueActiveProcessorImpl
 		standbyTaskProcessors    map[string]*transferQueueStandbyProcessorImpl
+		namespaceIsolation       *namespaceIsolation
+
+		isolationLock          sync.RWMutex
+		isolatedTaskProcessors map[string]*transferQueueActiveProcessorImpl
+		// isolatedAckLevels are the levels up to which the tasks of the queue partitions are completed
+		isolatedAckLevels map[string]int64
 	}
 )
 
@@ -163,7 +173,10 @@ func newTransferQueueProcessor(
 			queueTaskProcessor,
 			logger,
 		),
-		standbyTaskProcessors: standbyTaskProcessors,
+		standbyTaskProcessors:  standbyTaskProcessors,
+		namespaceIsolation:     newNamespaceIsolation(shard),
+		isolatedTaskProcessors: make(map[string]*transferQueueActiveProcessorImpl),
+		isolatedAckLevels:      make(map[string]int64),
 	}
 }
 
@@ -171,6 +184,7 @@ func (t *transferQueueProcessorImpl) Start() {
 	if !atomic.CompareAndSwapInt32(&t.isStarted, 0, 1) {
 		return
 	}
+	t.restoreIsolatedNamespaces()
 	t.activeTaskProcessor.Start()
 	if t.isGlobalNamespaceEnabled {
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
@@ -179,6 +193,7 @@ func (t *transferQueueProcessorImpl) Start() {
 	}
 
 	go t.completeTransferLoop()
+	go t.namespaceIsolationLoop()
 }
 
 func (t *transferQueueProcessorImpl) Stop() {
@@ -186,6 +201,11 @@ func (t *transferQueueProcessorImpl) Stop() {
 		return
 	}
 	t.activeTaskProcessor.Stop()
+	t.isolationLock.RLock()
+	for _, isolatedTaskProcessor := range t.isolatedTaskProcessors {
+		isolatedTaskProcessor.Stop()
+	}
+	t.isolationLock.RUnlock()
 	if t.isGlobalNamespaceEnabled {
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 			standbyTaskProcessor.Stop()
@@ -205,6 +225,11 @@ func (t *transferQueueProcessorImpl) NotifyNewTask(
 		// we will ignore the current time passed in, since the active processor process task immediately
 		if len(transferTasks) != 0 {
 			t.activeTaskProcessor.notifyNewTask()
+			t.isolationLock.RLock()
+			for _, isolatedTaskProcessor := range t.isolatedTaskProcessors {
+				isolatedTaskProcessor.notifyNewTask()
+			}
+			t.isolationLock.RUnlock()
 		}
 		return
 	}
@@ -278,6 +303,165 @@ func (t *transferQueueProcessorImpl) UnlockTaskPrrocessing() {
 	t.taskAllocator.unlock()
 }
 
+// ExecuteDLQTask executes a transfer task merged back from the task DLQ with the executor of the cluster
+// its namespace is active in, tasks of namespaces no longer handled by any cluster are dropped
+func (t *transferQueueProcessorImpl) ExecuteDLQTask(
+	task *persistenceblobs.TransferTaskInfo,
+) error {
+
+	ok, err := t.taskAllocator.verifyActiveTask(task.GetNamespaceId(), task)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return t.activeTaskProcessor.taskExecutor.execute(task, true)
+	}
+
+	for clusterName, standbyTaskProcessor := range t.standbyTaskProcessors {
+		ok, err := t.taskAllocator.verifyStandbyTask(clusterName, task.GetNamespaceId(), task)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return standbyTaskProcessor.taskExecutor.execute(task, true)
+		}
+	}
+	return nil
+}
+
+// restoreIsolatedNamespaces resumes processing the queue partitions persisted in shard info from their ack level.
+// Namespaces which are still configured to be isolated keep writing their tasks to their partition, the partitions of
+// the others are removed by refreshIsolatedNamespaces once drained.
+func (t *transferQueueProcessorImpl) restoreIsolatedNamespaces() {
+	t.isolationLock.Lock()
+	defer t.isolationLock.Unlock()
+
+	for namespaceID, ackLevel := range t.shard.GetAllIsolatedNamespaceTransferAckLevels() {
+		if t.namespaceIsolation.shouldIsolate(namespaceID) {
+			if err := t.shard.IsolateTransferNamespace(namespaceID, ackLevel); err != nil {
+				t.logger.Error("Error isolate namespace transfer tasks", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+			}
+		}
+		t.startIsolatedTaskProcessorLocked(namespaceID, ackLevel)
+	}
+}
+
+func (t *transferQueueProcessorImpl) namespaceIsolationLoop() {
+	timer := time.NewTimer(t.config.QueueProcessorNamespaceIsolationRefreshInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-t.shutdownChan:
+			return
+		case <-timer.C:
+			t.refreshIsolatedNamespaces()
+			timer.Reset(t.config.QueueProcessorNamespaceIsolationRefreshInterval())
+		}
+	}
+}
+
+func (t *transferQueueProcessorImpl) refreshIsolatedNamespaces() {
+	t.isolationLock.Lock()
+	defer t.isolationLock.Unlock()
+
+	if atomic.LoadInt32(&t.isStopped) == 1 {
+		return
+	}
+
+	isolate, release := t.namespaceIsolation.refresh(t.shard.GetIsolatedTransferNamespaces())
+	for _, namespaceID := range isolate {
+		// the partition is empty unless it is still processed since the namespace was released
+		isolatedTaskProcessor, ok := t.isolatedTaskProcessors[namespaceID]
+		ackLevel := t.activeTaskProcessor.queueAckMgr.getQueueAckLevel()
+		if ok {
+			ackLevel = isolatedTaskProcessor.queueAckMgr.getQueueAckLevel()
+		}
+		t.logger.Info("Isolate namespace transfer tasks", tag.WorkflowNamespaceID(namespaceID), tag.AckLevel(ackLevel))
+		if err := t.shard.IsolateTransferNamespace(namespaceID, ackLevel); err != nil {
+			t.logger.Error("Error isolate namespace transfer tasks", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+			continue
+		}
+		if !ok {
+			t.startIsolatedTaskProcessorLocked(namespaceID, ackLevel)
+		}
+	}
+	for _, namespaceID := range release {
+		t.logger.Info("Release isolated namespace transfer tasks", tag.WorkflowNamespaceID(namespaceID))
+		t.shard.ReleaseTransferNamespace(namespaceID)
+	}
+
+	// the partitions of released namespaces are processed until they are drained
+	isolatedNamespaceIDs := t.shard.GetIsolatedTransferNamespaces()
+	for namespaceID, isolatedTaskProcessor := range t.isolatedTaskProcessors {
+		if _, ok := isolatedNamespaceIDs[namespaceID]; ok {
+			continue
+		}
+		if err := t.removeDrainedTaskProcessorLocked(namespaceID, isolatedTaskProcessor); err != nil {
+			t.logger.Error("Error remove drained namespace transfer tasks", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+		}
+	}
+}
+
+func (t *transferQueueProcessorImpl) startIsolatedTaskProcessorLocked(
+	namespaceID string,
+	ackLevel int64,
+) {
+
+	isolatedTaskProcessor := newTransferQueueIsolatedProcessor(
+		t.shard,
+		t.historyService,
+		t.visibilityMgr,
+		t.matchingClient,
+		t.historyClient,
+		namespaceID,
+		ackLevel,
+		t.taskAllocator,
+		t.queueTaskProcessor,
+		t.logger,
+	)
+	t.isolatedTaskProcessors[namespaceID] = isolatedTaskProcessor
+	t.isolatedAckLevels[namespaceID] = ackLevel
+	isolatedTaskProcessor.Start()
+}
+
+// removeDrainedTaskProcessorLocked stops the processor of the partition of a released namespace and deletes the ack
+// level of the partition, if all the tasks of the partition are processed.
+func (t *transferQueueProcessorImpl) removeDrainedTaskProcessorLocked(
+	namespaceID string,
+	isolatedTaskProcessor *transferQueueActiveProcessorImpl,
+) error {
+
+	ackLevel := isolatedTaskProcessor.queueAckMgr.getQueueAckLevel()
+	response, err := t.shard.GetExecutionManager().GetTransferTasks(&persistence.GetTransferTasksRequest{
+		ReadLevel:           ackLevel,
+		MaxReadLevel:        math.MaxInt64,
+		BatchSize:           1,
+		IsolatedNamespaceID: namespaceID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(response.Tasks) != 0 {
+		return nil
+	}
+
+	t.logger.Info("Remove drained namespace transfer tasks", tag.WorkflowNamespaceID(namespaceID), tag.AckLevel(ackLevel))
+	isolatedTaskProcessor.Stop()
+	delete(t.isolatedTaskProcessors, namespaceID)
+	if t.isolatedAckLevels[namespaceID] < ackLevel {
+		if err := t.shard.GetExecutionManager().RangeCompleteTransferTask(&persistence.RangeCompleteTransferTaskRequest{
+			ExclusiveBeginTaskID: t.isolatedAckLevels[namespaceID],
+			InclusiveEndTaskID:   ackLevel,
+			IsolatedNamespaceID:  namespaceID,
+		}); err != nil {
+			t.logger.Warn("Failed to complete drained namespace transfer tasks", tag.WorkflowNamespaceID(namespaceID), tag.Error(err))
+		}
+	}
+	delete(t.isolatedAckLevels, namespaceID)
+	return t.shard.DeleteIsolatedNamespaceTransferAckLevel(namespaceID)
+}
+
 func (t *transferQueueProcessorImpl) completeTransferLoop() {
 	timer := time.NewTimer(t.config.TransferProcessorCompleteTransferInterval())
 	defer timer.Stop()
@@ -308,12 +492,19 @@ func (t *transferQueueProcessorImpl) completeTransferLoop() {
 					break CompleteLoop
 				}
 			}
+			if err := emitTaskDLQStats(t.shard, transferQueueType); err != nil {
+				t.logger.Warn("Failed to read transfer task DLQ", tag.Error(err))
+			}
 			timer.Reset(t.config.TransferProcessorCompleteTransferInterval())
 		}
 	}
 }
 
 func (t *transferQueueProcessorImpl) completeTransfer() error {
+	if err := t.completeIsolatedTransfer(); err != nil {
+		return err
+	}
+
 	lowerAckLevel := t.ackLevel
 	upperAckLevel := t.activeTaskProcessor.queueAckMgr.getQueueAckLevel()
 
@@ -353,3 +544,39 @@ func (t *transferQueueProcessorImpl) completeTransfer() error {
 
 	return t.shard.UpdateTransferAckLevel(upperAckLevel)
 }
+
+// completeIsolatedTransfer completes the tasks of each queue partition up to the ack level of its processor
+func (t *transferQueueProcessorImpl) completeIsolatedTransfer() error {
+	t.isolationLock.RLock()
+	lowerAckLevels := make(map[string]int64, len(t.isolatedTaskProcessors))
+	upperAckLevels := make(map[string]int64, len(t.isolatedTaskProcessors))
+	for namespaceID, isolatedTaskProcessor := range t.isolatedTaskProcessors {
+		lowerAckLevels[namespaceID] = t.isolatedAckLevels[namespaceID]
+		upperAckLevels[namespaceID] = isolatedTaskProcessor.queueAckMgr.getQueueAckLevel()
+	}
+	t.isolationLock.RUnlock()
+
+	for namespaceID, upperAckLevel := range upperAckLevels {
+		lowerAckLevel := lowerAckLevels[namespaceID]
+		if lowerAckLevel >= upperAckLevel {
+			continue
+		}
+
+		t.metricsClient.IncCounter(metrics.TransferQueueProcessorScope, metrics.TaskBatchCompleteCounter)
+		if err := t.shard.GetExecutionManager().RangeCompleteTransferTask(&persistence.RangeCompleteTransferTaskRequest{
+			ExclusiveBeginTaskID: lowerAckLevel,
+			InclusiveEndTaskID:   upperAckLevel,
+			IsolatedNamespaceID:  namespaceID,
+		}); err != nil {
+			return err
+		}
+
+		t.isolationLock.Lock()
+		// the partition may have been removed in the meantime
+		if _, ok := t.isolatedTaskProcessors[namespaceID]; ok && t.isolatedAckLevels[namespaceID] < upperAckLevel {
+			t.isolatedAckLevels[namespaceID] = upperAckLevel
+		}
+		t.isolationLock.Unlock()
+	}
+	return nil
+}
@@ -47,6 +47,9 @@ type (
 		updateTransferAckLevel updateTransferAckLevel
 		transferQueueShutdown  transferQueueShutdown
 		logger                 log.Logger
+
+		// isolatedNamespaceID is set for the processors reading the queue partition of an isolated namespace
+		isolatedNamespaceID string
 	}
 )
 
@@ -81,9 +84,10 @@ func (t *transferQueueProcessorBase) readTasks(
 ) ([]queueTaskInfo, bool, error) {
 
 	response, err := t.executionManager.GetTransferTasks(&persistence.GetTransferTasksRequest{
-		ReadLevel:    readLevel,
-		MaxReadLevel: t.maxReadAckLevel(),
-		BatchSize:    t.options.BatchSize(),
+		ReadLevel:           readLevel,
+		MaxReadLevel:        t.maxReadAckLevel(),
+		BatchSize:           t.options.BatchSize(),
+		IsolatedNamespaceID: t.isolatedNamespaceID,
 	})
 
 	if err != nil {
@@ -30,6 +30,7 @@ package history
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	persistenceblobs "github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	persistence "github.com/temporalio/temporal/common/persistence"
 	reflect "reflect"
 )
@@ -128,3 +129,17 @@ func (mr *MocktransferQueueProcessorMockRecorder) UnlockTaskPrrocessing() *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskPrrocessing", reflect.TypeOf((*MocktransferQueueProcessor)(nil).UnlockTaskPrrocessing))
 }
+
+// ExecuteDLQTask mocks base method.
+func (m *MocktransferQueueProcessor) ExecuteDLQTask(task *persistenceblobs.TransferTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteDLQTask", task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteDLQTask indicates an expected call of ExecuteDLQTask.
+func (mr *MocktransferQueueProcessorMockRecorder) ExecuteDLQTask(task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteDLQTask", reflect.TypeOf((*MocktransferQueueProcessor)(nil).ExecuteDLQTask), task)
+}
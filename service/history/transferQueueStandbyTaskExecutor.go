@@ -135,6 +135,8 @@ func (t *transferQueueStandbyTaskExecutor) processActivityTask(
 		if activityInfo.StartedID == common.EmptyEventID {
 			return newPushActivityToMatchingInfo(
 				activityInfo.ScheduleToStartTimeout,
+				activityInfo.Priority,
+				activityInfo.FairnessKey,
 			), nil
 		}
 
@@ -181,6 +183,9 @@ func (t *transferQueueStandbyTaskExecutor) processDecisionTask(
 			return newPushDecisionToMatchingInfo(
 				decisionTimeout,
 				taskqueuepb.TaskQueue{Name: transferTask.TaskQueue},
+				executionInfo.Priority,
+				executionInfo.FairnessKey,
+				getWorkerBuildID(executionInfo),
 			), nil
 		}
 
@@ -225,6 +230,7 @@ func (t *transferQueueStandbyTaskExecutor) processCloseExecution(
 		workflowCloseTimestamp := wfCloseTime
 		workflowStatus := executionInfo.Status
 		workflowHistoryLength := mutableState.GetNextEventID() - 1
+		workflowHistorySize := context.getHistorySize()
 		startEvent, err := mutableState.GetStartEvent()
 		if err != nil {
 			return nil, err
@@ -255,10 +261,13 @@ func (t *transferQueueStandbyTaskExecutor) processCloseExecution(
 			workflowCloseTimestamp,
 			workflowStatus,
 			workflowHistoryLength,
+			workflowHistorySize,
 			transferTask.GetTaskId(),
 			visibilityMemo,
 			executionInfo.TaskQueue,
+			executionInfo.RetentionDays,
 			searchAttr,
+			false,
 		)
 	}
 
@@ -388,7 +397,7 @@ func (t *transferQueueStandbyTaskExecutor) processRecordWorkflowStarted(
 		processTaskIfClosed,
 		transferTask,
 		func(context workflowExecutionContext, mutableState mutableState) (interface{}, error) {
-			return nil, t.processRecordWorkflowStartedOrUpsertHelper(transferTask, mutableState, true)
+			return nil, t.processRecordWorkflowStartedOrUpsertHelper(transferTask, context, mutableState, true)
 		},
 		standbyTaskPostActionNoOp,
 	)
@@ -403,7 +412,7 @@ func (t *transferQueueStandbyTaskExecutor) processUpsertWorkflowSearchAttributes
 		processTaskIfClosed,
 		transferTask,
 		func(context workflowExecutionContext, mutableState mutableState) (interface{}, error) {
-			return nil, t.processRecordWorkflowStartedOrUpsertHelper(transferTask, mutableState, false)
+			return nil, t.processRecordWorkflowStartedOrUpsertHelper(transferTask, context, mutableState, false)
 		},
 		standbyTaskPostActionNoOp,
 	)
@@ -411,6 +420,7 @@ func (t *transferQueueStandbyTaskExecutor) processUpsertWorkflowSearchAttributes
 
 func (t *transferQueueStandbyTaskExecutor) processRecordWorkflowStartedOrUpsertHelper(
 	transferTask *persistenceblobs.TransferTaskInfo,
+	context workflowExecutionContext,
 	mutableState mutableState,
 	isRecordStart bool,
 ) error {
@@ -463,6 +473,8 @@ func (t *transferQueueStandbyTaskExecutor) processRecordWorkflowStartedOrUpsertH
 		startTimestamp,
 		executionTimestamp.UnixNano(),
 		workflowTimeout,
+		mutableState.GetNextEventID()-1,
+		context.getHistorySize(),
 		transferTask.GetTaskId(),
 		executionInfo.TaskQueue,
 		visibilityMemo,
@@ -527,6 +539,8 @@ func (t *transferQueueStandbyTaskExecutor) pushActivity(
 	return t.transferQueueTaskExecutorBase.pushActivity(
 		task.(*persistenceblobs.TransferTaskInfo),
 		timeout,
+		pushActivityInfo.priority,
+		pushActivityInfo.fairnessKey,
 	)
 }
 
@@ -546,6 +560,9 @@ func (t *transferQueueStandbyTaskExecutor) pushDecision(
 		task.(*persistenceblobs.TransferTaskInfo),
 		&pushDecisionInfo.taskqueue,
 		timeout,
+		pushDecisionInfo.priority,
+		pushDecisionInfo.fairnessKey,
+		pushDecisionInfo.buildID,
 	)
 }
 
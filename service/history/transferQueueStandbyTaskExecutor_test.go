@@ -1145,6 +1145,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessUpsertWorkflowSearchA
 		WorkflowTypeName: executionInfo.WorkflowTypeName,
 		StartTimestamp:   event.GetTimestamp(),
 		WorkflowTimeout:  int64(executionInfo.WorkflowRunTimeout),
+		HistoryLength:    mutableState.GetNextEventID() - 1,
 		TaskID:           taskID,
 		TaskQueue:        taskQueueName,
 	}).Return(nil).Once()
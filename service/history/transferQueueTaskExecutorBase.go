@@ -97,6 +97,8 @@ func (t *transferQueueTaskExecutorBase) getNamespaceIDAndWorkflowExecution(
 func (t *transferQueueTaskExecutorBase) pushActivity(
 	task *persistenceblobs.TransferTaskInfo,
 	activityScheduleToStartTimeout int32,
+	priority int32,
+	fairnessKey string,
 ) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
@@ -116,6 +118,8 @@ func (t *transferQueueTaskExecutorBase) pushActivity(
 		TaskQueue:                     &taskqueuepb.TaskQueue{Name: task.TaskQueue},
 		ScheduleId:                    task.GetScheduleId(),
 		ScheduleToStartTimeoutSeconds: activityScheduleToStartTimeout,
+		Priority:                      priority,
+		FairnessKey:                   fairnessKey,
 	})
 
 	return err
@@ -125,6 +129,9 @@ func (t *transferQueueTaskExecutorBase) pushDecision(
 	task *persistenceblobs.TransferTaskInfo,
 	taskqueue *taskqueuepb.TaskQueue,
 	decisionScheduleToStartTimeout int32,
+	priority int32,
+	fairnessKey string,
+	buildID string,
 ) error {
 
 	ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
@@ -143,6 +150,9 @@ func (t *transferQueueTaskExecutorBase) pushDecision(
 		TaskQueue:                     taskqueue,
 		ScheduleId:                    task.GetScheduleId(),
 		ScheduleToStartTimeoutSeconds: decisionScheduleToStartTimeout,
+		Priority:                      priority,
+		FairnessKey:                   fairnessKey,
+		BuildId:                       buildID,
 	})
 	return err
 }
@@ -204,6 +214,8 @@ func (t *transferQueueTaskExecutorBase) upsertWorkflowExecution(
 	startTimeUnixNano int64,
 	executionTimeUnixNano int64,
 	workflowTimeout int32,
+	historyLength int64,
+	historySizeBytes int64,
 	taskID int64,
 	taskQueue string,
 	visibilityMemo *commonpb.Memo,
@@ -231,6 +243,8 @@ func (t *transferQueueTaskExecutorBase) upsertWorkflowExecution(
 		StartTimestamp:     startTimeUnixNano,
 		ExecutionTimestamp: executionTimeUnixNano,
 		WorkflowTimeout:    int64(workflowTimeout),
+		HistoryLength:      historyLength,
+		HistorySizeBytes:   historySizeBytes,
 		TaskID:             taskID,
 		Memo:               visibilityMemo,
 		TaskQueue:          taskQueue,
@@ -240,6 +254,9 @@ func (t *transferQueueTaskExecutorBase) upsertWorkflowExecution(
 	return t.visibilityMgr.UpsertWorkflowExecution(request)
 }
 
+// recordWorkflowClosed records the close of the workflow in visibility and archives the record. With reRecord the close
+// was already recorded and is recorded again to sync metadata updated since, the record keeps expiring at the original
+// close time plus retention and is not archived again.
 func (t *transferQueueTaskExecutorBase) recordWorkflowClosed(
 	namespaceID string,
 	workflowID string,
@@ -250,10 +267,13 @@ func (t *transferQueueTaskExecutorBase) recordWorkflowClosed(
 	endTimeUnixNano int64,
 	status enumspb.WorkflowExecutionStatus,
 	historyLength int64,
+	historySizeBytes int64,
 	taskID int64,
 	visibilityMemo *commonpb.Memo,
 	taskQueue string,
+	workflowRetentionDays int32,
 	searchAttributes map[string]*commonpb.Payload,
+	reRecord bool,
 ) error {
 
 	// Record closing in visibility store
@@ -279,7 +299,18 @@ func (t *transferQueueTaskExecutorBase) recordWorkflowClosed(
 
 		clusterConfiguredForVisibilityArchival := t.shard.GetService().GetArchivalMetadata().GetVisibilityConfig().ClusterConfiguredForArchival()
 		namespaceConfiguredForVisibilityArchival := namespaceEntry.GetConfig().VisibilityArchivalStatus == enumspb.ARCHIVAL_STATUS_ENABLED
-		archiveVisibility = clusterConfiguredForVisibilityArchival && namespaceConfiguredForVisibilityArchival
+		archiveVisibility = !reRecord && clusterConfiguredForVisibilityArchival && namespaceConfiguredForVisibilityArchival
+	}
+	// retention override specified when the workflow was started, already bounded at start time
+	if workflowRetentionDays > 0 {
+		retentionSeconds = int64(workflowRetentionDays) * int64(secondsInDay)
+	}
+	if reRecord {
+		// visibility stores like cassandra apply the retention from the time of the write
+		retentionSeconds -= int64(t.shard.GetTimeSource().Now().Sub(time.Unix(0, endTimeUnixNano)) / time.Second)
+		if retentionSeconds <= 0 {
+			recordWorkflowClose = false
+		}
 	}
 
 	if recordWorkflowClose {
@@ -296,6 +327,7 @@ func (t *transferQueueTaskExecutorBase) recordWorkflowClosed(
 			CloseTimestamp:     endTimeUnixNano,
 			Status:             status,
 			HistoryLength:      historyLength,
+			HistorySizeBytes:   historySizeBytes,
 			RetentionSeconds:   retentionSeconds,
 			TaskID:             taskID,
 			Memo:               visibilityMemo,
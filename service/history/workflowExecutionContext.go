@@ -29,6 +29,7 @@ package history
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	commonpb "go.temporal.io/temporal-proto/common/v1"
@@ -70,6 +71,7 @@ type (
 
 		getHistorySize() int64
 		setHistorySize(size int64)
+		getMutableStateSize() int64
 
 		reapplyEvents(
 			eventBatches []*persistence.WorkflowEvents,
@@ -158,6 +160,8 @@ type (
 		mutableState    mutableState
 		stats           *persistence.ExecutionStats
 		updateCondition int64
+		// size of the mutable state when it was loaded, read by the history cache without holding the lock
+		mutableStateSize int64
 	}
 )
 
@@ -204,6 +208,7 @@ func (c *workflowExecutionContextImpl) clear() {
 	c.stats = &persistence.ExecutionStats{
 		HistorySize: 0,
 	}
+	atomic.StoreInt64(&c.mutableStateSize, 0)
 }
 
 func (c *workflowExecutionContextImpl) getNamespaceID() string {
@@ -230,6 +235,10 @@ func (c *workflowExecutionContextImpl) setHistorySize(size int64) {
 	c.stats.HistorySize = size
 }
 
+func (c *workflowExecutionContextImpl) getMutableStateSize() int64 {
+	return atomic.LoadInt64(&c.mutableStateSize)
+}
+
 func (c *workflowExecutionContextImpl) loadExecutionStats() (*persistence.ExecutionStats, error) {
 	_, err := c.loadWorkflowExecution()
 	if err != nil {
@@ -264,6 +273,9 @@ func (c *workflowExecutionContextImpl) loadWorkflowExecutionForReplication(
 		)
 
 		c.mutableState.Load(response.State)
+		if response.MutableStateStats != nil {
+			atomic.StoreInt64(&c.mutableStateSize, int64(response.MutableStateStats.MutableStateSize))
+		}
 
 		c.stats = response.State.ExecutionStats
 		c.updateCondition = response.State.ExecutionInfo.NextEventID
@@ -337,6 +349,9 @@ func (c *workflowExecutionContextImpl) loadWorkflowExecution() (mutableState, er
 		)
 
 		c.mutableState.Load(response.State)
+		if response.MutableStateStats != nil {
+			atomic.StoreInt64(&c.mutableStateSize, int64(response.MutableStateStats.MutableStateSize))
+		}
 
 		c.stats = response.State.ExecutionStats
 		c.updateCondition = response.State.ExecutionInfo.NextEventID
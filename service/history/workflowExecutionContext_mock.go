@@ -211,6 +211,20 @@ func (mr *MockworkflowExecutionContextMockRecorder) setHistorySize(size interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "setHistorySize", reflect.TypeOf((*MockworkflowExecutionContext)(nil).setHistorySize), size)
 }
 
+// getMutableStateSize mocks base method.
+func (m *MockworkflowExecutionContext) getMutableStateSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getMutableStateSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// getMutableStateSize indicates an expected call of getMutableStateSize.
+func (mr *MockworkflowExecutionContextMockRecorder) getMutableStateSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getMutableStateSize", reflect.TypeOf((*MockworkflowExecutionContext)(nil).getMutableStateSize))
+}
+
 // reapplyEvents mocks base method.
 func (m *MockworkflowExecutionContext) reapplyEvents(eventBatches []*persistence.WorkflowEvents) error {
 	m.ctrl.T.Helper()
@@ -65,6 +65,31 @@ type (
 			resetReason string,
 			additionalReapplyEvents []*historypb.HistoryEvent,
 		) error
+		// resetWorkflowWithOptions is resetWorkflow with signal exclusion and dry run, returning the events
+		// appended to the reset workflow for dry runs
+		resetWorkflowWithOptions(
+			ctx context.Context,
+			namespaceID string,
+			workflowID string,
+			baseRunID string,
+			baseBranchToken []byte,
+			baseRebuildLastEventID int64,
+			baseRebuildLastEventVersion int64,
+			baseNextEventID int64,
+			resetRunID string,
+			resetRequestID string,
+			currentWorkflow nDCWorkflow,
+			resetReason string,
+			additionalReapplyEvents []*historypb.HistoryEvent,
+			options resetWorkflowOptions,
+		) ([]*historypb.HistoryEvent, error)
+	}
+
+	resetWorkflowOptions struct {
+		// signals with these names are not reapplied to the reset workflow
+		excludeSignalNames map[string]struct{}
+		// rebuild the reset workflow without forking history, terminating the current workflow or persisting anything
+		dryRun bool
 	}
 
 	nDCStateRebuilderProvider func() nDCStateRebuilder
@@ -114,25 +139,63 @@ func (r *workflowResetterImpl) resetWorkflow(
 	currentWorkflow nDCWorkflow,
 	resetReason string,
 	additionalReapplyEvents []*historypb.HistoryEvent,
-) (retError error) {
+) error {
+
+	_, err := r.resetWorkflowWithOptions(
+		ctx,
+		namespaceID,
+		workflowID,
+		baseRunID,
+		baseBranchToken,
+		baseRebuildLastEventID,
+		baseRebuildLastEventVersion,
+		baseNextEventID,
+		resetRunID,
+		resetRequestID,
+		currentWorkflow,
+		resetReason,
+		additionalReapplyEvents,
+		resetWorkflowOptions{},
+	)
+	return err
+}
+
+func (r *workflowResetterImpl) resetWorkflowWithOptions(
+	ctx context.Context,
+	namespaceID string,
+	workflowID string,
+	baseRunID string,
+	baseBranchToken []byte,
+	baseRebuildLastEventID int64,
+	baseRebuildLastEventVersion int64,
+	baseNextEventID int64,
+	resetRunID string,
+	resetRequestID string,
+	currentWorkflow nDCWorkflow,
+	resetReason string,
+	additionalReapplyEvents []*historypb.HistoryEvent,
+	options resetWorkflowOptions,
+) (_ []*historypb.HistoryEvent, retError error) {
 
 	namespaceEntry, err := r.namespaceCache.GetNamespaceByID(namespaceID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	resetWorkflowVersion := namespaceEntry.GetFailoverVersion()
 
 	currentMutableState := currentWorkflow.getMutableState()
 	currentWorkflowTerminated := false
 	if currentMutableState.IsWorkflowExecutionRunning() {
-		if err := r.terminateWorkflow(
-			currentMutableState,
-			resetReason,
-		); err != nil {
-			return err
+		if !options.dryRun {
+			if err := r.terminateWorkflow(
+				currentMutableState,
+				resetReason,
+			); err != nil {
+				return nil, err
+			}
+			currentWorkflowTerminated = true
 		}
 		resetWorkflowVersion = currentMutableState.GetCurrentVersion()
-		currentWorkflowTerminated = true
 	}
 
 	resetWorkflow, err := r.prepareResetWorkflow(
@@ -149,13 +212,17 @@ func (r *workflowResetterImpl) resetWorkflow(
 		resetWorkflowVersion,
 		resetReason,
 		additionalReapplyEvents,
+		options,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resetWorkflow.getReleaseFn()(retError)
 
-	return r.persistToDB(
+	if options.dryRun {
+		return r.previewResetWorkflow(resetWorkflow)
+	}
+	return nil, r.persistToDB(
 		currentWorkflowTerminated,
 		currentWorkflow,
 		resetWorkflow,
@@ -176,6 +243,7 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 	resetWorkflowVersion int64,
 	resetReason string,
 	additionalReapplyEvents []*historypb.HistoryEvent,
+	options resetWorkflowOptions,
 ) (nDCWorkflow, error) {
 
 	resetWorkflow, err := r.replayResetWorkflow(
@@ -188,6 +256,7 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 		baseRebuildLastEventVersion,
 		resetRunID,
 		resetRequestID,
+		options.dryRun,
 	)
 	if err != nil {
 		return nil, err
@@ -248,11 +317,12 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 		baseBranchToken,
 		baseRebuildLastEventID+1,
 		baseNextEventID,
+		options.excludeSignalNames,
 	); err != nil {
 		return nil, err
 	}
 
-	if err := r.reapplyEvents(resetMutableState, additionalReapplyEvents); err != nil {
+	if err := r.reapplyEvents(resetMutableState, additionalReapplyEvents, options.excludeSignalNames); err != nil {
 		return nil, err
 	}
 
@@ -307,6 +377,25 @@ func (r *workflowResetterImpl) persistToDB(
 	)
 }
 
+func (r *workflowResetterImpl) previewResetWorkflow(
+	resetWorkflow nDCWorkflow,
+) ([]*historypb.HistoryEvent, error) {
+
+	_, resetWorkflowEventsSeq, err := resetWorkflow.getMutableState().CloseTransactionAsSnapshot(
+		r.shard.GetTimeSource().Now(),
+		transactionPolicyActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*historypb.HistoryEvent
+	for _, workflowEvents := range resetWorkflowEventsSeq {
+		events = append(events, workflowEvents.Events...)
+	}
+	return events, nil
+}
+
 func (r *workflowResetterImpl) replayResetWorkflow(
 	ctx context.Context,
 	namespaceID string,
@@ -317,17 +406,23 @@ func (r *workflowResetterImpl) replayResetWorkflow(
 	baseRebuildLastEventVersion int64,
 	resetRunID string,
 	resetRequestID string,
+	dryRun bool,
 ) (nDCWorkflow, error) {
 
-	resetBranchToken, err := r.generateBranchToken(
-		namespaceID,
-		workflowID,
-		baseBranchToken,
-		baseRebuildLastEventID+1,
-		resetRunID,
-	)
-	if err != nil {
-		return nil, err
+	// a dry run replays onto the base branch, nothing is ever written to it
+	resetBranchToken := baseBranchToken
+	if !dryRun {
+		var err error
+		resetBranchToken, err = r.generateBranchToken(
+			namespaceID,
+			workflowID,
+			baseBranchToken,
+			baseRebuildLastEventID+1,
+			resetRunID,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	resetContext := newWorkflowExecutionContext(
@@ -448,6 +543,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 	baseBranchToken []byte,
 	baseRebuildNextEventID int64,
 	baseNextEventID int64,
+	excludeSignalNames map[string]struct{},
 ) error {
 
 	// TODO change this logic to fetching all workflow [baseWorkflow, currentWorkflow]
@@ -462,6 +558,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 		baseRebuildNextEventID,
 		baseNextEventID,
 		baseBranchToken,
+		excludeSignalNames,
 	); err != nil {
 		return err
 	}
@@ -506,6 +603,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 			common.FirstEventID,
 			nextWorkflowNextEventID,
 			nextWorkflowBranchToken,
+			excludeSignalNames,
 		); err != nil {
 			return err
 		}
@@ -518,6 +616,7 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 	firstEventID int64,
 	nextEventID int64,
 	branchToken []byte,
+	excludeSignalNames map[string]struct{},
 ) (string, error) {
 
 	// TODO change this logic to fetching all workflow [baseWorkflow, currentWorkflow]
@@ -539,7 +638,7 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 			return "", err
 		}
 		lastEvents = batch.(*historypb.History).Events
-		if err := r.reapplyEvents(mutableState, lastEvents); err != nil {
+		if err := r.reapplyEvents(mutableState, lastEvents, excludeSignalNames); err != nil {
 			return "", err
 		}
 	}
@@ -556,12 +655,16 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 func (r *workflowResetterImpl) reapplyEvents(
 	mutableState mutableState,
 	events []*historypb.HistoryEvent,
+	excludeSignalNames map[string]struct{},
 ) error {
 
 	for _, event := range events {
 		switch event.GetEventType() {
 		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
 			attr := event.GetWorkflowExecutionSignaledEventAttributes()
+			if _, ok := excludeSignalNames[attr.GetSignalName()]; ok {
+				continue
+			}
 			if _, err := mutableState.AddWorkflowExecutionSignaled(
 				attr.GetSignalName(),
 				attr.GetInput(),
@@ -260,6 +260,7 @@ func (s *workflowResetterSuite) TestReplayResetWorkflow() {
 		baseRebuildLastEventVersion,
 		s.resetRunID,
 		resetRequestID,
+		false,
 	)
 	s.NoError(err)
 	s.Equal(resetHistorySize, resetWorkflow.getContext().getHistorySize())
@@ -465,6 +466,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents() {
 		baseBranchToken,
 		baseFirstEventID,
 		baseNextEventID,
+		nil,
 	)
 	s.NoError(err)
 }
@@ -523,6 +525,7 @@ func (s *workflowResetterSuite) TestReapplyWorkflowEvents() {
 		firstEventID,
 		nextEventID,
 		branchToken,
+		nil,
 	)
 	s.NoError(err)
 	s.Equal(newRunID, nextRunID)
@@ -568,7 +571,41 @@ func (s *workflowResetterSuite) TestReapplyEvents() {
 		}
 	}
 
-	err := s.workflowResetter.reapplyEvents(mutableState, events)
+	err := s.workflowResetter.reapplyEvents(mutableState, events, nil)
+	s.NoError(err)
+}
+
+func (s *workflowResetterSuite) TestReapplyEvents_ExcludeSignals() {
+
+	event1 := &historypb.HistoryEvent{
+		EventId:   101,
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "some random signal name",
+			Input:      payloads.EncodeString("some random signal input"),
+			Identity:   "some random signal identity",
+		}},
+	}
+	event2 := &historypb.HistoryEvent{
+		EventId:   102,
+		EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED,
+		Attributes: &historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &historypb.WorkflowExecutionSignaledEventAttributes{
+			SignalName: "excluded signal name",
+			Input:      payloads.EncodeString("excluded signal input"),
+			Identity:   "excluded signal identity",
+		}},
+	}
+	events := []*historypb.HistoryEvent{event1, event2}
+
+	mutableState := NewMockmutableState(s.controller)
+	attr := event1.GetWorkflowExecutionSignaledEventAttributes()
+	mutableState.EXPECT().AddWorkflowExecutionSignaled(
+		attr.GetSignalName(),
+		attr.GetInput(),
+		attr.GetIdentity(),
+	).Return(&historypb.HistoryEvent{}, nil).Times(1)
+
+	err := s.workflowResetter.reapplyEvents(mutableState, events, map[string]struct{}{"excluded signal name": {}})
 	s.NoError(err)
 }
 
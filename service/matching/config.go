@@ -54,10 +54,35 @@ type (
 		ForwarderMaxRatePerSecond    dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 		ForwarderMaxChildrenPerNode  dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 
+		// partition auto scaling configuration
+		EnablePartitionAutoScaling   dynamicconfig.BoolPropertyFnWithTaskQueueInfoFilters
+		PartitionScalingInterval     dynamicconfig.DurationPropertyFnWithTaskQueueInfoFilters
+		PartitionTargetRatePerSecond dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+		MaxTaskqueuePartitions       dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+
+		// worker versioning configuration
+		MaxVersionSetsPerTaskQueue dynamicconfig.IntPropertyFnWithNamespaceFilter
+		MaxBuildIdsPerTaskQueue    dynamicconfig.IntPropertyFnWithNamespaceFilter
+
+		// worker registry configuration
+		WorkerHeartbeatTimeout  dynamicconfig.DurationPropertyFn
+		WorkerRegistryRetention dynamicconfig.DurationPropertyFn
+
+		// Number of backlog dispatches after which the oldest buffered task is dispatched regardless of priority
+		PriorityStarvationProtectionInterval dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+		// Round robin weights of task fairness keys
+		FairnessKeyWeights dynamicconfig.MapPropertyFn
+		// Number of buckets fairness keys are hashed into, each bucket has its own persisted backlog
+		FairnessKeyBuckets dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+		// Max number of subqueues persisted by a task queue partition besides its own backlog
+		MaxSubqueues dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+
 		// Time to hold a poll request before returning an empty response if there are no tasks
 		LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithTaskQueueInfoFilters
 		MinTaskThrottlingBurstSize dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
 		MaxTaskDeleteBatchSize     dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
+		// Operator defined dispatch rate limit which takes precedence over the limit set by pollers, 0 means unset
+		TaskQueueMaxTasksPerSecond dynamicconfig.FloatPropertyFnWithTaskQueueInfoFilters
 
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold dynamicconfig.IntPropertyFnWithTaskQueueInfoFilters
@@ -85,39 +110,63 @@ type (
 		MaxTaskqueueIdleTime       func() time.Duration
 		MinTaskThrottlingBurstSize func() int
 		MaxTaskDeleteBatchSize     func() int
+		MaxTasksPerSecond          func() float64
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold func() int
 		MaxTaskBatchSize                func() int
 		NumWritePartitions              func() int
 		NumReadPartitions               func() int
+		// partition auto scaling configuration, always looked up by the root task queue name
+		EnablePartitionAutoScaling   func() bool
+		PartitionScalingInterval     func() time.Duration
+		PartitionTargetRatePerSecond func() int
+		MaxPartitions                func() int
+		// taskReader configuration
+		PriorityStarvationProtectionInterval func() int
+		FairnessKeyWeights                   func() map[string]interface{}
+		FairnessKeyBuckets                   func() int
+		MaxSubqueues                         func() int
 	}
 )
 
 // NewConfig returns new service config with default values
 func NewConfig(dc *dynamicconfig.Collection) *Config {
 	return &Config{
-		PersistenceMaxQPS:               dc.GetIntProperty(dynamicconfig.MatchingPersistenceMaxQPS, 3000),
-		PersistenceGlobalMaxQPS:         dc.GetIntProperty(dynamicconfig.MatchingPersistenceGlobalMaxQPS, 0),
-		EnableSyncMatch:                 dc.GetBoolPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingEnableSyncMatch, true),
-		RPS:                             dc.GetIntProperty(dynamicconfig.MatchingRPS, 1200),
-		RangeSize:                       100000,
-		GetTasksBatchSize:               dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingGetTasksBatchSize, 1000),
-		UpdateAckInterval:               dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingUpdateAckInterval, 1*time.Minute),
-		IdleTaskqueueCheckInterval:      dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingIdleTaskqueueCheckInterval, 5*time.Minute),
-		MaxTaskqueueIdleTime:            dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MaxTaskqueueIdleTime, 5*time.Minute),
-		LongPollExpirationInterval:      dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingLongPollExpirationInterval, time.Minute),
-		MinTaskThrottlingBurstSize:      dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMinTaskThrottlingBurstSize, 1),
-		MaxTaskDeleteBatchSize:          dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMaxTaskDeleteBatchSize, 100),
-		OutstandingTaskAppendsThreshold: dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
-		MaxTaskBatchSize:                dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
-		ThrottledLogRPS:                 dc.GetIntProperty(dynamicconfig.MatchingThrottledLogRPS, 20),
-		NumTaskqueueWritePartitions:     dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingNumTaskqueueWritePartitions, 1),
-		NumTaskqueueReadPartitions:      dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingNumTaskqueueReadPartitions, 1),
-		ForwarderMaxOutstandingPolls:    dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxOutstandingPolls, 1),
-		ForwarderMaxOutstandingTasks:    dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxOutstandingTasks, 1),
-		ForwarderMaxRatePerSecond:       dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxRatePerSecond, 10),
-		ForwarderMaxChildrenPerNode:     dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxChildrenPerNode, 20),
-		ShutdownDrainDuration:           dc.GetDurationProperty(dynamicconfig.MatchingShutdownDrainDuration, 0),
+		PersistenceMaxQPS:                    dc.GetIntProperty(dynamicconfig.MatchingPersistenceMaxQPS, 3000),
+		PersistenceGlobalMaxQPS:              dc.GetIntProperty(dynamicconfig.MatchingPersistenceGlobalMaxQPS, 0),
+		EnableSyncMatch:                      dc.GetBoolPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingEnableSyncMatch, true),
+		RPS:                                  dc.GetIntProperty(dynamicconfig.MatchingRPS, 1200),
+		RangeSize:                            100000,
+		GetTasksBatchSize:                    dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingGetTasksBatchSize, 1000),
+		UpdateAckInterval:                    dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingUpdateAckInterval, 1*time.Minute),
+		IdleTaskqueueCheckInterval:           dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingIdleTaskqueueCheckInterval, 5*time.Minute),
+		MaxTaskqueueIdleTime:                 dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MaxTaskqueueIdleTime, 5*time.Minute),
+		LongPollExpirationInterval:           dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingLongPollExpirationInterval, time.Minute),
+		MinTaskThrottlingBurstSize:           dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMinTaskThrottlingBurstSize, 1),
+		MaxTaskDeleteBatchSize:               dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMaxTaskDeleteBatchSize, 100),
+		TaskQueueMaxTasksPerSecond:           dc.GetFloat64PropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingTaskQueueMaxTasksPerSecond, 0),
+		OutstandingTaskAppendsThreshold:      dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
+		MaxTaskBatchSize:                     dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
+		ThrottledLogRPS:                      dc.GetIntProperty(dynamicconfig.MatchingThrottledLogRPS, 20),
+		NumTaskqueueWritePartitions:          dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingNumTaskqueueWritePartitions, 1),
+		NumTaskqueueReadPartitions:           dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingNumTaskqueueReadPartitions, 1),
+		ForwarderMaxOutstandingPolls:         dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxOutstandingPolls, 1),
+		ForwarderMaxOutstandingTasks:         dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxOutstandingTasks, 1),
+		ForwarderMaxRatePerSecond:            dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxRatePerSecond, 10),
+		ForwarderMaxChildrenPerNode:          dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingForwarderMaxChildrenPerNode, 20),
+		ShutdownDrainDuration:                dc.GetDurationProperty(dynamicconfig.MatchingShutdownDrainDuration, 0),
+		PriorityStarvationProtectionInterval: dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingPriorityStarvationProtectionInterval, 5),
+		FairnessKeyWeights:                   dc.GetMapProperty(dynamicconfig.MatchingFairnessKeyWeights, map[string]interface{}{}),
+		FairnessKeyBuckets:                   dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingFairnessKeyBuckets, 8),
+		MaxSubqueues:                         dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMaxSubqueues, 8),
+		EnablePartitionAutoScaling:           dc.GetBoolPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingEnablePartitionAutoScaling, false),
+		PartitionScalingInterval:             dc.GetDurationPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingPartitionScalingInterval, time.Minute),
+		PartitionTargetRatePerSecond:         dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingPartitionTargetRatePerSecond, 500),
+		MaxTaskqueuePartitions:               dc.GetIntPropertyFilteredByTaskQueueInfo(dynamicconfig.MatchingMaxTaskqueuePartitions, 16),
+		MaxVersionSetsPerTaskQueue:           dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MatchingMaxVersionSetsPerTaskQueue, 10),
+		MaxBuildIdsPerTaskQueue:              dc.GetIntPropertyFilteredByNamespace(dynamicconfig.MatchingMaxBuildIdsPerTaskQueue, 100),
+		WorkerHeartbeatTimeout:               dc.GetDurationProperty(dynamicconfig.MatchingWorkerHeartbeatTimeout, 2*time.Minute),
+		WorkerRegistryRetention:              dc.GetDurationProperty(dynamicconfig.MatchingWorkerRegistryRetention, 10*time.Minute),
 	}
 }
 
@@ -129,6 +178,12 @@ func newTaskQueueConfig(id *taskQueueID, config *Config, namespaceCache cache.Na
 
 	namespace := namespaceEntry.GetInfo().Name
 	taskQueueName := id.name
+	if id.IsVersioned() {
+		// task queues of worker version sets are configured like the partition they belong to
+		unversioned := id.WithVersionSet("")
+		taskQueueName = unversioned.name
+	}
+	rootTaskQueueName := id.GetRoot()
 	taskType := id.taskType
 	return &taskQueueConfig{
 		RangeSize: config.RangeSize,
@@ -156,6 +211,9 @@ func newTaskQueueConfig(id *taskQueueID, config *Config, namespaceCache cache.Na
 		MaxTaskDeleteBatchSize: func() int {
 			return config.MaxTaskDeleteBatchSize(namespace, taskQueueName, taskType)
 		},
+		MaxTasksPerSecond: func() float64 {
+			return config.TaskQueueMaxTasksPerSecond(namespace, taskQueueName, taskType)
+		},
 		OutstandingTaskAppendsThreshold: func() int {
 			return config.OutstandingTaskAppendsThreshold(namespace, taskQueueName, taskType)
 		},
@@ -168,6 +226,34 @@ func newTaskQueueConfig(id *taskQueueID, config *Config, namespaceCache cache.Na
 		NumReadPartitions: func() int {
 			return common.MaxInt(1, config.NumTaskqueueReadPartitions(namespace, taskQueueName, taskType))
 		},
+		EnablePartitionAutoScaling: func() bool {
+			return config.EnablePartitionAutoScaling(namespace, rootTaskQueueName, taskType)
+		},
+		PartitionScalingInterval: func() time.Duration {
+			return config.PartitionScalingInterval(namespace, rootTaskQueueName, taskType)
+		},
+		PartitionTargetRatePerSecond: func() int {
+			return common.MaxInt(1, config.PartitionTargetRatePerSecond(namespace, rootTaskQueueName, taskType))
+		},
+		MaxPartitions: func() int {
+			return common.MaxInt(1, config.MaxTaskqueuePartitions(namespace, rootTaskQueueName, taskType))
+		},
+		PriorityStarvationProtectionInterval: func() int {
+			return config.PriorityStarvationProtectionInterval(namespace, taskQueueName, taskType)
+		},
+		FairnessKeyBuckets: func() int {
+			return config.FairnessKeyBuckets(namespace, taskQueueName, taskType)
+		},
+		MaxSubqueues: func() int {
+			return config.MaxSubqueues(namespace, taskQueueName, taskType)
+		},
+		FairnessKeyWeights: func() map[string]interface{} {
+			return config.FairnessKeyWeights(
+				dynamicconfig.NamespaceFilter(namespace),
+				dynamicconfig.TaskQueueFilter(taskQueueName),
+				dynamicconfig.TaskTypeFilter(taskType),
+			)
+		},
 		forwarderConfig: forwarderConfig{
 			ForwarderMaxOutstandingPolls: func() int {
 				return config.ForwarderMaxOutstandingPolls(namespace, taskQueueName, taskType)
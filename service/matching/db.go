@@ -28,9 +28,12 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/gogo/protobuf/types"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/persistence"
@@ -45,8 +48,19 @@ type (
 		taskType      enumspb.TaskQueueType
 		rangeID       int64
 		ackLevel      int64
-		store         persistence.TaskManager
-		logger        log.Logger
+		// partition counts of an automatically scaled task queue, only set on the root partition
+		numReadPartitions  int32
+		numWritePartitions int32
+		// worker version sets of a decision task queue, only set on the root partition
+		versioningData *taskqueuegenpb.VersioningData
+		// dispatch state set through the admin pause and resume APIs
+		dispatchState enumsgenpb.TaskQueueState
+		// dispatch rate limit set by operators, takes precedence over the limit set by pollers
+		maxTasksPerSecond *types.DoubleValue
+		// separately persisted backlogs of the task queue, see subqueue
+		subqueues []*persistenceblobs.TaskQueueSubqueue
+		store     persistence.TaskManager
+		logger    log.Logger
 	}
 	taskQueueState struct {
 		rangeID  int64
@@ -99,6 +113,12 @@ func (db *taskQueueDB) RenewLease() (taskQueueState, error) {
 	}
 	db.ackLevel = resp.TaskQueueInfo.Data.AckLevel
 	db.rangeID = resp.TaskQueueInfo.RangeID
+	db.numReadPartitions = resp.TaskQueueInfo.Data.GetNumReadPartitions()
+	db.numWritePartitions = resp.TaskQueueInfo.Data.GetNumWritePartitions()
+	db.versioningData = resp.TaskQueueInfo.Data.GetVersioningData()
+	db.dispatchState = resp.TaskQueueInfo.Data.GetState()
+	db.maxTasksPerSecond = resp.TaskQueueInfo.Data.GetMaxTasksPerSecond()
+	db.subqueues = resp.TaskQueueInfo.Data.GetSubqueues()
 	return taskQueueState{rangeID: db.rangeID, ackLevel: db.ackLevel}, nil
 }
 
@@ -107,14 +127,8 @@ func (db *taskQueueDB) UpdateState(ackLevel int64) error {
 	db.Lock()
 	defer db.Unlock()
 	_, err := db.store.UpdateTaskQueue(&persistence.UpdateTaskQueueRequest{
-		TaskQueueInfo: &persistenceblobs.TaskQueueInfo{
-			NamespaceId: db.namespaceID,
-			Name:        db.taskQueueName,
-			TaskType:    db.taskType,
-			AckLevel:    ackLevel,
-			Kind:        db.taskQueueKind,
-		},
-		RangeID: db.rangeID,
+		TaskQueueInfo: db.taskQueueInfo(ackLevel),
+		RangeID:       db.rangeID,
 	})
 	if err == nil {
 		db.ackLevel = ackLevel
@@ -122,6 +136,135 @@ func (db *taskQueueDB) UpdateState(ackLevel int64) error {
 	return err
 }
 
+// PartitionCounts returns the persisted partition counts of the task queue, 0 when never scaled
+func (db *taskQueueDB) PartitionCounts() (numRead int32, numWrite int32) {
+	db.Lock()
+	defer db.Unlock()
+	return db.numReadPartitions, db.numWritePartitions
+}
+
+// UpdatePartitionCounts updates the partition counts of the task queue with the given values
+func (db *taskQueueDB) UpdatePartitionCounts(numRead int32, numWrite int32) error {
+	db.Lock()
+	defer db.Unlock()
+	info := db.taskQueueInfo(db.ackLevel)
+	info.NumReadPartitions = numRead
+	info.NumWritePartitions = numWrite
+	_, err := db.store.UpdateTaskQueue(&persistence.UpdateTaskQueueRequest{
+		TaskQueueInfo: info,
+		RangeID:       db.rangeID,
+	})
+	if err == nil {
+		db.numReadPartitions = numRead
+		db.numWritePartitions = numWrite
+	}
+	return err
+}
+
+// VersioningData returns the worker version sets of the task queue
+func (db *taskQueueDB) VersioningData() *taskqueuegenpb.VersioningData {
+	db.Lock()
+	defer db.Unlock()
+	return db.versioningData
+}
+
+// UpdateVersioningData applies the given update to the worker version sets of the task queue
+// and persists the result, the update is serialized with other updates of the task queue
+func (db *taskQueueDB) UpdateVersioningData(
+	update func(*taskqueuegenpb.VersioningData) (*taskqueuegenpb.VersioningData, error),
+) (*taskqueuegenpb.VersioningData, error) {
+	db.Lock()
+	defer db.Unlock()
+	data, err := update(db.versioningData)
+	if err != nil {
+		return nil, err
+	}
+	info := db.taskQueueInfo(db.ackLevel)
+	info.VersioningData = data
+	if _, err := db.store.UpdateTaskQueue(&persistence.UpdateTaskQueueRequest{
+		TaskQueueInfo: info,
+		RangeID:       db.rangeID,
+	}); err != nil {
+		return nil, err
+	}
+	db.versioningData = data
+	return data, nil
+}
+
+// DispatchState returns the persisted dispatch state of the task queue, active when never set
+func (db *taskQueueDB) DispatchState() enumsgenpb.TaskQueueState {
+	db.Lock()
+	defer db.Unlock()
+	if db.dispatchState == enumsgenpb.TASK_QUEUE_STATE_UNSPECIFIED {
+		return enumsgenpb.TASK_QUEUE_STATE_ACTIVE
+	}
+	return db.dispatchState
+}
+
+// UpdateDispatchState updates the dispatch state of the task queue with the given value
+func (db *taskQueueDB) UpdateDispatchState(state enumsgenpb.TaskQueueState) error {
+	db.Lock()
+	defer db.Unlock()
+	info := db.taskQueueInfo(db.ackLevel)
+	info.State = state
+	_, err := db.store.UpdateTaskQueue(&persistence.UpdateTaskQueueRequest{
+		TaskQueueInfo: info,
+		RangeID:       db.rangeID,
+	})
+	if err == nil {
+		db.dispatchState = state
+	}
+	return err
+}
+
+// MaxTasksPerSecond returns the persisted dispatch rate limit of the task queue, nil when never set
+func (db *taskQueueDB) MaxTasksPerSecond() *types.DoubleValue {
+	db.Lock()
+	defer db.Unlock()
+	return db.maxTasksPerSecond
+}
+
+// UpdateMaxTasksPerSecond updates the dispatch rate limit of the task queue with the given value,
+// nil clears the limit
+func (db *taskQueueDB) UpdateMaxTasksPerSecond(maxTasksPerSecond *types.DoubleValue) error {
+	db.Lock()
+	defer db.Unlock()
+	info := db.taskQueueInfo(db.ackLevel)
+	info.MaxTasksPerSecond = maxTasksPerSecond
+	_, err := db.store.UpdateTaskQueue(&persistence.UpdateTaskQueueRequest{
+		TaskQueueInfo: info,
+		RangeID:       db.rangeID,
+	})
+	if err == nil {
+		db.maxTasksPerSecond = maxTasksPerSecond
+	}
+	return err
+}
+
+// Subqueues returns the separately persisted backlogs of the task queue
+func (db *taskQueueDB) Subqueues() []*persistenceblobs.TaskQueueSubqueue {
+	db.Lock()
+	defer db.Unlock()
+	return db.subqueues
+}
+
+// AddSubqueue records the given separately persisted backlog with the task queue
+func (db *taskQueueDB) AddSubqueue(subqueue *persistenceblobs.TaskQueueSubqueue) error {
+	db.Lock()
+	defer db.Unlock()
+	subqueues := append(append([]*persistenceblobs.TaskQueueSubqueue(nil), db.subqueues...), subqueue)
+	info := db.taskQueueInfo(db.ackLevel)
+	info.Subqueues = subqueues
+	_, err := db.store.UpdateTaskQueue(&persistence.UpdateTaskQueueRequest{
+		TaskQueueInfo: info,
+		RangeID:       db.rangeID,
+	})
+	if err == nil {
+		db.subqueues = subqueues
+	}
+	return err
+}
+
 // CreateTasks creates a batch of given tasks for this task queue
 func (db *taskQueueDB) CreateTasks(tasks []*persistenceblobs.AllocatedTaskInfo) (*persistence.CreateTasksResponse, error) {
 	db.Lock()
@@ -129,13 +272,7 @@ func (db *taskQueueDB) CreateTasks(tasks []*persistenceblobs.AllocatedTaskInfo)
 	return db.store.CreateTasks(
 		&persistence.CreateTasksRequest{
 			TaskQueueInfo: &persistence.PersistedTaskQueueInfo{
-				Data: &persistenceblobs.TaskQueueInfo{
-					NamespaceId: db.namespaceID,
-					Name:        db.taskQueueName,
-					TaskType:    db.taskType,
-					AckLevel:    db.ackLevel,
-					Kind:        db.taskQueueKind,
-				},
+				Data:    db.taskQueueInfo(db.ackLevel),
 				RangeID: db.rangeID,
 			},
 			Tasks: tasks,
@@ -196,3 +333,19 @@ func (db *taskQueueDB) CompleteTasksLessThan(taskID int64, limit int) (int, erro
 	}
 	return n, err
 }
+
+func (db *taskQueueDB) taskQueueInfo(ackLevel int64) *persistenceblobs.TaskQueueInfo {
+	return &persistenceblobs.TaskQueueInfo{
+		NamespaceId:        db.namespaceID,
+		Name:               db.taskQueueName,
+		TaskType:           db.taskType,
+		AckLevel:           ackLevel,
+		Kind:               db.taskQueueKind,
+		NumReadPartitions:  db.numReadPartitions,
+		NumWritePartitions: db.numWritePartitions,
+		VersioningData:     db.versioningData,
+		State:              db.dispatchState,
+		MaxTasksPerSecond:  db.maxTasksPerSecond,
+		Subqueues:          db.subqueues,
+	}
+}
@@ -161,6 +161,8 @@ func (fwdr *Forwarder) ForwardTask(ctx context.Context, task *internalTask) erro
 			Source:                        task.source,
 			ScheduleToStartTimeoutSeconds: newScheduleToStartTimeout,
 			ForwardedFrom:                 fwdr.taskQueueID.name,
+			Priority:                      task.event.Data.GetPriority(),
+			FairnessKey:                   task.event.Data.GetFairnessKey(),
 		})
 	case enumspb.TASK_QUEUE_TYPE_ACTIVITY:
 		_, err = fwdr.client.AddActivityTask(ctx, &matchingservice.AddActivityTaskRequest{
@@ -175,6 +177,8 @@ func (fwdr *Forwarder) ForwardTask(ctx context.Context, task *internalTask) erro
 			Source:                        task.source,
 			ScheduleToStartTimeoutSeconds: newScheduleToStartTimeout,
 			ForwardedFrom:                 fwdr.taskQueueID.name,
+			Priority:                      task.event.Data.GetPriority(),
+			FairnessKey:                   task.event.Data.GetFairnessKey(),
 		})
 	default:
 		return errInvalidTaskQueueType
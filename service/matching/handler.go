@@ -29,6 +29,7 @@ import (
 	"sync"
 	"time"
 
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
 	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -363,6 +364,150 @@ func (h *Handler) DescribeTaskQueue(
 	return response, hCtx.handleErr(err)
 }
 
+// GetTaskQueuePartitionCount returns the number of read and write partitions of a task queue
+func (h *Handler) GetTaskQueuePartitionCount(
+	ctx context.Context,
+	request *matchingservice.GetTaskQueuePartitionCountRequest,
+) (_ *matchingservice.GetTaskQueuePartitionCountResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		request.GetTaskQueue(),
+		metrics.MatchingGetPartitionCountScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, hCtx.handleErr(errMatchingHostThrottle)
+	}
+
+	response, err := h.engine.GetTaskQueuePartitionCount(hCtx, request)
+	return response, hCtx.handleErr(err)
+}
+
+// UpdateWorkerBuildIdCompatibility updates the worker version sets of a decision task queue
+func (h *Handler) UpdateWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *matchingservice.UpdateWorkerBuildIdCompatibilityRequest,
+) (_ *matchingservice.UpdateWorkerBuildIdCompatibilityResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		&taskqueuepb.TaskQueue{Name: request.GetTaskQueue(), Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+		metrics.MatchingUpdateVersionSetsScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, hCtx.handleErr(errMatchingHostThrottle)
+	}
+
+	response, err := h.engine.UpdateWorkerBuildIdCompatibility(hCtx, request)
+	return response, hCtx.handleErr(err)
+}
+
+// GetWorkerBuildIdCompatibility returns the worker version sets of a decision task queue
+func (h *Handler) GetWorkerBuildIdCompatibility(
+	ctx context.Context,
+	request *matchingservice.GetWorkerBuildIdCompatibilityRequest,
+) (_ *matchingservice.GetWorkerBuildIdCompatibilityResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		&taskqueuepb.TaskQueue{Name: request.GetTaskQueue(), Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+		metrics.MatchingGetVersionSetsScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, hCtx.handleErr(errMatchingHostThrottle)
+	}
+
+	response, err := h.engine.GetWorkerBuildIdCompatibility(hCtx, request)
+	return response, hCtx.handleErr(err)
+}
+
+// UpdateTaskQueueState pauses, drains or resumes dispatch of a task queue
+func (h *Handler) UpdateTaskQueueState(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskQueueStateRequest,
+) (_ *matchingservice.UpdateTaskQueueStateResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		request.GetTaskQueue(),
+		metrics.MatchingUpdateTaskQueueStateScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, hCtx.handleErr(errMatchingHostThrottle)
+	}
+
+	response, err := h.engine.UpdateTaskQueueState(hCtx, request)
+	return response, hCtx.handleErr(err)
+}
+
+// UpdateTaskQueueRateLimit sets or clears the operator defined dispatch rate limit of a task queue
+func (h *Handler) UpdateTaskQueueRateLimit(
+	ctx context.Context,
+	request *matchingservice.UpdateTaskQueueRateLimitRequest,
+) (_ *matchingservice.UpdateTaskQueueRateLimitResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		request.GetTaskQueue(),
+		metrics.MatchingUpdateTaskQueueRateLimitScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, hCtx.handleErr(errMatchingHostThrottle)
+	}
+
+	response, err := h.engine.UpdateTaskQueueRateLimit(hCtx, request)
+	return response, hCtx.handleErr(err)
+}
+
+// ListWorkers returns the workers which polled task queues of a namespace on this host
+func (h *Handler) ListWorkers(
+	ctx context.Context,
+	request *matchingservice.ListWorkersRequest,
+) (_ *matchingservice.ListWorkersResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := h.newHandlerContext(
+		ctx,
+		request.GetNamespaceId(),
+		nil,
+		metrics.MatchingListWorkersScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return nil, hCtx.handleErr(errMatchingHostThrottle)
+	}
+
+	response, err := h.engine.ListWorkers(hCtx, request)
+	return response, hCtx.handleErr(err)
+}
+
 // ListTaskQueuePartitions returns information about partitions for a taskQueue
 func (h *Handler) ListTaskQueuePartitions(
 	ctx context.Context,
@@ -27,6 +27,7 @@ package matching
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -54,6 +55,11 @@ type TaskMatcher struct {
 	fwdr          *Forwarder
 	scope         func() metrics.Scope // namespace metric scope
 	numPartitions func() int           // number of task queue partitions
+
+	// pausedC is closed while dispatch is paused and resumedC while it is not
+	pauseLock sync.Mutex
+	pausedC   chan struct{}
+	resumedC  chan struct{}
 }
 
 const (
@@ -69,6 +75,8 @@ var errTaskqueueThrottled = errors.New("cannot add to taskqueue, limit exceeded"
 func newTaskMatcher(config *taskQueueConfig, fwdr *Forwarder, scopeFunc func() metrics.Scope) *TaskMatcher {
 	dPtr := _defaultTaskDispatchRPS
 	limiter := quotas.NewRateLimiter(&dPtr, _defaultTaskDispatchRPSTTL, config.MinTaskThrottlingBurstSize())
+	resumedC := make(chan struct{})
+	close(resumedC)
 	return &TaskMatcher{
 		limiter:       limiter,
 		scope:         scopeFunc,
@@ -76,6 +84,8 @@ func newTaskMatcher(config *taskQueueConfig, fwdr *Forwarder, scopeFunc func() m
 		taskC:         make(chan *internalTask),
 		queryTaskC:    make(chan *internalTask),
 		numPartitions: config.NumReadPartitions,
+		pausedC:       make(chan struct{}),
+		resumedC:      resumedC,
 	}
 }
 
@@ -104,11 +114,19 @@ func newTaskMatcher(config *taskQueueConfig, fwdr *Forwarder, scopeFunc func() m
 // trying to match with a poller. The caller is expected to set the
 // correct context timeout.
 //
+// Paused dispatch:
+// When dispatch is paused, this method returns false right away so
+// the task gets persisted by the caller.
+//
 // returns error when:
 //  - ratelimit is exceeded (does not apply to query task)
 //  - context deadline is exceeded
 //  - task is matched and consumer returns error in response channel
 func (tm *TaskMatcher) Offer(ctx context.Context, task *internalTask) (bool, error) {
+	if tm.isPaused() {
+		return false, nil
+	}
+
 	var err error
 	var rsv *rate.Reservation
 	if !task.isForwarded() {
@@ -212,10 +230,14 @@ func (tm *TaskMatcher) OfferQuery(ctx context.Context, task *internalTask) (*mat
 	}
 }
 
-// MustOffer blocks until a consumer is found to handle this task
+// MustOffer blocks until a consumer is found to handle this task, it also blocks while dispatch is paused
 // Returns error only when context is canceled or the ratelimit is set to zero (allow nothing)
 // The passed in context MUST NOT have a deadline associated with it
 func (tm *TaskMatcher) MustOffer(ctx context.Context, task *internalTask) error {
+	pausedC, err := tm.waitUntilResumed(ctx)
+	if err != nil {
+		return err
+	}
 	if _, err := tm.ratelimit(ctx); err != nil {
 		return err
 	}
@@ -235,6 +257,10 @@ forLoop:
 		select {
 		case tm.taskC <- task:
 			return nil
+		case <-pausedC:
+			if pausedC, err = tm.waitUntilResumed(ctx); err != nil {
+				return err
+			}
 		case token := <-tm.fwdrAddReqTokenC():
 			childCtx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second*2))
 			err := tm.fwdr.ForwardTask(childCtx, task)
@@ -249,6 +275,7 @@ forLoop:
 					cancel()
 					return nil
 				case <-childCtx.Done():
+				case <-pausedC:
 				case <-ctx.Done():
 					cancel()
 					return ctx.Err()
@@ -314,6 +341,66 @@ func (tm *TaskMatcher) Rate() float64 {
 	return tm.limiter.Limit()
 }
 
+// Pause stops handing out tasks to pollers until Resume is called. Offer rejects tasks
+// while paused and MustOffer blocks, query tasks are still dispatched.
+func (tm *TaskMatcher) Pause() {
+	tm.pauseLock.Lock()
+	defer tm.pauseLock.Unlock()
+	select {
+	case <-tm.pausedC:
+		return
+	default:
+	}
+	close(tm.pausedC)
+	tm.resumedC = make(chan struct{})
+}
+
+// Resume resumes handing out tasks to pollers
+func (tm *TaskMatcher) Resume() {
+	tm.pauseLock.Lock()
+	defer tm.pauseLock.Unlock()
+	select {
+	case <-tm.resumedC:
+		return
+	default:
+	}
+	close(tm.resumedC)
+	tm.pausedC = make(chan struct{})
+}
+
+func (tm *TaskMatcher) isPaused() bool {
+	pausedC, _ := tm.pauseChannels()
+	select {
+	case <-pausedC:
+		return true
+	default:
+		return false
+	}
+}
+
+func (tm *TaskMatcher) pauseChannels() (pausedC <-chan struct{}, resumedC <-chan struct{}) {
+	tm.pauseLock.Lock()
+	defer tm.pauseLock.Unlock()
+	return tm.pausedC, tm.resumedC
+}
+
+// waitUntilResumed blocks while dispatch is paused, it returns a channel closed by the next pause
+func (tm *TaskMatcher) waitUntilResumed(ctx context.Context) (<-chan struct{}, error) {
+	for {
+		pausedC, resumedC := tm.pauseChannels()
+		select {
+		case <-pausedC:
+		default:
+			return pausedC, nil
+		}
+		select {
+		case <-resumedC:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func (tm *TaskMatcher) pollOrForward(
 	ctx context.Context,
 	taskC <-chan *internalTask,
@@ -359,6 +359,59 @@ func (t *MatcherTestSuite) TestMustOfferLocalMatch() {
 	t.NoError(err)
 }
 
+func (t *MatcherTestSuite) TestPausedDispatch() {
+	// force disable remote forwarding
+	<-t.fwdr.AddReqTokenC()
+	<-t.fwdr.PollReqTokenC()
+
+	t.matcher.Pause()
+
+	pollStarted := make(chan struct{})
+	polledC := make(chan *internalTask, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		close(pollStarted)
+		task, err := t.matcher.Poll(ctx)
+		cancel()
+		if err == nil {
+			task.finish(nil)
+		}
+		polledC <- task
+	}()
+
+	<-pollStarted
+	time.Sleep(10 * time.Millisecond)
+
+	// offered tasks are not matched so that they get persisted
+	task := newInternalTask(randomTaskInfo(), nil, enumsgenpb.TASK_SOURCE_HISTORY, "", true)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	syncMatch, err := t.matcher.Offer(ctx, task)
+	cancel()
+	t.NoError(err)
+	t.False(syncMatch)
+
+	// backlog tasks are held back until dispatch is resumed
+	mustOfferC := make(chan error, 1)
+	go func() {
+		task := newInternalTask(randomTaskInfo(), nil, enumsgenpb.TASK_SOURCE_DB_BACKLOG, "", false)
+		mustOfferC <- t.matcher.MustOffer(context.Background(), task)
+	}()
+	select {
+	case <-mustOfferC:
+		t.Fail("backlog task dispatched while paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	t.matcher.Resume()
+	select {
+	case err := <-mustOfferC:
+		t.NoError(err)
+	case <-time.After(time.Second):
+		t.Fail("backlog task not dispatched after resume")
+	}
+	t.NotNil(<-polledC)
+}
+
 func (t *MatcherTestSuite) TestMustOfferRemoteMatch() {
 	pollSigC := make(chan struct{})
 
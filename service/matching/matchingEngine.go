@@ -40,15 +40,18 @@ import (
 	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 	"github.com/temporalio/temporal/.gen/proto/historyservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token/v1"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -87,6 +90,9 @@ type (
 		namespaceCache       cache.NamespaceCache
 		versionChecker       headers.VersionChecker
 		keyResolver          membership.ServiceResolver
+		partitionCounts      *matching.PartitionCountCache // partition counts of automatically scaled root partitions
+		versioningData       *versioningDataCache          // worker version sets of root decision task queues
+		workerRegistry       *workerRegistry               // workers polling task queues on this host
 	}
 )
 
@@ -119,7 +125,7 @@ func NewEngine(taskManager persistence.TaskManager,
 	resolver membership.ServiceResolver,
 ) Engine {
 
-	return &matchingEngineImpl{
+	e := &matchingEngineImpl{
 		taskManager:          taskManager,
 		historyService:       historyService,
 		tokenSerializer:      common.NewProtoTaskTokenSerializer(),
@@ -133,6 +139,10 @@ func NewEngine(taskManager persistence.TaskManager,
 		versionChecker:       headers.NewVersionChecker(),
 		keyResolver:          resolver,
 	}
+	e.partitionCounts = matching.NewPartitionCountCache(e.fetchPartitionCount)
+	e.versioningData = newVersioningDataCache(e.fetchVersioningData)
+	e.workerRegistry = newWorkerRegistry(config, clock.NewRealTimeSource())
+	return e
 }
 
 func (e *matchingEngineImpl) Start() {
@@ -239,6 +249,10 @@ func (e *matchingEngineImpl) AddDecisionTask(
 	if err != nil {
 		return false, err
 	}
+	taskQueue, err = e.getVersionedTaskQueue(taskQueue, taskQueueKind, addRequest.GetForwardedFrom(), addRequest.GetBuildId(), false)
+	if err != nil {
+		return false, err
+	}
 
 	tlMgr, err := e.getTaskQueueManager(taskQueue, taskQueueKind)
 	if err != nil {
@@ -256,6 +270,8 @@ func (e *matchingEngineImpl) AddDecisionTask(
 		ScheduleId:  addRequest.GetScheduleId(),
 		Expiry:      expiry,
 		CreatedTime: now,
+		Priority:    addRequest.GetPriority(),
+		FairnessKey: addRequest.GetFairnessKey(),
 	}
 
 	return tlMgr.AddTask(hCtx.Context, addTaskParams{
@@ -303,6 +319,8 @@ func (e *matchingEngineImpl) AddActivityTask(
 		ScheduleId:  addRequest.GetScheduleId(),
 		CreatedTime: now,
 		Expiry:      expiry,
+		Priority:    addRequest.GetPriority(),
+		FairnessKey: addRequest.GetFairnessKey(),
 	}
 
 	return tlMgr.AddTask(hCtx.Context, addTaskParams{
@@ -323,6 +341,7 @@ func (e *matchingEngineImpl) PollForDecisionTask(
 	request := req.PollRequest
 	taskQueueName := request.TaskQueue.GetName()
 	e.logger.Debug("Received PollForDecisionTask for taskQueue", tag.WorkflowTaskQueueName(taskQueueName))
+	e.recordWorkerPoll(hCtx, namespaceID, request.GetIdentity(), request.TaskQueue, req.GetForwardedFrom(), enumspb.TASK_QUEUE_TYPE_DECISION)
 pollLoop:
 	for {
 		err := common.IsValidContext(hCtx.Context)
@@ -338,6 +357,10 @@ pollLoop:
 			return nil, err
 		}
 		taskQueueKind := request.TaskQueue.GetKind()
+		taskQueue, err = e.getVersionedTaskQueue(taskQueue, taskQueueKind, req.GetForwardedFrom(), request.GetBinaryChecksum(), true)
+		if err != nil {
+			return nil, err
+		}
 		task, err := e.getTask(pollerCtx, taskQueue, nil, taskQueueKind)
 		if err != nil {
 			// TODO: Is empty poll the best reply for errPumpClosed?
@@ -415,6 +438,7 @@ func (e *matchingEngineImpl) PollForActivityTask(
 	request := req.PollRequest
 	taskQueueName := request.TaskQueue.GetName()
 	e.logger.Debug("Received PollForActivityTask for taskQueue", tag.Name(taskQueueName))
+	e.recordWorkerPoll(hCtx, namespaceID, request.GetIdentity(), request.TaskQueue, req.GetForwardedFrom(), enumspb.TASK_QUEUE_TYPE_ACTIVITY)
 pollLoop:
 	for {
 		err := common.IsValidContext(hCtx.Context)
@@ -487,6 +511,10 @@ func (e *matchingEngineImpl) QueryWorkflow(
 	if err != nil {
 		return nil, err
 	}
+	taskQueue, err = e.getVersionedTaskQueue(taskQueue, taskQueueKind, queryRequest.GetForwardedFrom(), queryRequest.GetBuildId(), false)
+	if err != nil {
+		return nil, err
+	}
 
 	tlMgr, err := e.getTaskQueueManager(taskQueue, taskQueueKind)
 	if err != nil {
@@ -567,6 +595,12 @@ func (e *matchingEngineImpl) CancelOutstandingPoll(
 	}
 
 	tlMgr.CancelPoller(pollerID)
+	if taskQueueType == enumspb.TASK_QUEUE_TYPE_DECISION && !taskQueue.IsVersioned() {
+		// the poll may have been redirected to the task queue of a worker version set
+		for _, versionedMgr := range e.getVersionedTaskQueueManagers(taskQueue) {
+			versionedMgr.CancelPoller(pollerID)
+		}
+	}
 	return nil
 }
 
@@ -587,7 +621,374 @@ func (e *matchingEngineImpl) DescribeTaskQueue(
 		return nil, err
 	}
 
-	return tlMgr.DescribeTaskQueue(request.DescRequest.GetIncludeTaskQueueStatus()), nil
+	response := tlMgr.DescribeTaskQueue(request.DescRequest.GetIncludeTaskQueueStatus())
+	if !request.GetIncludeTaskQueueStats() {
+		return response, nil
+	}
+
+	response.Stats = tlMgr.DescribeTaskQueueStats()
+	if !request.GetIncludePartitions() || !taskQueue.IsRoot() || taskQueueKind == enumspb.TASK_QUEUE_KIND_STICKY {
+		return response, nil
+	}
+
+	nPartitions, _ := tlMgr.PartitionCounts()
+	partitions, err := e.describeTaskQueuePartitions(hCtx.Context, request.DescRequest.GetNamespace(), taskQueue, response.Stats, nPartitions)
+	if err != nil {
+		return nil, err
+	}
+	response.Partitions = partitions
+	response.Stats = aggregateTaskQueueStats(partitions)
+	return response, nil
+}
+
+// describeTaskQueuePartitions collects the stats of the first nPartitions partitions of the given root task queue
+func (e *matchingEngineImpl) describeTaskQueuePartitions(
+	ctx context.Context,
+	namespace string,
+	rootTaskQueue *taskQueueID,
+	rootStats *taskqueuegenpb.TaskQueueStats,
+	nPartitions int,
+) ([]*taskqueuegenpb.TaskQueuePartitionStats, error) {
+	partitions := []*taskqueuegenpb.TaskQueuePartitionStats{{Partition: rootTaskQueue.name, Stats: rootStats}}
+	for i := 1; i < nPartitions; i++ {
+		partition := rootTaskQueue.mkName(i)
+		resp, err := e.matchingClient.DescribeTaskQueue(ctx, &matchingservice.DescribeTaskQueueRequest{
+			NamespaceId: rootTaskQueue.namespaceID,
+			DescRequest: &workflowservice.DescribeTaskQueueRequest{
+				Namespace:     namespace,
+				TaskQueue:     &taskqueuepb.TaskQueue{Name: partition, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+				TaskQueueType: rootTaskQueue.taskType,
+			},
+			IncludeTaskQueueStats: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, &taskqueuegenpb.TaskQueuePartitionStats{Partition: partition, Stats: resp.GetStats()})
+	}
+	return partitions, nil
+}
+
+func (e *matchingEngineImpl) GetTaskQueuePartitionCount(
+	hCtx *handlerContext,
+	request *matchingservice.GetTaskQueuePartitionCountRequest,
+) (*matchingservice.GetTaskQueuePartitionCountResponse, error) {
+	numRead, numWrite, err := e.getPartitionCounts(request.GetNamespaceId(), request.TaskQueue.GetName(), request.GetTaskQueueType())
+	if err != nil {
+		return nil, err
+	}
+	return &matchingservice.GetTaskQueuePartitionCountResponse{
+		NumReadPartitions:  int32(numRead),
+		NumWritePartitions: int32(numWrite),
+	}, nil
+}
+
+// getPartitionCounts returns the partition counts of the given task queue as decided by its root partition
+func (e *matchingEngineImpl) getPartitionCounts(
+	namespaceID string,
+	taskQueueName string,
+	taskQueueType enumspb.TaskQueueType,
+) (numRead int, numWrite int, err error) {
+	taskQueue, err := newTaskQueueID(namespaceID, taskQueueName, taskQueueType)
+	if err != nil {
+		return 0, 0, err
+	}
+	rootTaskQueue, err := newTaskQueueID(namespaceID, taskQueue.GetRoot(), taskQueueType)
+	if err != nil {
+		return 0, 0, err
+	}
+	tlMgr, err := e.getTaskQueueManager(rootTaskQueue, enumspb.TASK_QUEUE_KIND_NORMAL)
+	if err != nil {
+		return 0, 0, err
+	}
+	numRead, numWrite = tlMgr.PartitionCounts()
+	return numRead, numWrite, nil
+}
+
+// fetchPartitionCount fetches the partition counts of a root task queue from the matching host owning it
+func (e *matchingEngineImpl) fetchPartitionCount(
+	ctx context.Context,
+	namespaceID string,
+	taskQueue string,
+	taskQueueType enumspb.TaskQueueType,
+) (int, int, error) {
+	resp, err := e.matchingClient.GetTaskQueuePartitionCount(ctx, &matchingservice.GetTaskQueuePartitionCountRequest{
+		NamespaceId:   namespaceID,
+		TaskQueue:     &taskqueuepb.TaskQueue{Name: taskQueue, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+		TaskQueueType: taskQueueType,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(resp.GetNumReadPartitions()), int(resp.GetNumWritePartitions()), nil
+}
+
+func (e *matchingEngineImpl) UpdateWorkerBuildIdCompatibility(
+	hCtx *handlerContext,
+	request *matchingservice.UpdateWorkerBuildIdCompatibilityRequest,
+) (*matchingservice.UpdateWorkerBuildIdCompatibilityResponse, error) {
+	namespaceID := request.GetNamespaceId()
+	tlMgr, err := e.getRootDecisionTaskQueueManager(namespaceID, request.GetTaskQueue())
+	if err != nil {
+		return nil, err
+	}
+	namespaceEntry, err := e.namespaceCache.GetNamespaceByID(namespaceID)
+	if err != nil {
+		return nil, err
+	}
+	namespace := namespaceEntry.GetInfo().Name
+
+	data, err := tlMgr.UpdateVersioningData(func(data *taskqueuegenpb.VersioningData) (*taskqueuegenpb.VersioningData, error) {
+		return updateVersioningData(
+			data,
+			request,
+			e.config.MaxVersionSetsPerTaskQueue(namespace),
+			e.config.MaxBuildIdsPerTaskQueue(namespace),
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// partitions hosted here see the update right away, other hosts within the cache refresh interval
+	e.versioningData.Put(namespaceID, request.GetTaskQueue(), data)
+	return &matchingservice.UpdateWorkerBuildIdCompatibilityResponse{}, nil
+}
+
+func (e *matchingEngineImpl) GetWorkerBuildIdCompatibility(
+	hCtx *handlerContext,
+	request *matchingservice.GetWorkerBuildIdCompatibilityRequest,
+) (*matchingservice.GetWorkerBuildIdCompatibilityResponse, error) {
+	tlMgr, err := e.getRootDecisionTaskQueueManager(request.GetNamespaceId(), request.GetTaskQueue())
+	if err != nil {
+		return nil, err
+	}
+	return &matchingservice.GetWorkerBuildIdCompatibilityResponse{
+		VersioningData: tlMgr.VersioningData(),
+	}, nil
+}
+
+func (e *matchingEngineImpl) UpdateTaskQueueState(
+	hCtx *handlerContext,
+	request *matchingservice.UpdateTaskQueueStateRequest,
+) (*matchingservice.UpdateTaskQueueStateResponse, error) {
+	namespaceID := request.GetNamespaceId()
+	taskQueueType := request.GetTaskQueueType()
+	if request.TaskQueue.GetKind() == enumspb.TASK_QUEUE_KIND_STICKY {
+		return nil, serviceerror.NewInvalidArgument("Dispatch state of sticky task queues cannot be updated.")
+	}
+	if request.GetState() == enumsgenpb.TASK_QUEUE_STATE_UNSPECIFIED {
+		return nil, serviceerror.NewInvalidArgument("Task queue state is not set on request.")
+	}
+	taskQueue, err := newTaskQueueID(namespaceID, request.TaskQueue.GetName(), taskQueueType)
+	if err != nil {
+		return nil, err
+	}
+	tlMgr, err := e.getTaskQueueManager(taskQueue, enumspb.TASK_QUEUE_KIND_NORMAL)
+	if err != nil {
+		return nil, err
+	}
+	if err := tlMgr.UpdateDispatchState(request.GetState()); err != nil {
+		return nil, err
+	}
+	if !request.GetIncludePartitions() || !taskQueue.IsRoot() || taskQueue.IsVersioned() {
+		return &matchingservice.UpdateTaskQueueStateResponse{}, nil
+	}
+
+	// every partition persists its own state with its lease, including the worker version set task queues
+	for _, name := range e.getOtherPartitionNames(taskQueue, tlMgr) {
+		if _, err := e.matchingClient.UpdateTaskQueueState(hCtx.Context, &matchingservice.UpdateTaskQueueStateRequest{
+			NamespaceId:   namespaceID,
+			TaskQueue:     &taskqueuepb.TaskQueue{Name: name, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+			TaskQueueType: taskQueueType,
+			State:         request.GetState(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return &matchingservice.UpdateTaskQueueStateResponse{}, nil
+}
+
+func (e *matchingEngineImpl) UpdateTaskQueueRateLimit(
+	hCtx *handlerContext,
+	request *matchingservice.UpdateTaskQueueRateLimitRequest,
+) (*matchingservice.UpdateTaskQueueRateLimitResponse, error) {
+	namespaceID := request.GetNamespaceId()
+	taskQueueType := request.GetTaskQueueType()
+	if request.TaskQueue.GetKind() == enumspb.TASK_QUEUE_KIND_STICKY {
+		return nil, serviceerror.NewInvalidArgument("Dispatch rate limit of sticky task queues cannot be updated.")
+	}
+	if request.GetMaxTasksPerSecond().GetValue() < 0 {
+		return nil, serviceerror.NewInvalidArgument("MaxTasksPerSecond cannot be negative.")
+	}
+	taskQueue, err := newTaskQueueID(namespaceID, request.TaskQueue.GetName(), taskQueueType)
+	if err != nil {
+		return nil, err
+	}
+	tlMgr, err := e.getTaskQueueManager(taskQueue, enumspb.TASK_QUEUE_KIND_NORMAL)
+	if err != nil {
+		return nil, err
+	}
+	if err := tlMgr.UpdateMaxTasksPerSecond(request.GetMaxTasksPerSecond()); err != nil {
+		return nil, err
+	}
+	if !request.GetIncludePartitions() || !taskQueue.IsRoot() || taskQueue.IsVersioned() {
+		return &matchingservice.UpdateTaskQueueRateLimitResponse{}, nil
+	}
+
+	// the limit applies to the task queue as a whole, every partition divides it by the number of partitions
+	for _, name := range e.getOtherPartitionNames(taskQueue, tlMgr) {
+		if _, err := e.matchingClient.UpdateTaskQueueRateLimit(hCtx.Context, &matchingservice.UpdateTaskQueueRateLimitRequest{
+			NamespaceId:       namespaceID,
+			TaskQueue:         &taskqueuepb.TaskQueue{Name: name, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+			TaskQueueType:     taskQueueType,
+			MaxTasksPerSecond: request.GetMaxTasksPerSecond(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return &matchingservice.UpdateTaskQueueRateLimitResponse{}, nil
+}
+
+func (e *matchingEngineImpl) ListWorkers(
+	hCtx *handlerContext,
+	request *matchingservice.ListWorkersRequest,
+) (*matchingservice.ListWorkersResponse, error) {
+	return &matchingservice.ListWorkersResponse{
+		Workers: e.workerRegistry.list(request.GetNamespaceId()),
+	}, nil
+}
+
+// recordWorkerPoll registers a poll in the worker registry of this host. Polls forwarded from other
+// partitions were already registered by the host which received them and sticky task queues are
+// specific to a single worker, so both are skipped.
+func (e *matchingEngineImpl) recordWorkerPoll(
+	hCtx *handlerContext,
+	namespaceID string,
+	identity string,
+	taskQueue *taskqueuepb.TaskQueue,
+	forwardedFrom string,
+	taskQueueType enumspb.TaskQueueType,
+) {
+	if forwardedFrom != "" || taskQueue.GetKind() == enumspb.TASK_QUEUE_KIND_STICKY {
+		return
+	}
+	id, err := newTaskQueueID(namespaceID, taskQueue.GetName(), taskQueueType)
+	if err != nil {
+		return
+	}
+	sdk := headers.GetValues(hCtx.Context, headers.ClientImplHeaderName, headers.ClientVersionHeaderName)
+	e.workerRegistry.recordPoll(namespaceID, identity, sdk[0], sdk[1], id.GetRoot(), taskQueueType)
+}
+
+// getOtherPartitionNames returns the names of all partitions of the given root task queue except the root
+// itself, including the partitions of the worker version set task queues of a decision task queue
+func (e *matchingEngineImpl) getOtherPartitionNames(rootTaskQueue *taskQueueID, rootTlMgr taskQueueManager) []string {
+	nPartitions, _ := rootTlMgr.PartitionCounts()
+	names := make([]qualifiedTaskQueueName, 0, nPartitions)
+	names = append(names, rootTaskQueue.qualifiedTaskQueueName)
+	if rootTaskQueue.taskType == enumspb.TASK_QUEUE_TYPE_DECISION {
+		for _, versionSet := range rootTlMgr.VersioningData().GetVersionSets() {
+			names = append(names, rootTaskQueue.WithVersionSet(versionSet.GetId()))
+		}
+	}
+	var result []string
+	for _, name := range names {
+		for i := 0; i < nPartitions; i++ {
+			if i == 0 && !name.IsVersioned() {
+				continue
+			}
+			result = append(result, name.mkName(i))
+		}
+	}
+	return result
+}
+
+// getRootDecisionTaskQueueManager returns the manager of the root partition of a decision task queue, which owns
+// the worker version sets of the task queue
+func (e *matchingEngineImpl) getRootDecisionTaskQueueManager(namespaceID string, taskQueueName string) (taskQueueManager, error) {
+	taskQueue, err := newTaskQueueID(namespaceID, taskQueueName, enumspb.TASK_QUEUE_TYPE_DECISION)
+	if err != nil {
+		return nil, err
+	}
+	if !taskQueue.IsRoot() || taskQueue.IsVersioned() {
+		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("%v is not the name of a root task queue.", taskQueueName))
+	}
+	return e.getTaskQueueManager(taskQueue, enumspb.TASK_QUEUE_KIND_NORMAL)
+}
+
+// getVersionedTaskQueue returns the task queue a decision task, query or poll with the given worker build id
+// is routed to. When the task queue has worker version sets, build ids in a version set are routed to the
+// task queue of the set, and tasks without build id, of workflow runs that have not completed a decision task
+// yet, to the one of the default set. Polls without build id and unknown build ids stay unversioned.
+func (e *matchingEngineImpl) getVersionedTaskQueue(
+	taskQueue *taskQueueID,
+	taskQueueKind enumspb.TaskQueueKind,
+	forwardedFrom string,
+	buildID string,
+	isPoll bool,
+) (*taskQueueID, error) {
+	if taskQueueKind == enumspb.TASK_QUEUE_KIND_STICKY || taskQueue.IsVersioned() || forwardedFrom != "" {
+		// sticky task queues belong to a single worker and forwarded requests were already routed by the child partition
+		return taskQueue, nil
+	}
+
+	data, err := e.getVersioningData(taskQueue)
+	if err != nil {
+		return nil, err
+	}
+	versionSet, ok := lookupVersionSet(data, buildID)
+	if !ok && buildID == "" && !isPoll {
+		versionSet, ok = defaultVersionSet(data)
+	}
+	if !ok {
+		return taskQueue, nil
+	}
+	return &taskQueueID{
+		qualifiedTaskQueueName: taskQueue.WithVersionSet(versionSet),
+		namespaceID:            taskQueue.namespaceID,
+		taskType:               taskQueue.taskType,
+	}, nil
+}
+
+// getVersioningData returns the worker version sets of the given decision task queue partition
+func (e *matchingEngineImpl) getVersioningData(taskQueue *taskQueueID) (*taskqueuegenpb.VersioningData, error) {
+	if taskQueue.IsRoot() {
+		tlMgr, err := e.getTaskQueueManager(taskQueue, enumspb.TASK_QUEUE_KIND_NORMAL)
+		if err != nil {
+			return nil, err
+		}
+		return tlMgr.VersioningData(), nil
+	}
+	return e.versioningData.Get(taskQueue.namespaceID, taskQueue.GetRoot())
+}
+
+// fetchVersioningData fetches the worker version sets of a root decision task queue from the matching host owning it
+func (e *matchingEngineImpl) fetchVersioningData(namespaceID string, taskQueue string) (*taskqueuegenpb.VersioningData, error) {
+	resp, err := e.matchingClient.GetWorkerBuildIdCompatibility(context.Background(), &matchingservice.GetWorkerBuildIdCompatibilityRequest{
+		NamespaceId: namespaceID,
+		TaskQueue:   taskQueue,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetVersioningData(), nil
+}
+
+// getVersionedTaskQueueManagers returns the loaded managers of the worker version set task queues of the given partition
+func (e *matchingEngineImpl) getVersionedTaskQueueManagers(taskQueue *taskQueueID) []taskQueueManager {
+	e.taskQueuesLock.RLock()
+	defer e.taskQueuesLock.RUnlock()
+	var result []taskQueueManager
+	for id, tlMgr := range e.taskQueues {
+		if id.IsVersioned() &&
+			id.namespaceID == taskQueue.namespaceID &&
+			id.taskType == taskQueue.taskType &&
+			id.baseName == taskQueue.baseName &&
+			id.partition == taskQueue.partition {
+			result = append(result, tlMgr)
+		}
+	}
+	return result
 }
 
 func (e *matchingEngineImpl) ListTaskQueuePartitions(
@@ -618,19 +1019,17 @@ func (e *matchingEngineImpl) listTaskQueuePartitions(request *matchingservice.Li
 	if err != nil {
 		return nil, err
 	}
-	partitionHostInfo := make([]*taskqueuepb.TaskQueuePartitionMetadata, len(partitions))
-
-	if err != nil {
-		return nil, err
-	}
+	partitionHostInfo := make([]*taskqueuepb.TaskQueuePartitionMetadata, 0, len(partitions))
 	for _, partition := range partitions {
-		if host, err := e.getHostInfo(partition); err != nil {
-			partitionHostInfo = append(partitionHostInfo,
-				&taskqueuepb.TaskQueuePartitionMetadata{
-					Key:           partition,
-					OwnerHostName: host,
-				})
+		host, err := e.getHostInfo(partition)
+		if err != nil {
+			return nil, err
 		}
+		partitionHostInfo = append(partitionHostInfo,
+			&taskqueuepb.TaskQueuePartitionMetadata{
+				Key:           partition,
+				OwnerHostName: host,
+			})
 	}
 	return partitionHostInfo, nil
 }
@@ -653,15 +1052,18 @@ func (e *matchingEngineImpl) getAllPartitions(
 	if err != nil {
 		return partitionKeys, err
 	}
-	taskQueueID, err := newTaskQueueID(namespaceID, taskQueue.GetName(), enumspb.TASK_QUEUE_TYPE_DECISION)
+	taskQueueID, err := newTaskQueueID(namespaceID, taskQueue.GetName(), taskQueueType)
+	if err != nil {
+		return partitionKeys, err
+	}
 	rootPartition := taskQueueID.GetRoot()
 
 	partitionKeys = append(partitionKeys, rootPartition)
 
-	nWritePartitions := e.config.GetTasksBatchSize
-	n := nWritePartitions(namespace, rootPartition, taskQueueType)
-	if n <= 0 {
-		return partitionKeys, nil
+	// partitions being drained still have to be listed, they are included in the read partitions
+	n, _, err := e.getPartitionCounts(namespaceID, rootPartition, taskQueueType)
+	if err != nil {
+		return partitionKeys, err
 	}
 
 	for i := 1; i < n; i++ {
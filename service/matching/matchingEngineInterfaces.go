@@ -40,6 +40,12 @@ type (
 		RespondQueryTaskCompleted(hCtx *handlerContext, request *matchingservice.RespondQueryTaskCompletedRequest) error
 		CancelOutstandingPoll(hCtx *handlerContext, request *matchingservice.CancelOutstandingPollRequest) error
 		DescribeTaskQueue(hCtx *handlerContext, request *matchingservice.DescribeTaskQueueRequest) (*matchingservice.DescribeTaskQueueResponse, error)
+		GetTaskQueuePartitionCount(hCtx *handlerContext, request *matchingservice.GetTaskQueuePartitionCountRequest) (*matchingservice.GetTaskQueuePartitionCountResponse, error)
+		UpdateWorkerBuildIdCompatibility(hCtx *handlerContext, request *matchingservice.UpdateWorkerBuildIdCompatibilityRequest) (*matchingservice.UpdateWorkerBuildIdCompatibilityResponse, error)
+		GetWorkerBuildIdCompatibility(hCtx *handlerContext, request *matchingservice.GetWorkerBuildIdCompatibilityRequest) (*matchingservice.GetWorkerBuildIdCompatibilityResponse, error)
+		UpdateTaskQueueState(hCtx *handlerContext, request *matchingservice.UpdateTaskQueueStateRequest) (*matchingservice.UpdateTaskQueueStateResponse, error)
+		UpdateTaskQueueRateLimit(hCtx *handlerContext, request *matchingservice.UpdateTaskQueueRateLimitRequest) (*matchingservice.UpdateTaskQueueRateLimitResponse, error)
+		ListWorkers(hCtx *handlerContext, request *matchingservice.ListWorkersRequest) (*matchingservice.ListWorkersResponse, error)
 		ListTaskQueuePartitions(hCtx *handlerContext, request *matchingservice.ListTaskQueuePartitionsRequest) (*matchingservice.ListTaskQueuePartitionsResponse, error)
 	}
 )
@@ -53,6 +53,7 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock/v1"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token/v1"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/common"
@@ -161,6 +162,9 @@ func newMatchingEngine(
 		tokenSerializer: common.NewProtoTaskTokenSerializer(),
 		config:          config,
 		namespaceCache:  mockNamespaceCache,
+		versioningData: newVersioningDataCache(func(string, string) (*taskqueuegenpb.VersioningData, error) {
+			return nil, nil
+		}),
 	}
 }
 
@@ -397,6 +401,39 @@ func (s *matchingEngineSuite) TestAddDecisionTasksForwarded() {
 	s.AddTasksTest(enumspb.TASK_QUEUE_TYPE_DECISION, true)
 }
 
+func (s *matchingEngineSuite) TestAddDecisionTasksRoutedToVersionSets() {
+	namespaceID := uuid.NewRandom().String()
+	tl := "makeToast"
+
+	_, err := s.matchingEngine.UpdateWorkerBuildIdCompatibility(s.handlerContext, &matchingservice.UpdateWorkerBuildIdCompatibilityRequest{
+		NamespaceId: namespaceID,
+		TaskQueue:   tl,
+		Operation: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet_{
+			AddNewBuildIdInNewDefaultSet: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet{BuildId: "v1"},
+		},
+	})
+	s.NoError(err)
+
+	execution := &commonpb.WorkflowExecution{RunId: uuid.NewRandom().String(), WorkflowId: "workflow1"}
+	for i, buildID := range []string{"", "v1", "unknown"} {
+		_, err := s.matchingEngine.AddDecisionTask(s.handlerContext, &matchingservice.AddDecisionTaskRequest{
+			NamespaceId:                   namespaceID,
+			Execution:                     execution,
+			ScheduleId:                    int64(i),
+			TaskQueue:                     &taskqueuepb.TaskQueue{Name: tl},
+			ScheduleToStartTimeoutSeconds: 1,
+			BuildId:                       buildID,
+		})
+		s.NoError(err)
+	}
+
+	// tasks of new runs go to the default version set, unknown build ids stay unversioned
+	tlID := newTestTaskQueueID(namespaceID, tl, enumspb.TASK_QUEUE_TYPE_DECISION)
+	versionedID := newTestTaskQueueID(namespaceID, tlID.WithVersionSet(versionSetID("v1")).name, enumspb.TASK_QUEUE_TYPE_DECISION)
+	s.EqualValues(1, s.taskManager.getTaskCount(tlID))
+	s.EqualValues(2, s.taskManager.getTaskCount(versionedID))
+}
+
 func (s *matchingEngineSuite) AddTasksTest(taskType enumspb.TaskQueueType, isForwarded bool) {
 	s.matchingEngine.config.RangeSize = 300 // override to low number for the test
 
@@ -1473,8 +1510,8 @@ func (s *matchingEngineSuite) TestTaskQueueManagerGetTaskBatch() {
 
 	// wait until all tasks are read by the task pump and enqeued into the in-memory buffer
 	// at the end of this step, ackManager readLevel will also be equal to the buffer size
-	expectedBufSize := common.MinInt(cap(tlMgr.taskReader.taskBuffer), taskCount)
-	s.True(s.awaitCondition(func() bool { return len(tlMgr.taskReader.taskBuffer) == expectedBufSize }, time.Second))
+	expectedBufSize := common.MinInt(tlMgr.taskReader.taskBuffer.cap(), taskCount)
+	s.True(s.awaitCondition(func() bool { return tlMgr.taskReader.taskBuffer.len() == expectedBufSize }, time.Second))
 
 	// stop all goroutines that read / write tasks in the background
 	// remainder of this test works with the in-memory buffer
@@ -1617,7 +1654,7 @@ func (s *matchingEngineSuite) TestTaskExpiryAndCompletion() {
 
 		// wait until all tasks are loaded by into in-memory buffers by task queue manager
 		// the buffer size should be one less than expected because dispatcher will dequeue the head
-		s.True(s.awaitCondition(func() bool { return len(tlMgr.taskReader.taskBuffer) >= (taskCount/2 - 1) }, time.Second))
+		s.True(s.awaitCondition(func() bool { return tlMgr.taskReader.taskBuffer.len() >= (taskCount/2 - 1) }, time.Second))
 
 		maxTimeBetweenTaskDeletes = tc.maxTimeBtwnDeletes
 		s.matchingEngine.config.MaxTaskDeleteBatchSize = dynamicconfig.GetIntPropertyFilteredByTaskQueueInfo(tc.batchSize)
@@ -1734,19 +1771,31 @@ func (m *testTaskManager) Close() {
 func (m *testTaskManager) getTaskQueueManager(id *taskQueueID) *testTaskQueueManager {
 	m.Lock()
 	defer m.Unlock()
-	result, ok := m.taskQueues[*id]
+	key := *newTestTaskQueueKey(id.namespaceID, id.name, id.taskType)
+	result, ok := m.taskQueues[key]
 	if ok {
 		return result
 	}
 	result = newTestTaskQueueManager()
-	m.taskQueues[*id] = result
+	m.taskQueues[key] = result
 	return result
 }
 
+// newTestTaskQueueKey returns the key of a persisted task queue, which unlike a taskQueueID
+// can also be the one of a subqueue
+func newTestTaskQueueKey(namespaceID string, name string, taskType enumspb.TaskQueueType) *taskQueueID {
+	return &taskQueueID{
+		qualifiedTaskQueueName: qualifiedTaskQueueName{name: name},
+		namespaceID:            namespaceID,
+		taskType:               taskType,
+	}
+}
+
 type testTaskQueueManager struct {
 	sync.Mutex
 	rangeID         int64
 	ackLevel        int64
+	subqueues       []*persistenceblobs.TaskQueueSubqueue
 	createTaskCount int
 	tasks           *treemap.Map
 }
@@ -1778,7 +1827,7 @@ func newTestTaskQueueID(namespaceID string, name string, taskType enumspb.TaskQu
 
 // LeaseTaskQueue provides a mock function with given fields: request
 func (m *testTaskManager) LeaseTaskQueue(request *persistence.LeaseTaskQueueRequest) (*persistence.LeaseTaskQueueResponse, error) {
-	tlm := m.getTaskQueueManager(newTestTaskQueueID(request.NamespaceID, request.TaskQueue, request.TaskType))
+	tlm := m.getTaskQueueManager(newTestTaskQueueKey(request.NamespaceID, request.TaskQueue, request.TaskType))
 	tlm.Lock()
 	defer tlm.Unlock()
 	tlm.rangeID++
@@ -1792,6 +1841,7 @@ func (m *testTaskManager) LeaseTaskQueue(request *persistence.LeaseTaskQueueRequ
 				Name:        request.TaskQueue,
 				TaskType:    request.TaskType,
 				Kind:        request.TaskQueueKind,
+				Subqueues:   tlm.subqueues,
 			},
 			RangeID: tlm.rangeID,
 		},
@@ -1803,7 +1853,7 @@ func (m *testTaskManager) UpdateTaskQueue(request *persistence.UpdateTaskQueueRe
 	m.logger.Debug("UpdateTaskQueue", tag.TaskQueueInfo(request.TaskQueueInfo), tag.AckLevel(request.TaskQueueInfo.AckLevel))
 
 	tli := request.TaskQueueInfo
-	tlm := m.getTaskQueueManager(newTestTaskQueueID(tli.GetNamespaceId(), tli.Name, tli.TaskType))
+	tlm := m.getTaskQueueManager(newTestTaskQueueKey(tli.GetNamespaceId(), tli.Name, tli.TaskType))
 
 	tlm.Lock()
 	defer tlm.Unlock()
@@ -1813,6 +1863,7 @@ func (m *testTaskManager) UpdateTaskQueue(request *persistence.UpdateTaskQueueRe
 		}
 	}
 	tlm.ackLevel = tli.AckLevel
+	tlm.subqueues = tli.GetSubqueues()
 	return &persistence.UpdateTaskQueueResponse{}, nil
 }
 
@@ -1824,7 +1875,7 @@ func (m *testTaskManager) CompleteTask(request *persistence.CompleteTaskRequest)
 	}
 
 	tli := request.TaskQueue
-	tlm := m.getTaskQueueManager(newTestTaskQueueID(tli.NamespaceID, tli.Name, tli.TaskType))
+	tlm := m.getTaskQueueManager(newTestTaskQueueKey(tli.NamespaceID, tli.Name, tli.TaskType))
 
 	tlm.Lock()
 	defer tlm.Unlock()
@@ -1834,7 +1885,7 @@ func (m *testTaskManager) CompleteTask(request *persistence.CompleteTaskRequest)
 }
 
 func (m *testTaskManager) CompleteTasksLessThan(request *persistence.CompleteTasksLessThanRequest) (int, error) {
-	tlm := m.getTaskQueueManager(newTestTaskQueueID(request.NamespaceID, request.TaskQueueName, request.TaskType))
+	tlm := m.getTaskQueueManager(newTestTaskQueueKey(request.NamespaceID, request.TaskQueueName, request.TaskType))
 	tlm.Lock()
 	defer tlm.Unlock()
 	keys := tlm.tasks.Keys()
@@ -1854,7 +1905,7 @@ func (m *testTaskManager) ListTaskQueue(request *persistence.ListTaskQueueReques
 func (m *testTaskManager) DeleteTaskQueue(request *persistence.DeleteTaskQueueRequest) error {
 	m.Lock()
 	defer m.Unlock()
-	key := newTestTaskQueueID(request.TaskQueue.NamespaceID, request.TaskQueue.Name, request.TaskQueue.TaskType)
+	key := newTestTaskQueueKey(request.TaskQueue.NamespaceID, request.TaskQueue.Name, request.TaskQueue.TaskType)
 	delete(m.taskQueues, *key)
 	return nil
 }
@@ -1866,7 +1917,7 @@ func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (
 	taskType := request.TaskQueueInfo.Data.TaskType
 	rangeID := request.TaskQueueInfo.RangeID
 
-	tlm := m.getTaskQueueManager(newTestTaskQueueID(namespaceID, taskQueue, taskType))
+	tlm := m.getTaskQueueManager(newTestTaskQueueKey(namespaceID, taskQueue, taskType))
 	tlm.Lock()
 	defer tlm.Unlock()
 
@@ -1912,7 +1963,7 @@ func (m *testTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persi
 		m.logger.Debug("testTaskManager.GetTasks", tag.ReadLevel(request.ReadLevel))
 	}
 
-	tlm := m.getTaskQueueManager(newTestTaskQueueID(request.NamespaceID, request.TaskQueue, request.TaskType))
+	tlm := m.getTaskQueueManager(newTestTaskQueueKey(request.NamespaceID, request.TaskQueue, request.TaskType))
 	tlm.Lock()
 	defer tlm.Unlock()
 	var tasks []*persistenceblobs.AllocatedTaskInfo
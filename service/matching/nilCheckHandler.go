@@ -117,6 +117,54 @@ func (h *NilCheckHandler) DescribeTaskQueue(ctx context.Context, request *matchi
 	return resp, err
 }
 
+func (h *NilCheckHandler) GetTaskQueuePartitionCount(ctx context.Context, request *matchingservice.GetTaskQueuePartitionCountRequest) (*matchingservice.GetTaskQueuePartitionCountResponse, error) {
+	resp, err := h.parentHandler.GetTaskQueuePartitionCount(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.GetTaskQueuePartitionCountResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UpdateWorkerBuildIdCompatibility(ctx context.Context, request *matchingservice.UpdateWorkerBuildIdCompatibilityRequest) (*matchingservice.UpdateWorkerBuildIdCompatibilityResponse, error) {
+	resp, err := h.parentHandler.UpdateWorkerBuildIdCompatibility(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.UpdateWorkerBuildIdCompatibilityResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) GetWorkerBuildIdCompatibility(ctx context.Context, request *matchingservice.GetWorkerBuildIdCompatibilityRequest) (*matchingservice.GetWorkerBuildIdCompatibilityResponse, error) {
+	resp, err := h.parentHandler.GetWorkerBuildIdCompatibility(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.GetWorkerBuildIdCompatibilityResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UpdateTaskQueueState(ctx context.Context, request *matchingservice.UpdateTaskQueueStateRequest) (*matchingservice.UpdateTaskQueueStateResponse, error) {
+	resp, err := h.parentHandler.UpdateTaskQueueState(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.UpdateTaskQueueStateResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) UpdateTaskQueueRateLimit(ctx context.Context, request *matchingservice.UpdateTaskQueueRateLimitRequest) (*matchingservice.UpdateTaskQueueRateLimitResponse, error) {
+	resp, err := h.parentHandler.UpdateTaskQueueRateLimit(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.UpdateTaskQueueRateLimitResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) ListWorkers(ctx context.Context, request *matchingservice.ListWorkersRequest) (*matchingservice.ListWorkersResponse, error) {
+	resp, err := h.parentHandler.ListWorkers(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.ListWorkersResponse{}
+	}
+	return resp, err
+}
+
 func (h *NilCheckHandler) ListTaskQueuePartitions(ctx context.Context, request *matchingservice.ListTaskQueuePartitionsRequest) (*matchingservice.ListTaskQueuePartitionsResponse, error) {
 	resp, err := h.parentHandler.ListTaskQueuePartitions(ctx, request)
 	if resp == nil && err == nil {
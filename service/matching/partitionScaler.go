@@ -0,0 +1,180 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"math"
+	"time"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+)
+
+const (
+	// partitionScaleDownHeadroom is the fraction of the target rate per partition the task rate has
+	// to fall under before write partitions are removed, it keeps the partition count from flapping
+	partitionScaleDownHeadroom = 0.8
+	// partitionScalingTimeout is the timeout for collecting the stats of all partitions
+	partitionScalingTimeout = 10 * time.Second
+)
+
+type (
+	// partitionCounts is the number of read and write partitions of a task queue. Read partitions
+	// are always a superset of write partitions: the extra ones are being drained before removal.
+	partitionCounts struct {
+		numRead  int
+		numWrite int
+	}
+
+	// partitionScaler periodically adjusts the partition counts of a root task queue to its task rate
+	partitionScaler struct {
+		tlMgr *taskQueueManagerImpl
+	}
+)
+
+func newPartitionScaler(tlMgr *taskQueueManagerImpl) *partitionScaler {
+	return &partitionScaler{tlMgr: tlMgr}
+}
+
+func (s *partitionScaler) Start() {
+	go s.scaleLoop()
+}
+
+func (s *partitionScaler) scaleLoop() {
+	timer := time.NewTimer(s.tlMgr.config.PartitionScalingInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.tlMgr.shutdownCh:
+			return
+		case <-timer.C:
+			// partitions added while paused or draining would not be in that state
+			if s.tlMgr.config.EnablePartitionAutoScaling() && s.tlMgr.DispatchState() == enumsgenpb.TASK_QUEUE_STATE_ACTIVE {
+				if err := s.scale(); err != nil {
+					s.tlMgr.logger.Warn("Failed to scale task queue partitions", tag.Error(err))
+				}
+			}
+			timer.Reset(s.tlMgr.config.PartitionScalingInterval())
+		}
+	}
+}
+
+func (s *partitionScaler) scale() error {
+	numRead, numWrite := s.tlMgr.PartitionCounts()
+	current := partitionCounts{numRead: numRead, numWrite: numWrite}
+
+	ctx, cancel := context.WithTimeout(context.Background(), partitionScalingTimeout)
+	defer cancel()
+	partitions, err := s.tlMgr.engine.describeTaskQueuePartitions(
+		ctx,
+		s.tlMgr.namespace(),
+		s.tlMgr.taskQueueID,
+		s.tlMgr.DescribeTaskQueueStats(),
+		current.numRead,
+	)
+	if err != nil {
+		return err
+	}
+
+	next := scalePartitionCounts(
+		current,
+		partitions,
+		float64(s.tlMgr.config.PartitionTargetRatePerSecond()),
+		s.tlMgr.config.MaxPartitions(),
+	)
+	if next != current {
+		if err := s.tlMgr.db.UpdatePartitionCounts(int32(next.numRead), int32(next.numWrite)); err != nil {
+			return err
+		}
+		s.tlMgr.logger.Info("Scaled task queue partitions",
+			tag.ReadPartitions(next.numRead),
+			tag.WritePartitions(next.numWrite))
+	}
+
+	scope := s.tlMgr.metricScope()
+	scope.UpdateGauge(metrics.ReadPartitionsPerTaskQueueGauge, float64(next.numRead))
+	scope.UpdateGauge(metrics.WritePartitionsPerTaskQueueGauge, float64(next.numWrite))
+	return nil
+}
+
+// scalePartitionCounts returns the partition counts of a task queue given its current counts and the
+// stats of its read partitions, indexed by partition number. Write partitions follow the task rate,
+// read partitions are only removed once the partitions no longer written to have been drained.
+func scalePartitionCounts(
+	current partitionCounts,
+	partitions []*taskqueuegenpb.TaskQueuePartitionStats,
+	targetRatePerPartition float64,
+	maxPartitions int,
+) partitionCounts {
+	stats := aggregateTaskQueueStats(partitions)
+	rate := math.Max(stats.GetTasksAddRate(), stats.GetTasksDispatchRate())
+
+	next := current
+	if desired := desiredPartitions(rate, targetRatePerPartition, maxPartitions); desired > current.numWrite {
+		next.numWrite = desired
+	} else if desired := desiredPartitions(rate, targetRatePerPartition*partitionScaleDownHeadroom, maxPartitions); desired < current.numWrite {
+		next.numWrite = desired
+	}
+
+	switch {
+	case next.numRead < next.numWrite:
+		next.numRead = next.numWrite
+	case next.numRead > next.numWrite && next.numWrite == current.numWrite:
+		// write partitions were removed during a previous round, so clients have stopped
+		// writing to the extra read partitions by now and they can go once they are empty
+		if partitionsDrained(partitions, next.numWrite, next.numRead) {
+			next.numRead = next.numWrite
+		}
+	}
+	return next
+}
+
+func desiredPartitions(rate float64, targetRatePerPartition float64, maxPartitions int) int {
+	desired := int(math.Ceil(rate / targetRatePerPartition))
+	if desired > maxPartitions {
+		desired = maxPartitions
+	}
+	if desired < 1 {
+		desired = 1
+	}
+	return desired
+}
+
+// partitionsDrained returns true when partitions [from, to) have no backlog and no tasks added to them
+func partitionsDrained(partitions []*taskqueuegenpb.TaskQueuePartitionStats, from int, to int) bool {
+	if len(partitions) < to {
+		return false
+	}
+	for _, p := range partitions[from:to] {
+		if p.GetStats().GetApproximateBacklogCount() > 0 || p.GetStats().GetTasksAddRate() > 0 {
+			return false
+		}
+	}
+	return true
+}
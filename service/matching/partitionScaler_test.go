@@ -0,0 +1,89 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
+)
+
+func TestScalePartitionCounts_ScaleUp(t *testing.T) {
+	partitions := mkPartitionStats([]float64{900, 700}, []int64{0, 0})
+
+	next := scalePartitionCounts(partitionCounts{numRead: 2, numWrite: 2}, partitions, 500, 16)
+	require.Equal(t, partitionCounts{numRead: 4, numWrite: 4}, next)
+
+	// partitions never exceed the configured max
+	next = scalePartitionCounts(partitionCounts{numRead: 2, numWrite: 2}, partitions, 500, 3)
+	require.Equal(t, partitionCounts{numRead: 3, numWrite: 3}, next)
+}
+
+func TestScalePartitionCounts_NoChangeWithinHeadroom(t *testing.T) {
+	// 1850 tasks/s needs 4 partitions at 500/s but would need 5 at 400/s
+	partitions := mkPartitionStats([]float64{500, 450, 450, 450}, []int64{0, 0, 0, 0})
+
+	next := scalePartitionCounts(partitionCounts{numRead: 4, numWrite: 4}, partitions, 500, 16)
+	require.Equal(t, partitionCounts{numRead: 4, numWrite: 4}, next)
+}
+
+func TestScalePartitionCounts_ScaleDownDrainsReadPartitions(t *testing.T) {
+	// write partitions are removed first while read partitions keep being polled
+	partitions := mkPartitionStats([]float64{100, 50, 50, 50}, []int64{0, 3, 0, 10})
+	next := scalePartitionCounts(partitionCounts{numRead: 4, numWrite: 4}, partitions, 500, 16)
+	require.Equal(t, partitionCounts{numRead: 4, numWrite: 1}, next)
+
+	// read partitions with backlog or recent adds are kept
+	next = scalePartitionCounts(next, partitions, 500, 16)
+	require.Equal(t, partitionCounts{numRead: 4, numWrite: 1}, next)
+
+	// read partitions are removed once drained
+	partitions = mkPartitionStats([]float64{250, 0, 0, 0}, []int64{5, 0, 0, 0})
+	next = scalePartitionCounts(next, partitions, 500, 16)
+	require.Equal(t, partitionCounts{numRead: 1, numWrite: 1}, next)
+}
+
+func TestScalePartitionCounts_ScaleUpWhileDraining(t *testing.T) {
+	partitions := mkPartitionStats([]float64{600, 600, 0, 0}, []int64{0, 0, 0, 2})
+
+	next := scalePartitionCounts(partitionCounts{numRead: 4, numWrite: 2}, partitions, 500, 16)
+	require.Equal(t, partitionCounts{numRead: 4, numWrite: 3}, next)
+}
+
+func mkPartitionStats(addRates []float64, backlogCounts []int64) []*taskqueuegenpb.TaskQueuePartitionStats {
+	var partitions []*taskqueuegenpb.TaskQueuePartitionStats
+	for i := range addRates {
+		partitions = append(partitions, &taskqueuegenpb.TaskQueuePartitionStats{
+			Stats: &taskqueuegenpb.TaskQueueStats{
+				ApproximateBacklogCount: backlogCounts[i],
+				TasksAddRate:            addRates[i],
+				TasksDispatchRate:       addRates[i],
+			},
+		})
+	}
+	return partitions
+}
@@ -0,0 +1,283 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sync"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	// priorityTaskBuffer is the in-memory buffer of backlog tasks loaded by the taskReader.
+	// Tasks are dispatched highest priority first. Within a priority level, tasks are
+	// dispatched round robin across fairness keys, each key getting as many tasks per
+	// turn as its weight, and in load order for the same key. To prevent starvation
+	// of lower priorities, every starvationInterval-th dispatch takes the oldest buffered
+	// task regardless of its priority or fairness key.
+	//
+	// Every subqueue of the task queue gets capacity buffer slots of its own, so that a
+	// subqueue with a large backlog can't keep the tasks of the others from being loaded.
+	// Fairness keys are hashed into subqueues of their own, so the round robin here only
+	// orders the tasks already loaded, keys hashed into the same bucket share a read cursor.
+	// Tasks offered without a subqueue are those of the default backlog of the task queue.
+	priorityTaskBuffer struct {
+		sync.Mutex
+		levels             []*fairTaskQueue
+		sourceSizes        map[*subqueue]int
+		size               int
+		capacity           int
+		closed             bool
+		dispatchCount      int
+		starvationInterval func() int
+		keyWeight          func(string) int
+
+		readyC chan struct{} // signalled when a task is added or the buffer is closed
+		spaceC chan struct{} // signalled when a task of the default backlog is removed
+	}
+
+	// bufferedTask is a task in the buffer along with the subqueue it was loaded from
+	bufferedTask struct {
+		task   *persistenceblobs.AllocatedTaskInfo
+		source *subqueue
+	}
+
+	// fairTaskQueue holds the buffered tasks of one priority level, one FIFO per fairness key
+	fairTaskQueue struct {
+		keys   []string // fairness keys with buffered tasks, in round robin order
+		tasks  map[string][]bufferedTask
+		next   int // index in keys of the key currently being served
+		credit int // tasks left to dispatch for keys[next] in the current turn
+	}
+)
+
+func newPriorityTaskBuffer(
+	capacity int,
+	starvationInterval func() int,
+	keyWeight func(string) int,
+) *priorityTaskBuffer {
+	levels := make([]*fairTaskQueue, common.LowestTaskPriority)
+	for i := range levels {
+		levels[i] = &fairTaskQueue{tasks: make(map[string][]bufferedTask)}
+	}
+	return &priorityTaskBuffer{
+		levels:             levels,
+		sourceSizes:        make(map[*subqueue]int),
+		capacity:           common.MaxInt(1, capacity),
+		starvationInterval: starvationInterval,
+		keyWeight:          keyWeight,
+		readyC:             make(chan struct{}, 1),
+		spaceC:             make(chan struct{}, 1),
+	}
+}
+
+// offer adds a task of the default backlog to the buffer if there is room for it
+func (b *priorityTaskBuffer) offer(task *persistenceblobs.AllocatedTaskInfo) bool {
+	return b.offerFrom(nil, task)
+}
+
+// offerFrom adds a task loaded from the given subqueue to the buffer if the subqueue has room left
+func (b *priorityTaskBuffer) offerFrom(source *subqueue, task *persistenceblobs.AllocatedTaskInfo) bool {
+	b.Lock()
+	if b.closed || b.sourceSizes[source] >= b.capacity {
+		b.Unlock()
+		return false
+	}
+	level := common.NormalizeTaskPriority(task.GetData().GetPriority()) - common.HighestTaskPriority
+	b.levels[level].push(bufferedTask{task: task, source: source})
+	b.sourceSizes[source]++
+	b.size++
+	b.Unlock()
+
+	signalNonBlocking(b.readyC)
+	return true
+}
+
+// poll removes and returns the next task to dispatch, or nil if the buffer is empty.
+// The returned bool is false once the buffer is closed and fully drained.
+func (b *priorityTaskBuffer) poll() (*persistenceblobs.AllocatedTaskInfo, bool) {
+	task, _, ok := b.pollWithSource()
+	return task, ok
+}
+
+// pollWithSource is poll that also returns the subqueue the task was loaded from, nil for the default backlog
+func (b *priorityTaskBuffer) pollWithSource() (*persistenceblobs.AllocatedTaskInfo, *subqueue, bool) {
+	b.Lock()
+	if b.size == 0 {
+		closed := b.closed
+		b.Unlock()
+		return nil, nil, !closed
+	}
+
+	var entry bufferedTask
+	b.dispatchCount++
+	if interval := b.starvationInterval(); interval > 0 && b.dispatchCount%interval == 0 {
+		entry = b.pollOldest()
+	} else {
+		for _, level := range b.levels {
+			if len(level.keys) > 0 {
+				entry = level.pop(b.keyWeight)
+				break
+			}
+		}
+	}
+
+	if b.sourceSizes[entry.source]--; b.sourceSizes[entry.source] == 0 {
+		delete(b.sourceSizes, entry.source)
+	}
+	b.size--
+	b.Unlock()
+
+	if entry.source == nil {
+		signalNonBlocking(b.spaceC)
+	} else {
+		signalNonBlocking(entry.source.spaceC)
+	}
+	return entry.task, entry.source, true
+}
+
+// pollOldest removes and returns the oldest buffered task
+func (b *priorityTaskBuffer) pollOldest() bufferedTask {
+	level, key := b.findOldest()
+	return level.popKey(key)
+}
+
+// findOldest returns the level and fairness key of the oldest buffered task. Task ids of different
+// subqueues are not comparable, so tasks are compared by creation time first and by task id when
+// the creation times are equal or unknown. Tasks of a key are loaded oldest first, so the oldest
+// task is at the head of one of the key FIFOs.
+func (b *priorityTaskBuffer) findOldest() (*fairTaskQueue, string) {
+	var oldestLevel *fairTaskQueue
+	var oldestKey string
+	for _, level := range b.levels {
+		for _, key := range level.keys {
+			if oldestLevel == nil || isOlderTask(level.tasks[key][0].task, oldestLevel.tasks[oldestKey][0].task) {
+				oldestLevel = level
+				oldestKey = key
+			}
+		}
+	}
+	return oldestLevel, oldestKey
+}
+
+// peekOldest returns the oldest buffered task without removing it, or nil if empty
+func (b *priorityTaskBuffer) peekOldest() *persistenceblobs.AllocatedTaskInfo {
+	b.Lock()
+	defer b.Unlock()
+	if b.size == 0 {
+		return nil
+	}
+	level, key := b.findOldest()
+	return level.tasks[key][0].task
+}
+
+// close stops the buffer from accepting new tasks; already buffered tasks can still be polled
+func (b *priorityTaskBuffer) close() {
+	b.Lock()
+	b.closed = true
+	b.Unlock()
+	signalNonBlocking(b.readyC)
+}
+
+func (b *priorityTaskBuffer) len() int {
+	b.Lock()
+	defer b.Unlock()
+	return b.size
+}
+
+// cap returns the number of buffered tasks allowed per subqueue
+func (b *priorityTaskBuffer) cap() int {
+	return b.capacity
+}
+
+func isOlderTask(task *persistenceblobs.AllocatedTaskInfo, other *persistenceblobs.AllocatedTaskInfo) bool {
+	created, otherCreated := taskCreatedTime(task), taskCreatedTime(other)
+	if created != 0 && otherCreated != 0 && created != otherCreated {
+		return created < otherCreated
+	}
+	return task.GetTaskId() < other.GetTaskId()
+}
+
+func (q *fairTaskQueue) push(entry bufferedTask) {
+	key := entry.task.GetData().GetFairnessKey()
+	if _, ok := q.tasks[key]; !ok {
+		q.keys = append(q.keys, key)
+	}
+	q.tasks[key] = append(q.tasks[key], entry)
+}
+
+// pop removes and returns the next task in round robin order; the queue must not be empty
+func (q *fairTaskQueue) pop(keyWeight func(string) int) bufferedTask {
+	if q.credit <= 0 {
+		q.credit = common.MaxInt(1, keyWeight(q.keys[q.next]))
+	}
+	q.credit--
+	key := q.keys[q.next]
+	task := q.popKey(key)
+	if q.credit == 0 && q.next < len(q.keys) && q.keys[q.next] == key {
+		// turn of this key is over, move on to the next one
+		q.next = (q.next + 1) % len(q.keys)
+	}
+	return task
+}
+
+// popKey removes and returns the head task of the given fairness key
+func (q *fairTaskQueue) popKey(key string) bufferedTask {
+	tasks := q.tasks[key]
+	task := tasks[0]
+	tasks[0] = bufferedTask{}
+	if len(tasks) > 1 {
+		q.tasks[key] = tasks[1:]
+		return task
+	}
+
+	// no more tasks for this key, remove it from the round robin
+	delete(q.tasks, key)
+	for i, k := range q.keys {
+		if k != key {
+			continue
+		}
+		q.keys = append(q.keys[:i], q.keys[i+1:]...)
+		if i < q.next {
+			q.next--
+		} else if i == q.next {
+			// the next key slides into this position and starts a new turn
+			q.credit = 0
+		}
+		break
+	}
+	if q.next >= len(q.keys) {
+		q.next = 0
+	}
+	return task
+}
+
+func signalNonBlocking(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default: // channel already has an event, don't block
+	}
+}
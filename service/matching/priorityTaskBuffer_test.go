@@ -0,0 +1,220 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common/primitives/timestamp"
+)
+
+func unitKeyWeight(string) int { return 1 }
+
+func newTestPriorityTask(taskID int64, priority int32) *persistenceblobs.AllocatedTaskInfo {
+	return newTestFairTask(taskID, priority, "")
+}
+
+func newTestFairTask(taskID int64, priority int32, fairnessKey string) *persistenceblobs.AllocatedTaskInfo {
+	return &persistenceblobs.AllocatedTaskInfo{
+		TaskId: taskID,
+		Data:   &persistenceblobs.TaskInfo{Priority: priority, FairnessKey: fairnessKey},
+	}
+}
+
+func pollTaskIDs(t *testing.T, buffer *priorityTaskBuffer) []int64 {
+	var taskIDs []int64
+	for {
+		task, ok := buffer.poll()
+		require.True(t, ok)
+		if task == nil {
+			return taskIDs
+		}
+		taskIDs = append(taskIDs, task.GetTaskId())
+	}
+}
+
+func TestPriorityTaskBuffer_DispatchByPriority(t *testing.T) {
+	buffer := newPriorityTaskBuffer(10, func() int { return 0 }, unitKeyWeight)
+	require.True(t, buffer.offer(newTestPriorityTask(1, 5)))
+	require.True(t, buffer.offer(newTestPriorityTask(2, 0)))
+	require.True(t, buffer.offer(newTestPriorityTask(3, 1)))
+	require.True(t, buffer.offer(newTestPriorityTask(4, 5)))
+	require.True(t, buffer.offer(newTestPriorityTask(5, 1)))
+
+	// unset priority is dispatched as the default priority
+	require.Equal(t, []int64{3, 5, 2, 1, 4}, pollTaskIDs(t, buffer))
+}
+
+func TestPriorityTaskBuffer_StarvationProtection(t *testing.T) {
+	buffer := newPriorityTaskBuffer(10, func() int { return 3 }, unitKeyWeight)
+	require.True(t, buffer.offer(newTestPriorityTask(1, 5)))
+	require.True(t, buffer.offer(newTestPriorityTask(2, 5)))
+	for i := int64(3); i <= 8; i++ {
+		require.True(t, buffer.offer(newTestPriorityTask(i, 1)))
+	}
+
+	// every third dispatch takes the oldest buffered task
+	require.Equal(t, []int64{3, 4, 1, 5, 6, 2, 7, 8}, pollTaskIDs(t, buffer))
+}
+
+func TestPriorityTaskBuffer_Capacity(t *testing.T) {
+	buffer := newPriorityTaskBuffer(2, func() int { return 0 }, unitKeyWeight)
+	require.Equal(t, 2, buffer.cap())
+	require.True(t, buffer.offer(newTestPriorityTask(1, 3)))
+	require.True(t, buffer.offer(newTestPriorityTask(2, 3)))
+	require.False(t, buffer.offer(newTestPriorityTask(3, 3)))
+	require.Equal(t, 2, buffer.len())
+
+	task, ok := buffer.poll()
+	require.True(t, ok)
+	require.Equal(t, int64(1), task.GetTaskId())
+	select {
+	case <-buffer.spaceC:
+	default:
+		require.Fail(t, "expected space notification after poll")
+	}
+	require.True(t, buffer.offer(newTestPriorityTask(3, 3)))
+}
+
+func TestPriorityTaskBuffer_Close(t *testing.T) {
+	buffer := newPriorityTaskBuffer(2, func() int { return 0 }, unitKeyWeight)
+	require.True(t, buffer.offer(newTestPriorityTask(1, 3)))
+	buffer.close()
+	require.False(t, buffer.offer(newTestPriorityTask(2, 3)))
+
+	// buffered tasks are still drained after close
+	task, ok := buffer.poll()
+	require.True(t, ok)
+	require.Equal(t, int64(1), task.GetTaskId())
+	task, ok = buffer.poll()
+	require.False(t, ok)
+	require.Nil(t, task)
+}
+
+func TestPriorityTaskBuffer_PeekOldest(t *testing.T) {
+	buffer := newPriorityTaskBuffer(10, func() int { return 0 }, unitKeyWeight)
+	require.Nil(t, buffer.peekOldest())
+	require.True(t, buffer.offer(newTestFairTask(1, 5, "a")))
+	require.True(t, buffer.offer(newTestFairTask(2, 1, "b")))
+
+	require.Equal(t, int64(1), buffer.peekOldest().GetTaskId())
+	require.Equal(t, 2, buffer.len())
+	task, _ := buffer.poll()
+	require.Equal(t, int64(2), task.GetTaskId())
+	require.Equal(t, int64(1), buffer.peekOldest().GetTaskId())
+}
+
+func TestPriorityTaskBuffer_RoundRobinFairnessKeys(t *testing.T) {
+	buffer := newPriorityTaskBuffer(20, func() int { return 0 }, unitKeyWeight)
+	for i := int64(1); i <= 5; i++ {
+		require.True(t, buffer.offer(newTestFairTask(i, 0, "a")))
+	}
+	require.True(t, buffer.offer(newTestFairTask(6, 0, "b")))
+	require.True(t, buffer.offer(newTestFairTask(7, 0, "c")))
+	require.True(t, buffer.offer(newTestFairTask(8, 0, "b")))
+	require.Equal(t, 8, buffer.len())
+
+	require.Equal(t, []int64{1, 6, 7, 2, 8, 3, 4, 5}, pollTaskIDs(t, buffer))
+	require.Equal(t, 0, buffer.len())
+}
+
+func TestPriorityTaskBuffer_WeightedFairnessKeys(t *testing.T) {
+	weights := map[string]interface{}{"a": 1, "b": 3}
+	buffer := newPriorityTaskBuffer(20, func() int { return 0 }, func(key string) int {
+		return fairnessKeyWeight(weights, key)
+	})
+	for i := int64(1); i <= 4; i++ {
+		require.True(t, buffer.offer(newTestFairTask(i, 0, "a")))
+	}
+	for i := int64(5); i <= 10; i++ {
+		require.True(t, buffer.offer(newTestFairTask(i, 0, "b")))
+	}
+
+	require.Equal(t, []int64{1, 5, 6, 7, 2, 8, 9, 10, 3, 4}, pollTaskIDs(t, buffer))
+}
+
+func TestPriorityTaskBuffer_PriorityBeforeFairness(t *testing.T) {
+	buffer := newPriorityTaskBuffer(20, func() int { return 0 }, unitKeyWeight)
+	require.True(t, buffer.offer(newTestFairTask(1, 5, "a")))
+	require.True(t, buffer.offer(newTestFairTask(2, 5, "b")))
+	require.True(t, buffer.offer(newTestFairTask(3, 1, "a")))
+	require.True(t, buffer.offer(newTestFairTask(4, 1, "a")))
+	require.True(t, buffer.offer(newTestFairTask(5, 1, "b")))
+
+	require.Equal(t, []int64{3, 5, 4, 1, 2}, pollTaskIDs(t, buffer))
+}
+
+func TestPriorityTaskBuffer_CapacityPerSubqueue(t *testing.T) {
+	buffer := newPriorityTaskBuffer(1, func() int { return 0 }, unitKeyWeight)
+	sq := &subqueue{key: subqueueKey{priority: 1}, spaceC: make(chan struct{}, 1)}
+	require.True(t, buffer.offer(newTestPriorityTask(1, 3)))
+	require.False(t, buffer.offer(newTestPriorityTask(2, 3)))
+
+	// a full default backlog does not keep the tasks of a subqueue out
+	require.True(t, buffer.offerFrom(sq, newTestPriorityTask(1, 1)))
+	require.False(t, buffer.offerFrom(sq, newTestPriorityTask(2, 1)))
+	require.Equal(t, 2, buffer.len())
+
+	task, source, ok := buffer.pollWithSource()
+	require.True(t, ok)
+	require.Equal(t, int32(1), task.GetData().GetPriority())
+	require.Equal(t, sq, source)
+	select {
+	case <-sq.spaceC:
+	default:
+		require.Fail(t, "expected space notification of the subqueue after poll")
+	}
+	require.True(t, buffer.offerFrom(sq, newTestPriorityTask(2, 1)))
+
+	task, source, ok = buffer.pollWithSource()
+	require.True(t, ok)
+	require.Equal(t, int64(2), task.GetTaskId())
+	require.Equal(t, sq, source)
+	task, source, ok = buffer.pollWithSource()
+	require.True(t, ok)
+	require.Equal(t, int64(1), task.GetTaskId())
+	require.Nil(t, source)
+}
+
+func TestPriorityTaskBuffer_OldestByCreatedTime(t *testing.T) {
+	buffer := newPriorityTaskBuffer(10, func() int { return 1 }, unitKeyWeight)
+	sq := &subqueue{key: subqueueKey{priority: 1}, spaceC: make(chan struct{}, 1)}
+	now := time.Now()
+	minuteAgo := now.Add(-time.Minute)
+	older := newTestPriorityTask(7, 5)
+	older.Data.CreatedTime = timestamp.TimestampFromTime(&minuteAgo).ToProto()
+	newer := newTestPriorityTask(1, 1)
+	newer.Data.CreatedTime = timestamp.TimestampFromTime(&now).ToProto()
+	require.True(t, buffer.offer(older))
+	require.True(t, buffer.offerFrom(sq, newer))
+
+	// task ids of different subqueues are not comparable, the creation time decides
+	require.Equal(t, int64(7), buffer.peekOldest().GetTaskId())
+	require.Equal(t, []int64{7, 1}, pollTaskIDs(t, buffer))
+}
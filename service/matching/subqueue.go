@@ -0,0 +1,127 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"fmt"
+
+	"github.com/dgryski/go-farm"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	// subqueue is a backlog of a task queue partition. Tasks that are not matched synchronously
+	// are written to the subqueue of their priority and fairness bucket, and each subqueue is
+	// persisted as a task queue of its own, with its own lease, task ids, read level and ack
+	// level. This way every priority level and fairness bucket is read from persistence through
+	// its own cursor, and a large backlog of low priority tasks or of a single fairness key does
+	// not delay loading the other tasks. The subqueue of the default priority without a fairness
+	// key is the task queue partition itself, the others are created when their first task is
+	// written and recorded with the partition so that they are loaded along with it. The number of
+	// subqueues of a partition is bounded by MaxSubqueues, as each of them adds lease and ack level
+	// writes of its own.
+	subqueue struct {
+		key        subqueueKey
+		db         *taskQueueDB
+		writer     *taskWriter
+		ackManager *ackManager
+		gc         *taskGC
+		notifyC    chan struct{} // signals the pump of the subqueue that there are new tasks to read
+		spaceC     chan struct{} // signalled when a task of the subqueue is removed from the task buffer
+	}
+
+	// subqueueKey identifies a subqueue of a task queue partition
+	subqueueKey struct {
+		priority int32
+		// fairnessBucket is the bucket the fairness key of the tasks is hashed into, 0 for tasks without a key
+		fairnessBucket int32
+	}
+)
+
+const (
+	// subqueueSeparator separates the partition name from the priority in the name of a subqueue
+	subqueueSeparator = "#"
+	// fairnessBucketSeparator separates the priority from the fairness bucket in the name of a subqueue
+	fairnessBucketSeparator = "."
+)
+
+// defaultSubqueueKey is the key of the subqueue persisted as the task queue partition itself
+var defaultSubqueueKey = subqueueKey{priority: common.DefaultTaskPriority}
+
+// newSubqueueKey returns the key of the subqueue of tasks of the given priority and fairness key.
+// Fairness keys are hashed into numBuckets buckets, tasks of keys in the same bucket share a subqueue.
+func newSubqueueKey(priority int32, fairnessKey string, numBuckets int) subqueueKey {
+	key := subqueueKey{priority: common.NormalizeTaskPriority(priority)}
+	if fairnessKey != "" && numBuckets > 0 {
+		key.fairnessBucket = int32(farm.Fingerprint32([]byte(fairnessKey))%uint32(numBuckets)) + 1
+	}
+	return key
+}
+
+// subqueueName returns the persisted name of the subqueue with the given key. The name starts with
+// the reserved partition prefix but does not parse as a partition name, so pollers and task
+// producers can't address it directly.
+func subqueueName(partition string, key subqueueKey) string {
+	name := fmt.Sprintf("%v%v%v%v", taskQueuePartitionPrefix, partition, subqueueSeparator, key.priority)
+	if key.fairnessBucket != 0 {
+		name = fmt.Sprintf("%v%v%v", name, fairnessBucketSeparator, key.fairnessBucket)
+	}
+	return name
+}
+
+func newSubqueue(tlMgr *taskQueueManagerImpl, key subqueueKey) *subqueue {
+	db := newTaskQueueDB(
+		tlMgr.engine.taskManager,
+		tlMgr.taskQueueID.namespaceID,
+		subqueueName(tlMgr.taskQueueID.name, key),
+		tlMgr.taskQueueID.taskType,
+		tlMgr.taskQueueKind,
+		tlMgr.logger,
+	)
+	ackManager := newAckManager(tlMgr.logger)
+	return &subqueue{
+		key:        key,
+		db:         db,
+		writer:     newTaskWriter(tlMgr, db),
+		ackManager: &ackManager,
+		gc:         newTaskGC(db, tlMgr.config),
+		notifyC:    make(chan struct{}, 1),
+		spaceC:     make(chan struct{}, 1),
+	}
+}
+
+func (sq *subqueue) signal() {
+	signalNonBlocking(sq.notifyC)
+}
+
+func (sq *subqueue) persistAckLevel() error {
+	return sq.db.UpdateState(sq.ackManager.getAckLevel())
+}
+
+func (sq *subqueue) info() *persistenceblobs.TaskQueueSubqueue {
+	return &persistenceblobs.TaskQueueSubqueue{Priority: sq.key.priority, FairnessBucket: sq.key.fairnessBucket}
+}
@@ -33,6 +33,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/gogo/protobuf/types"
 	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
@@ -41,9 +42,11 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
@@ -88,6 +91,22 @@ type (
 		GetAllPollerInfo() []*taskqueuepb.PollerInfo
 		// DescribeTaskQueue returns information about the target task queue
 		DescribeTaskQueue(includeTaskQueueStatus bool) *matchingservice.DescribeTaskQueueResponse
+		// DescribeTaskQueueStats returns approximate backlog and throughput statistics of the task queue
+		DescribeTaskQueueStats() *taskqueuegenpb.TaskQueueStats
+		// PartitionCounts returns the number of read and write partitions of the task queue
+		PartitionCounts() (numRead int, numWrite int)
+		// VersioningData returns the worker version sets of the task queue, only set on the root partition
+		VersioningData() *taskqueuegenpb.VersioningData
+		// UpdateVersioningData applies the given update to the worker version sets of the task queue
+		UpdateVersioningData(update func(*taskqueuegenpb.VersioningData) (*taskqueuegenpb.VersioningData, error)) (*taskqueuegenpb.VersioningData, error)
+		// DispatchState returns whether the task queue is active, paused or draining
+		DispatchState() enumsgenpb.TaskQueueState
+		// UpdateDispatchState persists and applies the given dispatch state
+		UpdateDispatchState(state enumsgenpb.TaskQueueState) error
+		// MaxTasksPerSecond returns the dispatch rate limit set by operators, nil when the limit set by pollers applies
+		MaxTasksPerSecond() *float64
+		// UpdateMaxTasksPerSecond persists and applies the given dispatch rate limit, nil clears the limit
+		UpdateMaxTasksPerSecond(maxTasksPerSecond *types.DoubleValue) error
 		String() string
 	}
 
@@ -102,7 +121,10 @@ type (
 		taskReader       *taskReader // reads tasks from db and async matches it with poller
 		taskGC           *taskGC
 		taskAckManager   ackManager   // tracks ackLevel for delivered messages
+		defaultSubqueue  *subqueue    // the backlog of the task queue itself, made of the fields above
 		matcher          *TaskMatcher // for matching a task producer with a poller
+		stats            *taskQueueStats
+		scaler           *partitionScaler // scales the partitions of a root task queue, nil for other partitions or when auto scaling is disabled
 		namespaceCache   cache.NamespaceCache
 		logger           log.Logger
 		metricsClient    metrics.Client
@@ -117,6 +139,9 @@ type (
 		// prevent tasks being dispatched to zombie pollers.
 		outstandingPollsLock sync.Mutex
 		outstandingPollsMap  map[string]context.CancelFunc
+		// subqueuesLock guards subqueues, the backlogs other than the default one by key
+		subqueuesLock sync.Mutex
+		subqueues     map[subqueueKey]*subqueue
 
 		shutdownCh chan struct{}  // Delivers stop to the pump that populates taskBuffer
 		startWG    sync.WaitGroup // ensures that background processes do not start until setup is ready
@@ -131,7 +156,9 @@ const (
 
 var _ taskQueueManager = (*taskQueueManagerImpl)(nil)
 
-var errRemoteSyncMatchFailed = errors.New("remote sync match failed")
+var (
+	errRemoteSyncMatchFailed = errors.New("remote sync match failed")
+)
 
 func newTaskQueueManager(
 	e *matchingEngineImpl,
@@ -162,6 +189,8 @@ func newTaskQueueManager(
 		config:              taskQueueConfig,
 		pollerHistory:       newPollerHistory(),
 		outstandingPollsMap: make(map[string]context.CancelFunc),
+		subqueues:           make(map[subqueueKey]*subqueue),
+		stats:               newTaskQueueStats(clock.NewRealTimeSource()),
 	}
 
 	tlMgr.namespaceValue.Store("")
@@ -176,13 +205,31 @@ func newTaskQueueManager(
 		))
 	}
 
-	tlMgr.taskWriter = newTaskWriter(tlMgr)
+	tlMgr.taskWriter = newTaskWriter(tlMgr, db)
 	tlMgr.taskReader = newTaskReader(tlMgr)
+	tlMgr.defaultSubqueue = &subqueue{
+		key:        defaultSubqueueKey,
+		db:         db,
+		writer:     tlMgr.taskWriter,
+		ackManager: &tlMgr.taskAckManager,
+		gc:         tlMgr.taskGC,
+		notifyC:    tlMgr.taskReader.notifyC,
+		spaceC:     tlMgr.taskReader.taskBuffer.spaceC,
+	}
 	var fwdr *Forwarder
 	if tlMgr.isFowardingAllowed(taskQueue, taskQueueKind) {
 		fwdr = newForwarder(&taskQueueConfig.forwarderConfig, taskQueue, taskQueueKind, e.matchingClient)
 	}
 	tlMgr.matcher = newTaskMatcher(taskQueueConfig, fwdr, tlMgr.metricScope)
+	tlMgr.matcher.numPartitions = func() int {
+		numRead, _ := tlMgr.PartitionCounts()
+		return numRead
+	}
+	// enabling the auto scaling of a task queue takes effect the next time it is loaded
+	if taskQueue.IsRoot() && !taskQueue.IsVersioned() && taskQueueKind != enumspb.TASK_QUEUE_KIND_STICKY &&
+		taskQueueConfig.EnablePartitionAutoScaling() {
+		tlMgr.scaler = newPartitionScaler(tlMgr)
+	}
 	tlMgr.startWG.Add(1)
 	return tlMgr, nil
 }
@@ -193,15 +240,30 @@ func (c *taskQueueManagerImpl) Start() error {
 	defer c.startWG.Done()
 
 	// Make sure to grab the range first before starting task writer, as it needs the range to initialize maxReadLevel
-	state, err := c.renewLeaseWithRetry()
+	state, err := c.renewLeaseWithRetry(c.db)
 	if err != nil {
 		c.Stop()
 		return err
 	}
 
 	c.taskAckManager.setAckLevel(state.ackLevel)
+	c.applyDispatchState(c.db.DispatchState())
+	c.matcher.UpdateRatelimit(c.MaxTasksPerSecond())
 	c.taskWriter.Start(c.rangeIDToTaskIDBlock(state.rangeID))
+	for _, info := range c.db.Subqueues() {
+		sq := newSubqueue(c, subqueueKey{priority: info.GetPriority(), fairnessBucket: info.GetFairnessBucket()})
+		if err := c.startSubqueue(sq); err != nil {
+			c.Stop()
+			return err
+		}
+		c.subqueuesLock.Lock()
+		c.subqueues[sq.key] = sq
+		c.subqueuesLock.Unlock()
+	}
 	c.taskReader.Start()
+	if c.scaler != nil {
+		c.scaler.Start()
+	}
 
 	return nil
 }
@@ -213,6 +275,9 @@ func (c *taskQueueManagerImpl) Stop() {
 	}
 	close(c.shutdownCh)
 	c.taskWriter.Stop()
+	for _, sq := range c.getSubqueues() {
+		sq.writer.Stop()
+	}
 	c.taskReader.Stop()
 	c.engine.removeTaskQueueManager(c.taskQueueID)
 	c.engine.removeTaskQueueManager(c.taskQueueID)
@@ -224,6 +289,10 @@ func (c *taskQueueManagerImpl) Stop() {
 // be written to database and later asynchronously matched with a poller
 func (c *taskQueueManagerImpl) AddTask(ctx context.Context, params addTaskParams) (bool, error) {
 	c.startWG.Wait()
+	if c.DispatchState() == enumsgenpb.TASK_QUEUE_STATE_DRAINING {
+		return false, common.ErrTaskQueueDraining
+	}
+
 	var syncMatch bool
 	_, err := c.executeWithRetry(func() (interface{}, error) {
 		td := params.taskInfo
@@ -234,7 +303,7 @@ func (c *taskQueueManagerImpl) AddTask(ctx context.Context, params addTaskParams
 		}
 
 		if namespaceEntry.GetNamespaceNotActiveErr() != nil {
-			r, err := c.taskWriter.appendTask(params.execution, td)
+			r, err := c.appendTask(params.execution, td)
 			syncMatch = false
 			return r, err
 		}
@@ -250,9 +319,13 @@ func (c *taskQueueManagerImpl) AddTask(ctx context.Context, params addTaskParams
 			return &persistence.CreateTasksResponse{}, errRemoteSyncMatchFailed
 		}
 
-		return c.taskWriter.appendTask(params.execution, params.taskInfo)
+		return c.appendTask(params.execution, params.taskInfo)
 	})
 	if err == nil {
+		if params.forwardedFrom == "" {
+			// tasks forwarded from a child partition are already counted there
+			c.stats.recordAdd(syncMatch)
+		}
 		c.taskReader.Signal()
 	}
 	return syncMatch, err
@@ -290,7 +363,11 @@ func (c *taskQueueManagerImpl) GetTask(
 		return nil, err
 	}
 	task.namespace = c.namespace()
-	task.backlogCountHint = c.taskAckManager.getBacklogCountHint()
+	task.backlogCountHint = c.backlogCountHint()
+	if !task.isQuery() && !task.isStarted() {
+		// started tasks come from a parent partition which already counted the dispatch
+		c.stats.recordDispatch()
+	}
 	return task, nil
 }
 
@@ -330,7 +407,11 @@ func (c *taskQueueManagerImpl) getTask(ctx context.Context, maxDispatchPerSecond
 	// poller, which lives inside the client side worker. There is
 	// one rateLimiter for this entire task queue and as we get polls,
 	// we update the ratelimiter rps if it has changed from the last
-	// value. Last poller wins if different pollers provide different values
+	// value. Last poller wins if different pollers provide different values.
+	// A limit set by operators takes precedence over the poller provided one
+	if limit := c.MaxTasksPerSecond(); limit != nil {
+		maxDispatchPerSecond = limit
+	}
 	c.matcher.UpdateRatelimit(maxDispatchPerSecond)
 
 	if namespaceEntry.GetNamespaceNotActiveErr() != nil {
@@ -359,7 +440,13 @@ func (c *taskQueueManagerImpl) CancelPoller(pollerID string) {
 // pollers which polled this taskqueue in last few minutes and status of taskqueue's ackManager
 // (readLevel, ackLevel, backlogCountHint and taskIDBlock).
 func (c *taskQueueManagerImpl) DescribeTaskQueue(includeTaskQueueStatus bool) *matchingservice.DescribeTaskQueueResponse {
-	response := &matchingservice.DescribeTaskQueueResponse{Pollers: c.GetAllPollerInfo()}
+	response := &matchingservice.DescribeTaskQueueResponse{
+		Pollers: c.GetAllPollerInfo(),
+		State:   c.DispatchState(),
+	}
+	if limit := c.MaxTasksPerSecond(); limit != nil {
+		response.MaxTasksPerSecond = &types.DoubleValue{Value: *limit}
+	}
 	if !includeTaskQueueStatus {
 		return response
 	}
@@ -379,6 +466,198 @@ func (c *taskQueueManagerImpl) DescribeTaskQueue(includeTaskQueueStatus bool) *m
 	return response
 }
 
+// DescribeTaskQueueStats returns approximate backlog and throughput statistics of this task queue partition
+func (c *taskQueueManagerImpl) DescribeTaskQueueStats() *taskqueuegenpb.TaskQueueStats {
+	return c.stats.snapshot(c.approximateBacklogCount(), c.taskReader.backlogAge())
+}
+
+// PartitionCounts returns the number of read and write partitions of the task queue. They come
+// from dynamic config unless partition auto scaling is enabled, in which case they are decided
+// by the root partition and looked up from it by the other partitions.
+func (c *taskQueueManagerImpl) PartitionCounts() (numRead int, numWrite int) {
+	numRead, numWrite = c.config.NumReadPartitions(), c.config.NumWritePartitions()
+	if c.taskQueueKind == enumspb.TASK_QUEUE_KIND_STICKY || !c.config.EnablePartitionAutoScaling() {
+		return numRead, numWrite
+	}
+
+	if c.taskQueueID.IsRoot() && !c.taskQueueID.IsVersioned() {
+		if read, write := c.db.PartitionCounts(); read > 0 && write > 0 {
+			return int(read), int(write)
+		}
+		return numRead, numWrite
+	}
+
+	if c.engine.partitionCounts != nil {
+		if read, write, ok := c.engine.partitionCounts.Get(
+			c.taskQueueID.namespaceID,
+			c.taskQueueID.GetRoot(),
+			c.taskQueueID.taskType,
+		); ok {
+			return read, write
+		}
+	}
+	return numRead, numWrite
+}
+
+// VersioningData returns the worker version sets of the task queue
+func (c *taskQueueManagerImpl) VersioningData() *taskqueuegenpb.VersioningData {
+	return c.db.VersioningData()
+}
+
+// UpdateVersioningData applies the given update to the worker version sets of the task queue
+func (c *taskQueueManagerImpl) UpdateVersioningData(
+	update func(*taskqueuegenpb.VersioningData) (*taskqueuegenpb.VersioningData, error),
+) (*taskqueuegenpb.VersioningData, error) {
+	return c.db.UpdateVersioningData(update)
+}
+
+// DispatchState returns whether the task queue is active, paused or draining
+func (c *taskQueueManagerImpl) DispatchState() enumsgenpb.TaskQueueState {
+	return c.db.DispatchState()
+}
+
+// UpdateDispatchState persists the given dispatch state with the task queue lease and applies it
+func (c *taskQueueManagerImpl) UpdateDispatchState(state enumsgenpb.TaskQueueState) error {
+	if err := c.db.UpdateDispatchState(state); err != nil {
+		return err
+	}
+	c.applyDispatchState(state)
+	c.logger.Info("Updated task queue dispatch state", tag.Value(state.String()))
+	return nil
+}
+
+// MaxTasksPerSecond returns the dispatch rate limit persisted through the admin API or, when not
+// set, the one from dynamic config. Returns nil when neither is set and the limit set by pollers applies.
+func (c *taskQueueManagerImpl) MaxTasksPerSecond() *float64 {
+	if limit := c.db.MaxTasksPerSecond(); limit != nil {
+		value := limit.GetValue()
+		return &value
+	}
+	if value := c.config.MaxTasksPerSecond(); value > 0 {
+		return &value
+	}
+	return nil
+}
+
+// UpdateMaxTasksPerSecond persists the given dispatch rate limit with the task queue lease and applies it
+func (c *taskQueueManagerImpl) UpdateMaxTasksPerSecond(maxTasksPerSecond *types.DoubleValue) error {
+	if err := c.db.UpdateMaxTasksPerSecond(maxTasksPerSecond); err != nil {
+		return err
+	}
+	c.matcher.UpdateRatelimit(c.MaxTasksPerSecond())
+	c.logger.Info("Updated task queue dispatch rate limit", tag.Value(maxTasksPerSecond.String()))
+	return nil
+}
+
+func (c *taskQueueManagerImpl) applyDispatchState(state enumsgenpb.TaskQueueState) {
+	if state == enumsgenpb.TASK_QUEUE_STATE_PAUSED {
+		c.matcher.Pause()
+	} else {
+		c.matcher.Resume()
+	}
+}
+
+// approximateBacklogCount returns the number of loaded but not yet completed tasks plus the
+// number of task ids of the current id blocks that are written but not yet read by the taskReader
+func (c *taskQueueManagerImpl) approximateBacklogCount() int64 {
+	var count int64
+	for _, sq := range append(c.getSubqueues(), c.defaultSubqueue) {
+		readLevel := sq.ackManager.getReadLevel()
+		if blockStart := c.rangeIDToTaskIDBlock(sq.db.RangeID()).start; readLevel < blockStart-1 {
+			readLevel = blockStart - 1
+		}
+		unread := sq.writer.GetMaxReadLevel() - readLevel
+		if unread < 0 {
+			unread = 0
+		}
+		count += sq.ackManager.getBacklogCountHint() + unread
+	}
+	return count
+}
+
+// backlogCountHint returns the number of loaded but not yet completed tasks of all subqueues
+func (c *taskQueueManagerImpl) backlogCountHint() int64 {
+	count := c.taskAckManager.getBacklogCountHint()
+	for _, sq := range c.getSubqueues() {
+		count += sq.ackManager.getBacklogCountHint()
+	}
+	return count
+}
+
+// appendTask writes the task to the subqueue of its priority and fairness key
+func (c *taskQueueManagerImpl) appendTask(
+	execution *commonpb.WorkflowExecution,
+	taskInfo *persistenceblobs.TaskInfo,
+) (*persistence.CreateTasksResponse, error) {
+	sq, err := c.getOrCreateSubqueue(newSubqueueKey(taskInfo.GetPriority(), taskInfo.GetFairnessKey(), c.config.FairnessKeyBuckets()))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := sq.writer.appendTask(execution, taskInfo)
+	if err == nil {
+		sq.signal()
+	}
+	return resp, err
+}
+
+// getOrCreateSubqueue returns the subqueue with the given key. Tasks of sticky task queues are
+// dispatched as soon as possible to a single worker, so they are always written to the default one.
+// Once the partition has as many subqueues as allowed, tasks of a new fairness bucket share the
+// subqueue of their priority, if any, and tasks of a new priority the default one.
+func (c *taskQueueManagerImpl) getOrCreateSubqueue(key subqueueKey) (*subqueue, error) {
+	if key == defaultSubqueueKey || c.taskQueueKind == enumspb.TASK_QUEUE_KIND_STICKY {
+		return c.defaultSubqueue, nil
+	}
+
+	c.subqueuesLock.Lock()
+	defer c.subqueuesLock.Unlock()
+	if sq, ok := c.subqueues[key]; ok {
+		return sq, nil
+	}
+	if len(c.subqueues) >= c.config.MaxSubqueues() {
+		if sq, ok := c.subqueues[subqueueKey{priority: key.priority}]; ok {
+			return sq, nil
+		}
+		return c.defaultSubqueue, nil
+	}
+	if atomic.LoadInt32(&c.stopped) == 1 {
+		return nil, errShutdown
+	}
+	sq := newSubqueue(c, key)
+	if err := c.db.AddSubqueue(sq.info()); err != nil {
+		return nil, err
+	}
+	if err := c.startSubqueue(sq); err != nil {
+		return nil, err
+	}
+	c.subqueues[key] = sq
+	return sq, nil
+}
+
+// startSubqueue acquires the lease of the given subqueue and starts loading its tasks
+func (c *taskQueueManagerImpl) startSubqueue(sq *subqueue) error {
+	state, err := c.renewLeaseWithRetry(sq.db)
+	if err != nil {
+		return err
+	}
+	sq.ackManager.setAckLevel(state.ackLevel)
+	sq.writer.Start(c.rangeIDToTaskIDBlock(state.rangeID))
+	sq.signal()
+	go c.taskReader.getSubqueueTasksPump(sq)
+	return nil
+}
+
+// getSubqueues returns the subqueues other than the default one
+func (c *taskQueueManagerImpl) getSubqueues() []*subqueue {
+	c.subqueuesLock.Lock()
+	defer c.subqueuesLock.Unlock()
+	result := make([]*subqueue, 0, len(c.subqueues))
+	for _, sq := range c.subqueues {
+		result = append(result, sq)
+	}
+	return result
+}
+
 func (c *taskQueueManagerImpl) String() string {
 	buf := new(bytes.Buffer)
 	if c.taskQueueID.taskType == enumspb.TASK_QUEUE_TYPE_ACTIVITY {
@@ -396,11 +675,16 @@ func (c *taskQueueManagerImpl) String() string {
 	return buf.String()
 }
 
-// completeTask marks a task as processed. Only tasks created by taskReader (i.e. backlog from db) reach
-// here. As part of completion:
+// completeTask marks a task of the default subqueue as processed, see completeSubqueueTask
+func (c *taskQueueManagerImpl) completeTask(task *persistenceblobs.AllocatedTaskInfo, err error) {
+	c.completeSubqueueTask(c.defaultSubqueue, task, err)
+}
+
+// completeSubqueueTask marks a task loaded from the given subqueue as processed. Only tasks created by
+// taskReader (i.e. backlog from db) reach here. As part of completion:
 //   - task is deleted from the database when err is nil
 //   - new task is created and current task is deleted when err is not nil
-func (c *taskQueueManagerImpl) completeTask(task *persistenceblobs.AllocatedTaskInfo, err error) {
+func (c *taskQueueManagerImpl) completeSubqueueTask(sq *subqueue, task *persistenceblobs.AllocatedTaskInfo, err error) {
 	if err != nil {
 		// failed to start the task.
 		// We cannot just remove it from persistence because then it will be lost.
@@ -411,7 +695,7 @@ func (c *taskQueueManagerImpl) completeTask(task *persistenceblobs.AllocatedTask
 		// re-written to persistence frequently.
 		_, err = c.executeWithRetry(func() (interface{}, error) {
 			wf := &commonpb.WorkflowExecution{WorkflowId: task.Data.GetWorkflowId(), RunId: task.Data.GetRunId()}
-			return c.taskWriter.appendTask(wf, task.Data)
+			return sq.writer.appendTask(wf, task.Data)
 		})
 
 		if err != nil {
@@ -426,17 +710,17 @@ func (c *taskQueueManagerImpl) completeTask(task *persistenceblobs.AllocatedTask
 			c.Stop()
 			return
 		}
-		c.taskReader.Signal()
+		sq.signal()
 	}
 
-	ackLevel := c.taskAckManager.completeTask(task.GetTaskId())
-	c.taskGC.Run(ackLevel)
+	ackLevel := sq.ackManager.completeTask(task.GetTaskId())
+	sq.gc.Run(ackLevel)
 }
 
-func (c *taskQueueManagerImpl) renewLeaseWithRetry() (taskQueueState, error) {
+func (c *taskQueueManagerImpl) renewLeaseWithRetry(db *taskQueueDB) (taskQueueState, error) {
 	var newState taskQueueState
 	op := func() (err error) {
-		newState, err = c.db.RenewLease()
+		newState, err = db.RenewLease()
 		return
 	}
 	c.metricScope().IncCounter(metrics.LeaseRequestPerTaskQueueCounter)
@@ -456,13 +740,13 @@ func (c *taskQueueManagerImpl) rangeIDToTaskIDBlock(rangeID int64) taskIDBlock {
 	}
 }
 
-func (c *taskQueueManagerImpl) allocTaskIDBlock(prevBlockEnd int64) (taskIDBlock, error) {
-	currBlock := c.rangeIDToTaskIDBlock(c.db.RangeID())
+func (c *taskQueueManagerImpl) allocTaskIDBlock(db *taskQueueDB, prevBlockEnd int64) (taskIDBlock, error) {
+	currBlock := c.rangeIDToTaskIDBlock(db.RangeID())
 	if currBlock.end != prevBlockEnd {
 		return taskIDBlock{},
 			fmt.Errorf("allocTaskIDBlock: invalid state: prevBlockEnd:%v != currTaskIDBlock:%+v", prevBlockEnd, currBlock)
 	}
-	state, err := c.renewLeaseWithRetry()
+	state, err := c.renewLeaseWithRetry(db)
 	if err != nil {
 		return taskIDBlock{}, err
 	}
@@ -26,11 +26,13 @@ package matching
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,6 +40,7 @@ import (
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -50,12 +53,12 @@ func TestDeliverBufferTasks(t *testing.T) {
 	defer controller.Finish()
 
 	tests := []func(tlm *taskQueueManagerImpl){
-		func(tlm *taskQueueManagerImpl) { close(tlm.taskReader.taskBuffer) },
+		func(tlm *taskQueueManagerImpl) { tlm.taskReader.taskBuffer.close() },
 		func(tlm *taskQueueManagerImpl) { close(tlm.taskReader.dispatcherShutdownC) },
 		func(tlm *taskQueueManagerImpl) {
 			rps := 0.1
 			tlm.matcher.UpdateRatelimit(&rps)
-			tlm.taskReader.taskBuffer <- &persistenceblobs.AllocatedTaskInfo{}
+			tlm.taskReader.taskBuffer.offer(&persistenceblobs.AllocatedTaskInfo{})
 			_, err := tlm.matcher.ratelimit(context.Background()) // consume the token
 			assert.NoError(t, err)
 			tlm.taskReader.cancelFunc()
@@ -80,7 +83,7 @@ func TestDeliverBufferTasks_NoPollers(t *testing.T) {
 	defer controller.Finish()
 
 	tlm := createTestTaskQueueManager(controller)
-	tlm.taskReader.taskBuffer <- &persistenceblobs.AllocatedTaskInfo{}
+	tlm.taskReader.taskBuffer.offer(&persistenceblobs.AllocatedTaskInfo{})
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -244,6 +247,32 @@ func TestDescribeTaskQueue(t *testing.T) {
 	require.Zero(t, taskQueueStatus.GetBacklogCountHint())
 }
 
+func TestMaxTasksPerSecond(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cfg := defaultTestConfig()
+	tlm := createTestTaskQueueManagerWithConfig(controller, cfg)
+	_, err := tlm.db.RenewLease()
+	require.NoError(t, err)
+
+	// without any server side limit the limit set by pollers applies
+	require.Nil(t, tlm.MaxTasksPerSecond())
+	require.Nil(t, tlm.DescribeTaskQueue(false).GetMaxTasksPerSecond())
+
+	cfg.TaskQueueMaxTasksPerSecond = dynamicconfig.GetFloatPropertyFilteredByTaskQueueInfo(50)
+	require.Equal(t, float64(50), *tlm.MaxTasksPerSecond())
+
+	// the persisted limit takes precedence over dynamic config
+	require.NoError(t, tlm.UpdateMaxTasksPerSecond(&types.DoubleValue{Value: 10}))
+	require.Equal(t, float64(10), *tlm.MaxTasksPerSecond())
+	require.Equal(t, float64(10), tlm.DescribeTaskQueue(false).GetMaxTasksPerSecond().GetValue())
+	require.InDelta(t, 10, tlm.matcher.Rate(), 0.1)
+
+	require.NoError(t, tlm.UpdateMaxTasksPerSecond(nil))
+	require.Equal(t, float64(50), *tlm.MaxTasksPerSecond())
+}
+
 func tlMgrStartWithoutNotifyEvent(tlm *taskQueueManagerImpl) {
 	// mimic tlm.Start() but avoid calling notifyEvent
 	tlm.startWG.Done()
@@ -284,3 +313,194 @@ func TestCheckIdleTaskQueue(t *testing.T) {
 	tlm.Stop()
 	require.Equal(t, int32(1), tlm.stopped)
 }
+
+func TestSubqueueOfPriority(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	logger, err := loggerimpl.NewDevelopment()
+	require.NoError(t, err)
+	tm := newTestTaskManager(logger)
+	mockNamespaceCache := cache.NewMockNamespaceCache(controller)
+	mockNamespaceCache.EXPECT().GetNamespaceByID(gomock.Any()).Return(cache.CreateNamespaceCacheEntry("namespace"), nil).AnyTimes()
+	cfg := defaultTestConfig()
+	cfg.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskQueueInfo(time.Second)
+	cfg.GetTasksBatchSize = dynamicconfig.GetIntPropertyFilteredByTaskQueueInfo(3)
+	me := newMatchingEngine(cfg, tm, nil, logger, mockNamespaceCache)
+	namespaceID := "deadbeef-0000-4567-890a-bcdef0123456"
+	tlID := newTestTaskQueueID(namespaceID, "tq", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	startManager := func() *taskQueueManagerImpl {
+		tlMgr, err := newTaskQueueManager(me, tlID, enumspb.TASK_QUEUE_KIND_NORMAL, cfg)
+		require.NoError(t, err)
+		require.NoError(t, tlMgr.Start())
+		return tlMgr.(*taskQueueManagerImpl)
+	}
+	newTask := func(scheduleID int64, priority int32) *persistenceblobs.TaskInfo {
+		return &persistenceblobs.TaskInfo{
+			NamespaceId: namespaceID,
+			WorkflowId:  "wid",
+			RunId:       "rid",
+			ScheduleId:  scheduleID,
+			CreatedTime: timestamp.TimestampNow().ToProto(),
+			Priority:    priority,
+		}
+	}
+	getTask := func(tlm *taskQueueManagerImpl) *internalTask {
+		for {
+			task, err := tlm.GetTask(context.Background(), nil)
+			if err == nil {
+				return task
+			}
+			require.Equal(t, ErrNoTasks, err)
+		}
+	}
+
+	// a large backlog of the default priority fills its share of the task buffer
+	tlm := startManager()
+	for i := int64(1); i <= 10; i++ {
+		_, err := tlm.appendTask(nil, newTask(i, common.DefaultTaskPriority))
+		require.NoError(t, err)
+	}
+	tlm.taskReader.Signal()
+	require.Eventually(t, func() bool { return tlm.taskReader.taskBuffer.len() == tlm.taskReader.taskBuffer.cap() }, time.Second, 10*time.Millisecond)
+
+	// the urgent task is persisted in a subqueue of its own and loaded regardless
+	_, err = tlm.appendTask(nil, newTask(11, common.HighestTaskPriority))
+	require.NoError(t, err)
+	subqueueID := newTestTaskQueueKey(namespaceID, subqueueName("tq", subqueueKey{priority: common.HighestTaskPriority}), enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	require.Equal(t, 1, tm.getTaskCount(subqueueID))
+	require.Equal(t, 10, tm.getTaskCount(tlID))
+	require.Len(t, tlm.db.Subqueues(), 1)
+	require.Eventually(t, func() bool { return tlm.taskReader.taskBuffer.len() == tlm.taskReader.taskBuffer.cap()+1 }, time.Second, 10*time.Millisecond)
+
+	// the task already taken by the dispatcher may go first, the urgent one comes right after
+	var priorities []int32
+	for i := 0; i < 2; i++ {
+		task := getTask(tlm)
+		priorities = append(priorities, task.event.Data.GetPriority())
+		task.finish(nil)
+	}
+	require.Contains(t, priorities, common.HighestTaskPriority)
+	require.Eventually(t, func() bool { return tm.getTaskCount(subqueueID) == 0 }, time.Second, 10*time.Millisecond)
+	tlm.Stop()
+
+	// the subqueue is recorded with the task queue and loaded along with it
+	tlm = startManager()
+	_, err = tlm.appendTask(nil, newTask(12, common.LowestTaskPriority))
+	require.NoError(t, err)
+	tlm.Stop()
+	tlm = startManager()
+	require.Len(t, tlm.getSubqueues(), 2)
+	tlm.Stop()
+}
+
+func TestSubqueueKeyOfFairnessKey(t *testing.T) {
+	require.Equal(t, defaultSubqueueKey, newSubqueueKey(0, "", 8))
+	require.Equal(t, defaultSubqueueKey, newSubqueueKey(common.DefaultTaskPriority, "tenant", 0))
+	for _, fairnessKey := range []string{"a", "b", "c", "tenant-1", "tenant-2"} {
+		key := newSubqueueKey(common.HighestTaskPriority, fairnessKey, 4)
+		require.Equal(t, common.HighestTaskPriority, key.priority)
+		require.True(t, key.fairnessBucket >= 1 && key.fairnessBucket <= 4)
+		require.Equal(t, key, newSubqueueKey(common.HighestTaskPriority, fairnessKey, 4))
+	}
+	require.Equal(t, "/__temporal_sys/tq#1", subqueueName("tq", subqueueKey{priority: 1}))
+	require.Equal(t, "/__temporal_sys/tq#1.3", subqueueName("tq", subqueueKey{priority: 1, fairnessBucket: 3}))
+}
+
+func TestSubqueueLimit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	logger, err := loggerimpl.NewDevelopment()
+	require.NoError(t, err)
+	tm := newTestTaskManager(logger)
+	mockNamespaceCache := cache.NewMockNamespaceCache(controller)
+	mockNamespaceCache.EXPECT().GetNamespaceByID(gomock.Any()).Return(cache.CreateNamespaceCacheEntry("namespace"), nil).AnyTimes()
+	cfg := defaultTestConfig()
+	cfg.MaxSubqueues = dynamicconfig.GetIntPropertyFilteredByTaskQueueInfo(2)
+	me := newMatchingEngine(cfg, tm, nil, logger, mockNamespaceCache)
+	tlID := newTestTaskQueueID("deadbeef-0000-4567-890a-bcdef0123456", "tq", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	tlMgr, err := newTaskQueueManager(me, tlID, enumspb.TASK_QUEUE_KIND_NORMAL, cfg)
+	require.NoError(t, err)
+	require.NoError(t, tlMgr.Start())
+	tlm := tlMgr.(*taskQueueManagerImpl)
+	defer tlm.Stop()
+
+	highest, err := tlm.getOrCreateSubqueue(subqueueKey{priority: common.HighestTaskPriority})
+	require.NoError(t, err)
+	require.NotSame(t, tlm.defaultSubqueue, highest)
+	bucket1, err := tlm.getOrCreateSubqueue(subqueueKey{priority: common.HighestTaskPriority, fairnessBucket: 1})
+	require.NoError(t, err)
+	require.NotSame(t, highest, bucket1)
+
+	// at the limit, a new fairness bucket shares the subqueue of its priority
+	bucket2, err := tlm.getOrCreateSubqueue(subqueueKey{priority: common.HighestTaskPriority, fairnessBucket: 2})
+	require.NoError(t, err)
+	require.Same(t, highest, bucket2)
+	// and a new priority the default subqueue
+	lowest, err := tlm.getOrCreateSubqueue(subqueueKey{priority: common.LowestTaskPriority})
+	require.NoError(t, err)
+	require.Same(t, tlm.defaultSubqueue, lowest)
+
+	require.Len(t, tlm.getSubqueues(), 2)
+	require.Len(t, tlm.db.Subqueues(), 2)
+}
+
+func TestSubqueueOfFairnessKey(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	logger, err := loggerimpl.NewDevelopment()
+	require.NoError(t, err)
+	tm := newTestTaskManager(logger)
+	mockNamespaceCache := cache.NewMockNamespaceCache(controller)
+	mockNamespaceCache.EXPECT().GetNamespaceByID(gomock.Any()).Return(cache.CreateNamespaceCacheEntry("namespace"), nil).AnyTimes()
+	cfg := defaultTestConfig()
+	cfg.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskQueueInfo(time.Second)
+	cfg.GetTasksBatchSize = dynamicconfig.GetIntPropertyFilteredByTaskQueueInfo(3)
+	cfg.FairnessKeyBuckets = dynamicconfig.GetIntPropertyFilteredByTaskQueueInfo(2)
+	me := newMatchingEngine(cfg, tm, nil, logger, mockNamespaceCache)
+	namespaceID := "deadbeef-0000-4567-890a-bcdef0123456"
+	tlID := newTestTaskQueueID(namespaceID, "tq", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	tlMgr, err := newTaskQueueManager(me, tlID, enumspb.TASK_QUEUE_KIND_NORMAL, cfg)
+	require.NoError(t, err)
+	require.NoError(t, tlMgr.Start())
+	tlm := tlMgr.(*taskQueueManagerImpl)
+	defer tlm.Stop()
+
+	// find two fairness keys hashed into different buckets
+	busyKey := "tenant-0"
+	busyBucket := newSubqueueKey(common.DefaultTaskPriority, busyKey, 2)
+	quietKey := ""
+	for i := 1; quietKey == ""; i++ {
+		if key := fmt.Sprintf("tenant-%v", i); newSubqueueKey(common.DefaultTaskPriority, key, 2) != busyBucket {
+			quietKey = key
+		}
+	}
+	newTask := func(scheduleID int64, fairnessKey string) *persistenceblobs.TaskInfo {
+		return &persistenceblobs.TaskInfo{
+			NamespaceId: namespaceID,
+			WorkflowId:  "wid",
+			RunId:       "rid",
+			ScheduleId:  scheduleID,
+			CreatedTime: timestamp.TimestampNow().ToProto(),
+			FairnessKey: fairnessKey,
+		}
+	}
+
+	// a large backlog of one fairness key fills the share of the task buffer of its bucket
+	for i := int64(1); i <= 10; i++ {
+		_, err := tlm.appendTask(nil, newTask(i, busyKey))
+		require.NoError(t, err)
+	}
+	busyID := newTestTaskQueueKey(namespaceID, subqueueName("tq", busyBucket), enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	require.Equal(t, 10, tm.getTaskCount(busyID))
+	require.Equal(t, 0, tm.getTaskCount(tlID))
+	require.Eventually(t, func() bool { return tlm.taskReader.taskBuffer.len() == tlm.taskReader.taskBuffer.cap() }, time.Second, 10*time.Millisecond)
+
+	// the task of another key is read through the cursor of its own bucket and loaded regardless
+	_, err = tlm.appendTask(nil, newTask(11, quietKey))
+	require.NoError(t, err)
+	require.Len(t, tlm.db.Subqueues(), 2)
+	require.Eventually(t, func() bool { return tlm.taskReader.taskBuffer.len() == tlm.taskReader.taskBuffer.cap()+1 }, time.Second, 10*time.Millisecond)
+}
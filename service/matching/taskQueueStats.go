@@ -0,0 +1,165 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
+	"github.com/temporalio/temporal/common/clock"
+)
+
+const (
+	// taskQueueStatsWindow is the sliding window over which task queue rates are computed
+	taskQueueStatsWindow = time.Minute
+	// taskQueueStatsBuckets is the number of buckets the window is split into
+	taskQueueStatsBuckets = 12
+)
+
+type (
+	// rateCounter counts events over a sliding time window split into fixed size buckets
+	rateCounter struct {
+		sync.Mutex
+		timeSource clock.TimeSource
+		bucketSize time.Duration
+		startTime  time.Time
+		counts     []int64
+		epochs     []int64 // sequence number of the bucket each count belongs to
+	}
+
+	// taskQueueStats tracks the approximate add, dispatch and sync match rates of a task queue partition
+	taskQueueStats struct {
+		added       *rateCounter
+		dispatched  *rateCounter
+		syncMatched *rateCounter
+	}
+)
+
+func newRateCounter(timeSource clock.TimeSource, window time.Duration, numBuckets int) *rateCounter {
+	return &rateCounter{
+		timeSource: timeSource,
+		bucketSize: window / time.Duration(numBuckets),
+		startTime:  timeSource.Now(),
+		counts:     make([]int64, numBuckets),
+		epochs:     make([]int64, numBuckets),
+	}
+}
+
+func (c *rateCounter) inc() {
+	c.Lock()
+	defer c.Unlock()
+	epoch := c.timeSource.Now().UnixNano() / int64(c.bucketSize)
+	i := epoch % int64(len(c.counts))
+	if c.epochs[i] != epoch {
+		c.epochs[i] = epoch
+		c.counts[i] = 0
+	}
+	c.counts[i]++
+}
+
+// rate returns the average number of events per second over the window
+func (c *rateCounter) rate() float64 {
+	c.Lock()
+	defer c.Unlock()
+	now := c.timeSource.Now()
+	epoch := now.UnixNano() / int64(c.bucketSize)
+	var total int64
+	for i, e := range c.epochs {
+		if e > epoch-int64(len(c.counts)) && e <= epoch {
+			total += c.counts[i]
+		}
+	}
+	// the current bucket is only partially elapsed, and the counter may be younger than the window
+	elapsed := time.Duration(len(c.counts)-1)*c.bucketSize + time.Duration(now.UnixNano()%int64(c.bucketSize))
+	if sinceStart := now.Sub(c.startTime); sinceStart < elapsed {
+		elapsed = sinceStart
+	}
+	if elapsed < time.Second {
+		elapsed = time.Second
+	}
+	return float64(total) / elapsed.Seconds()
+}
+
+func newTaskQueueStats(timeSource clock.TimeSource) *taskQueueStats {
+	return &taskQueueStats{
+		added:       newRateCounter(timeSource, taskQueueStatsWindow, taskQueueStatsBuckets),
+		dispatched:  newRateCounter(timeSource, taskQueueStatsWindow, taskQueueStatsBuckets),
+		syncMatched: newRateCounter(timeSource, taskQueueStatsWindow, taskQueueStatsBuckets),
+	}
+}
+
+// recordAdd records a task added to the partition by a producer
+func (s *taskQueueStats) recordAdd(syncMatch bool) {
+	s.added.inc()
+	if syncMatch {
+		s.syncMatched.inc()
+	}
+}
+
+// recordDispatch records a task handed out by the partition to a poller
+func (s *taskQueueStats) recordDispatch() {
+	s.dispatched.inc()
+}
+
+// snapshot returns the current stats of the partition given its approximate backlog count and age
+func (s *taskQueueStats) snapshot(backlogCount int64, backlogAge time.Duration) *taskqueuegenpb.TaskQueueStats {
+	stats := &taskqueuegenpb.TaskQueueStats{
+		ApproximateBacklogCount:      backlogCount,
+		ApproximateBacklogAgeSeconds: int64(backlogAge / time.Second),
+		TasksAddRate:                 s.added.rate(),
+		TasksDispatchRate:            s.dispatched.rate(),
+	}
+	if stats.TasksAddRate > 0 {
+		stats.SyncMatchRatio = math.Min(1, s.syncMatched.rate()/stats.TasksAddRate)
+	}
+	return stats
+}
+
+// aggregateTaskQueueStats combines the stats of all partitions of a task queue. Backlog counts and
+// rates are summed, backlog age is the max across partitions and sync match ratio is weighted by
+// the add rate of each partition.
+func aggregateTaskQueueStats(partitions []*taskqueuegenpb.TaskQueuePartitionStats) *taskqueuegenpb.TaskQueueStats {
+	result := &taskqueuegenpb.TaskQueueStats{}
+	var syncMatchRate float64
+	for _, p := range partitions {
+		stats := p.GetStats()
+		if stats == nil {
+			continue
+		}
+		result.ApproximateBacklogCount += stats.GetApproximateBacklogCount()
+		if stats.GetApproximateBacklogAgeSeconds() > result.ApproximateBacklogAgeSeconds {
+			result.ApproximateBacklogAgeSeconds = stats.GetApproximateBacklogAgeSeconds()
+		}
+		result.TasksAddRate += stats.GetTasksAddRate()
+		result.TasksDispatchRate += stats.GetTasksDispatchRate()
+		syncMatchRate += stats.GetSyncMatchRatio() * stats.GetTasksAddRate()
+	}
+	if result.TasksAddRate > 0 {
+		result.SyncMatchRatio = syncMatchRate / result.TasksAddRate
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
+	"github.com/temporalio/temporal/common/clock"
+)
+
+func TestRateCounter_Rate(t *testing.T) {
+	start := time.Unix(1000, 0)
+	timeSource := clock.NewEventTimeSource().Update(start)
+	counter := newRateCounter(timeSource, time.Minute, 12)
+	for i := 0; i < 30; i++ {
+		counter.inc()
+	}
+
+	// counter younger than the window is averaged over its lifetime
+	timeSource.Update(start.Add(30 * time.Second))
+	require.Equal(t, 1.0, counter.rate())
+
+	// events older than the window are dropped
+	timeSource.Update(start.Add(90 * time.Second))
+	require.Equal(t, 0.0, counter.rate())
+	for i := 0; i < 55; i++ {
+		counter.inc()
+	}
+	timeSource.Update(start.Add(145 * time.Second))
+	require.Equal(t, 1.0, counter.rate())
+}
+
+func TestTaskQueueStats_Snapshot(t *testing.T) {
+	start := time.Unix(1000, 0)
+	timeSource := clock.NewEventTimeSource().Update(start)
+	stats := newTaskQueueStats(timeSource)
+	stats.recordAdd(true)
+	stats.recordAdd(false)
+	stats.recordAdd(false)
+	stats.recordAdd(false)
+	stats.recordDispatch()
+	stats.recordDispatch()
+
+	timeSource.Update(start.Add(2 * time.Second))
+	snapshot := stats.snapshot(7, 90*time.Second)
+	require.Equal(t, int64(7), snapshot.GetApproximateBacklogCount())
+	require.Equal(t, int64(90), snapshot.GetApproximateBacklogAgeSeconds())
+	require.Equal(t, 2.0, snapshot.GetTasksAddRate())
+	require.Equal(t, 1.0, snapshot.GetTasksDispatchRate())
+	require.Equal(t, 0.25, snapshot.GetSyncMatchRatio())
+}
+
+func TestAggregateTaskQueueStats(t *testing.T) {
+	stats := aggregateTaskQueueStats([]*taskqueuegenpb.TaskQueuePartitionStats{
+		{
+			Partition: "tq",
+			Stats: &taskqueuegenpb.TaskQueueStats{
+				ApproximateBacklogCount:      10,
+				ApproximateBacklogAgeSeconds: 5,
+				TasksAddRate:                 3,
+				TasksDispatchRate:            2,
+				SyncMatchRatio:               1,
+			},
+		},
+		{
+			Partition: "/__temporal_sys/tq/1",
+			Stats: &taskqueuegenpb.TaskQueueStats{
+				ApproximateBacklogCount:      20,
+				ApproximateBacklogAgeSeconds: 30,
+				TasksAddRate:                 1,
+				TasksDispatchRate:            4,
+				SyncMatchRatio:               0,
+			},
+		},
+		{Partition: "/__temporal_sys/tq/2"},
+	})
+	require.Equal(t, int64(30), stats.GetApproximateBacklogCount())
+	require.Equal(t, int64(30), stats.GetApproximateBacklogAgeSeconds())
+	require.Equal(t, 4.0, stats.GetTasksAddRate())
+	require.Equal(t, 6.0, stats.GetTasksDispatchRate())
+	require.Equal(t, 0.75, stats.GetSyncMatchRatio())
+}
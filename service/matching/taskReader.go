@@ -27,6 +27,7 @@ package matching
 import (
 	"context"
 	"runtime"
+	"sync/atomic"
 	"time"
 
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
@@ -35,13 +36,14 @@ import (
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/primitives/timestamp"
 	"github.com/temporalio/temporal/service/worker/scanner/taskqueue"
 )
 
 type (
 	taskReader struct {
-		taskBuffer chan *persistenceblobs.AllocatedTaskInfo // tasks loaded from persistence
-		notifyC    chan struct{}                            // Used as signal to notify pump of new tasks
+		taskBuffer *priorityTaskBuffer // tasks loaded from persistence
+		notifyC    chan struct{}       // Used as signal to notify pump of new tasks
 		tlMgr      *taskQueueManagerImpl
 		// The cancel objects are to cancel the ratelimiter Wait in dispatchBufferedTasks. The ideal
 		// approach is to use request-scoped contexts and use a unique one for each call to Wait. However
@@ -53,6 +55,8 @@ type (
 		// separate shutdownC needed for dispatchTasks go routine to allow
 		// getTasksPump to be stopped without stopping dispatchTasks in unit tests
 		dispatcherShutdownC chan struct{}
+		// creation time in unix nanos of the task being dispatched, 0 when none
+		dispatchingCreatedTime int64
 	}
 )
 
@@ -66,7 +70,11 @@ func newTaskReader(tlMgr *taskQueueManagerImpl) *taskReader {
 		dispatcherShutdownC: make(chan struct{}),
 		// we always dequeue the head of the buffer and try to dispatch it to a poller
 		// so allocate one less than desired target buffer size
-		taskBuffer: make(chan *persistenceblobs.AllocatedTaskInfo, tlMgr.config.GetTasksBatchSize()-1),
+		taskBuffer: newPriorityTaskBuffer(
+			tlMgr.config.GetTasksBatchSize()-1,
+			tlMgr.config.PriorityStarvationProtectionInterval,
+			func(key string) int { return fairnessKeyWeight(tlMgr.config.FairnessKeyWeights(), key) },
+		),
 	}
 }
 
@@ -92,35 +100,52 @@ func (tr *taskReader) Signal() {
 func (tr *taskReader) dispatchBufferedTasks() {
 dispatchLoop:
 	for {
-		select {
-		case taskInfo, ok := <-tr.taskBuffer:
-			if !ok { // Task queue getTasks pump is shutdown
+		taskInfo, source, ok := tr.taskBuffer.pollWithSource()
+		if !ok { // Task queue getTasks pump is shutdown
+			break dispatchLoop
+		}
+		if taskInfo == nil {
+			select {
+			case <-tr.taskBuffer.readyC:
+				continue dispatchLoop
+			case <-tr.dispatcherShutdownC:
 				break dispatchLoop
 			}
-			task := newInternalTask(taskInfo, tr.tlMgr.completeTask, enumsgenpb.TASK_SOURCE_DB_BACKLOG, "", false)
-			for {
-				err := tr.tlMgr.DispatchTask(tr.cancelCtx, task)
-				if err == nil {
-					break
-				}
-				if err == context.Canceled {
-					tr.tlMgr.logger.Info("Taskqueue manager context is cancelled, shutting down")
-					break dispatchLoop
-				}
-				// this should never happen unless there is a bug - don't drop the task
-				tr.scope().IncCounter(metrics.BufferThrottlePerTaskQueueCounter)
-				tr.logger().Error("taskReader: unexpected error dispatching task", tag.Error(err))
-				runtime.Gosched()
+		}
+		completionFunc := tr.tlMgr.completeTask
+		if source != nil {
+			completionFunc = func(task *persistenceblobs.AllocatedTaskInfo, err error) {
+				tr.tlMgr.completeSubqueueTask(source, task, err)
+			}
+		}
+		task := newInternalTask(taskInfo, completionFunc, enumsgenpb.TASK_SOURCE_DB_BACKLOG, "", false)
+		atomic.StoreInt64(&tr.dispatchingCreatedTime, taskCreatedTime(taskInfo))
+		for {
+			err := tr.tlMgr.DispatchTask(tr.cancelCtx, task)
+			if err == nil {
+				break
 			}
+			if err == context.Canceled {
+				tr.tlMgr.logger.Info("Taskqueue manager context is cancelled, shutting down")
+				break dispatchLoop
+			}
+			// this should never happen unless there is a bug - don't drop the task
+			tr.scope().IncCounter(metrics.BufferThrottlePerTaskQueueCounter)
+			tr.logger().Error("taskReader: unexpected error dispatching task", tag.Error(err))
+			runtime.Gosched()
+		}
+		atomic.StoreInt64(&tr.dispatchingCreatedTime, 0)
+		select {
 		case <-tr.dispatcherShutdownC:
 			break dispatchLoop
+		default:
 		}
 	}
 }
 
 func (tr *taskReader) getTasksPump() {
 	tr.tlMgr.startWG.Wait()
-	defer close(tr.taskBuffer)
+	defer tr.taskBuffer.close()
 
 	updateAckTimer := time.NewTimer(tr.tlMgr.config.UpdateAckInterval())
 	checkIdleTaskQueueTimer := time.NewTimer(tr.tlMgr.config.IdleTaskqueueCheckInterval())
@@ -169,6 +194,7 @@ getTasksPumpLoop:
 					}
 					// keep going as saving ack is not critical
 				}
+				tr.emitStats()
 				tr.Signal() // periodically signal pump to check persistence for tasks
 				updateAckTimer = time.NewTimer(tr.tlMgr.config.UpdateAckInterval())
 			}
@@ -187,9 +213,58 @@ getTasksPumpLoop:
 	checkIdleTaskQueueTimer.Stop()
 }
 
-func (tr *taskReader) getTaskBatchWithRange(readLevel int64, maxReadLevel int64) ([]*persistenceblobs.AllocatedTaskInfo, error) {
+// getSubqueueTasksPump loads the tasks of a subqueue other than the default one into the task buffer.
+// Idle checks and stats are left to getTasksPump, which is signalled whenever a task is added.
+func (tr *taskReader) getSubqueueTasksPump(sq *subqueue) {
+	tr.tlMgr.startWG.Wait()
+
+	updateAckTimer := time.NewTimer(tr.tlMgr.config.UpdateAckInterval())
+getTasksPumpLoop:
+	for {
+		select {
+		case <-tr.tlMgr.shutdownCh:
+			break getTasksPumpLoop
+		case <-sq.notifyC:
+			tasks, readLevel, isReadBatchDone, err := tr.getSubqueueTaskBatch(sq)
+			if err != nil {
+				sq.signal() // re-enqueue the event
+				continue getTasksPumpLoop
+			}
+
+			if len(tasks) == 0 {
+				sq.ackManager.setReadLevel(readLevel)
+				if !isReadBatchDone {
+					sq.signal()
+				}
+				continue getTasksPumpLoop
+			}
+
+			if !tr.addSubqueueTasksToBuffer(sq, tasks) {
+				break getTasksPumpLoop
+			}
+			sq.signal()
+		case <-updateAckTimer.C:
+			if err := sq.persistAckLevel(); err != nil {
+				if _, ok := err.(*persistence.ConditionFailedError); ok {
+					// This indicates the task queue may have moved to another host.
+					tr.tlMgr.Stop()
+				} else {
+					tr.logger().Error("Persistent store operation failure",
+						tag.StoreOperationUpdateTaskQueue,
+						tag.Error(err))
+				}
+			}
+			sq.signal()
+			updateAckTimer = time.NewTimer(tr.tlMgr.config.UpdateAckInterval())
+		}
+	}
+
+	updateAckTimer.Stop()
+}
+
+func (tr *taskReader) getTaskBatchWithRange(sq *subqueue, readLevel int64, maxReadLevel int64) ([]*persistenceblobs.AllocatedTaskInfo, error) {
 	response, err := tr.tlMgr.executeWithRetry(func() (interface{}, error) {
-		return tr.tlMgr.db.GetTasks(readLevel, maxReadLevel, tr.tlMgr.config.GetTasksBatchSize())
+		return sq.db.GetTasks(readLevel, maxReadLevel, tr.tlMgr.config.GetTasksBatchSize())
 	})
 	if err != nil {
 		return nil, err
@@ -201,9 +276,14 @@ func (tr *taskReader) getTaskBatchWithRange(readLevel int64, maxReadLevel int64)
 // Also return a number that can be used to update readLevel
 // Also return a bool to indicate whether read is finished
 func (tr *taskReader) getTaskBatch() ([]*persistenceblobs.AllocatedTaskInfo, int64, bool, error) {
+	return tr.getSubqueueTaskBatch(tr.tlMgr.defaultSubqueue)
+}
+
+// getSubqueueTaskBatch is getTaskBatch for the given subqueue
+func (tr *taskReader) getSubqueueTaskBatch(sq *subqueue) ([]*persistenceblobs.AllocatedTaskInfo, int64, bool, error) {
 	var tasks []*persistenceblobs.AllocatedTaskInfo
-	readLevel := tr.tlMgr.taskAckManager.getReadLevel()
-	maxReadLevel := tr.tlMgr.taskWriter.GetMaxReadLevel()
+	readLevel := sq.ackManager.getReadLevel()
+	maxReadLevel := sq.writer.GetMaxReadLevel()
 
 	// counter i is used to break and let caller check whether taskqueue is still alive and need resume read.
 	for i := 0; i < 10 && readLevel < maxReadLevel; i++ {
@@ -211,7 +291,7 @@ func (tr *taskReader) getTaskBatch() ([]*persistenceblobs.AllocatedTaskInfo, int
 		if upper > maxReadLevel {
 			upper = maxReadLevel
 		}
-		tasks, err := tr.getTaskBatchWithRange(readLevel, upper)
+		tasks, err := tr.getTaskBatchWithRange(sq, readLevel, upper)
 		if err != nil {
 			return nil, readLevel, true, err
 		}
@@ -231,6 +311,10 @@ func (tr *taskReader) isIdle(lastWriteTime time.Time) bool {
 func (tr *taskReader) handleIdleTimeout() {
 	_ = tr.persistAckLevel()
 	tr.tlMgr.taskGC.RunNow(tr.tlMgr.taskAckManager.getAckLevel())
+	for _, sq := range tr.tlMgr.getSubqueues() {
+		_ = sq.persistAckLevel()
+		sq.gc.RunNow(sq.ackManager.getAckLevel())
+	}
 	tr.tlMgr.Stop()
 }
 
@@ -254,9 +338,11 @@ func (tr *taskReader) addSingleTaskToBuffer(
 	task *persistenceblobs.AllocatedTaskInfo, lastWriteTime time.Time, idleTimer *time.Timer) bool {
 	tr.tlMgr.taskAckManager.addTask(task.GetTaskId())
 	for {
-		select {
-		case tr.taskBuffer <- task:
+		if tr.taskBuffer.offer(task) {
 			return true
+		}
+		select {
+		case <-tr.taskBuffer.spaceC:
 		case <-idleTimer.C:
 			if tr.isIdle(lastWriteTime) {
 				tr.handleIdleTimeout()
@@ -268,6 +354,66 @@ func (tr *taskReader) addSingleTaskToBuffer(
 	}
 }
 
+// addSubqueueTasksToBuffer is addTasksToBuffer for the tasks of a subqueue other than the default one
+func (tr *taskReader) addSubqueueTasksToBuffer(sq *subqueue, tasks []*persistenceblobs.AllocatedTaskInfo) bool {
+	for _, t := range tasks {
+		if taskqueue.IsTaskExpired(t) {
+			tr.scope().IncCounter(metrics.ExpiredTasksPerTaskQueueCounter)
+			sq.ackManager.setReadLevel(t.GetTaskId())
+			continue
+		}
+		sq.ackManager.addTask(t.GetTaskId())
+		for !tr.taskBuffer.offerFrom(sq, t) {
+			select {
+			case <-sq.spaceC:
+			case <-tr.tlMgr.shutdownCh:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fairnessKeyWeight returns the round robin weight of the given fairness key, 1 unless configured
+func fairnessKeyWeight(weights map[string]interface{}, key string) int {
+	if weight, ok := weights[key].(int); ok && weight > 0 {
+		return weight
+	}
+	return 1
+}
+
+// backlogAge returns how long the oldest loaded task that is not yet dispatched has been waiting
+func (tr *taskReader) backlogAge() time.Duration {
+	oldest := atomic.LoadInt64(&tr.dispatchingCreatedTime)
+	if task := tr.taskBuffer.peekOldest(); task != nil {
+		if created := taskCreatedTime(task); oldest == 0 || (created != 0 && created < oldest) {
+			oldest = created
+		}
+	}
+	if oldest == 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, oldest))
+}
+
+func (tr *taskReader) emitStats() {
+	stats := tr.tlMgr.DescribeTaskQueueStats()
+	scope := tr.scope()
+	scope.UpdateGauge(metrics.BacklogCountPerTaskQueueGauge, float64(stats.GetApproximateBacklogCount()))
+	scope.UpdateGauge(metrics.BacklogAgePerTaskQueueGauge, float64(stats.GetApproximateBacklogAgeSeconds()))
+	scope.UpdateGauge(metrics.TaskAddRatePerTaskQueueGauge, stats.GetTasksAddRate())
+	scope.UpdateGauge(metrics.TaskDispatchRatePerTaskQueueGauge, stats.GetTasksDispatchRate())
+	scope.UpdateGauge(metrics.SyncMatchRatioPerTaskQueueGauge, stats.GetSyncMatchRatio())
+}
+
+// taskCreatedTime returns the creation time of the task in unix nanos, 0 if unknown
+func taskCreatedTime(task *persistenceblobs.AllocatedTaskInfo) int64 {
+	if task.GetData().GetCreatedTime() == nil {
+		return 0
+	}
+	return timestamp.TimestampFromProto(task.GetData().GetCreatedTime()).UnixNano()
+}
+
 func (tr *taskReader) persistAckLevel() error {
 	return tr.tlMgr.db.UpdateState(tr.tlMgr.taskAckManager.getAckLevel())
 }
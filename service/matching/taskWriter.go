@@ -57,6 +57,7 @@ type (
 	// taskWriter writes tasks sequentially to persistence
 	taskWriter struct {
 		tlMgr        *taskQueueManagerImpl
+		db           *taskQueueDB
 		config       *taskQueueConfig
 		taskQueueID  *taskQueueID
 		appendCh     chan *writeTaskRequest
@@ -71,9 +72,10 @@ type (
 // errShutdown indicates that the task queue is shutting down
 var errShutdown = errors.New("task queue shutting down")
 
-func newTaskWriter(tlMgr *taskQueueManagerImpl) *taskWriter {
+func newTaskWriter(tlMgr *taskQueueManagerImpl, db *taskQueueDB) *taskWriter {
 	return &taskWriter{
 		tlMgr:       tlMgr,
+		db:          db,
 		config:      tlMgr.config,
 		taskQueueID: tlMgr.taskQueueID,
 		stopCh:      make(chan struct{}),
@@ -137,7 +139,7 @@ func (w *taskWriter) allocTaskIDs(count int) ([]int64, error) {
 	for i := 0; i < count; i++ {
 		if w.taskIDBlock.start > w.taskIDBlock.end {
 			// we ran out of current allocation block
-			newBlock, err := w.tlMgr.allocTaskIDBlock(w.taskIDBlock.end)
+			newBlock, err := w.tlMgr.allocTaskIDBlock(w.db, w.taskIDBlock.end)
 			if err != nil {
 				return nil, err
 			}
@@ -177,7 +179,7 @@ writerLoop:
 					maxReadLevel = taskIDs[i]
 				}
 
-				r, err := w.tlMgr.db.CreateTasks(tasks)
+				r, err := w.db.CreateTasks(tasks)
 				if err != nil {
 					w.logger.Error("Persistent store operation failure",
 						tag.StoreOperationCreateTask,
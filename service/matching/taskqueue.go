@@ -42,15 +42,18 @@ type (
 	}
 	// qualifiedTaskQueueName refers to the fully qualified task queue name
 	qualifiedTaskQueueName struct {
-		name      string // internal name of the tasks list
-		baseName  string // original name of the task queue as specified by user
-		partition int    // partitionID of task queue
+		name       string // internal name of the tasks list
+		baseName   string // original name of the task queue as specified by user
+		partition  int    // partitionID of task queue
+		versionSet string // id of the worker version set this task queue belongs to, empty if unversioned
 	}
 )
 
 const (
 	// taskQueuePartitionPrefix is the required naming prefix for any task queue partition other than partition 0
 	taskQueuePartitionPrefix = "/__temporal_sys/"
+	// versionSetSeparator separates the version set id from the partitionID in the name of a versioned task queue
+	versionSetSeparator = ":"
 )
 
 // newTaskQueueName returns a fully qualified task queue name.
@@ -68,6 +71,11 @@ const (
 // optimization to allow for partitioned task queues to dispatch tasks with low latency when
 // throughput is low - See https://github.com/temporalio/temporal/issues/2098
 //
+// Decision tasks of workers with a build id registered in a worker version set are
+// dispatched through separate task queues, one per version set and partition, named
+//
+//     /__temporal_sys/[original-name]/[versionSetID]:[partitionID]
+//
 // Returns error if the given name is non-compliant with the required format
 // for task queue names
 func newTaskQueueName(name string) (qualifiedTaskQueueName, error) {
@@ -81,6 +89,19 @@ func newTaskQueueName(name string) (qualifiedTaskQueueName, error) {
 	return tn, nil
 }
 
+// IsVersioned returns true if this task queue belongs to a worker version set
+func (tn *qualifiedTaskQueueName) IsVersioned() bool {
+	return tn.versionSet != ""
+}
+
+// WithVersionSet returns the name of the same partition of the given worker version set
+func (tn *qualifiedTaskQueueName) WithVersionSet(versionSet string) qualifiedTaskQueueName {
+	result := *tn
+	result.versionSet = versionSet
+	result.name = result.mkName(result.partition)
+	return result
+}
+
 // IsRoot returns true if this task queue is a root partition
 func (tn *qualifiedTaskQueueName) IsRoot() bool {
 	return tn.partition == 0
@@ -104,6 +125,9 @@ func (tn *qualifiedTaskQueueName) Parent(degree int) string {
 }
 
 func (tn *qualifiedTaskQueueName) mkName(partition int) string {
+	if tn.versionSet != "" {
+		return fmt.Sprintf("%v%v/%v%v%v", taskQueuePartitionPrefix, tn.baseName, tn.versionSet, versionSetSeparator, partition)
+	}
 	if partition == 0 {
 		return tn.baseName
 	}
@@ -120,8 +144,20 @@ func (tn *qualifiedTaskQueueName) init() error {
 		return fmt.Errorf("invalid partitioned task queue name %v", tn.name)
 	}
 
-	p, err := strconv.Atoi(tn.name[suffixOff+1:])
-	if err != nil || p <= 0 {
+	suffix := tn.name[suffixOff+1:]
+	minPartition := 1
+	if versionOff := strings.LastIndex(suffix, versionSetSeparator); versionOff >= 0 {
+		// the root partition of a version set has a partitionID too
+		tn.versionSet = suffix[:versionOff]
+		suffix = suffix[versionOff+1:]
+		minPartition = 0
+		if tn.versionSet == "" {
+			return fmt.Errorf("invalid versioned task queue name %v", tn.name)
+		}
+	}
+
+	p, err := strconv.Atoi(suffix)
+	if err != nil || p < minPartition {
 		return fmt.Errorf("invalid partitioned task queue name %v", tn.name)
 	}
 
@@ -102,6 +102,26 @@ func TestTaskQueueParentName(t *testing.T) {
 	}
 }
 
+func TestVersionedTaskQueueNames(t *testing.T) {
+	tn, err := newTaskQueueName("/__temporal_sys/list0/1")
+	require.NoError(t, err)
+	require.False(t, tn.IsVersioned())
+
+	versioned := tn.WithVersionSet("abc")
+	require.True(t, versioned.IsVersioned())
+	require.Equal(t, "/__temporal_sys/list0/abc:1", versioned.name)
+	require.Equal(t, "list0", versioned.GetRoot())
+	require.Equal(t, "/__temporal_sys/list0/abc:0", versioned.Parent(2))
+
+	parsed, err := newTaskQueueName("/__temporal_sys/list0/abc:0")
+	require.NoError(t, err)
+	require.True(t, parsed.IsRoot())
+	require.Equal(t, "abc", parsed.versionSet)
+	require.Equal(t, "list0", parsed.baseName)
+	require.Equal(t, "", parsed.Parent(2))
+	require.Equal(t, "/__temporal_sys/list0/abc:3", parsed.mkName(3))
+}
+
 func TestInvalidTaskqueueNames(t *testing.T) {
 	inputs := []string{
 		"/__temporal_sys/",
@@ -111,6 +131,9 @@ func TestInvalidTaskqueueNames(t *testing.T) {
 		"/__temporal_sys/list0",
 		"/__temporal_sys/list0/0",
 		"/__temporal_sys/list0/-1",
+		"/__temporal_sys/list0/:1",
+		"/__temporal_sys/list0/abc:",
+		"/__temporal_sys/list0/abc:-1",
 	}
 	for _, name := range inputs {
 		t.Run(name, func(t *testing.T) {
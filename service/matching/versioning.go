@@ -0,0 +1,181 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"fmt"
+
+	"github.com/dgryski/go-farm"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
+)
+
+var (
+	errEmptyBuildID         = serviceerror.NewInvalidArgument("Build id is not set.")
+	errMissingVersionOp     = serviceerror.NewInvalidArgument("Build id compatibility operation is not set.")
+	errTooManyVersionSets   = serviceerror.NewInvalidArgument("Task queue has too many worker version sets.")
+	errTooManyBuildIDs      = serviceerror.NewInvalidArgument("Task queue has too many worker build ids.")
+	errVersionSetIDConflict = serviceerror.NewInvalidArgument("Build id conflicts with an existing version set, use a different build id.")
+)
+
+// versionSetID returns the id of a version set created for the given build id. Build ids can contain
+// any character, the id is a hash so that it can be embedded in internal task queue names.
+func versionSetID(buildID string) string {
+	return fmt.Sprintf("%016x", farm.Fingerprint64([]byte(buildID)))
+}
+
+// lookupVersionSet returns the id of the version set containing the given build id
+func lookupVersionSet(data *taskqueuegenpb.VersioningData, buildID string) (string, bool) {
+	if buildID == "" {
+		return "", false
+	}
+	index := findVersionSet(data, buildID)
+	if index < 0 {
+		return "", false
+	}
+	return data.VersionSets[index].GetId(), true
+}
+
+// defaultVersionSet returns the id of the version set receiving new workflows
+func defaultVersionSet(data *taskqueuegenpb.VersioningData) (string, bool) {
+	sets := data.GetVersionSets()
+	if len(sets) == 0 {
+		return "", false
+	}
+	return sets[len(sets)-1].GetId(), true
+}
+
+func findVersionSet(data *taskqueuegenpb.VersioningData, buildID string) int {
+	for i, set := range data.GetVersionSets() {
+		for _, id := range set.GetBuildIds() {
+			if id == buildID {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// updateVersioningData returns a copy of the given versioning data with the update applied,
+// the input is left untouched as it may be shared with concurrent readers
+func updateVersioningData(
+	data *taskqueuegenpb.VersioningData,
+	request *matchingservice.UpdateWorkerBuildIdCompatibilityRequest,
+	maxVersionSets int,
+	maxBuildIDs int,
+) (*taskqueuegenpb.VersioningData, error) {
+	sets := make([]*taskqueuegenpb.CompatibleVersionSet, len(data.GetVersionSets()))
+	copy(sets, data.GetVersionSets())
+	result := &taskqueuegenpb.VersioningData{VersionSets: sets}
+
+	switch op := request.GetOperation().(type) {
+	case *matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet_:
+		buildID := op.AddNewBuildIdInNewDefaultSet.GetBuildId()
+		if err := validateNewBuildID(result, buildID); err != nil {
+			return nil, err
+		}
+		id := versionSetID(buildID)
+		for _, set := range sets {
+			if set.GetId() == id {
+				return nil, errVersionSetIDConflict
+			}
+		}
+		result.VersionSets = append(result.VersionSets, &taskqueuegenpb.CompatibleVersionSet{
+			Id:       id,
+			BuildIds: []string{buildID},
+		})
+
+	case *matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewCompatibleBuildId_:
+		buildID := op.AddNewCompatibleBuildId.GetNewBuildId()
+		if err := validateNewBuildID(result, buildID); err != nil {
+			return nil, err
+		}
+		index, err := findExistingVersionSet(result, op.AddNewCompatibleBuildId.GetExistingCompatibleBuildId())
+		if err != nil {
+			return nil, err
+		}
+		set := result.VersionSets[index]
+		buildIDs := make([]string, len(set.GetBuildIds()), len(set.GetBuildIds())+1)
+		copy(buildIDs, set.GetBuildIds())
+		result.VersionSets[index] = &taskqueuegenpb.CompatibleVersionSet{
+			Id:       set.GetId(),
+			BuildIds: append(buildIDs, buildID),
+		}
+		if op.AddNewCompatibleBuildId.GetMakeSetDefault() {
+			makeDefaultVersionSet(result, index)
+		}
+
+	case *matchingservice.UpdateWorkerBuildIdCompatibilityRequest_PromoteSetByBuildId:
+		index, err := findExistingVersionSet(result, op.PromoteSetByBuildId)
+		if err != nil {
+			return nil, err
+		}
+		makeDefaultVersionSet(result, index)
+
+	default:
+		return nil, errMissingVersionOp
+	}
+
+	if len(result.VersionSets) > maxVersionSets {
+		return nil, errTooManyVersionSets
+	}
+	numBuildIDs := 0
+	for _, set := range result.VersionSets {
+		numBuildIDs += len(set.GetBuildIds())
+	}
+	if numBuildIDs > maxBuildIDs {
+		return nil, errTooManyBuildIDs
+	}
+	return result, nil
+}
+
+func validateNewBuildID(data *taskqueuegenpb.VersioningData, buildID string) error {
+	if buildID == "" {
+		return errEmptyBuildID
+	}
+	if findVersionSet(data, buildID) >= 0 {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Build id %v already exists.", buildID))
+	}
+	return nil
+}
+
+func findExistingVersionSet(data *taskqueuegenpb.VersioningData, buildID string) (int, error) {
+	if buildID == "" {
+		return -1, errEmptyBuildID
+	}
+	index := findVersionSet(data, buildID)
+	if index < 0 {
+		return -1, serviceerror.NewNotFound(fmt.Sprintf("Build id %v not found.", buildID))
+	}
+	return index, nil
+}
+
+func makeDefaultVersionSet(data *taskqueuegenpb.VersioningData, index int) {
+	set := data.VersionSets[index]
+	data.VersionSets = append(data.VersionSets[:index], data.VersionSets[index+1:]...)
+	data.VersionSets = append(data.VersionSets, set)
+}
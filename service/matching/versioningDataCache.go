@@ -0,0 +1,119 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sync"
+	"time"
+
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
+)
+
+const (
+	// versioningDataRefreshInterval is how often cached versioning data is refreshed from the root partition
+	versioningDataRefreshInterval = 10 * time.Second
+)
+
+type (
+	// versioningDataFetchFn returns the versioning data of the given root decision task queue
+	versioningDataFetchFn func(namespaceID string, taskQueue string) (*taskqueuegenpb.VersioningData, error)
+
+	// versioningDataCache caches the worker version sets of decision task queues for the partitions
+	// other than the root one. The first lookup of a task queue blocks on loading its versioning data,
+	// as routing without it could hand tasks to incompatible workers, later ones refresh it in the
+	// background while returning the cached value.
+	versioningDataCache struct {
+		sync.Mutex
+		fetch   versioningDataFetchFn
+		entries map[versioningDataKey]*versioningDataEntry
+	}
+
+	versioningDataKey struct {
+		namespaceID string
+		taskQueue   string
+	}
+
+	versioningDataEntry struct {
+		data        *taskqueuegenpb.VersioningData
+		refreshTime time.Time
+		refreshing  bool
+	}
+)
+
+func newVersioningDataCache(fetch versioningDataFetchFn) *versioningDataCache {
+	return &versioningDataCache{
+		fetch:   fetch,
+		entries: make(map[versioningDataKey]*versioningDataEntry),
+	}
+}
+
+// Get returns the versioning data of the given root decision task queue
+func (c *versioningDataCache) Get(namespaceID string, taskQueue string) (*taskqueuegenpb.VersioningData, error) {
+	key := versioningDataKey{namespaceID: namespaceID, taskQueue: taskQueue}
+
+	c.Lock()
+	entry, ok := c.entries[key]
+	if ok {
+		if !entry.refreshing && time.Since(entry.refreshTime) >= versioningDataRefreshInterval {
+			entry.refreshing = true
+			go c.refresh(key, entry)
+		}
+		data := entry.data
+		c.Unlock()
+		return data, nil
+	}
+	c.Unlock()
+
+	data, err := c.fetch(namespaceID, taskQueue)
+	if err != nil {
+		return nil, err
+	}
+	c.Put(namespaceID, taskQueue, data)
+	return data, nil
+}
+
+// Put replaces the cached versioning data of the given root decision task queue
+func (c *versioningDataCache) Put(namespaceID string, taskQueue string, data *taskqueuegenpb.VersioningData) {
+	c.Lock()
+	defer c.Unlock()
+	c.entries[versioningDataKey{namespaceID: namespaceID, taskQueue: taskQueue}] = &versioningDataEntry{
+		data:        data,
+		refreshTime: time.Now(),
+	}
+}
+
+func (c *versioningDataCache) refresh(key versioningDataKey, entry *versioningDataEntry) {
+	data, err := c.fetch(key.namespaceID, key.taskQueue)
+
+	c.Lock()
+	defer c.Unlock()
+	entry.refreshing = false
+	entry.refreshTime = time.Now()
+	if err != nil {
+		// keep the last known data, it is retried after the refresh interval
+		return
+	}
+	entry.data = data
+}
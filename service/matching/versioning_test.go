@@ -0,0 +1,127 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/temporalio/temporal/.gen/proto/matchingservice/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
+)
+
+func TestUpdateVersioningData(t *testing.T) {
+	data, err := updateVersioningData(nil, mkAddNewDefaultSetRequest("v1"), 10, 100)
+	require.NoError(t, err)
+	data, err = updateVersioningData(data, mkAddNewDefaultSetRequest("v2"), 10, 100)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1", "v2"}, buildIDsBySet(data))
+
+	// compatible build ids join the set of the existing one without changing the default set
+	updated, err := updateVersioningData(data, mkAddCompatibleRequest("v1.1", "v1", false), 10, 100)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1,v1.1", "v2"}, buildIDsBySet(updated))
+	require.Equal(t, []string{"v1", "v2"}, buildIDsBySet(data), "input must not be modified")
+
+	updated, err = updateVersioningData(updated, mkAddCompatibleRequest("v1.2", "v1.1", true), 10, 100)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v2", "v1,v1.1,v1.2"}, buildIDsBySet(updated))
+
+	updated, err = updateVersioningData(updated, &matchingservice.UpdateWorkerBuildIdCompatibilityRequest{
+		Operation: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_PromoteSetByBuildId{PromoteSetByBuildId: "v2"},
+	}, 10, 100)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1,v1.1,v1.2", "v2"}, buildIDsBySet(updated))
+
+	versionSet, ok := defaultVersionSet(updated)
+	require.True(t, ok)
+	require.Equal(t, versionSetID("v2"), versionSet)
+	versionSet, ok = lookupVersionSet(updated, "v1.2")
+	require.True(t, ok)
+	require.Equal(t, versionSetID("v1"), versionSet)
+	_, ok = lookupVersionSet(updated, "v3")
+	require.False(t, ok)
+}
+
+func TestUpdateVersioningData_Errors(t *testing.T) {
+	data, err := updateVersioningData(nil, mkAddNewDefaultSetRequest("v1"), 2, 3)
+	require.NoError(t, err)
+
+	_, err = updateVersioningData(data, mkAddNewDefaultSetRequest(""), 2, 3)
+	require.Error(t, err)
+	_, err = updateVersioningData(data, mkAddNewDefaultSetRequest("v1"), 2, 3)
+	require.Error(t, err)
+	_, err = updateVersioningData(data, mkAddCompatibleRequest("v2", "unknown", false), 2, 3)
+	require.Error(t, err)
+	_, err = updateVersioningData(data, &matchingservice.UpdateWorkerBuildIdCompatibilityRequest{}, 2, 3)
+	require.Error(t, err)
+
+	data, err = updateVersioningData(data, mkAddNewDefaultSetRequest("v2"), 2, 3)
+	require.NoError(t, err)
+	_, err = updateVersioningData(data, mkAddNewDefaultSetRequest("v3"), 2, 3)
+	require.Equal(t, errTooManyVersionSets, err)
+	data, err = updateVersioningData(data, mkAddCompatibleRequest("v2.1", "v2", false), 2, 3)
+	require.NoError(t, err)
+	_, err = updateVersioningData(data, mkAddCompatibleRequest("v2.2", "v2", false), 2, 3)
+	require.Equal(t, errTooManyBuildIDs, err)
+}
+
+func mkAddNewDefaultSetRequest(buildID string) *matchingservice.UpdateWorkerBuildIdCompatibilityRequest {
+	return &matchingservice.UpdateWorkerBuildIdCompatibilityRequest{
+		Operation: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet_{
+			AddNewBuildIdInNewDefaultSet: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet{
+				BuildId: buildID,
+			},
+		},
+	}
+}
+
+func mkAddCompatibleRequest(buildID string, existing string, makeDefault bool) *matchingservice.UpdateWorkerBuildIdCompatibilityRequest {
+	return &matchingservice.UpdateWorkerBuildIdCompatibilityRequest{
+		Operation: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewCompatibleBuildId_{
+			AddNewCompatibleBuildId: &matchingservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewCompatibleBuildId{
+				NewBuildId:                buildID,
+				ExistingCompatibleBuildId: existing,
+				MakeSetDefault:            makeDefault,
+			},
+		},
+	}
+}
+
+func buildIDsBySet(data *taskqueuegenpb.VersioningData) []string {
+	var result []string
+	for _, set := range data.GetVersionSets() {
+		ids := ""
+		for i, id := range set.GetBuildIds() {
+			if i > 0 {
+				ids += ","
+			}
+			ids += id
+		}
+		result = append(result, ids)
+	}
+	return result
+}
@@ -0,0 +1,176 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// workerRegistry keeps track of the workers polling task queues on this host, identified by the
+	// identity of their pollers. Every poll acts as a heartbeat of the worker, workers which did not
+	// poll within the retention are removed from the registry.
+	workerRegistry struct {
+		sync.Mutex
+		timeSource       clock.TimeSource
+		heartbeatTimeout dynamicconfig.DurationPropertyFn
+		retention        dynamicconfig.DurationPropertyFn
+		// namespaceID -> identity -> worker
+		workers   map[string]map[string]*registeredWorker
+		lastSweep time.Time
+	}
+
+	registeredWorker struct {
+		sdkName      string
+		sdkVersion   string
+		lastPollTime time.Time
+		taskQueues   map[workerTaskQueue]time.Time
+	}
+
+	workerTaskQueue struct {
+		name     string
+		taskType enumspb.TaskQueueType
+	}
+)
+
+func newWorkerRegistry(config *Config, timeSource clock.TimeSource) *workerRegistry {
+	return &workerRegistry{
+		timeSource:       timeSource,
+		heartbeatTimeout: config.WorkerHeartbeatTimeout,
+		retention:        config.WorkerRegistryRetention,
+		workers:          make(map[string]map[string]*registeredWorker),
+		lastSweep:        timeSource.Now(),
+	}
+}
+
+// recordPoll registers a poll of the worker with the given identity on the given task queue
+func (r *workerRegistry) recordPoll(
+	namespaceID string,
+	identity string,
+	sdkName string,
+	sdkVersion string,
+	taskQueueName string,
+	taskType enumspb.TaskQueueType,
+) {
+	if identity == "" {
+		return
+	}
+	now := r.timeSource.Now()
+
+	r.Lock()
+	defer r.Unlock()
+	r.sweepLocked(now)
+	workers, ok := r.workers[namespaceID]
+	if !ok {
+		workers = make(map[string]*registeredWorker)
+		r.workers[namespaceID] = workers
+	}
+	worker, ok := workers[identity]
+	if !ok {
+		worker = &registeredWorker{taskQueues: make(map[workerTaskQueue]time.Time)}
+		workers[identity] = worker
+	}
+	if sdkName != "" {
+		worker.sdkName = sdkName
+		worker.sdkVersion = sdkVersion
+	}
+	worker.lastPollTime = now
+	worker.taskQueues[workerTaskQueue{name: taskQueueName, taskType: taskType}] = now
+}
+
+// list returns the workers of the given namespace ordered by identity
+func (r *workerRegistry) list(namespaceID string) []*taskqueuegenpb.WorkerInfo {
+	now := r.timeSource.Now()
+	heartbeatTimeout := r.heartbeatTimeout()
+
+	r.Lock()
+	defer r.Unlock()
+	r.sweepLocked(now)
+	result := make([]*taskqueuegenpb.WorkerInfo, 0, len(r.workers[namespaceID]))
+	for identity, worker := range r.workers[namespaceID] {
+		info := &taskqueuegenpb.WorkerInfo{
+			Identity:     identity,
+			SdkName:      worker.sdkName,
+			SdkVersion:   worker.sdkVersion,
+			LastPollTime: worker.lastPollTime.UnixNano(),
+			Alive:        now.Sub(worker.lastPollTime) < heartbeatTimeout,
+		}
+		for taskQueue, lastPollTime := range worker.taskQueues {
+			info.TaskQueues = append(info.TaskQueues, &taskqueuegenpb.WorkerTaskQueue{
+				Name:          taskQueue.name,
+				TaskQueueType: taskQueue.taskType,
+				LastPollTime:  lastPollTime.UnixNano(),
+			})
+		}
+		sortWorkerTaskQueues(info.TaskQueues)
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetIdentity() < result[j].GetIdentity()
+	})
+	return result
+}
+
+// sweepLocked removes the workers and task queues which were not polled within the retention,
+// at most once per retention period
+func (r *workerRegistry) sweepLocked(now time.Time) {
+	retention := r.retention()
+	if now.Sub(r.lastSweep) < retention {
+		return
+	}
+	r.lastSweep = now
+	for namespaceID, workers := range r.workers {
+		for identity, worker := range workers {
+			for taskQueue, lastPollTime := range worker.taskQueues {
+				if now.Sub(lastPollTime) >= retention {
+					delete(worker.taskQueues, taskQueue)
+				}
+			}
+			if len(worker.taskQueues) == 0 {
+				delete(workers, identity)
+			}
+		}
+		if len(workers) == 0 {
+			delete(r.workers, namespaceID)
+		}
+	}
+}
+
+func sortWorkerTaskQueues(taskQueues []*taskqueuegenpb.WorkerTaskQueue) {
+	sort.Slice(taskQueues, func(i, j int) bool {
+		if taskQueues[i].GetName() != taskQueues[j].GetName() {
+			return taskQueues[i].GetName() < taskQueues[j].GetName()
+		}
+		return taskQueues[i].GetTaskQueueType() < taskQueues[j].GetTaskQueueType()
+	})
+}
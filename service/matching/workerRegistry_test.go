@@ -0,0 +1,76 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/temporal-proto/enums/v1"
+
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+func TestWorkerRegistry(t *testing.T) {
+	start := time.Unix(1000, 0)
+	timeSource := clock.NewEventTimeSource().Update(start)
+	config := defaultTestConfig()
+	config.WorkerHeartbeatTimeout = dynamicconfig.GetDurationPropertyFn(2 * time.Minute)
+	config.WorkerRegistryRetention = dynamicconfig.GetDurationPropertyFn(10 * time.Minute)
+	registry := newWorkerRegistry(config, timeSource)
+
+	registry.recordPoll("ns", "worker-b", "temporal-go", "0.26.0", "tq1", enumspb.TASK_QUEUE_TYPE_DECISION)
+	registry.recordPoll("ns", "worker-a", "temporal-java", "0.22.0", "tq1", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	registry.recordPoll("ns", "", "temporal-go", "0.26.0", "tq1", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+	registry.recordPoll("other-ns", "worker-c", "temporal-go", "0.26.0", "tq1", enumspb.TASK_QUEUE_TYPE_DECISION)
+	timeSource.Update(start.Add(3 * time.Minute))
+	registry.recordPoll("ns", "worker-b", "temporal-go", "0.26.0", "tq2", enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+
+	workers := registry.list("ns")
+	require.Len(t, workers, 2)
+	require.Equal(t, "worker-a", workers[0].GetIdentity())
+	require.Equal(t, "temporal-java", workers[0].GetSdkName())
+	require.Equal(t, "0.22.0", workers[0].GetSdkVersion())
+	require.False(t, workers[0].GetAlive())
+	require.Equal(t, start.UnixNano(), workers[0].GetLastPollTime())
+	require.Equal(t, "worker-b", workers[1].GetIdentity())
+	require.True(t, workers[1].GetAlive())
+	require.Len(t, workers[1].GetTaskQueues(), 2)
+	require.Equal(t, "tq1", workers[1].GetTaskQueues()[0].GetName())
+	require.Equal(t, start.UnixNano(), workers[1].GetTaskQueues()[0].GetLastPollTime())
+	require.Equal(t, "tq2", workers[1].GetTaskQueues()[1].GetName())
+
+	// workers and task queues which were not polled within the retention are removed
+	timeSource.Update(start.Add(12 * time.Minute))
+	workers = registry.list("ns")
+	require.Len(t, workers, 1)
+	require.Equal(t, "worker-b", workers[0].GetIdentity())
+	require.False(t, workers[0].GetAlive())
+	require.Len(t, workers[0].GetTaskQueues(), 1)
+	require.Equal(t, "tq2", workers[0].GetTaskQueues()[0].GetName())
+	require.Empty(t, registry.list("other-ns"))
+}
@@ -38,6 +38,7 @@ import (
 	"go.temporal.io/temporal/workflow"
 	"golang.org/x/time/rate"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	"github.com/temporalio/temporal/client/frontend"
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/log"
@@ -73,10 +74,12 @@ const (
 	BatchTypeCancel = "cancel"
 	// BatchTypeSignal is batch type for signaling workflows
 	BatchTypeSignal = "signal"
+	// BatchTypeUpdateMetadata is batch type for updating memo and search attributes of workflows
+	BatchTypeUpdateMetadata = "update_metadata"
 )
 
 // AllBatchTypes is the batch types we supported
-var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal}
+var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal, BatchTypeUpdateMetadata}
 
 type (
 	// TerminateParams is the parameters for terminating workflow
@@ -101,6 +104,12 @@ type (
 		Input      *commonpb.Payloads
 	}
 
+	// UpdateMetadataParams is the parameters for updating memo and search attributes of workflow
+	UpdateMetadataParams struct {
+		Memo             *commonpb.Memo
+		SearchAttributes *commonpb.SearchAttributes
+	}
+
 	// BatchParams is the parameters for batch operation workflow
 	BatchParams struct {
 		// Target namespace to execute batch operation
@@ -119,6 +128,8 @@ type (
 		CancelParams CancelParams
 		// SignalParams is params only for BatchTypeSignal
 		SignalParams SignalParams
+		// UpdateMetadataParams is params only for BatchTypeUpdateMetadata
+		UpdateMetadataParams UpdateMetadataParams
 		// RPS of processing. Default to DefaultRPS
 		// TODO we will implement smarter way than this static rate limiter: https://github.com/temporalio/temporal/issues/2138
 		RPS int
@@ -195,6 +206,12 @@ func validateParams(params BatchParams) error {
 			return fmt.Errorf("must provide signal name")
 		}
 		return nil
+	case BatchTypeUpdateMetadata:
+		if len(params.UpdateMetadataParams.Memo.GetFields()) == 0 &&
+			len(params.UpdateMetadataParams.SearchAttributes.GetIndexedFields()) == 0 {
+			return fmt.Errorf("must provide memo or search attributes")
+		}
+		return nil
 	case BatchTypeCancel:
 		fallthrough
 	case BatchTypeTerminate:
@@ -391,6 +408,22 @@ func startTaskProcessor(
 						})
 						return err
 					})
+			case BatchTypeUpdateMetadata:
+				adminClient := batcher.clientBean.GetRemoteAdminClient(batcher.cfg.ClusterMetadata.GetCurrentClusterName())
+				err = processTask(ctx, limiter, task, batchParams, client, convert.BoolPtr(false),
+					func(workflowID, runID string) error {
+						_, err := adminClient.UpdateWorkflowExecutionMetadata(ctx, &adminservice.UpdateWorkflowExecutionMetadataRequest{
+							Namespace: batchParams.Namespace,
+							Execution: &commonpb.WorkflowExecution{
+								WorkflowId: workflowID,
+								RunId:      runID,
+							},
+							Memo:             batchParams.UpdateMetadataParams.Memo,
+							SearchAttributes: batchParams.UpdateMetadataParams.SearchAttributes,
+							Identity:         BatchWFTypeName,
+						})
+						return err
+					})
 			}
 			if err != nil {
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorFailures)
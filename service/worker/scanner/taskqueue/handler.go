@@ -109,6 +109,9 @@ func (s *Scavenger) tryDeleteTaskQueue(key *p.TaskQueueKey, state *taskQueueStat
 	if strings.HasPrefix(key.Name, scannerTaskQueuePrefix) {
 		return // avoid deleting our own task queue
 	}
+	if state.hasSubqueues {
+		return // the subqueues of the task queue can only be found through it
+	}
 
 	lastUpdated, _ := types.TimestampFromProto(&state.lastUpdated)
 	delta := time.Now().Sub(lastUpdated)
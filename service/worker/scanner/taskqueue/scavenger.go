@@ -55,6 +55,8 @@ type (
 	taskQueueState struct {
 		rangeID     int64
 		lastUpdated types.Timestamp
+		// set when tasks of the task queue are persisted in subqueues, which are found through it
+		hasSubqueues bool
 	}
 
 	stats struct {
@@ -208,8 +210,9 @@ func (s *Scavenger) newTask(info *p.PersistedTaskQueueInfo) executor.Task {
 			TaskType:    info.Data.TaskType,
 		},
 		taskQueueState: taskQueueState{
-			rangeID:     info.RangeID,
-			lastUpdated: *info.Data.LastUpdated,
+			rangeID:      info.RangeID,
+			lastUpdated:  *info.Data.LastUpdated,
+			hasSubqueues: len(info.Data.GetSubqueues()) > 0,
 		},
 		scvg: s,
 	}
@@ -28,6 +28,8 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gocql/gocql"
@@ -72,6 +74,9 @@ const (
 	readSchemaVersionCQL        = `SELECT curr_version from schema_version where keyspace_name=?`
 	listTablesCQL               = `SELECT table_name from system_schema.tables where keyspace_name=?`
 	listTypesCQL                = `SELECT type_name from system_schema.types where keyspace_name=?`
+	listColumnsCQL              = `SELECT table_name, column_name, type, kind, position, clustering_order from system_schema.columns where keyspace_name=?`
+	listIndexesCQL              = `SELECT table_name, index_name, kind, options from system_schema.indexes where keyspace_name=?`
+	listTypeFieldsCQL           = `SELECT type_name, field_names, field_types from system_schema.types where keyspace_name=?`
 	writeSchemaVersionCQL       = `INSERT into schema_version(keyspace_name, creation_time, curr_version, min_compatible_version) VALUES (?,?,?,?)`
 	writeSchemaUpdateHistoryCQL = `INSERT into schema_update_history(year, month, update_time, old_version, new_version, manifest_md5, description) VALUES(?,?,?,?,?,?,?)`
 
@@ -95,6 +100,7 @@ const (
 )
 
 var _ schema.DB = (*cqlClient)(nil)
+var _ schema.SchemaDescriber = (*cqlClient)(nil)
 
 // NewCassandraCluster return gocql clusterConfig
 func NewCassandraCluster(cfg *config.Cassandra, timeoutSeconds int) (*gocql.ClusterConfig, error) {
@@ -244,6 +250,93 @@ func (client *cqlClient) listTypes() ([]string, error) {
 	return names, nil
 }
 
+// DescribeSchema returns the tables, columns, indexes and types of the Keyspace
+func (client *cqlClient) DescribeSchema() (*schema.SchemaDescription, error) {
+	result := &schema.SchemaDescription{
+		Tables: make(map[string]*schema.TableDescription),
+		Types:  make(map[string]*schema.TypeDescription),
+	}
+	table := func(name string) *schema.TableDescription {
+		t, ok := result.Tables[name]
+		if !ok {
+			t = &schema.TableDescription{
+				Columns: make(map[string]string),
+				Indexes: make(map[string]string),
+			}
+			result.Tables[name] = t
+		}
+		return t
+	}
+
+	type keyColumn struct {
+		name     string
+		position int
+		order    string
+	}
+	partitionKeys := make(map[string][]keyColumn)
+	clusteringKeys := make(map[string][]keyColumn)
+
+	iter := client.session.Query(listColumnsCQL, client.clusterConfig.Keyspace).Iter()
+	var tableName, columnName, columnType, kind, order string
+	var position int
+	for iter.Scan(&tableName, &columnName, &columnType, &kind, &position, &order) {
+		table(tableName).Columns[columnName] = columnType
+		switch kind {
+		case "partition_key":
+			partitionKeys[tableName] = append(partitionKeys[tableName], keyColumn{name: columnName, position: position})
+		case "clustering":
+			clusteringKeys[tableName] = append(clusteringKeys[tableName], keyColumn{name: columnName, position: position, order: order})
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	for name, t := range result.Tables {
+		partition := partitionKeys[name]
+		sort.Slice(partition, func(i, j int) bool { return partition[i].position < partition[j].position })
+		clustering := clusteringKeys[name]
+		sort.Slice(clustering, func(i, j int) bool { return clustering[i].position < clustering[j].position })
+
+		var partitionNames []string
+		for _, c := range partition {
+			partitionNames = append(partitionNames, c.name)
+		}
+		key := []string{"(" + strings.Join(partitionNames, ", ") + ")"}
+		for _, c := range clustering {
+			key = append(key, c.name+" "+c.order)
+		}
+		t.PrimaryKey = "(" + strings.Join(key, ", ") + ")"
+	}
+
+	iter = client.session.Query(listIndexesCQL, client.clusterConfig.Keyspace).Iter()
+	var indexName string
+	var options map[string]string
+	for iter.Scan(&tableName, &indexName, &kind, &options) {
+		table(tableName).Indexes[indexName] = fmt.Sprintf("%v(%v)", kind, options["target"])
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	iter = client.session.Query(listTypeFieldsCQL, client.clusterConfig.Keyspace).Iter()
+	var typeName string
+	var fieldNames, fieldTypes []string
+	for iter.Scan(&typeName, &fieldNames, &fieldTypes) {
+		fields := make(map[string]string, len(fieldNames))
+		for i, f := range fieldNames {
+			if i < len(fieldTypes) {
+				fields[f] = fieldTypes[i]
+			}
+		}
+		result.Types[typeName] = &schema.TypeDescription{Fields: fields}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // dropTable drops a given table from the Keyspace
 func (client *cqlClient) dropTable(name string) error {
 	return client.Exec(fmt.Sprintf("DROP TABLE %v", name))
@@ -137,6 +137,36 @@ func updateSchema(cli *cli.Context) error {
 	return nil
 }
 
+// verifySchema executes the verifySchemaTask
+// using the given command line args as input
+func verifySchema(cli *cli.Context) error {
+	config, err := newCQLClientConfig(cli)
+	if err != nil {
+		return handleErr(schema.NewConfigError(err.Error()))
+	}
+	expectedConfig := *config
+	expectedConfig.Keyspace = schema.VerifyDBName
+	if err := doCreateKeyspace(expectedConfig, expectedConfig.Keyspace); err != nil {
+		return handleErr(fmt.Errorf("error creating verify Keyspace: %v", err))
+	}
+	defer doDropKeyspace(expectedConfig, expectedConfig.Keyspace)
+
+	client, err := newCQLClient(config)
+	if err != nil {
+		return handleErr(err)
+	}
+	defer client.Close()
+	expectedClient, err := newCQLClient(&expectedConfig)
+	if err != nil {
+		return handleErr(err)
+	}
+	defer expectedClient.Close()
+	if err := schema.Verify(cli, client, expectedClient); err != nil {
+		return handleErr(err)
+	}
+	return nil
+}
+
 // createKeyspace creates a cassandra Keyspace
 func createKeyspace(cli *cli.Context) error {
 	config, err := newCQLClientConfig(cli)
@@ -250,6 +250,51 @@ func newAdminShardManagementCommands() []cli.Command {
 				AdminRemoveTask(c)
 			},
 		},
+		{
+			Name:    "reshard",
+			Aliases: []string{"rs"},
+			Usage: "Change the number of history shards offline. Only cassandra is supported and online resharding is not: " +
+				"the final cut-over requires stopping the history service",
+			Subcommands: newAdminReshardCommands(),
+		},
+	}
+}
+
+func newAdminReshardCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "plan",
+			Aliases: []string{"p"},
+			Usage:   "report how executions and tasks of each shard would be split over the target number of shards",
+			Flags:   getReshardFlags(),
+			Action: func(c *cli.Context) {
+				AdminReshardPlan(c)
+			},
+		},
+		{
+			Name:    "execute",
+			Aliases: []string{"e"},
+			Usage: "copy executions and tasks moving to a new shard into that shard, safe to run against a live cluster and to repeat. " +
+				"The last run has to happen while the history service is stopped, right before verify --commit",
+			Flags: getReshardFlags(),
+			Action: func(c *cli.Context) {
+				AdminReshardExecute(c)
+			},
+		},
+		{
+			Name:    "verify",
+			Aliases: []string{"v"},
+			Usage:   "verify that new shards contain exactly the executions and tasks moving to them",
+			Flags: append(getReshardFlags(),
+				cli.BoolFlag{
+					Name: FlagCommit,
+					Usage: "once verified, update the number of history shards in cluster metadata and delete moved rows from source shards. " +
+						"The history service has to be stopped and must be started with the new numHistoryShards afterwards",
+				}),
+			Action: func(c *cli.Context) {
+				AdminReshardVerify(c)
+			},
+		},
 	}
 }
 
@@ -753,6 +798,68 @@ func newAdminTaskQueueCommands() []cli.Command {
 				AdminDescribeTaskQueue(c)
 			},
 		},
+		{
+			Name:  "stats",
+			Usage: "Describe approximate backlog and throughput statistics of taskqueue",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskQueueTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskQueue type [decision|activity]",
+				},
+				cli.BoolFlag{
+					Name:  FlagIncludePartitions,
+					Usage: "Also show statistics of every partition of the taskqueue",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeTaskQueueStats(c)
+			},
+		},
+		{
+			Name:  "pause",
+			Usage: "Stop dispatching tasks of taskqueue while still accepting new ones",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskQueueTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskQueue type [decision|activity]",
+				},
+				cli.BoolFlag{
+					Name:  FlagDrain,
+					Usage: "Reject new tasks and keep dispatching the backlog instead",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminPauseTaskQueue(c)
+			},
+		},
+		{
+			Name:  "resume",
+			Usage: "Resume dispatching and accepting tasks of a paused or draining taskqueue",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskQueueTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskQueue type [decision|activity]",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminResumeTaskQueue(c)
+			},
+		},
 		{
 			Name:  "list_tasks",
 			Usage: "List tasks of a taskqueue",
@@ -832,7 +939,7 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -862,7 +969,7 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -884,7 +991,7 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -29,11 +29,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/urfave/cli"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
-
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/codec"
@@ -66,6 +67,7 @@ func AdminGetDLQMessages(c *cli.Context) {
 	paginationFunc := func(paginationToken []byte) ([]interface{}, []byte, error) {
 		resp, err := adminClient.ReadDLQMessages(ctx, &adminservice.ReadDLQMessagesRequest{
 			Type:                  toQueueType(dlqType),
+			ShardId:               int32(c.Int(FlagShardID)),
 			InclusiveEndMessageId: lastMessageID,
 			MaximumPageSize:       defaultPageSize,
 			NextPageToken:         paginationToken,
@@ -77,6 +79,12 @@ func AdminGetDLQMessages(c *cli.Context) {
 		for _, item := range resp.GetReplicationTasks() {
 			paginateItems = append(paginateItems, item)
 		}
+		for _, item := range resp.GetTransferTasks() {
+			paginateItems = append(paginateItems, item)
+		}
+		for _, item := range resp.GetTimerTasks() {
+			paginateItems = append(paginateItems, item)
+		}
 		return paginateItems, resp.GetNextPageToken(), err
 	}
 
@@ -88,14 +96,24 @@ func AdminGetDLQMessages(c *cli.Context) {
 			ErrorAndExit(fmt.Sprintf("fail to read dlq message. Last read message id: %v", lastReadMessageID), err)
 		}
 
-		task := item.(*replicationgenpb.ReplicationTask)
+		var task proto.Message
+		switch item := item.(type) {
+		case *replicationgenpb.ReplicationTask:
+			task = item
+			lastReadMessageID = int(item.SourceTaskId)
+		case *persistenceblobs.TransferTaskInfo:
+			task = item
+			lastReadMessageID = int(item.GetTaskId())
+		case *persistenceblobs.TimerTaskInfo:
+			task = item
+			lastReadMessageID = int(item.GetTaskId())
+		}
 		encoder := codec.NewJSONPBIndentEncoder(" ")
 		taskStr, err := encoder.Encode(task)
 		if err != nil {
 			ErrorAndExit(fmt.Sprintf("fail to encode dlq message. Last read message id: %v", lastReadMessageID), err)
 		}
 
-		lastReadMessageID = int(task.SourceTaskId)
 		remainingMessageCount--
 		_, err = outputFile.WriteString(fmt.Sprintf("%v\n", string(taskStr)))
 		if err != nil {
@@ -121,6 +139,7 @@ func AdminPurgeDLQMessages(c *cli.Context) {
 	adminClient := cFactory.AdminClient(c)
 	if _, err := adminClient.PurgeDLQMessages(ctx, &adminservice.PurgeDLQMessagesRequest{
 		Type:                  toQueueType(dlqType),
+		ShardId:               int32(c.Int(FlagShardID)),
 		InclusiveEndMessageId: lastMessageID,
 	}); err != nil {
 		ErrorAndExit("Failed to purge dlq", nil)
@@ -145,6 +164,7 @@ func AdminMergeDLQMessages(c *cli.Context) {
 	adminClient := cFactory.AdminClient(c)
 	request := &adminservice.MergeDLQMessagesRequest{
 		Type:                  toQueueType(dlqType),
+		ShardId:               int32(c.Int(FlagShardID)),
 		InclusiveEndMessageId: lastMessageID,
 		MaximumPageSize:       defaultPageSize,
 	}
@@ -169,6 +189,10 @@ func toQueueType(dlqType string) enumsgenpb.DeadLetterQueueType {
 		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_NAMESPACE
 	case "history":
 		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_REPLICATION
+	case "transfer":
+		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TRANSFER
+	case "timer":
+		return enumsgenpb.DEAD_LETTER_QUEUE_TYPE_TIMER
 	default:
 		ErrorAndExit("The queue type is not supported.", fmt.Errorf("the queue type is not supported. Type: %v", dlqType))
 	}
@@ -0,0 +1,777 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/gogo/protobuf/proto"
+	"github.com/urfave/cli"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/persistence"
+	cassp "github.com/temporalio/temporal/common/persistence/cassandra"
+	"github.com/temporalio/temporal/common/persistence/serialization"
+	"github.com/temporalio/temporal/common/quotas"
+)
+
+type (
+	// ReshardShardReport is the report of planning, executing or verifying the resharding of a single shard
+	ReshardShardReport struct {
+		ShardID         int
+		TotalDBRequests int64
+		Rows            ReshardRowCounts
+		TargetShards    map[int]int64
+		Failure         *ShardScanReportFailure
+	}
+
+	// ReshardRowCounts breaks down the rows of the executions table handled by resharding
+	ReshardRowCounts struct {
+		TotalRowsCount        int64
+		MovedExecutions       int64
+		MovedTransferTasks    int64
+		MovedTimerTasks       int64
+		MovedReplicationTasks int64
+		MovedDLQTasks         int64
+		CopiedRows            int64
+		MissingRows           int64
+		MismatchedRows        int64
+		OrphanedRows          int64
+		DeletedRows           int64
+		RowCheckFailures      int64
+	}
+
+	// ReshardProgressReport contains metadata about the resharding of all shards which have been finished
+	// This is periodically printed to stdout
+	ReshardProgressReport struct {
+		NumberOfShardsFinished int
+		NumberOfShardFailures  int64
+		Rows                   ReshardRowCounts
+		Rates                  Rates
+	}
+
+	reshardShardFn func(shardID int) *ReshardShardReport
+
+	// reshardContext holds the state shared by all shards of a resharding command
+	reshardContext struct {
+		session             *gocql.Session
+		limiter             *quotas.DynamicRateLimiter
+		serializer          persistence.PayloadSerializer
+		currentShardCount   int
+		targetShardCount    int
+		persistedShardCount int
+		pageSize            int
+	}
+
+	// reshardRow is the primary key of a row of the executions table, along with the task blobs needed to route it
+	reshardRow struct {
+		rowType             int
+		namespaceID         string
+		workflowID          string
+		runID               string
+		visibilityTimestamp time.Time
+		taskID              int64
+		transfer            []byte
+		transferEncoding    string
+		timer               []byte
+		timerEncoding       string
+		replication         []byte
+		replicationEncoding string
+	}
+)
+
+// row types of the executions table, see common/persistence/cassandra
+const (
+	reshardRowTypeShard = iota
+	reshardRowTypeExecution
+	reshardRowTypeTransferTask
+	reshardRowTypeTimerTask
+	reshardRowTypeReplicationTask
+	reshardRowTypeDLQ
+	reshardRowTypeTransferDLQ
+	reshardRowTypeTimerDLQ
+)
+
+const (
+	templateReshardListRowsQuery = `SELECT type, namespace_id, workflow_id, run_id, visibility_ts, task_id, ` +
+		`transfer, transfer_encoding, timer, timer_encoding, replication, replication_encoding ` +
+		`FROM executions ` +
+		`WHERE shard_id = ?`
+
+	templateReshardRowKeyCondition = `WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and namespace_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ?`
+
+	templateReshardGetRowJSONQuery = `SELECT JSON * FROM executions ` + templateReshardRowKeyCondition
+
+	templateReshardInsertRowJSONQuery = `INSERT INTO executions JSON ?`
+
+	templateReshardDeleteRowQuery = `DELETE FROM executions ` + templateReshardRowKeyCondition
+
+	templateReshardGetClusterMetadataQuery = `SELECT immutable_data, immutable_data_encoding FROM cluster_metadata ` +
+		`WHERE metadata_partition = ?`
+
+	templateReshardUpdateClusterMetadataQuery = `UPDATE cluster_metadata ` +
+		`SET immutable_data = ?, immutable_data_encoding = ? ` +
+		`WHERE metadata_partition = ? ` +
+		`IF immutable_data = ?`
+
+	reshardClusterMetadataPartition = 0
+	reshardShardIDColumn            = "shard_id"
+)
+
+// AdminReshardPlan reports how the executions and tasks of each shard would be split over the target number of shards.
+// It only reads from the database and is safe to run against a live cluster.
+func AdminReshardPlan(c *cli.Context) {
+	rc := newReshardContext(c)
+	defer rc.session.Close()
+	if rc.persistedShardCount != rc.currentShardCount {
+		ErrorAndExit(fmt.Sprintf("cluster metadata has %v history shards, expected %v", rc.persistedShardCount, rc.currentShardCount), nil)
+	}
+
+	runReshard(c, rc, rc.planShard)
+}
+
+// AdminReshardExecute copies the executions, transfer, timer and replication tasks and the dlq tasks which move
+// to a new shard into that shard, creating its shard info from the shard they are split from. The source shards
+// keep serving traffic, so it is safe to run against a live cluster and can be repeated: every run re-syncs the
+// new shards. Resharding is not online though, the last run has to happen while the history service is stopped,
+// right before verify --commit.
+func AdminReshardExecute(c *cli.Context) {
+	rc := newReshardContext(c)
+	defer rc.session.Close()
+	if rc.persistedShardCount != rc.currentShardCount {
+		ErrorAndExit(fmt.Sprintf("cluster metadata has %v history shards, resharding was already committed or the current number of shards is wrong", rc.persistedShardCount), nil)
+	}
+
+	prompt(fmt.Sprintf("Copy executions and tasks of shards [%v, %v) into %v shards? (Y/N)",
+		c.Int(FlagLowerShardBound), getUpperShardBound(c, rc), rc.targetShardCount), c.GlobalBool(FlagAutoConfirm))
+	runReshard(c, rc, rc.executeShard)
+}
+
+// AdminReshardVerify verifies that the new shards contain exactly the rows which are moving to them.
+// With commit, once all shards are verified, it updates the number of history shards in the cluster metadata
+// and deletes the moved rows from the source shards. The history service has to be stopped while committing
+// and must be started with the new numHistoryShards afterwards.
+func AdminReshardVerify(c *cli.Context) {
+	rc := newReshardContext(c)
+	defer rc.session.Close()
+	commit := c.Bool(FlagCommit)
+
+	switch rc.persistedShardCount {
+	case rc.currentShardCount:
+		progressReport := runReshard(c, rc, rc.verifyShard)
+		if !commit {
+			return
+		}
+		if c.Int(FlagLowerShardBound) != 0 || getUpperShardBound(c, rc) != rc.currentShardCount {
+			ErrorAndExit("commit requires verifying all shards", nil)
+		}
+		if progressReport.NumberOfShardFailures != 0 ||
+			progressReport.Rows.MissingRows != 0 ||
+			progressReport.Rows.MismatchedRows != 0 ||
+			progressReport.Rows.OrphanedRows != 0 ||
+			progressReport.Rows.RowCheckFailures != 0 {
+			ErrorAndExit("verification failed, run execute again before committing", nil)
+		}
+		prompt(fmt.Sprintf("Change the number of history shards from %v to %v? The history service must be stopped. (Y/N)",
+			rc.currentShardCount, rc.targetShardCount), c.GlobalBool(FlagAutoConfirm))
+		rc.commitShardCount()
+		fmt.Println("number of history shards updated, deleting moved rows from source shards")
+		runReshard(c, rc, rc.cleanupShard)
+
+	case rc.targetShardCount:
+		// resharding is already committed, only the moved rows left in the source shards remain to be handled
+		if commit {
+			runReshard(c, rc, rc.cleanupShard)
+		} else {
+			runReshard(c, rc, rc.planShard)
+		}
+
+	default:
+		ErrorAndExit(fmt.Sprintf("cluster metadata has %v history shards, expected %v or %v", rc.persistedShardCount, rc.currentShardCount, rc.targetShardCount), nil)
+	}
+}
+
+func newReshardContext(c *cli.Context) *reshardContext {
+	currentShardCount := getRequiredIntOption(c, FlagCurrentNumberOfShards)
+	targetShardCount := getRequiredIntOption(c, FlagTargetNumberOfShards)
+	if currentShardCount <= 0 || targetShardCount <= currentShardCount {
+		ErrorAndExit("target number of shards must be greater than current number of shards", nil)
+	}
+	if targetShardCount%currentShardCount != 0 {
+		// shard = hash % count, so each new shard is split from exactly one current shard
+		// only if the target count is a multiple of the current count
+		ErrorAndExit("target number of shards must be a multiple of current number of shards", nil)
+	}
+
+	rc := &reshardContext{
+		session:           connectToCassandra(c),
+		limiter:           getRateLimiter(c.Int(FlagStartingRPS), c.Int(FlagRPS), c.Int(FlagRPSScaleUpSeconds)),
+		serializer:        persistence.NewPayloadSerializer(),
+		currentShardCount: currentShardCount,
+		targetShardCount:  targetShardCount,
+		pageSize:          c.Int(FlagPageSize),
+	}
+	icm, _, err := rc.getClusterMetadata()
+	if err != nil {
+		ErrorAndExit("failed to read cluster metadata", err)
+	}
+	rc.persistedShardCount = int(icm.GetHistoryShardCount())
+	return rc
+}
+
+func getUpperShardBound(c *cli.Context, rc *reshardContext) int {
+	if c.IsSet(FlagUpperShardBound) {
+		return c.Int(FlagUpperShardBound)
+	}
+	return rc.currentShardCount
+}
+
+func runReshard(c *cli.Context, rc *reshardContext, fn reshardShardFn) *ReshardProgressReport {
+	lowerShardBound := c.Int(FlagLowerShardBound)
+	upperShardBound := getUpperShardBound(c, rc)
+	if lowerShardBound < 0 || upperShardBound > rc.currentShardCount || lowerShardBound >= upperShardBound {
+		ErrorAndExit(fmt.Sprintf("shard bounds must be within [0, %v)", rc.currentShardCount), nil)
+	}
+	numShards := upperShardBound - lowerShardBound
+	workerCount := c.Int(FlagConcurrency)
+	reportRate := c.Int(FlagReportRate)
+	if numShards < workerCount {
+		workerCount = numShards
+	}
+
+	reports := make(chan *ReshardShardReport)
+	for i := 0; i < workerCount; i++ {
+		go func(workerIdx int) {
+			for shardID := lowerShardBound; shardID < upperShardBound; shardID++ {
+				if shardID%workerCount == workerIdx {
+					reports <- fn(shardID)
+				}
+			}
+		}(i)
+	}
+
+	startTime := time.Now()
+	progressReport := &ReshardProgressReport{}
+	for i := 0; i < numShards; i++ {
+		report := <-reports
+		includeShardInReshardProgressReport(report, progressReport, startTime)
+		if report.Failure != nil || len(report.TargetShards) != 0 {
+			printReshardReport(report)
+		}
+		if i%reportRate == 0 || i == numShards-1 {
+			printReshardReport(progressReport)
+		}
+	}
+	return progressReport
+}
+
+func (rc *reshardContext) planShard(shardID int) *ReshardShardReport {
+	report := &ReshardShardReport{
+		ShardID:      shardID,
+		TargetShards: make(map[int]int64),
+	}
+	err := rc.forEachRow(shardID, report, func(row *reshardRow) {
+		targetShardID, ok := rc.targetShardForRow(row, report)
+		if !ok || targetShardID == shardID {
+			return
+		}
+		countMovedRow(row, report)
+		if row.rowType == reshardRowTypeExecution {
+			report.TargetShards[targetShardID]++
+		}
+	})
+	if err != nil {
+		report.Failure = &ShardScanReportFailure{
+			Note:    "failed to list rows of shard",
+			Details: err.Error(),
+		}
+	}
+	return report
+}
+
+func (rc *reshardContext) executeShard(shardID int) *ReshardShardReport {
+	report := &ReshardShardReport{
+		ShardID: shardID,
+	}
+	if err := rc.createTargetShards(shardID, report); err != nil {
+		report.Failure = &ShardScanReportFailure{
+			Note:    "failed to create target shards",
+			Details: err.Error(),
+		}
+		return report
+	}
+
+	err := rc.forEachRow(shardID, report, func(row *reshardRow) {
+		targetShardID, ok := rc.targetShardForRow(row, report)
+		if !ok || targetShardID == shardID {
+			return
+		}
+		countMovedRow(row, report)
+		if err := rc.copyRow(row, shardID, targetShardID, report); err != nil {
+			report.Rows.RowCheckFailures++
+			return
+		}
+		report.Rows.CopiedRows++
+	})
+	if err != nil {
+		report.Failure = &ShardScanReportFailure{
+			Note:    "failed to copy rows of shard",
+			Details: err.Error(),
+		}
+		return report
+	}
+
+	// rows deleted from the source shard since the last run, e.g. completed tasks, have to go from the target shard too
+	for _, targetShardID := range rc.targetShards(shardID) {
+		err := rc.forEachRow(targetShardID, report, func(row *reshardRow) {
+			if row.rowType == reshardRowTypeShard {
+				return
+			}
+			source, err := rc.getRowJSON(shardID, row, report)
+			if err != nil {
+				report.Rows.RowCheckFailures++
+				return
+			}
+			if source != nil {
+				return
+			}
+			report.Rows.OrphanedRows++
+			if err := rc.deleteRow(targetShardID, row, report); err != nil {
+				report.Rows.RowCheckFailures++
+				return
+			}
+			report.Rows.DeletedRows++
+		})
+		if err != nil {
+			report.Failure = &ShardScanReportFailure{
+				Note:    fmt.Sprintf("failed to delete orphaned rows of target shard %v", targetShardID),
+				Details: err.Error(),
+			}
+			return report
+		}
+	}
+	return report
+}
+
+func (rc *reshardContext) verifyShard(shardID int) *ReshardShardReport {
+	report := &ReshardShardReport{
+		ShardID: shardID,
+	}
+
+	err := rc.forEachRow(shardID, report, func(row *reshardRow) {
+		targetShardID, ok := rc.targetShardForRow(row, report)
+		if !ok || targetShardID == shardID {
+			return
+		}
+		countMovedRow(row, report)
+		source, err := rc.getRowJSON(shardID, row, report)
+		if err != nil {
+			report.Rows.RowCheckFailures++
+			return
+		}
+		target, err := rc.getRowJSON(targetShardID, row, report)
+		if err != nil {
+			report.Rows.RowCheckFailures++
+			return
+		}
+		switch {
+		case source == nil:
+			// row was deleted from the source shard since it was listed
+		case target == nil:
+			report.Rows.MissingRows++
+		default:
+			source[reshardShardIDColumn] = target[reshardShardIDColumn]
+			if !reflect.DeepEqual(source, target) {
+				report.Rows.MismatchedRows++
+			}
+		}
+	})
+	if err != nil {
+		report.Failure = &ShardScanReportFailure{
+			Note:    "failed to verify rows of shard",
+			Details: err.Error(),
+		}
+		return report
+	}
+
+	for _, targetShardID := range rc.targetShards(shardID) {
+		if _, err := rc.getShardStore(shardID).GetShard(&persistence.GetShardRequest{ShardID: int32(targetShardID)}); err != nil {
+			report.Failure = &ShardScanReportFailure{
+				Note:    fmt.Sprintf("failed to get shard info of target shard %v", targetShardID),
+				Details: err.Error(),
+			}
+			return report
+		}
+		err := rc.forEachRow(targetShardID, report, func(row *reshardRow) {
+			if row.rowType == reshardRowTypeShard {
+				return
+			}
+			source, err := rc.getRowJSON(shardID, row, report)
+			if err != nil {
+				report.Rows.RowCheckFailures++
+				return
+			}
+			if source == nil {
+				report.Rows.OrphanedRows++
+			}
+		})
+		if err != nil {
+			report.Failure = &ShardScanReportFailure{
+				Note:    fmt.Sprintf("failed to verify rows of target shard %v", targetShardID),
+				Details: err.Error(),
+			}
+			return report
+		}
+	}
+	return report
+}
+
+// cleanupShard deletes the rows which moved to another shard from the source shard once resharding is committed
+func (rc *reshardContext) cleanupShard(shardID int) *ReshardShardReport {
+	report := &ReshardShardReport{
+		ShardID: shardID,
+	}
+	err := rc.forEachRow(shardID, report, func(row *reshardRow) {
+		targetShardID, ok := rc.targetShardForRow(row, report)
+		if !ok || targetShardID == shardID {
+			return
+		}
+		countMovedRow(row, report)
+		if err := rc.deleteRow(shardID, row, report); err != nil {
+			report.Rows.RowCheckFailures++
+			return
+		}
+		report.Rows.DeletedRows++
+	})
+	if err != nil {
+		report.Failure = &ShardScanReportFailure{
+			Note:    "failed to delete moved rows of shard",
+			Details: err.Error(),
+		}
+	}
+	return report
+}
+
+// targetShards returns the new shards which are split from the given current shard
+func (rc *reshardContext) targetShards(shardID int) []int {
+	var result []int
+	for targetShardID := shardID + rc.currentShardCount; targetShardID < rc.targetShardCount; targetShardID += rc.currentShardCount {
+		result = append(result, targetShardID)
+	}
+	return result
+}
+
+// targetShardForRow returns the shard the row belongs to after resharding. Shard rows always stay in place,
+// DLQ rows move with the workflow of their task like the tasks themselves
+func (rc *reshardContext) targetShardForRow(row *reshardRow, report *ReshardShardReport) (int, bool) {
+	var workflowID string
+	switch row.rowType {
+	case reshardRowTypeExecution:
+		workflowID = row.workflowID
+	case reshardRowTypeTransferTask, reshardRowTypeTransferDLQ:
+		info, err := serialization.TransferTaskInfoFromBlob(row.transfer, row.transferEncoding)
+		if err != nil {
+			report.Rows.RowCheckFailures++
+			return 0, false
+		}
+		workflowID = info.GetWorkflowId()
+	case reshardRowTypeTimerTask, reshardRowTypeTimerDLQ:
+		info, err := serialization.TimerTaskInfoFromBlob(row.timer, row.timerEncoding)
+		if err != nil {
+			report.Rows.RowCheckFailures++
+			return 0, false
+		}
+		workflowID = info.GetWorkflowId()
+	case reshardRowTypeReplicationTask, reshardRowTypeDLQ:
+		// replication dlq rows are keyed by source cluster, the task routes them to the shard of its workflow
+		info, err := serialization.ReplicationTaskInfoFromBlob(row.replication, row.replicationEncoding)
+		if err != nil {
+			report.Rows.RowCheckFailures++
+			return 0, false
+		}
+		workflowID = info.GetWorkflowId()
+	case reshardRowTypeShard:
+		return 0, false
+	default:
+		// refuse rows which are not known to be movable, so they fail verification instead of being left behind
+		report.Rows.RowCheckFailures++
+		return 0, false
+	}
+	return common.WorkflowIDToHistoryShard(workflowID, rc.targetShardCount), true
+}
+
+func countMovedRow(row *reshardRow, report *ReshardShardReport) {
+	switch row.rowType {
+	case reshardRowTypeExecution:
+		report.Rows.MovedExecutions++
+	case reshardRowTypeTransferTask:
+		report.Rows.MovedTransferTasks++
+	case reshardRowTypeTimerTask:
+		report.Rows.MovedTimerTasks++
+	case reshardRowTypeReplicationTask:
+		report.Rows.MovedReplicationTasks++
+	case reshardRowTypeDLQ, reshardRowTypeTransferDLQ, reshardRowTypeTimerDLQ:
+		report.Rows.MovedDLQTasks++
+	}
+}
+
+func (rc *reshardContext) forEachRow(shardID int, report *ReshardShardReport, fn func(row *reshardRow)) error {
+	var pageToken []byte
+	for {
+		preconditionForDBCall(&report.TotalDBRequests, rc.limiter)
+		iter := rc.session.Query(templateReshardListRowsQuery, shardID).PageSize(rc.pageSize).PageState(pageToken).Iter()
+		row := &reshardRow{}
+		for iter.Scan(
+			&row.rowType,
+			&row.namespaceID,
+			&row.workflowID,
+			&row.runID,
+			&row.visibilityTimestamp,
+			&row.taskID,
+			&row.transfer,
+			&row.transferEncoding,
+			&row.timer,
+			&row.timerEncoding,
+			&row.replication,
+			&row.replicationEncoding,
+		) {
+			report.Rows.TotalRowsCount++
+			fn(row)
+			row = &reshardRow{}
+		}
+		pageToken = iter.PageState()
+		if err := iter.Close(); err != nil {
+			return err
+		}
+		if len(pageToken) == 0 {
+			return nil
+		}
+	}
+}
+
+// getRowJSON returns the full row with the primary key of the given row from the given shard, nil if it does not exist
+func (rc *reshardContext) getRowJSON(shardID int, row *reshardRow, report *ReshardShardReport) (map[string]interface{}, error) {
+	preconditionForDBCall(&report.TotalDBRequests, rc.limiter)
+	var data string
+	err := rc.session.Query(templateReshardGetRowJSONQuery,
+		shardID,
+		row.rowType,
+		row.namespaceID,
+		row.workflowID,
+		row.runID,
+		row.visibilityTimestamp,
+		row.taskID,
+	).Scan(&data)
+	if err == gocql.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{})
+	// numbers are kept as is, task IDs do not fit into a float64
+	decoder := json.NewDecoder(bytes.NewBufferString(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (rc *reshardContext) copyRow(row *reshardRow, sourceShardID int, targetShardID int, report *ReshardShardReport) error {
+	data, err := rc.getRowJSON(sourceShardID, row, report)
+	if err != nil || data == nil {
+		return err
+	}
+	data[reshardShardIDColumn] = json.Number(strconv.Itoa(targetShardID))
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	preconditionForDBCall(&report.TotalDBRequests, rc.limiter)
+	return rc.session.Query(templateReshardInsertRowJSONQuery, string(payload)).Exec()
+}
+
+func (rc *reshardContext) deleteRow(shardID int, row *reshardRow, report *ReshardShardReport) error {
+	preconditionForDBCall(&report.TotalDBRequests, rc.limiter)
+	return rc.session.Query(templateReshardDeleteRowQuery,
+		shardID,
+		row.rowType,
+		row.namespaceID,
+		row.workflowID,
+		row.runID,
+		row.visibilityTimestamp,
+		row.taskID,
+	).Exec()
+}
+
+func (rc *reshardContext) getShardStore(shardID int) persistence.ShardStore {
+	execStore, err := cassp.NewWorkflowExecutionPersistence(shardID, rc.session, loggerimpl.NewNopLogger())
+	if err != nil {
+		ErrorAndExit("failed to create execution store", err)
+	}
+	return execStore.(persistence.ShardStore)
+}
+
+// createTargetShards creates the shard info of the new shards split from the given shard, copying its ack levels.
+// Range ID of a new shard is kept above the one of its source shard, so that task IDs allocated after resharding
+// never collide with the IDs of copied tasks.
+func (rc *reshardContext) createTargetShards(shardID int, report *ReshardShardReport) error {
+	shardStore := rc.getShardStore(shardID)
+	preconditionForDBCall(&report.TotalDBRequests, rc.limiter)
+	resp, err := shardStore.GetShard(&persistence.GetShardRequest{ShardID: int32(shardID)})
+	if err != nil {
+		return err
+	}
+	sourceShardInfo := resp.ShardInfo
+
+	for _, targetShardID := range rc.targetShards(shardID) {
+		preconditionForDBCall(&report.TotalDBRequests, rc.limiter)
+		resp, err := shardStore.GetShard(&persistence.GetShardRequest{ShardID: int32(targetShardID)})
+		switch err.(type) {
+		case nil:
+			if resp.ShardInfo.GetRangeId() > sourceShardInfo.GetRangeId() {
+				continue
+			}
+			targetShardInfo := proto.Clone(resp.ShardInfo).(*persistenceblobs.ShardInfo)
+			targetShardInfo.RangeId = sourceShardInfo.GetRangeId() + 1
+			preconditionForDBCall(&report.TotalDBRequests, rc.limiter)
+			if err := shardStore.UpdateShard(&persistence.UpdateShardRequest{
+				ShardInfo:       targetShardInfo,
+				PreviousRangeID: resp.ShardInfo.GetRangeId(),
+			}); err != nil {
+				return err
+			}
+
+		case *serviceerror.NotFound:
+			targetShardInfo := proto.Clone(sourceShardInfo).(*persistenceblobs.ShardInfo)
+			targetShardInfo.ShardId = int32(targetShardID)
+			targetShardInfo.RangeId = sourceShardInfo.GetRangeId() + 1
+			targetShardInfo.Owner = ""
+			targetShardInfo.StolenSinceRenew = 0
+			preconditionForDBCall(&report.TotalDBRequests, rc.limiter)
+			if err := shardStore.CreateShard(&persistence.CreateShardRequest{ShardInfo: targetShardInfo}); err != nil {
+				return err
+			}
+
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+func (rc *reshardContext) getClusterMetadata() (*persistenceblobs.ImmutableClusterMetadata, []byte, error) {
+	var data []byte
+	var encoding string
+	if err := rc.session.Query(templateReshardGetClusterMetadataQuery, reshardClusterMetadataPartition).Scan(&data, &encoding); err != nil {
+		return nil, nil, err
+	}
+	icm, err := rc.serializer.DeserializeImmutableClusterMetadata(persistence.NewDataBlob(data, common.EncodingType(encoding)))
+	if err != nil {
+		return nil, nil, err
+	}
+	return icm, data, nil
+}
+
+func (rc *reshardContext) commitShardCount() {
+	icm, previousData, err := rc.getClusterMetadata()
+	if err != nil {
+		ErrorAndExit("failed to read cluster metadata", err)
+	}
+	icm.HistoryShardCount = int32(rc.targetShardCount)
+	blob, err := rc.serializer.SerializeImmutableClusterMetadata(icm, common.EncodingTypeProto3)
+	if err != nil {
+		ErrorAndExit("failed to serialize cluster metadata", err)
+	}
+
+	previous := make(map[string]interface{})
+	applied, err := rc.session.Query(templateReshardUpdateClusterMetadataQuery,
+		blob.Data,
+		blob.Encoding.String(),
+		reshardClusterMetadataPartition,
+		previousData,
+	).MapScanCAS(previous)
+	if err != nil {
+		ErrorAndExit("failed to update cluster metadata", err)
+	}
+	if !applied {
+		ErrorAndExit("cluster metadata was updated concurrently", nil)
+	}
+}
+
+func includeShardInReshardProgressReport(report *ReshardShardReport, progressReport *ReshardProgressReport, startTime time.Time) {
+	progressReport.NumberOfShardsFinished++
+	progressReport.Rates.TotalDBRequests += report.TotalDBRequests
+	progressReport.Rates.TimeRunning = time.Now().Sub(startTime).String()
+	if report.Failure != nil {
+		progressReport.NumberOfShardFailures++
+	}
+
+	rows := &progressReport.Rows
+	rows.TotalRowsCount += report.Rows.TotalRowsCount
+	rows.MovedExecutions += report.Rows.MovedExecutions
+	rows.MovedTransferTasks += report.Rows.MovedTransferTasks
+	rows.MovedTimerTasks += report.Rows.MovedTimerTasks
+	rows.MovedReplicationTasks += report.Rows.MovedReplicationTasks
+	rows.MovedDLQTasks += report.Rows.MovedDLQTasks
+	rows.CopiedRows += report.Rows.CopiedRows
+	rows.MissingRows += report.Rows.MissingRows
+	rows.MismatchedRows += report.Rows.MismatchedRows
+	rows.OrphanedRows += report.Rows.OrphanedRows
+	rows.DeletedRows += report.Rows.DeletedRows
+	rows.RowCheckFailures += report.Rows.RowCheckFailures
+
+	pastTime := time.Now().Sub(startTime)
+	hoursPast := float64(pastTime) / float64(time.Hour)
+	progressReport.Rates.ShardsPerHour = float64(progressReport.NumberOfShardsFinished) / hoursPast
+	progressReport.Rates.ExecutionsPerHour = float64(rows.MovedExecutions) / hoursPast
+	secondsPast := float64(pastTime) / float64(time.Second)
+	progressReport.Rates.DatabaseRPS = float64(progressReport.Rates.TotalDBRequests) / secondsPast
+}
+
+func printReshardReport(report interface{}) {
+	reportBytes, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		ErrorAndExit("failed to print report", err)
+	}
+	fmt.Println(string(reportBytes))
+}
@@ -0,0 +1,130 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs/v1"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/persistence/serialization"
+)
+
+type reshardSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestReshardSuite(t *testing.T) {
+	suite.Run(t, new(reshardSuite))
+}
+
+func (s *reshardSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *reshardSuite) TestTargetShards() {
+	rc := &reshardContext{currentShardCount: 4, targetShardCount: 16}
+	s.Equal([]int{5, 9, 13}, rc.targetShards(1))
+	s.Equal([]int{7, 11, 15}, rc.targetShards(3))
+}
+
+func (s *reshardSuite) TestTargetShardForRow_SplitFromSourceShard() {
+	rc := &reshardContext{currentShardCount: 4, targetShardCount: 16}
+	report := &ReshardShardReport{}
+	for i := 0; i < 100; i++ {
+		workflowID := fmt.Sprintf("workflow-%v", i)
+		executionTargetShardID, ok := rc.targetShardForRow(&reshardRow{
+			rowType:    reshardRowTypeExecution,
+			workflowID: workflowID,
+		}, report)
+		s.True(ok)
+		sourceShardID := common.WorkflowIDToHistoryShard(workflowID, rc.currentShardCount)
+		if executionTargetShardID != sourceShardID {
+			s.Contains(rc.targetShards(sourceShardID), executionTargetShardID)
+		}
+
+		blob, err := serialization.TransferTaskInfoToBlob(&persistenceblobs.TransferTaskInfo{WorkflowId: workflowID})
+		s.NoError(err)
+		transferTargetShardID, ok := rc.targetShardForRow(&reshardRow{
+			rowType:          reshardRowTypeTransferTask,
+			transfer:         blob.Data,
+			transferEncoding: blob.Encoding.String(),
+		}, report)
+		s.True(ok)
+		s.Equal(executionTargetShardID, transferTargetShardID)
+	}
+
+	_, ok := rc.targetShardForRow(&reshardRow{rowType: reshardRowTypeShard}, report)
+	s.False(ok)
+	s.Equal(int64(0), report.Rows.RowCheckFailures)
+}
+
+func (s *reshardSuite) TestTargetShardForRow_DLQ() {
+	rc := &reshardContext{currentShardCount: 4, targetShardCount: 16}
+	report := &ReshardShardReport{}
+	workflowID := "workflow-id"
+	expectedShardID := common.WorkflowIDToHistoryShard(workflowID, rc.targetShardCount)
+
+	transferBlob, err := serialization.TransferTaskInfoToBlob(&persistenceblobs.TransferTaskInfo{WorkflowId: workflowID})
+	s.NoError(err)
+	shardID, ok := rc.targetShardForRow(&reshardRow{
+		rowType:          reshardRowTypeTransferDLQ,
+		transfer:         transferBlob.Data,
+		transferEncoding: transferBlob.Encoding.String(),
+	}, report)
+	s.True(ok)
+	s.Equal(expectedShardID, shardID)
+
+	timerBlob, err := serialization.TimerTaskInfoToBlob(&persistenceblobs.TimerTaskInfo{WorkflowId: workflowID})
+	s.NoError(err)
+	shardID, ok = rc.targetShardForRow(&reshardRow{
+		rowType:       reshardRowTypeTimerDLQ,
+		timer:         timerBlob.Data,
+		timerEncoding: timerBlob.Encoding.String(),
+	}, report)
+	s.True(ok)
+	s.Equal(expectedShardID, shardID)
+
+	replicationBlob, err := serialization.ReplicationTaskInfoToBlob(&persistenceblobs.ReplicationTaskInfo{WorkflowId: workflowID})
+	s.NoError(err)
+	shardID, ok = rc.targetShardForRow(&reshardRow{
+		rowType:             reshardRowTypeDLQ,
+		workflowID:          "source-cluster",
+		replication:         replicationBlob.Data,
+		replicationEncoding: replicationBlob.Encoding.String(),
+	}, report)
+	s.True(ok)
+	s.Equal(expectedShardID, shardID)
+	s.Equal(int64(0), report.Rows.RowCheckFailures)
+
+	_, ok = rc.targetShardForRow(&reshardRow{rowType: reshardRowTypeTimerDLQ + 1}, report)
+	s.False(ok)
+	s.Equal(int64(1), report.Rows.RowCheckFailures)
+}
@@ -35,6 +35,8 @@ import (
 	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
+	taskqueuegenpb "github.com/temporalio/temporal/.gen/proto/taskqueue/v1"
 	"github.com/temporalio/temporal/common/persistence"
 )
 
@@ -113,6 +115,119 @@ func printPollerInfo(pollers []*taskqueuepb.PollerInfo, taskQueueType enumspb.Ta
 	table.Render()
 }
 
+// AdminDescribeTaskQueueStats displays approximate backlog and throughput statistics of task queue.
+func AdminDescribeTaskQueueStats(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskQueue := getRequiredOption(c, FlagTaskQueue)
+	tlTypeInt, err := stringToEnum(c.String(FlagTaskQueueType), enumspb.TaskQueueType_value)
+	if err != nil {
+		ErrorAndExit("Failed to parse TaskQueue Type", err)
+	}
+	tlType := enumspb.TaskQueueType(tlTypeInt)
+	if tlType == enumspb.TASK_QUEUE_TYPE_UNSPECIFIED {
+		ErrorAndExit("TaskQueue type Unspecified is currently not supported", nil)
+	}
+	ctx, cancel := newContext(c)
+	defer cancel()
+	request := &adminservice.DescribeTaskQueueRequest{
+		Namespace:         namespace,
+		TaskQueue:         &taskqueuepb.TaskQueue{Name: taskQueue},
+		TaskQueueType:     tlType,
+		IncludePartitions: c.Bool(FlagIncludePartitions),
+	}
+
+	response, err := adminClient.DescribeTaskQueue(ctx, request)
+	if err != nil {
+		ErrorAndExit("Operation DescribeTaskQueue failed.", err)
+	}
+
+	fmt.Printf("State: %v\n\n", response.GetState())
+	partitions := response.GetPartitions()
+	if len(partitions) == 0 {
+		partitions = []*taskqueuegenpb.TaskQueuePartitionStats{{Partition: taskQueue, Stats: response.GetStats()}}
+	} else {
+		partitions = append(partitions, &taskqueuegenpb.TaskQueuePartitionStats{Partition: "Total", Stats: response.GetStats()})
+	}
+	printTaskQueueStats(partitions)
+}
+
+func printTaskQueueStats(partitions []*taskqueuegenpb.TaskQueuePartitionStats) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Partition", "Backlog", "Backlog Age (s)", "Add Rate", "Dispatch Rate", "Sync Match Ratio"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, partition := range partitions {
+		stats := partition.GetStats()
+		table.Append([]string{partition.GetPartition(),
+			strconv.FormatInt(stats.GetApproximateBacklogCount(), 10),
+			strconv.FormatInt(stats.GetApproximateBacklogAgeSeconds(), 10),
+			strconv.FormatFloat(stats.GetTasksAddRate(), 'f', 2, 64),
+			strconv.FormatFloat(stats.GetTasksDispatchRate(), 'f', 2, 64),
+			strconv.FormatFloat(stats.GetSyncMatchRatio(), 'f', 2, 64)})
+	}
+	table.Render()
+}
+
+// AdminPauseTaskQueue stops dispatching tasks of all partitions of a task queue, or drains it
+func AdminPauseTaskQueue(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskQueue := getRequiredOption(c, FlagTaskQueue)
+	tlType := getTaskQueueTypeOption(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.PauseTaskQueue(ctx, &adminservice.PauseTaskQueueRequest{
+		Namespace:     namespace,
+		TaskQueue:     &taskqueuepb.TaskQueue{Name: taskQueue},
+		TaskQueueType: tlType,
+		Drain:         c.Bool(FlagDrain),
+	})
+	if err != nil {
+		ErrorAndExit("Operation PauseTaskQueue failed.", err)
+	}
+	if c.Bool(FlagDrain) {
+		fmt.Printf("TaskQueue %v is draining\n", taskQueue)
+	} else {
+		fmt.Printf("TaskQueue %v is paused\n", taskQueue)
+	}
+}
+
+// AdminResumeTaskQueue resumes dispatching and accepting tasks of all partitions of a task queue
+func AdminResumeTaskQueue(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskQueue := getRequiredOption(c, FlagTaskQueue)
+	tlType := getTaskQueueTypeOption(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.ResumeTaskQueue(ctx, &adminservice.ResumeTaskQueueRequest{
+		Namespace:     namespace,
+		TaskQueue:     &taskqueuepb.TaskQueue{Name: taskQueue},
+		TaskQueueType: tlType,
+	})
+	if err != nil {
+		ErrorAndExit("Operation ResumeTaskQueue failed.", err)
+	}
+	fmt.Printf("TaskQueue %v is active\n", taskQueue)
+}
+
+func getTaskQueueTypeOption(c *cli.Context) enumspb.TaskQueueType {
+	tlTypeInt, err := stringToEnum(c.String(FlagTaskQueueType), enumspb.TaskQueueType_value)
+	if err != nil {
+		ErrorAndExit("Failed to parse TaskQueue Type", err)
+	}
+	tlType := enumspb.TaskQueueType(tlTypeInt)
+	if tlType == enumspb.TASK_QUEUE_TYPE_UNSPECIFIED {
+		ErrorAndExit("TaskQueue type Unspecified is currently not supported", nil)
+	}
+	return tlType
+}
+
 // AdminListTaskQueueTasks displays task information
 func AdminListTaskQueueTasks(c *cli.Context) {
 	namespace := getRequiredOption(c, FlagNamespaceID)
@@ -105,6 +105,26 @@ func newBatchCommands() []cli.Command {
 					Name:  FlagInputWithAlias,
 					Usage: "Optional input of signal",
 				},
+				cli.StringFlag{
+					Name:  FlagMemoKey,
+					Usage: "Key of memo to upsert for batch update_metadata. If there are multiple keys, concatenate them and separate by space",
+				},
+				cli.StringFlag{
+					Name:  FlagMemo,
+					Usage: "Value of memo to upsert for batch update_metadata, in JSON format. The order must be same as memo_key",
+				},
+				cli.StringFlag{
+					Name:  FlagMemoFile,
+					Usage: "Value of memo to upsert for batch update_metadata, from JSON format file. The order must be same as memo_key",
+				},
+				cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Search attribute keys to upsert for batch update_metadata. If there are multiple keys, concatenate them and separate by |",
+				},
+				cli.StringFlag{
+					Name:  FlagSearchAttributesVal,
+					Usage: "Search attribute values to upsert for batch update_metadata. If there are multiple values, concatenate them and separate by |",
+				},
 				cli.IntFlag{
 					Name:  FlagRPS,
 					Value: batcher.DefaultRPS,
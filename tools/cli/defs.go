@@ -31,6 +31,8 @@ import (
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
+
+	enumsgenpb "github.com/temporalio/temporal/.gen/proto/enums/v1"
 )
 
 const (
@@ -87,6 +89,13 @@ var resetTypesMap = map[string]string{
 	"BadBinary":              FlagResetBadBinaryChecksum,
 }
 
+var resetTypesToProto = map[string]enumsgenpb.ResetType{
+	"FirstDecisionCompleted": enumsgenpb.RESET_TYPE_FIRST_DECISION_COMPLETED,
+	"LastDecisionCompleted":  enumsgenpb.RESET_TYPE_LAST_DECISION_COMPLETED,
+	"LastContinuedAsNew":     enumsgenpb.RESET_TYPE_LAST_CONTINUED_AS_NEW,
+	"BadBinary":              enumsgenpb.RESET_TYPE_BAD_BINARY,
+}
+
 type jsonType int
 
 const (
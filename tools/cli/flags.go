@@ -184,9 +184,12 @@ const (
 	FlagSearchAttributesType              = "search_attr_type"
 	FlagAddBadBinary                      = "add_bad_binary"
 	FlagRemoveBadBinary                   = "remove_bad_binary"
+	FlagDefaultActivityRetryPolicy        = "default_activity_retry_policy"
+	FlagDefaultWorkflowRetryPolicy        = "default_workflow_retry_policy"
 	FlagResetType                         = "reset_type"
 	FlagResetPointsOnly                   = "reset_points_only"
 	FlagResetBadBinaryChecksum            = "reset_bad_binary_checksum"
+	FlagExcludeSignals                    = "exclude_signals"
 	FlagListQuery                         = "query"
 	FlagListQueryWithAlias                = FlagListQuery + ", q"
 	FlagBatchType                         = "batch_type"
@@ -229,6 +232,15 @@ const (
 	FlagUpperShardBound                   = "upper_shard_bound"
 	FlagInputDirectory                    = "input_directory"
 	FlagAutoConfirm                       = "auto_confirm"
+	FlagCurrentNumberOfShards             = "current_number_of_shards"
+	FlagTargetNumberOfShards              = "target_number_of_shards"
+	FlagCommit                            = "commit"
+	FlagIncludePartitions                 = "include_partitions"
+	FlagBuildID                           = "build_id"
+	FlagExistingCompatibleBuildID         = "existing_compatible_build_id"
+	FlagSetAsDefault                      = "set_as_default"
+	FlagDrain                             = "drain"
+	FlagClear                             = "clear"
 )
 
 var flagsForExecution = []cli.Flag{
@@ -581,6 +593,57 @@ func getFlagsForObserveID() []cli.Flag {
 	}
 }
 
+func getReshardFlags() []cli.Flag {
+	return append(getDBFlags(),
+		cli.IntFlag{
+			Name:  FlagCurrentNumberOfShards,
+			Usage: "current number of history shards of the cluster (see config for numHistoryShards)",
+		},
+		cli.IntFlag{
+			Name:  FlagTargetNumberOfShards,
+			Usage: "target number of history shards, must be a multiple of the current number of shards",
+		},
+		cli.IntFlag{
+			Name:  FlagLowerShardBound,
+			Usage: "lower bound of current shards to handle (inclusive)",
+			Value: 0,
+		},
+		cli.IntFlag{
+			Name:  FlagUpperShardBound,
+			Usage: "upper bound of current shards to handle (exclusive), defaults to the current number of shards",
+		},
+		cli.IntFlag{
+			Name:  FlagStartingRPS,
+			Usage: "starting rps of database queries, rps will be increased to target over scale up seconds",
+			Value: 100,
+		},
+		cli.IntFlag{
+			Name:  FlagRPS,
+			Usage: "target rps of database queries, target will be reached over scale up seconds",
+			Value: 7000,
+		},
+		cli.IntFlag{
+			Name:  FlagRPSScaleUpSeconds,
+			Usage: "number of seconds over which rps is scaled up to target",
+			Value: 1800,
+		},
+		cli.IntFlag{
+			Name:  FlagPageSize,
+			Usage: "page size used to query db executions table",
+			Value: 500,
+		},
+		cli.IntFlag{
+			Name:  FlagConcurrency,
+			Usage: "number of threads to handle resharding",
+			Value: 100,
+		},
+		cli.IntFlag{
+			Name:  FlagReportRate,
+			Usage: "the number of shards which get handled between each emitting of progress",
+			Value: 10,
+		})
+}
+
 func getDBFlags() []cli.Flag {
 	return []cli.Flag{
 		cli.StringFlag{
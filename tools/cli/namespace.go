@@ -26,11 +26,15 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/urfave/cli"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
 )
 
+const retryPolicyNone = "none"
+
 // by default we don't require any namespace data. But this can be overridden by calling SetRequiredNamespaceDataKeys()
 var requiredNamespaceDataKeys = []string{}
 
@@ -66,6 +70,53 @@ func parseNamespaceDataKVs(namespaceDataStr string) (map[string]string, error) {
 	return kvMap, nil
 }
 
+// parseRetryPolicy parses a retry policy in format of initial_interval:1,backoff_coefficient:2,maximum_interval:100,
+// maximum_attempts:5,non_retryable_error_types:ErrA|ErrB. Unspecified fields are left unset.
+func parseRetryPolicy(retryPolicyStr string) (*commonpb.RetryPolicy, error) {
+	kvMap, err := parseNamespaceDataKVs(retryPolicyStr)
+	if err != nil {
+		return nil, fmt.Errorf("retry policy format error. It must be k1:v2,k2:v2,...,kn:vn")
+	}
+
+	policy := &commonpb.RetryPolicy{BackoffCoefficient: 2}
+	for k, v := range kvMap {
+		switch k {
+		case "initial_interval":
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid initial_interval %v: %v", v, err)
+			}
+			policy.InitialIntervalInSeconds = int32(i)
+		case "backoff_coefficient":
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid backoff_coefficient %v: %v", v, err)
+			}
+			policy.BackoffCoefficient = f
+		case "maximum_interval":
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid maximum_interval %v: %v", v, err)
+			}
+			policy.MaximumIntervalInSeconds = int32(i)
+		case "maximum_attempts":
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid maximum_attempts %v: %v", v, err)
+			}
+			policy.MaximumAttempts = int32(i)
+		case "non_retryable_error_types":
+			policy.NonRetryableErrorTypes = strings.Split(v, "|")
+		default:
+			return nil, fmt.Errorf("unknown retry policy field %v", k)
+		}
+	}
+	if policy.InitialIntervalInSeconds == 0 {
+		return nil, fmt.Errorf("retry policy must specify initial_interval")
+	}
+	return policy, nil
+}
+
 func newNamespaceCommands() []cli.Command {
 	return []cli.Command{
 		{
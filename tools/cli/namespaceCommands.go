@@ -30,17 +30,20 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	namespacepb "go.temporal.io/temporal-proto/namespace/v1"
 	replicationpb "go.temporal.io/temporal-proto/replication/v1"
 	"go.temporal.io/temporal-proto/serviceerror"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	"github.com/temporalio/temporal/common/namespace"
 )
 
@@ -48,6 +51,8 @@ type (
 	namespaceCLIImpl struct {
 		// used when making RPC call to frontend service``
 		frontendClient workflowservice.WorkflowServiceClient
+		// used when making RPC call to admin service for settings not exposed by frontend service
+		adminClient adminservice.AdminServiceClient
 
 		// act as admin to modify namespace in DB directly
 		namespaceHandler namespace.Handler
@@ -61,14 +66,17 @@ func newNamespaceCLI(
 ) *namespaceCLIImpl {
 
 	var frontendClient workflowservice.WorkflowServiceClient
+	var adminClient adminservice.AdminServiceClient
 	var namespaceHandler namespace.Handler
 	if !isAdminMode {
 		frontendClient = initializeFrontendClient(c)
+		adminClient = cFactory.AdminClient(c)
 	} else {
 		namespaceHandler = initializeAdminNamespaceHandler(c)
 	}
 	return &namespaceCLIImpl{
 		frontendClient:   frontendClient,
+		adminClient:      adminClient,
 		namespaceHandler: namespaceHandler,
 	}
 }
@@ -285,6 +293,37 @@ func (d *namespaceCLIImpl) UpdateNamespace(c *cli.Context) {
 	} else {
 		fmt.Printf("Namespace %s successfully updated.\n", namespace)
 	}
+
+	if !c.IsSet(FlagActiveClusterName) && (c.IsSet(FlagDefaultActivityRetryPolicy) || c.IsSet(FlagDefaultWorkflowRetryPolicy)) {
+		retryRequest := &adminservice.UpdateNamespaceDefaultRetryPoliciesRequest{
+			Namespace:     namespace,
+			SecurityToken: securityToken,
+		}
+		if c.IsSet(FlagDefaultActivityRetryPolicy) {
+			retryRequest.DefaultActivityRetryPolicy, retryRequest.ClearDefaultActivityRetryPolicy =
+				parseRetryPolicyFlag(c, FlagDefaultActivityRetryPolicy)
+		}
+		if c.IsSet(FlagDefaultWorkflowRetryPolicy) {
+			retryRequest.DefaultWorkflowRetryPolicy, retryRequest.ClearDefaultWorkflowRetryPolicy =
+				parseRetryPolicyFlag(c, FlagDefaultWorkflowRetryPolicy)
+		}
+		if err := d.updateNamespaceDefaultRetryPolicies(ctx, retryRequest); err != nil {
+			ErrorAndExit("Operation UpdateNamespaceDefaultRetryPolicies failed.", err)
+		}
+		fmt.Printf("Namespace %s default retry policies successfully updated.\n", namespace)
+	}
+}
+
+func parseRetryPolicyFlag(c *cli.Context, flagName string) (*commonpb.RetryPolicy, bool) {
+	value := c.String(flagName)
+	if strings.EqualFold(value, retryPolicyNone) {
+		return nil, true
+	}
+	policy, err := parseRetryPolicy(value)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", flagName), err)
+	}
+	return policy, false
 }
 
 // DescribeNamespace updates a namespace
@@ -309,6 +348,30 @@ func (d *namespaceCLIImpl) DescribeNamespace(c *cli.Context) {
 	}
 
 	printNamespace(resp)
+
+	if namespace != "" {
+		retryResp, err := d.describeNamespaceDefaultRetryPolicies(ctx, &adminservice.DescribeNamespaceDefaultRetryPoliciesRequest{
+			Namespace: namespace,
+		})
+		if err != nil {
+			ErrorAndExit("Operation DescribeNamespaceDefaultRetryPolicies failed.", err)
+		}
+		fmt.Printf("DefaultActivityRetryPolicy: %v\nDefaultWorkflowRetryPolicy: %v\n",
+			retryPolicyToString(retryResp.DefaultActivityRetryPolicy),
+			retryPolicyToString(retryResp.DefaultWorkflowRetryPolicy))
+	}
+}
+
+func retryPolicyToString(policy *commonpb.RetryPolicy) string {
+	if policy == nil {
+		return retryPolicyNone
+	}
+	return fmt.Sprintf("initial_interval:%v,backoff_coefficient:%v,maximum_interval:%v,maximum_attempts:%v,non_retryable_error_types:%v",
+		policy.GetInitialIntervalInSeconds(),
+		policy.GetBackoffCoefficient(),
+		policy.GetMaximumIntervalInSeconds(),
+		policy.GetMaximumAttempts(),
+		strings.Join(policy.GetNonRetryableErrorTypes(), "|"))
 }
 
 func printNamespace(resp *workflowservice.DescribeNamespaceResponse) {
@@ -437,6 +500,31 @@ func (d *namespaceCLIImpl) describeNamespace(
 	return resp, err
 }
 
+func (d *namespaceCLIImpl) updateNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.UpdateNamespaceDefaultRetryPoliciesRequest,
+) error {
+	if d.adminClient != nil {
+		_, err := d.adminClient.UpdateNamespaceDefaultRetryPolicies(ctx, request)
+		return err
+	}
+
+	_, err := d.namespaceHandler.UpdateNamespaceDefaultRetryPolicies(ctx, request)
+	return err
+}
+
+func (d *namespaceCLIImpl) describeNamespaceDefaultRetryPolicies(
+	ctx context.Context,
+	request *adminservice.DescribeNamespaceDefaultRetryPoliciesRequest,
+) (*adminservice.DescribeNamespaceDefaultRetryPoliciesResponse, error) {
+
+	if d.adminClient != nil {
+		return d.adminClient.DescribeNamespaceDefaultRetryPolicies(ctx, request)
+	}
+
+	return d.namespaceHandler.DescribeNamespaceDefaultRetryPolicies(ctx, request)
+}
+
 func clustersToString(clusters []*replicationpb.ClusterReplicationConfig) string {
 	var res string
 	for i, cluster := range clusters {
@@ -162,6 +162,16 @@ var (
 			Name:  FlagRemoveBadBinary,
 			Usage: "Binary checksum to remove for resetting workflow",
 		},
+		cli.StringFlag{
+			Name: FlagDefaultActivityRetryPolicy,
+			Usage: "Retry policy for activities scheduled without one, in format of initial_interval:1,backoff_coefficient:2," +
+				"maximum_interval:100,maximum_attempts:5,non_retryable_error_types:ErrA|ErrB (\"none\" to remove)",
+		},
+		cli.StringFlag{
+			Name: FlagDefaultWorkflowRetryPolicy,
+			Usage: "Retry policy for workflows started without one, in format of initial_interval:1,backoff_coefficient:2," +
+				"maximum_interval:100,maximum_attempts:5,non_retryable_error_types:ErrA|ErrB (\"none\" to remove)",
+		},
 		cli.StringFlag{
 			Name:  FlagReason,
 			Usage: "Reason for the operation",
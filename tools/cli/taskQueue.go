@@ -61,5 +61,134 @@ func newTaskQueueCommands() []cli.Command {
 				ListTaskQueuePartitions(c)
 			},
 		},
+		{
+			Name:  "get-build-ids",
+			Usage: "Show the compatible worker build id sets of the decision taskqueue, the default set is listed last",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+			},
+			Action: func(c *cli.Context) {
+				GetTaskQueueBuildIDs(c)
+			},
+		},
+		{
+			Name:  "add-default-build-id",
+			Usage: "Add a build id in a new compatible set which becomes the default set for new workflows",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+				cli.StringFlag{
+					Name:  FlagBuildID,
+					Usage: "Worker build id",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AddDefaultTaskQueueBuildID(c)
+			},
+		},
+		{
+			Name:  "add-compatible-build-id",
+			Usage: "Add a build id to the set of an existing compatible build id",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+				cli.StringFlag{
+					Name:  FlagBuildID,
+					Usage: "Worker build id",
+				},
+				cli.StringFlag{
+					Name:  FlagExistingCompatibleBuildID,
+					Usage: "Build id already known to the taskqueue which is compatible with the new one",
+				},
+				cli.BoolFlag{
+					Name:  FlagSetAsDefault,
+					Usage: "Also make the set the default set for new workflows",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AddCompatibleTaskQueueBuildID(c)
+			},
+		},
+		{
+			Name:  "promote-build-id-set",
+			Usage: "Make the set containing the build id the default set for new workflows",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+				cli.StringFlag{
+					Name:  FlagBuildID,
+					Usage: "Worker build id",
+				},
+			},
+			Action: func(c *cli.Context) {
+				PromoteTaskQueueBuildIDSet(c)
+			},
+		},
+		{
+			Name:  "get-rate-limit",
+			Usage: "Show the dispatch rate limit of taskqueue set by operators",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskQueueTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskQueue type [decision|activity]",
+				},
+			},
+			Action: func(c *cli.Context) {
+				GetTaskQueueRateLimit(c)
+			},
+		},
+		{
+			Name:  "set-rate-limit",
+			Usage: "Set the dispatch rate limit of taskqueue, which takes precedence over the limit set by workers",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "TaskQueue name",
+				},
+				cli.StringFlag{
+					Name:  FlagTaskQueueTypeWithAlias,
+					Value: "decision",
+					Usage: "Optional TaskQueue type [decision|activity]",
+				},
+				cli.Float64Flag{
+					Name:  FlagRPS,
+					Usage: "Max tasks dispatched per second across all partitions of the taskqueue",
+				},
+				cli.BoolFlag{
+					Name:  FlagClear,
+					Usage: "Clear the limit so that the limit set by workers applies again",
+				},
+			},
+			Action: func(c *cli.Context) {
+				SetTaskQueueRateLimit(c)
+			},
+		},
+		{
+			Name:  "list-workers",
+			Usage: "List the workers polling taskqueues of the namespace",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskQueueWithAlias,
+					Usage: "Optional TaskQueue name, only lists the workers polling it",
+				},
+			},
+			Action: func(c *cli.Context) {
+				ListTaskQueueWorkers(c)
+			},
+		},
 	}
 }
@@ -25,14 +25,20 @@
 package cli
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	taskqueuepb "go.temporal.io/temporal-proto/taskqueue/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 )
 
 // DescribeTaskQueue show pollers info of a given taskqueue
@@ -106,3 +112,183 @@ func printTaskQueuePartitions(taskQueueType string, partitions []*taskqueuepb.Ta
 	}
 	table.Render()
 }
+
+// GetTaskQueueBuildIDs shows the compatible worker build id sets of a decision taskqueue
+func GetTaskQueueBuildIDs(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskQueue := getRequiredOption(c, FlagTaskQueue)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.GetWorkerBuildIdCompatibility(ctx, &adminservice.GetWorkerBuildIdCompatibilityRequest{
+		Namespace: namespace,
+		TaskQueue: taskQueue,
+	})
+	if err != nil {
+		ErrorAndExit("Operation GetWorkerBuildIdCompatibility failed.", err)
+	}
+
+	versionSets := response.GetVersioningData().GetVersionSets()
+	if len(versionSets) == 0 {
+		ErrorAndExit(colorMagenta("No build ids for taskqueue: "+taskQueue), nil)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Set", "Build Ids", "Default"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for i, versionSet := range versionSets {
+		table.Append([]string{versionSet.GetId(),
+			strings.Join(versionSet.GetBuildIds(), ", "),
+			strconv.FormatBool(i == len(versionSets)-1)})
+	}
+	table.Render()
+}
+
+// AddDefaultTaskQueueBuildID adds a build id in a new default compatible set of a decision taskqueue
+func AddDefaultTaskQueueBuildID(c *cli.Context) {
+	request := newUpdateBuildIDCompatibilityRequest(c)
+	request.Operation = &adminservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet_{
+		AddNewBuildIdInNewDefaultSet: &adminservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewBuildIdInNewDefaultSet{
+			BuildId: getRequiredOption(c, FlagBuildID),
+		},
+	}
+	updateBuildIDCompatibility(c, request)
+}
+
+// AddCompatibleTaskQueueBuildID adds a build id to the compatible set of an existing build id of a decision taskqueue
+func AddCompatibleTaskQueueBuildID(c *cli.Context) {
+	request := newUpdateBuildIDCompatibilityRequest(c)
+	request.Operation = &adminservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewCompatibleBuildId_{
+		AddNewCompatibleBuildId: &adminservice.UpdateWorkerBuildIdCompatibilityRequest_AddNewCompatibleBuildId{
+			NewBuildId:                getRequiredOption(c, FlagBuildID),
+			ExistingCompatibleBuildId: getRequiredOption(c, FlagExistingCompatibleBuildID),
+			MakeSetDefault:            c.Bool(FlagSetAsDefault),
+		},
+	}
+	updateBuildIDCompatibility(c, request)
+}
+
+// PromoteTaskQueueBuildIDSet makes the compatible set containing the build id the default one of a decision taskqueue
+func PromoteTaskQueueBuildIDSet(c *cli.Context) {
+	request := newUpdateBuildIDCompatibilityRequest(c)
+	request.Operation = &adminservice.UpdateWorkerBuildIdCompatibilityRequest_PromoteSetByBuildId{
+		PromoteSetByBuildId: getRequiredOption(c, FlagBuildID),
+	}
+	updateBuildIDCompatibility(c, request)
+}
+
+func newUpdateBuildIDCompatibilityRequest(c *cli.Context) *adminservice.UpdateWorkerBuildIdCompatibilityRequest {
+	return &adminservice.UpdateWorkerBuildIdCompatibilityRequest{
+		Namespace: getRequiredGlobalOption(c, FlagNamespace),
+		TaskQueue: getRequiredOption(c, FlagTaskQueue),
+	}
+}
+
+func updateBuildIDCompatibility(c *cli.Context, request *adminservice.UpdateWorkerBuildIdCompatibilityRequest) {
+	adminClient := cFactory.AdminClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	if _, err := adminClient.UpdateWorkerBuildIdCompatibility(ctx, request); err != nil {
+		ErrorAndExit("Operation UpdateWorkerBuildIdCompatibility failed.", err)
+	}
+	GetTaskQueueBuildIDs(c)
+}
+
+// GetTaskQueueRateLimit shows the dispatch rate limit of a taskqueue set by operators
+func GetTaskQueueRateLimit(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskQueue := getRequiredOption(c, FlagTaskQueue)
+	tlType := getTaskQueueTypeOption(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DescribeTaskQueue(ctx, &adminservice.DescribeTaskQueueRequest{
+		Namespace:     namespace,
+		TaskQueue:     &taskqueuepb.TaskQueue{Name: taskQueue},
+		TaskQueueType: tlType,
+	})
+	if err != nil {
+		ErrorAndExit("Operation DescribeTaskQueue failed.", err)
+	}
+
+	if response.GetMaxTasksPerSecond() == nil {
+		fmt.Printf("TaskQueue %v has no rate limit set by operators, the limit set by workers applies\n", taskQueue)
+		return
+	}
+	fmt.Printf("TaskQueue %v is limited to %v tasks per second\n", taskQueue, response.GetMaxTasksPerSecond().GetValue())
+}
+
+// SetTaskQueueRateLimit sets or clears the dispatch rate limit of a taskqueue
+func SetTaskQueueRateLimit(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	taskQueue := getRequiredOption(c, FlagTaskQueue)
+	tlType := getTaskQueueTypeOption(c)
+
+	var maxTasksPerSecond *types.DoubleValue
+	if !c.Bool(FlagClear) {
+		if !c.IsSet(FlagRPS) {
+			ErrorAndExit(fmt.Sprintf("Option %s or %s is required", FlagRPS, FlagClear), nil)
+		}
+		maxTasksPerSecond = &types.DoubleValue{Value: c.Float64(FlagRPS)}
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.UpdateTaskQueueRateLimit(ctx, &adminservice.UpdateTaskQueueRateLimitRequest{
+		Namespace:         namespace,
+		TaskQueue:         &taskqueuepb.TaskQueue{Name: taskQueue},
+		TaskQueueType:     tlType,
+		MaxTasksPerSecond: maxTasksPerSecond,
+	})
+	if err != nil {
+		ErrorAndExit("Operation UpdateTaskQueueRateLimit failed.", err)
+	}
+	GetTaskQueueRateLimit(c)
+}
+
+// ListTaskQueueWorkers lists the workers polling taskqueues of a namespace
+func ListTaskQueueWorkers(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.ListWorkers(ctx, &adminservice.ListWorkersRequest{
+		Namespace: namespace,
+		TaskQueue: c.String(FlagTaskQueue),
+	})
+	if err != nil {
+		ErrorAndExit("Operation ListWorkers failed.", err)
+	}
+
+	workers := response.GetWorkers()
+	if len(workers) == 0 {
+		ErrorAndExit(colorMagenta("No workers for namespace: "+namespace), nil)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Identity", "SDK", "Last Poll Time", "Alive", "TaskQueues"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+	for _, worker := range workers {
+		var taskQueues []string
+		for _, taskQueue := range worker.GetTaskQueues() {
+			taskQueues = append(taskQueues, fmt.Sprintf("%v (%v)", taskQueue.GetName(), taskQueue.GetTaskQueueType()))
+		}
+		table.Append([]string{worker.GetIdentity(),
+			strings.TrimSpace(worker.GetSdkName() + " " + worker.GetSdkVersion()),
+			convertTime(worker.GetLastPollTime(), false),
+			strconv.FormatBool(worker.GetAlive()),
+			strings.Join(taskQueues, ", ")})
+	}
+	table.Render()
+}
@@ -104,6 +104,44 @@ func newWorkflowCommands() []cli.Command {
 				SignalWorkflow(c)
 			},
 		},
+		{
+			Name:  "update-metadata",
+			Usage: "upsert memo and search attributes of a running or closed workflow execution",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.StringFlag{
+					Name:  FlagMemoKey,
+					Usage: "Key of memo to upsert. If there are multiple keys, concatenate them and separate by space",
+				},
+				cli.StringFlag{
+					Name: FlagMemo,
+					Usage: "Value of memo to upsert, in JSON format. If there are multiple JSON, concatenate them and separate by space. " +
+						"The order must be same as memo_key",
+				},
+				cli.StringFlag{
+					Name:  FlagMemoFile,
+					Usage: "Value of memo to upsert, from JSON format file. The order must be same as memo_key",
+				},
+				cli.StringFlag{
+					Name:  FlagSearchAttributesKey,
+					Usage: "Search attribute keys to upsert. If there are multiple keys, concatenate them and separate by |",
+				},
+				cli.StringFlag{
+					Name:  FlagSearchAttributesVal,
+					Usage: "Search attribute values to upsert. If there are multiple values, concatenate them and separate by |",
+				},
+			},
+			Action: func(c *cli.Context) {
+				UpdateWorkflowMetadata(c)
+			},
+		},
 		{
 			Name:    "terminate",
 			Aliases: []string{"term"},
@@ -254,6 +292,14 @@ func newWorkflowCommands() []cli.Command {
 					Name:  FlagResetBadBinaryChecksum,
 					Usage: "Binary checksum for resetType of BadBinary",
 				},
+				cli.StringFlag{
+					Name:  FlagExcludeSignals,
+					Usage: "Comma separated names of signals not to reapply after the reset point. Uses the admin API, which resolves resetType on the server",
+				},
+				cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "Print the events the reset run would start with instead of resetting. Uses the admin API, which resolves resetType on the server",
+				},
 			},
 			Action: func(c *cli.Context) {
 				ResetWorkflow(c)
@@ -31,6 +31,7 @@ import (
 	"strings"
 
 	"github.com/urfave/cli"
+	commonpb "go.temporal.io/temporal-proto/common/v1"
 	enumspb "go.temporal.io/temporal-proto/enums/v1"
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 	sdkclient "go.temporal.io/temporal/client"
@@ -155,6 +156,14 @@ func StartBatchJob(c *cli.Context) {
 		sigName = getRequiredOption(c, FlagSignalName)
 		sigVal = getRequiredOption(c, FlagInput)
 	}
+	var memo, searchAttr map[string]*commonpb.Payload
+	if batchType == batcher.BatchTypeUpdateMetadata {
+		memo = processMemo(c)
+		searchAttr = processSearchAttr(c)
+		if len(memo) == 0 && len(searchAttr) == 0 {
+			ErrorAndExit("Memo or search attributes must be provided for batch update_metadata.", nil)
+		}
+	}
 	rps := c.Int(FlagRPS)
 
 	client := cFactory.SDKClient(c, common.SystemLocalNamespace)
@@ -212,6 +221,10 @@ func StartBatchJob(c *cli.Context) {
 			SignalName: sigName,
 			Input:      sigInput,
 		},
+		UpdateMetadataParams: batcher.UpdateMetadataParams{
+			Memo:             &commonpb.Memo{Fields: memo},
+			SearchAttributes: &commonpb.SearchAttributes{IndexedFields: searchAttr},
+		},
 		RPS: rps,
 	}
 	wf, err := client.ExecuteWorkflow(tcCtx, options, batcher.BatchWFTypeName, params)
@@ -56,6 +56,7 @@ import (
 	"go.temporal.io/temporal-proto/workflowservice/v1"
 	"go.temporal.io/temporal/client"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice/v1"
 	cligenpb "github.com/temporalio/temporal/.gen/proto/cli/v1"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/clock"
@@ -500,6 +501,39 @@ func SignalWorkflow(c *cli.Context) {
 	}
 }
 
+// UpdateWorkflowMetadata upserts memo and search attributes of a workflow execution
+func UpdateWorkflowMetadata(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	memo := processMemo(c)
+	searchAttr := processSearchAttr(c)
+	if len(memo) == 0 && len(searchAttr) == 0 {
+		ErrorAndExit("Memo or search attributes must be provided.", nil)
+	}
+
+	tcCtx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.UpdateWorkflowExecutionMetadata(tcCtx, &adminservice.UpdateWorkflowExecutionMetadataRequest{
+		Namespace: namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		Memo:             &commonpb.Memo{Fields: memo},
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: searchAttr},
+		Identity:         getCliIdentity(),
+	})
+
+	if err != nil {
+		ErrorAndExit("Update workflow metadata failed.", err)
+	} else {
+		fmt.Println("Update workflow metadata succeeded.")
+	}
+}
+
 // QueryWorkflow query workflow execution
 func QueryWorkflow(c *cli.Context) {
 	getRequiredGlobalOption(c, FlagNamespace) // for pre-check and alert if not provided
@@ -1375,6 +1409,11 @@ func ResetWorkflow(c *cli.Context) {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
+	if c.Bool(FlagDryRun) || c.String(FlagExcludeSignals) != "" {
+		resetWorkflowWithOptions(ctx, c, namespace, wid, rid, reason, eventID, resetType)
+		return
+	}
+
 	frontendClient := cFactory.FrontendClient(c)
 
 	resetBaseRunID := rid
@@ -1402,6 +1441,39 @@ func ResetWorkflow(c *cli.Context) {
 	prettyPrintJSONObject(resp)
 }
 
+// resetWorkflowWithOptions resets through the admin API, which resolves the reset point on the server, and
+// supports excluding signals from reapplication and dry runs
+func resetWorkflowWithOptions(ctx context.Context, c *cli.Context, namespace, wid, rid, reason string, eventID int64, resetType string) {
+	var excludeSignalNames []string
+	for _, signalName := range strings.Split(c.String(FlagExcludeSignals), ",") {
+		if signalName = strings.TrimSpace(signalName); signalName != "" {
+			excludeSignalNames = append(excludeSignalNames, signalName)
+		}
+	}
+
+	adminClient := cFactory.AdminClient(c)
+	resp, err := adminClient.ResetWorkflowExecution(ctx, &adminservice.ResetWorkflowExecutionRequest{
+		ResetRequest: &workflowservice.ResetWorkflowExecutionRequest{
+			Namespace: namespace,
+			WorkflowExecution: &commonpb.WorkflowExecution{
+				WorkflowId: wid,
+				RunId:      rid,
+			},
+			Reason:                fmt.Sprintf("%v:%v", getCurrentUserFromEnv(), reason),
+			DecisionFinishEventId: eventID,
+			RequestId:             uuid.New(),
+		},
+		ResetType:              resetTypesToProto[resetType],
+		ResetBadBinaryChecksum: c.String(FlagResetBadBinaryChecksum),
+		ExcludeSignalNames:     excludeSignalNames,
+		DryRun:                 c.Bool(FlagDryRun),
+	})
+	if err != nil {
+		ErrorAndExit("reset failed", err)
+	}
+	prettyPrintJSONObject(resp)
+}
+
 func processResets(c *cli.Context, namespace string, wes chan commonpb.WorkflowExecution, done chan bool, wg *sync.WaitGroup, params batchResetParamsType) {
 	for {
 		select {
@@ -80,6 +80,16 @@ func Update(cli *cli.Context, db DB) error {
 	return newUpdateSchemaTask(db, cfg).Run()
 }
 
+// Verify compares the schema of the specified database against the versioned schema,
+// expectedDB is a scratch database that is used to build the expected schema
+func Verify(cli *cli.Context, db DB, expectedDB DB) error {
+	cfg, err := newVerifyConfig(cli)
+	if err != nil {
+		return err
+	}
+	return newVerifySchemaTask(db, expectedDB, cfg).Run()
+}
+
 func newUpdateConfig(cli *cli.Context) (*UpdateConfig, error) {
 	config := new(UpdateConfig)
 	config.SchemaDir = cli.String(CLIOptSchemaDir)
@@ -92,6 +102,17 @@ func newUpdateConfig(cli *cli.Context) (*UpdateConfig, error) {
 	return config, nil
 }
 
+func newVerifyConfig(cli *cli.Context) (*VerifyConfig, error) {
+	config := new(VerifyConfig)
+	config.SchemaDir = cli.String(CLIOptSchemaDir)
+	config.TargetVersion = cli.String(CLIOptTargetVersion)
+
+	if err := validateVerifyConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func newSetupConfig(cli *cli.Context) (*SetupConfig, error) {
 	config := new(SetupConfig)
 	config.SchemaFilePath = cli.String(CLIOptSchemaFile)
@@ -138,6 +159,20 @@ func validateUpdateConfig(config *UpdateConfig) error {
 	return nil
 }
 
+func validateVerifyConfig(config *VerifyConfig) error {
+	if len(config.SchemaDir) == 0 {
+		return NewConfigError("missing " + flag(CLIOptSchemaDir) + " argument ")
+	}
+	if len(config.TargetVersion) > 0 {
+		ver, err := parseValidateVersion(config.TargetVersion)
+		if err != nil {
+			return NewConfigError("invalid " + flag(CLIOptTargetVersion) + " argument:" + err.Error())
+		}
+		config.TargetVersion = ver
+	}
+	return nil
+}
+
 func flag(opt string) string {
 	return "(-" + opt + ")"
 }
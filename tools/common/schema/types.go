@@ -43,6 +43,12 @@ type (
 		SchemaDir     string
 		IsDryRun      bool
 	}
+	// VerifyConfig holds the config
+	// params for executing a VerifyTask
+	VerifyConfig struct {
+		TargetVersion string
+		SchemaDir     string
+	}
 	// SetupConfig holds the config
 	// params need by the SetupTask
 	SetupConfig struct {
@@ -69,6 +75,33 @@ type (
 		// Close gracefully closes the client object
 		Close()
 	}
+	// SchemaDescriber is implemented by databases whose
+	// live schema can be introspected by the schema-tool
+	SchemaDescriber interface {
+		// DescribeSchema returns the tables, columns, indexes and types of the database
+		DescribeSchema() (*SchemaDescription, error)
+	}
+	// SchemaDescription is a snapshot of the
+	// schema objects found in a keyspace/database
+	SchemaDescription struct {
+		Tables map[string]*TableDescription
+		Types  map[string]*TypeDescription
+	}
+	// TableDescription describes a single table
+	TableDescription struct {
+		// Columns maps column name to column type
+		Columns map[string]string
+		// PrimaryKey is the primary key definition, empty if
+		// the primary key is reported as an index instead
+		PrimaryKey string
+		// Indexes maps index name to index definition
+		Indexes map[string]string
+	}
+	// TypeDescription describes a user defined type
+	TypeDescription struct {
+		// Fields maps field name to field type
+		Fields map[string]string
+	}
 )
 
 const (
@@ -161,6 +194,9 @@ const (
 // DryrunDBName is the db name used for dryrun
 const DryrunDBName = "_temporal_dryrun_"
 
+// VerifyDBName is the db name used to build the expected schema during verify
+const VerifyDBName = "_temporal_verify_"
+
 var rmspaceRegex = regexp.MustCompile(`\s+`)
 
 // NewConfigError creates and returns an instance of ConfigError
@@ -0,0 +1,214 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package schema
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+type (
+	// VerifyTask represents a task that compares
+	// the live schema of a database against the
+	// schema described by the versioned schema dir
+	VerifyTask struct {
+		db         DB
+		expectedDB DB
+		config     *VerifyConfig
+	}
+)
+
+// newVerifySchemaTask returns a new instance of VerifyTask
+func newVerifySchemaTask(db DB, expectedDB DB, config *VerifyConfig) *VerifyTask {
+	return &VerifyTask{
+		db:         db,
+		expectedDB: expectedDB,
+		config:     config,
+	}
+}
+
+// Run executes the task
+func (task *VerifyTask) Run() error {
+	config := task.config
+
+	log.Printf("VerifySchemaTask started, config=%+v\n", config)
+
+	currVer, err := task.db.ReadSchemaVersion()
+	if err != nil {
+		return fmt.Errorf("error reading current schema version:%v", err.Error())
+	}
+
+	targetVer := config.TargetVersion
+	if len(targetVer) == 0 {
+		targetVer = currVer
+	}
+	if cmpVersion(currVer, targetVer) != 0 {
+		log.Printf("schema_version reports version %v, verifying against version %v\n", currVer, targetVer)
+	}
+
+	if err := task.buildExpectedSchema(targetVer); err != nil {
+		return fmt.Errorf("error building expected schema for version %v:%v", targetVer, err.Error())
+	}
+
+	expected, err := describeSchema(task.expectedDB)
+	if err != nil {
+		return fmt.Errorf("error describing expected schema:%v", err.Error())
+	}
+	actual, err := describeSchema(task.db)
+	if err != nil {
+		return fmt.Errorf("error describing current schema:%v", err.Error())
+	}
+
+	drifts := diffSchema(expected, actual)
+	if len(drifts) == 0 {
+		log.Printf("VerifySchemaTask done, schema matches version %v\n", targetVer)
+		return nil
+	}
+
+	log.Printf("---- Schema drift from version %v ----\n", targetVer)
+	for _, d := range drifts {
+		log.Println(d)
+	}
+	log.Printf("---- Done ----\n")
+
+	return fmt.Errorf("found %v schema difference(s) from version %v", len(drifts), targetVer)
+}
+
+// buildExpectedSchema replays the versioned schema
+// up to the given version into the scratch database
+func (task *VerifyTask) buildExpectedSchema(version string) error {
+	setupConfig := &SetupConfig{
+		Overwrite:      true,
+		InitialVersion: "0.0",
+	}
+	if err := newSetupSchemaTask(task.expectedDB, setupConfig).Run(); err != nil {
+		return err
+	}
+	if cmpVersion(version, "0.0") <= 0 {
+		return nil
+	}
+	updateConfig := &UpdateConfig{
+		SchemaDir:     task.config.SchemaDir,
+		TargetVersion: version,
+	}
+	return newUpdateSchemaTask(task.expectedDB, updateConfig).Run()
+}
+
+func describeSchema(db DB) (*SchemaDescription, error) {
+	describer, ok := db.(SchemaDescriber)
+	if !ok {
+		return nil, fmt.Errorf("schema introspection is not supported by %T", db)
+	}
+	return describer.DescribeSchema()
+}
+
+// diffSchema returns a sorted, human readable list of
+// differences between the expected and actual schema
+func diffSchema(expected *SchemaDescription, actual *SchemaDescription) []string {
+	var drifts []string
+
+	for _, name := range unionKeys(tableNames(expected.Tables), tableNames(actual.Tables)) {
+		exp, inExpected := expected.Tables[name]
+		act, inActual := actual.Tables[name]
+		switch {
+		case !inActual:
+			drifts = append(drifts, fmt.Sprintf("table %v: missing", name))
+		case !inExpected:
+			drifts = append(drifts, fmt.Sprintf("table %v: unexpected", name))
+		default:
+			prefix := fmt.Sprintf("table %v: ", name)
+			drifts = append(drifts, diffDefinitions(prefix+"column", exp.Columns, act.Columns)...)
+			drifts = append(drifts, diffDefinitions(prefix+"index", exp.Indexes, act.Indexes)...)
+			if exp.PrimaryKey != act.PrimaryKey {
+				drifts = append(drifts, fmt.Sprintf("%vprimary key mismatch, expected %v, actual %v",
+					prefix, exp.PrimaryKey, act.PrimaryKey))
+			}
+		}
+	}
+
+	for _, name := range unionKeys(typeNames(expected.Types), typeNames(actual.Types)) {
+		exp, inExpected := expected.Types[name]
+		act, inActual := actual.Types[name]
+		switch {
+		case !inActual:
+			drifts = append(drifts, fmt.Sprintf("type %v: missing", name))
+		case !inExpected:
+			drifts = append(drifts, fmt.Sprintf("type %v: unexpected", name))
+		default:
+			drifts = append(drifts, diffDefinitions(fmt.Sprintf("type %v: field", name), exp.Fields, act.Fields)...)
+		}
+	}
+
+	return drifts
+}
+
+// diffDefinitions compares two name -> definition maps
+func diffDefinitions(prefix string, expected map[string]string, actual map[string]string) []string {
+	var drifts []string
+	for _, name := range unionKeys(expected, actual) {
+		exp, inExpected := expected[name]
+		act, inActual := actual[name]
+		switch {
+		case !inActual:
+			drifts = append(drifts, fmt.Sprintf("%v %v: missing, expected %v", prefix, name, exp))
+		case !inExpected:
+			drifts = append(drifts, fmt.Sprintf("%v %v: unexpected, actual %v", prefix, name, act))
+		case exp != act:
+			drifts = append(drifts, fmt.Sprintf("%v %v: mismatch, expected %v, actual %v", prefix, name, exp, act))
+		}
+	}
+	return drifts
+}
+
+func tableNames(tables map[string]*TableDescription) map[string]string {
+	names := make(map[string]string, len(tables))
+	for name := range tables {
+		names[name] = name
+	}
+	return names
+}
+
+func typeNames(types map[string]*TypeDescription) map[string]string {
+	names := make(map[string]string, len(types))
+	for name := range types {
+		names[name] = name
+	}
+	return names
+}
+
+func unionKeys(a map[string]string, b map[string]string) []string {
+	var keys []string
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
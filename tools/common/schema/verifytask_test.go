@@ -0,0 +1,108 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerifyTaskTestSuite struct {
+	*require.Assertions // override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test, not merely log an error
+	suite.Suite
+}
+
+func TestVerifyTaskTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyTaskTestSuite))
+}
+
+func (s *VerifyTaskTestSuite) SetupSuite() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *VerifyTaskTestSuite) TestDiffSchema_NoDrift() {
+	s.Empty(diffSchema(s.newSchema(), s.newSchema()))
+}
+
+func (s *VerifyTaskTestSuite) TestDiffSchema_Drift() {
+	expected := s.newSchema()
+	actual := s.newSchema()
+
+	delete(actual.Tables, "tasks")
+	actual.Tables["extra"] = &TableDescription{}
+	executions := actual.Tables["executions"]
+	delete(executions.Columns, "next_event_id")
+	executions.Columns["run_id"] = "blob"
+	executions.Columns["extra_col"] = "int"
+	executions.PrimaryKey = "((shard_id), run_id ASC)"
+	delete(executions.Indexes, "executions_idx")
+	actual.Types["serialized_event_batch"].Fields["data"] = "text"
+
+	s.Equal([]string{
+		"table executions: column extra_col: unexpected, actual int",
+		"table executions: column next_event_id: missing, expected bigint",
+		"table executions: column run_id: mismatch, expected uuid, actual blob",
+		"table executions: index executions_idx: missing, expected COMPOSITES(run_id)",
+		"table executions: primary key mismatch, expected ((shard_id), run_id ASC, type ASC), actual ((shard_id), run_id ASC)",
+		"table extra: unexpected",
+		"table tasks: missing",
+		"type serialized_event_batch: field data: mismatch, expected blob, actual text",
+	}, diffSchema(expected, actual))
+}
+
+func (s *VerifyTaskTestSuite) newSchema() *SchemaDescription {
+	return &SchemaDescription{
+		Tables: map[string]*TableDescription{
+			"executions": {
+				Columns: map[string]string{
+					"shard_id":      "int",
+					"run_id":        "uuid",
+					"type":          "int",
+					"next_event_id": "bigint",
+				},
+				PrimaryKey: "((shard_id), run_id ASC, type ASC)",
+				Indexes: map[string]string{
+					"executions_idx": "COMPOSITES(run_id)",
+				},
+			},
+			"tasks": {
+				Columns: map[string]string{
+					"task_id": "bigint",
+				},
+				PrimaryKey: "((task_id))",
+			},
+		},
+		Types: map[string]*TypeDescription{
+			"serialized_event_batch": {
+				Fields: map[string]string{
+					"encoding_type": "text",
+					"data":          "blob",
+				},
+			},
+		},
+	}
+}
@@ -40,6 +40,7 @@ type (
 )
 
 var _ schema.DB = (*Connection)(nil)
+var _ schema.SchemaDescriber = (*Connection)(nil)
 
 // NewConnection creates a new connection to database
 func NewConnection(cfg *config.SQL) (*Connection, error) {
@@ -85,6 +86,42 @@ func (c *Connection) ListTables() ([]string, error) {
 	return c.adminDb.ListTables(c.dbName)
 }
 
+// DescribeSchema returns the tables, columns and indexes of this database
+func (c *Connection) DescribeSchema() (*schema.SchemaDescription, error) {
+	result := &schema.SchemaDescription{
+		Tables: make(map[string]*schema.TableDescription),
+		Types:  make(map[string]*schema.TypeDescription),
+	}
+	table := func(name string) *schema.TableDescription {
+		t, ok := result.Tables[name]
+		if !ok {
+			t = &schema.TableDescription{
+				Columns: make(map[string]string),
+				Indexes: make(map[string]string),
+			}
+			result.Tables[name] = t
+		}
+		return t
+	}
+
+	columns, err := c.adminDb.ListColumns(c.dbName)
+	if err != nil {
+		return nil, err
+	}
+	for _, col := range columns {
+		table(col.TableName).Columns[col.ColumnName] = col.ColumnType
+	}
+
+	indexes, err := c.adminDb.ListIndexes(c.dbName)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range indexes {
+		table(idx.TableName).Indexes[idx.IndexName] = idx.IndexDef
+	}
+	return result, nil
+}
+
 // DropTable drops a given table from the database
 func (c *Connection) DropTable(name string) error {
 	return c.adminDb.DropTable(name)
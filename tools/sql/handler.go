@@ -119,6 +119,36 @@ func updateSchema(cli *cli.Context) error {
 	return nil
 }
 
+// verifySchema executes the verifySchemaTask
+// using the given command line args as input
+func verifySchema(cli *cli.Context) error {
+	cfg, err := parseConnectConfig(cli)
+	if err != nil {
+		return handleErr(schema.NewConfigError(err.Error()))
+	}
+	expectedCfg := *cfg
+	if err := doCreateDatabase(&expectedCfg, schema.VerifyDBName); err != nil {
+		return handleErr(fmt.Errorf("error creating verify database: %v", err))
+	}
+	expectedCfg.DatabaseName = schema.VerifyDBName
+	defer doDropDatabase(&expectedCfg, schema.VerifyDBName)
+
+	conn, err := NewConnection(cfg)
+	if err != nil {
+		return handleErr(err)
+	}
+	defer conn.Close()
+	expectedConn, err := NewConnection(&expectedCfg)
+	if err != nil {
+		return handleErr(err)
+	}
+	defer expectedConn.Close()
+	if err := schema.Verify(cli, conn, expectedConn); err != nil {
+		return handleErr(err)
+	}
+	return nil
+}
+
 // createDatabase creates a sql database
 func createDatabase(cli *cli.Context) error {
 	cfg, err := parseConnectConfig(cli)
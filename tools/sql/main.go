@@ -179,6 +179,24 @@ func BuildCLIOptions() *cli.App {
 				cliHandler(c, updateSchema)
 			},
 		},
+		{
+			Name:    "verify-schema",
+			Aliases: []string{"verify"},
+			Usage:   "verify sql schema against the versioned schema and report drift",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  schema.CLIFlagTargetVersion,
+					Usage: "version to verify against, defaults to the version recorded in schema_version",
+				},
+				cli.StringFlag{
+					Name:  schema.CLIFlagSchemaDir,
+					Usage: "path to directory containing versioned schema",
+				},
+			},
+			Action: func(c *cli.Context) {
+				cliHandler(c, verifySchema)
+			},
+		},
 		{
 			Name:    "create-database",
 			Aliases: []string{"create"},